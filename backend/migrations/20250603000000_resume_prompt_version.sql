@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Версия промпта, которым получен анализ резюме (<name>.<lang>@<version>)
+ALTER TABLE cv.resume_database ADD COLUMN prompt_version TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE cv.resume_database DROP COLUMN prompt_version;
+
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Задачи загрузки архивов с резюме
+CREATE TABLE cv.ingestion_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    archive_name TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'processing' CHECK (status IN ('processing', 'completed')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Файлы архива и состояние их обработки
+CREATE TABLE cv.ingestion_files (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id UUID NOT NULL REFERENCES cv.ingestion_jobs(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    extension TEXT NOT NULL,
+    object_name TEXT,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'succeeded', 'failed')),
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    resume_id UUID REFERENCES cv.resume_database(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_ingestion_jobs_user_id ON cv.ingestion_jobs(user_id);
+CREATE INDEX idx_ingestion_files_job_id ON cv.ingestion_files(job_id);
+CREATE INDEX idx_ingestion_files_status ON cv.ingestion_files(status, created_at);
+
+-- Grant permissions
+GRANT ALL ON cv.ingestion_jobs TO backend;
+GRANT ALL ON cv.ingestion_files TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_ingestion_files_status;
+DROP INDEX IF EXISTS idx_ingestion_files_job_id;
+DROP INDEX IF EXISTS idx_ingestion_jobs_user_id;
+DROP TABLE cv.ingestion_files;
+DROP TABLE cv.ingestion_jobs;
+
+-- +goose StatementEnd
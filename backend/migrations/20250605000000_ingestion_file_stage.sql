@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Этап, на котором обработка файла завершилась ошибкой
+ALTER TABLE cv.ingestion_files
+    ADD COLUMN stage TEXT CHECK (stage IN ('archive', 'validation', 'storage', 'extraction', 'analysis', 'database'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE cv.ingestion_files DROP COLUMN stage;
+
+-- +goose StatementEnd
@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Кандидаты: разные версии резюме одного человека объединяются в одного кандидата
+CREATE TABLE cv.candidates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    email TEXT,
+    phone TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_candidates_user_id ON cv.candidates(user_id);
+
+-- Хеш содержимого файла (SHA-256) и контакты, извлечённые из текста резюме
+ALTER TABLE cv.resume_database
+    ADD COLUMN content_hash TEXT,
+    ADD COLUMN email TEXT,
+    ADD COLUMN phone TEXT,
+    ADD COLUMN candidate_id UUID REFERENCES cv.candidates(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_resume_database_content_hash ON cv.resume_database(user_id, content_hash);
+CREATE INDEX idx_resume_database_candidate_id ON cv.resume_database(candidate_id);
+
+-- Файлы, совпадающие с уже загруженными, пропускаются
+ALTER TABLE cv.ingestion_files ADD COLUMN content_hash TEXT;
+ALTER TABLE cv.ingestion_files DROP CONSTRAINT ingestion_files_status_check;
+ALTER TABLE cv.ingestion_files ADD CONSTRAINT ingestion_files_status_check
+    CHECK (status IN ('pending', 'processing', 'succeeded', 'failed', 'skipped'));
+
+CREATE INDEX idx_ingestion_files_content_hash ON cv.ingestion_files(content_hash);
+
+-- Grant permissions
+GRANT ALL ON cv.candidates TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_ingestion_files_content_hash;
+UPDATE cv.ingestion_files SET status = 'succeeded' WHERE status = 'skipped';
+ALTER TABLE cv.ingestion_files DROP CONSTRAINT ingestion_files_status_check;
+ALTER TABLE cv.ingestion_files ADD CONSTRAINT ingestion_files_status_check
+    CHECK (status IN ('pending', 'processing', 'succeeded', 'failed'));
+ALTER TABLE cv.ingestion_files DROP COLUMN content_hash;
+
+DROP INDEX IF EXISTS idx_resume_database_candidate_id;
+DROP INDEX IF EXISTS idx_resume_database_content_hash;
+ALTER TABLE cv.resume_database
+    DROP COLUMN candidate_id,
+    DROP COLUMN phone,
+    DROP COLUMN email,
+    DROP COLUMN content_hash;
+
+DROP INDEX IF EXISTS idx_candidates_user_id;
+DROP TABLE cv.candidates;
+
+-- +goose StatementEnd
@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Эмбеддинги резюме для семантического поиска. Хранится вектор текущей модели,
+-- при смене модели эмбеддинги пересчитываются
+CREATE TABLE cv.resume_embeddings (
+    resume_id UUID PRIMARY KEY REFERENCES cv.resume_database(id) ON DELETE CASCADE,
+    model TEXT NOT NULL,
+    embedding REAL[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_resume_embeddings_model ON cv.resume_embeddings(model);
+
+-- Косинусное сходство векторов; для векторов разной размерности - NULL
+CREATE FUNCTION cv.embedding_similarity(a REAL[], b REAL[]) RETURNS DOUBLE PRECISION
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $f$
+    SELECT CASE WHEN array_length(a, 1) IS DISTINCT FROM array_length(b, 1) THEN NULL
+    ELSE (
+        SELECT SUM(x * y) / NULLIF(sqrt(SUM(x * x)) * sqrt(SUM(y * y)), 0)
+        FROM unnest(a, b) AS v(x, y)
+    ) END
+$f$;
+
+-- Стаж из текстового поля анализа ("5 лет", "3,5 года") в годах
+CREATE FUNCTION cv.experience_years_value(experience TEXT) RETURNS DOUBLE PRECISION
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $f$
+    SELECT replace(substring(experience FROM '[0-9]+(?:[.,][0-9]+)?'), ',', '.')::DOUBLE PRECISION
+$f$;
+
+-- Если в PostgreSQL установлен pgvector, сходство считается им
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') THEN
+        CREATE EXTENSION IF NOT EXISTS vector SCHEMA public;
+        CREATE OR REPLACE FUNCTION cv.embedding_similarity(a REAL[], b REAL[]) RETURNS DOUBLE PRECISION
+        LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $f$
+            SELECT CASE WHEN array_length(a, 1) IS DISTINCT FROM array_length(b, 1) THEN NULL
+            ELSE 1 - (a::public.vector OPERATOR(public.<=>) b::public.vector) END
+        $f$;
+    END IF;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'pgvector is not available, using SQL fallback for embedding similarity';
+END
+$$;
+
+-- Grant permissions
+GRANT ALL ON cv.resume_embeddings TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP FUNCTION IF EXISTS cv.experience_years_value(TEXT);
+DROP FUNCTION IF EXISTS cv.embedding_similarity(REAL[], REAL[]);
+DROP INDEX IF EXISTS idx_resume_embeddings_model;
+DROP TABLE cv.resume_embeddings;
+
+-- +goose StatementEnd
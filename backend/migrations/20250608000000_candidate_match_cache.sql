@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Последний подбор кандидатов для вакансии. Результат действителен, пока не
+-- изменились вакансия (updated_at) и резюме, попавшие в предварительную выборку
+CREATE TABLE cv.job_match_runs (
+    job_id UUID PRIMARY KEY REFERENCES job.jobs(id) ON DELETE CASCADE,
+    job_updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    shortlist_hash TEXT NOT NULL,
+    shortlist_size INTEGER NOT NULL,
+    prompt_version TEXT NOT NULL,
+    matched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Оценки кандидатов из предварительной выборки с разбивкой по критериям
+CREATE TABLE cv.job_match_results (
+    job_id UUID NOT NULL REFERENCES cv.job_match_runs(job_id) ON DELETE CASCADE,
+    resume_id UUID NOT NULL REFERENCES cv.resume_database(id) ON DELETE CASCADE,
+    match_score INTEGER NOT NULL,
+    skills_score INTEGER NOT NULL,
+    experience_score INTEGER NOT NULL,
+    seniority_score INTEGER NOT NULL,
+    location_score INTEGER,
+    reasoning TEXT NOT NULL,
+    PRIMARY KEY (job_id, resume_id)
+);
+
+CREATE INDEX idx_job_match_results_resume_id ON cv.job_match_results(resume_id);
+
+-- Grant permissions
+GRANT ALL ON cv.job_match_runs TO backend;
+GRANT ALL ON cv.job_match_results TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_job_match_results_resume_id;
+DROP TABLE cv.job_match_results;
+DROP TABLE cv.job_match_runs;
+
+-- +goose StatementEnd
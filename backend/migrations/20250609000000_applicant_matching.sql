@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Согласие пользователя участвовать в подборе на вакансии, на которые он не откликался
+ALTER TABLE profile.profiles ADD COLUMN open_to_offers BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_profiles_open_to_offers ON profile.profiles(guid) WHERE open_to_offers;
+
+-- Последний подбор среди откликнувшихся на вакансию и открытых профилей
+CREATE TABLE cv.job_applicant_match_runs (
+    job_id UUID PRIMARY KEY REFERENCES job.jobs(id) ON DELETE CASCADE,
+    job_updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    shortlist_hash TEXT NOT NULL,
+    shortlist_size INTEGER NOT NULL,
+    prompt_version TEXT NOT NULL,
+    matched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE cv.job_applicant_match_results (
+    job_id UUID NOT NULL REFERENCES cv.job_applicant_match_runs(job_id) ON DELETE CASCADE,
+    profile_id UUID NOT NULL REFERENCES profile.profiles(guid) ON DELETE CASCADE,
+    match_score INTEGER NOT NULL,
+    skills_score INTEGER NOT NULL,
+    experience_score INTEGER NOT NULL,
+    seniority_score INTEGER NOT NULL,
+    location_score INTEGER,
+    reasoning TEXT NOT NULL,
+    PRIMARY KEY (job_id, profile_id)
+);
+
+CREATE INDEX idx_job_applicant_match_results_profile_id ON cv.job_applicant_match_results(profile_id);
+
+-- Grant permissions
+GRANT ALL ON cv.job_applicant_match_runs TO backend;
+GRANT ALL ON cv.job_applicant_match_results TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_job_applicant_match_results_profile_id;
+DROP TABLE cv.job_applicant_match_results;
+DROP TABLE cv.job_applicant_match_runs;
+DROP INDEX IF EXISTS profile.idx_profiles_open_to_offers;
+ALTER TABLE profile.profiles DROP COLUMN open_to_offers;
+
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Последняя подборка вакансий для пользователя. Результат действителен, пока
+-- не изменились профиль пользователя и вакансии, попавшие в выборку
+CREATE TABLE cv.job_recommendation_runs (
+    profile_id UUID PRIMARY KEY REFERENCES profile.profiles(guid) ON DELETE CASCADE,
+    shortlist_hash TEXT NOT NULL,
+    shortlist_size INTEGER NOT NULL,
+    prompt_version TEXT NOT NULL,
+    matched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE cv.job_recommendation_results (
+    profile_id UUID NOT NULL REFERENCES cv.job_recommendation_runs(profile_id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES job.jobs(id) ON DELETE CASCADE,
+    match_score INTEGER NOT NULL,
+    skills_score INTEGER NOT NULL,
+    experience_score INTEGER NOT NULL,
+    seniority_score INTEGER NOT NULL,
+    location_score INTEGER,
+    reasoning TEXT NOT NULL,
+    PRIMARY KEY (profile_id, job_id)
+);
+
+CREATE INDEX idx_job_recommendation_results_job_id ON cv.job_recommendation_results(job_id);
+
+-- Grant permissions
+GRANT ALL ON cv.job_recommendation_runs TO backend;
+GRANT ALL ON cv.job_recommendation_results TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_job_recommendation_results_job_id;
+DROP TABLE cv.job_recommendation_results;
+DROP TABLE cv.job_recommendation_runs;
+
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Теги и заметки рекрутера к резюме из базы
+ALTER TABLE cv.resume_database
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN notes TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_resume_database_tags ON cv.resume_database USING gin(tags);
+
+-- Папки (кадровые резервы) пользователя; одно резюме может лежать в нескольких папках
+CREATE TABLE cv.resume_folders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE cv.resume_folder_items (
+    folder_id UUID NOT NULL REFERENCES cv.resume_folders(id) ON DELETE CASCADE,
+    resume_id UUID NOT NULL REFERENCES cv.resume_database(id) ON DELETE CASCADE,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (folder_id, resume_id)
+);
+
+CREATE INDEX idx_resume_folder_items_resume_id ON cv.resume_folder_items(resume_id);
+
+-- Grant permissions
+GRANT ALL ON cv.resume_folders TO backend;
+GRANT ALL ON cv.resume_folder_items TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_resume_folder_items_resume_id;
+DROP TABLE cv.resume_folder_items;
+DROP TABLE cv.resume_folders;
+DROP INDEX IF EXISTS idx_resume_database_tags;
+ALTER TABLE cv.resume_database
+    DROP COLUMN notes,
+    DROP COLUMN tags;
+
+-- +goose StatementEnd
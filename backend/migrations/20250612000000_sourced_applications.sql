@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Кандидаты из базы резюме, добавленные HR в воронку вакансии. У такой заявки
+-- нет пользователя, пока кандидат не перейдёт по ссылке из приглашения;
+-- имя и email сохраняются на момент приглашения, чтобы заявка пережила
+-- удаление резюме из базы
+ALTER TABLE job.job_applications
+    ALTER COLUMN applicant_id DROP NOT NULL,
+    ADD COLUMN source TEXT NOT NULL DEFAULT 'platform' CHECK (source IN ('platform', 'database')),
+    ADD COLUMN resume_id UUID REFERENCES cv.resume_database(id) ON DELETE SET NULL,
+    ADD COLUMN candidate_name TEXT,
+    ADD COLUMN candidate_email TEXT,
+    ADD COLUMN invite_token_hash TEXT UNIQUE,
+    ADD COLUMN invited_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN claimed_at TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT job_applications_applicant_check CHECK (source = 'database' OR applicant_id IS NOT NULL),
+    ADD CONSTRAINT job_applications_job_id_resume_id_key UNIQUE (job_id, resume_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM job.job_applications WHERE applicant_id IS NULL;
+ALTER TABLE job.job_applications
+    DROP CONSTRAINT job_applications_job_id_resume_id_key,
+    DROP CONSTRAINT job_applications_applicant_check,
+    DROP COLUMN claimed_at,
+    DROP COLUMN invited_at,
+    DROP COLUMN invite_token_hash,
+    DROP COLUMN candidate_email,
+    DROP COLUMN candidate_name,
+    DROP COLUMN resume_id,
+    DROP COLUMN source,
+    ALTER COLUMN applicant_id SET NOT NULL;
+
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Владелец и метаданные объектов в хранилище: по ним проверяется доступ при скачивании
+-- kind: cv - резюме пользователя платформы, resume - файл из базы резюме
+CREATE TABLE cv.files (
+    object_name TEXT PRIMARY KEY,
+    owner_id UUID NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('cv', 'resume')),
+    original_filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_files_owner_id ON cv.files(owner_id);
+
+CREATE FUNCTION cv.content_type_by_name(name TEXT) RETURNS TEXT AS $$
+    SELECT CASE lower(substring(name FROM '\.[^.]*$'))
+        WHEN '.pdf' THEN 'application/pdf'
+        WHEN '.doc' THEN 'application/msword'
+        WHEN '.docx' THEN 'application/vnd.openxmlformats-officedocument.wordprocessingml.document'
+        WHEN '.txt' THEN 'text/plain; charset=utf-8'
+        ELSE 'application/octet-stream'
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Файлы, загруженные до появления таблицы: исходное имя известно только для архивов базы резюме
+INSERT INTO cv.files (object_name, owner_id, kind, original_filename, content_type)
+SELECT DISTINCT ON (f.object_name) f.object_name, j.user_id, 'resume', f.file_name, cv.content_type_by_name(f.object_name)
+FROM cv.ingestion_files f
+JOIN cv.ingestion_jobs j ON j.id = f.job_id
+WHERE f.object_name IS NOT NULL
+ORDER BY f.object_name, f.created_at
+ON CONFLICT (object_name) DO NOTHING;
+
+INSERT INTO cv.files (object_name, owner_id, kind, original_filename, content_type)
+SELECT DISTINCT ON (o.name) o.name, r.user_id, 'resume', o.name, cv.content_type_by_name(o.name)
+FROM cv.resume_database r
+CROSS JOIN LATERAL (SELECT substring(r.file_url FROM '/api/v1/cv/([^/?]+)$') AS name) o
+WHERE o.name IS NOT NULL
+ORDER BY o.name, r.created_at
+ON CONFLICT (object_name) DO NOTHING;
+
+INSERT INTO cv.files (object_name, owner_id, kind, original_filename, content_type)
+SELECT DISTINCT ON (o.name) o.name, c.user_guid::uuid, 'cv', o.name, cv.content_type_by_name(o.name)
+FROM cv.cv c
+CROSS JOIN LATERAL (SELECT substring(c.link FROM '/api/v1/cv/([^/?]+)$') AS name) o
+WHERE o.name IS NOT NULL
+    AND c.user_guid ~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$'
+ORDER BY o.name, c.created_at
+ON CONFLICT (object_name) DO NOTHING;
+
+DROP FUNCTION cv.content_type_by_name(TEXT);
+
+-- Grant permissions
+GRANT ALL ON cv.files TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE cv.files;
+
+-- +goose StatementEnd
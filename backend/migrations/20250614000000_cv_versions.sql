@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Версии CV пользователя: подпись ("Backend CV", "Team lead CV") и основная
+-- версия, которая показывается в профиле и прикладывается к откликам по умолчанию
+ALTER TABLE cv.cv
+    ADD COLUMN label TEXT NOT NULL DEFAULT '',
+    ADD COLUMN is_primary BOOLEAN NOT NULL DEFAULT false;
+
+-- Раньше действующим считалось последнее загруженное CV
+UPDATE cv.cv c
+SET is_primary = true
+WHERE c.guid = (
+    SELECT l.guid FROM cv.cv l
+    WHERE l.user_guid = c.user_guid
+    ORDER BY l.created_at DESC NULLS LAST
+    LIMIT 1
+);
+
+CREATE INDEX idx_cv_user_guid ON cv.cv(user_guid);
+CREATE UNIQUE INDEX idx_cv_primary ON cv.cv(user_guid) WHERE is_primary;
+
+-- Версия CV, с которой кандидат откликнулся на вакансию
+ALTER TABLE job.job_applications
+    ADD COLUMN cv_id UUID REFERENCES cv.cv(guid) ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE job.job_applications
+    DROP COLUMN cv_id;
+DROP INDEX IF EXISTS idx_cv_primary;
+DROP INDEX IF EXISTS idx_cv_user_guid;
+ALTER TABLE cv.cv
+    DROP COLUMN is_primary,
+    DROP COLUMN label;
+
+-- +goose StatementEnd
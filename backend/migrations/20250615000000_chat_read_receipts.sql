@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Последнее прочитанное участником сообщение: всё, что отправлено позже другими участниками, считается непрочитанным
+ALTER TABLE chat.chat_users
+    ADD COLUMN last_read_message_id UUID REFERENCES chat.messages(id) ON DELETE SET NULL,
+    ADD COLUMN last_read_at TIMESTAMP WITH TIME ZONE;
+
+-- Раньше прочитанными считались сообщения до последнего собственного сообщения участника
+UPDATE chat.chat_users cu
+SET last_read_message_id = r.id, last_read_at = r.created_at
+FROM (
+    SELECT DISTINCT ON (u.chat_id, u.user_id) u.chat_id, u.user_id, m.id, m.created_at
+    FROM chat.chat_users u
+    JOIN chat.messages m ON m.chat_id = u.chat_id
+    WHERE m.created_at <= (
+        SELECT MAX(own.created_at) FROM chat.messages own
+        WHERE own.chat_id = u.chat_id AND own.user_id = u.user_id
+    )
+    ORDER BY u.chat_id, u.user_id, m.created_at DESC, m.id DESC
+) r
+WHERE cu.chat_id = r.chat_id AND cu.user_id = r.user_id;
+
+CREATE INDEX IF NOT EXISTS idx_messages_chat_id_created_at ON chat.messages(chat_id, created_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS chat.idx_messages_chat_id_created_at;
+
+ALTER TABLE chat.chat_users
+    DROP COLUMN IF EXISTS last_read_at,
+    DROP COLUMN IF EXISTS last_read_message_id;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Групповые чаты: название, комната команды найма по вакансии
+ALTER TABLE chat.chats
+    ADD COLUMN type TEXT NOT NULL DEFAULT 'direct' CHECK (type IN ('direct', 'group')),
+    ADD COLUMN title TEXT,
+    ADD COLUMN job_id UUID REFERENCES job.jobs(id) ON DELETE SET NULL;
+
+-- Роль участника: администратор группы управляет названием и составом
+ALTER TABLE chat.chat_users
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('admin', 'member'));
+
+-- Системные сообщения о смене состава: user_id - кто сделал, target_user_id - с кем
+ALTER TABLE chat.messages
+    ADD COLUMN type TEXT NOT NULL DEFAULT 'text' CHECK (type IN ('text', 'chat_created', 'member_added', 'member_removed', 'member_left', 'role_changed', 'title_changed')),
+    ADD COLUMN target_user_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_chats_job_id ON chat.chats(job_id) WHERE job_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS chat.idx_chats_job_id;
+
+ALTER TABLE chat.messages
+    DROP COLUMN IF EXISTS target_user_id,
+    DROP COLUMN IF EXISTS type;
+
+ALTER TABLE chat.chat_users
+    DROP COLUMN IF EXISTS role;
+
+ALTER TABLE chat.chats
+    DROP COLUMN IF EXISTS job_id,
+    DROP COLUMN IF EXISTS title,
+    DROP COLUMN IF EXISTS type;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Вложения сообщений чата. Файл загружается до отправки сообщения, поэтому
+-- message_id пуст, пока сообщение не отправлено.
+-- kind: file - произвольный файл, image - изображение с превью, cv - CV отправителя,
+-- объект принадлежит cv.files и при удалении вложения не удаляется
+CREATE TABLE chat.attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL REFERENCES chat.chats(id) ON DELETE CASCADE,
+    message_id UUID REFERENCES chat.messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('file', 'image', 'cv')),
+    object_name TEXT NOT NULL,
+    preview_object_name TEXT,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_message_id ON chat.attachments(message_id);
+CREATE INDEX idx_attachments_chat_id ON chat.attachments(chat_id);
+CREATE INDEX idx_attachments_object_name ON chat.attachments(object_name);
+
+-- Grant permissions
+GRANT ALL ON chat.attachments TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE chat.attachments;
+
+-- +goose StatementEnd
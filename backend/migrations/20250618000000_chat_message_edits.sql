@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Правка, удаление и ответы: edited_at - время последней правки, deleted_at -
+-- время удаления (текст стирается, сообщение остаётся заглушкой),
+-- reply_to_id - сообщение, на которое отвечают
+ALTER TABLE chat.messages
+    ADD COLUMN edited_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reply_to_id UUID REFERENCES chat.messages(id) ON DELETE SET NULL;
+
+-- История правок: прежний текст и время, когда его заменили
+CREATE TABLE chat.message_edits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id UUID NOT NULL REFERENCES chat.messages(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    replaced_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Реакции: каждый участник ставит эмодзи не больше одного раза
+CREATE TABLE chat.message_reactions (
+    message_id UUID NOT NULL REFERENCES chat.messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id, emoji)
+);
+
+CREATE INDEX idx_message_edits_message_id ON chat.message_edits(message_id);
+CREATE INDEX idx_messages_reply_to_id ON chat.messages(reply_to_id) WHERE reply_to_id IS NOT NULL;
+
+-- Grant permissions
+GRANT ALL ON chat.message_edits TO backend;
+GRANT ALL ON chat.message_reactions TO backend;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE chat.message_reactions;
+DROP TABLE chat.message_edits;
+
+DROP INDEX IF EXISTS chat.idx_messages_reply_to_id;
+
+ALTER TABLE chat.messages
+    DROP COLUMN IF EXISTS reply_to_id,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS edited_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Полнотекстовый поиск по сообщениям: текст разбирается русским и английским
+-- словарями, чтобы находились обе формы слов. Выражение должно совпадать с
+-- запросом SearchMessages, иначе индекс не используется
+CREATE INDEX idx_messages_text_search ON chat.messages
+    USING gin((to_tsvector('russian', text) || to_tsvector('english', text)))
+    WHERE type = 'text' AND deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS chat.idx_messages_text_search;
+
+-- +goose StatementEnd
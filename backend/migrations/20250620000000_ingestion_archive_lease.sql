@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Архив хранится целиком, пока воркеры не извлекут из него все файлы
+ALTER TABLE cv.ingestion_jobs
+    ADD COLUMN archive_object TEXT;
+
+-- Путь файла внутри архива и срок, до которого файл закреплён за воркером.
+-- Файл с истёкшим сроком снова забирается в обработку
+ALTER TABLE cv.ingestion_files
+    ADD COLUMN archive_entry TEXT,
+    ADD COLUMN lease_expires_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_ingestion_files_lease ON cv.ingestion_files(lease_expires_at) WHERE status = 'processing';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_ingestion_files_lease;
+ALTER TABLE cv.ingestion_files DROP COLUMN lease_expires_at, DROP COLUMN archive_entry;
+ALTER TABLE cv.ingestion_jobs DROP COLUMN archive_object;
+
+-- +goose StatementEnd
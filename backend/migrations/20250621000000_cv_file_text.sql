@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Текст, извлечённый из файла резюме при загрузке: подбор кандидатов и рекомендации
+-- вакансий берут его отсюда, а не скачивают и разбирают файл на каждый запрос
+ALTER TABLE cv.files
+    ADD COLUMN extracted_text TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE cv.files DROP COLUMN extracted_text;
+
+-- +goose StatementEnd
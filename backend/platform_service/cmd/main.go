@@ -80,6 +80,12 @@ func main() {
 		return
 	}
 
+	err = services.CV.StartIngestion(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "services.CV.StartIngestion", "error", err)
+		return
+	}
+
 	handlers := httprouter.NewHandler(cfg, logger, services)
 
 	// init server
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/viper"
@@ -20,6 +21,10 @@ type Config struct {
 
 	HTTPReadHeaderTimeout int `mapstructure:"HTTP_READ_HEADER_TIMEOUT" required:"true" default:"10"`
 
+	// Хранилище файлов: minio - MinIO/S3, local - каталог на диске STORAGE_LOCAL_PATH
+	StorageProvider  string `mapstructure:"STORAGE_PROVIDER" default:"minio"`
+	StorageLocalPath string `mapstructure:"STORAGE_LOCAL_PATH" default:"data/storage"`
+
 	// MinIO configuration
 	MinioEndpoint  string `mapstructure:"MINIO_ENDPOINT" required:"true" default:"localhost:9001"`
 	MinioAccessKey string `mapstructure:"MINIO_ACCESS_KEY" required:"true" default:"admin"`
@@ -27,11 +32,65 @@ type Config struct {
 	MinioUseSSL    bool   `mapstructure:"MINIO_USE_SSL" required:"true" default:"false"`
 	MinioBucket    string `mapstructure:"MINIO_BUCKET" required:"true" default:"cv"`
 	MinioInsecure  bool   `mapstructure:"MINIO_INSECURE" required:"true" default:"true"`
+	// Подписанные ссылки на файлы резюме: секрет для HMAC и срок действия в секундах.
+	// Секрет обязателен: с пустым ключом подпись может вычислить кто угодно
+	FileURLSecret string `mapstructure:"FILE_URL_SECRET" required:"true"`
+	FileURLTTL    int    `mapstructure:"FILE_URL_TTL" required:"true" default:"300"`
+
+	// Ограничения загрузки: размер одного резюме и архива в байтах, защита от ZIP-бомб
+	UploadMaxFileSize            int64 `mapstructure:"UPLOAD_MAX_FILE_SIZE" default:"10485760"`
+	UploadMaxArchiveSize         int64 `mapstructure:"UPLOAD_MAX_ARCHIVE_SIZE" default:"209715200"`
+	UploadMaxArchiveEntries      int   `mapstructure:"UPLOAD_MAX_ARCHIVE_ENTRIES" default:"1000"`
+	UploadMaxArchiveUncompressed int64 `mapstructure:"UPLOAD_MAX_ARCHIVE_UNCOMPRESSED" default:"1073741824"`
+	UploadMaxCompressionRatio    int   `mapstructure:"UPLOAD_MAX_COMPRESSION_RATIO" default:"100"`
+
+	// Максимальный размер загружаемого аватара или логотипа в байтах
+	ImageMaxFileSize int64 `mapstructure:"IMAGE_MAX_FILE_SIZE" default:"5242880"`
+
+	// Максимальный размер вложения в чате в байтах
+	ChatAttachmentMaxFileSize int64 `mapstructure:"CHAT_ATTACHMENT_MAX_FILE_SIZE" default:"20971520"`
+
+	// Антивирусная проверка загружаемых файлов: none - отключена, clamav - демон clamd
+	ScannerProvider string `mapstructure:"SCANNER_PROVIDER" default:"none"`
+	// ClamAVAddress: tcp://host:port или unix:///path/to/clamd.sock
+	ClamAVAddress string `mapstructure:"CLAMAV_ADDRESS" default:"tcp://localhost:3310"`
+	ClamAVTimeout int    `mapstructure:"CLAMAV_TIMEOUT_SEC" default:"60"`
 
 	// DeepSeek API configuration
 	DeepSeekAPIKey string `mapstructure:"DEEPSEEK_API_KEY" required:"true" default:""`
 	DeepSeekAPIURL string `mapstructure:"DEEPSEEK_API_URL" required:"true" default:"https://api.deepseek.com"`
 	DeepSeekModel  string `mapstructure:"DEEPSEEK_MODEL" required:"true" default:"deepseek-chat"`
+	// DeepSeekOutputMode: tools - ответ через вызов функции со схемой, json_object - через response_format
+	DeepSeekOutputMode     string `mapstructure:"DEEPSEEK_OUTPUT_MODE" default:"tools"`
+	DeepSeekRepairAttempts int    `mapstructure:"DEEPSEEK_REPAIR_ATTEMPTS" default:"2"`
+	// DeepSeekPromptsDir: каталог с шаблонами, заменяющими встроенные (<name>.<lang>.tmpl)
+	DeepSeekPromptsDir     string `mapstructure:"DEEPSEEK_PROMPTS_DIR" default:""`
+	DeepSeekPromptLanguage string `mapstructure:"DEEPSEEK_PROMPT_LANGUAGE" default:"ru"`
+
+	// OCR для PDF без текстового слоя: none - отключено, tesseract - локальные tesseract и pdftoppm
+	OCRProvider      string `mapstructure:"OCR_PROVIDER" default:"none"`
+	OCRTesseractPath string `mapstructure:"OCR_TESSERACT_PATH" default:"tesseract"`
+	OCRPdftoppmPath  string `mapstructure:"OCR_PDFTOPPM_PATH" default:"pdftoppm"`
+	OCRLanguages     string `mapstructure:"OCR_LANGUAGES" default:"rus+eng"`
+	OCRMaxPages      int    `mapstructure:"OCR_MAX_PAGES" default:"10"`
+	OCRTimeout       int    `mapstructure:"OCR_TIMEOUT_SEC" default:"120"`
+
+	// Эмбеддинги для семантического поиска по базе резюме: none - только полнотекстовый поиск,
+	// openai - API, совместимый с OpenAI embeddings
+	EmbeddingProvider string `mapstructure:"EMBEDDING_PROVIDER" default:"none"`
+	EmbeddingAPIURL   string `mapstructure:"EMBEDDING_API_URL" default:"https://api.openai.com/v1/embeddings"`
+	EmbeddingAPIKey   string `mapstructure:"EMBEDDING_API_KEY" default:""`
+	EmbeddingModel    string `mapstructure:"EMBEDDING_MODEL" default:"text-embedding-3-small"`
+
+	// Отправка писем: none - письма не отправляются, smtp - через SMTP-сервер
+	EmailProvider string `mapstructure:"EMAIL_PROVIDER" default:"none"`
+	SMTPHost      string `mapstructure:"SMTP_HOST" default:""`
+	SMTPPort      int    `mapstructure:"SMTP_PORT" default:"587"`
+	SMTPUsername  string `mapstructure:"SMTP_USERNAME" default:""`
+	SMTPPassword  string `mapstructure:"SMTP_PASSWORD" default:""`
+	EmailFrom     string `mapstructure:"EMAIL_FROM" default:""`
+	// FrontendURL: адрес фронтенда для ссылок в письмах
+	FrontendURL string `mapstructure:"FRONTEND_URL" default:"http://localhost:3000"`
 
 	// PSQL DB
 	// dbHost - host соединения
@@ -56,7 +115,7 @@ type Config struct {
 	VictoriaMetricsExtraLabels  string `mapstructure:"VICTORIA_METRICS_EXTRALABELS" default:"project=platform_service"`
 	VictoriaMetricsPushInterval int    `mapstructure:"VICTORIA_METRICS_PUSH_INTERVAL" default:"10"`
 
-	DatasyncParallelCnt int `mapstructure:"DATASYNC_PARALLEL" required:"true" default:"true"`
+	DatasyncParallelCnt int `mapstructure:"DATASYNC_PARALLEL" required:"true" default:"4"`
 }
 
 func NewConfig() (*Config, error) {
@@ -76,6 +135,11 @@ func NewConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// viper не читает теги default, поэтому обязательные секреты проверяются явно
+	if conf.FileURLSecret == "" {
+		return nil, errors.New("FILE_URL_SECRET is not set")
+	}
+
 	return &conf, nil
 }
 
@@ -2,33 +2,174 @@ package models
 
 import "time"
 
+const (
+	ChatTypeDirect = "direct"
+	ChatTypeGroup  = "group"
+)
+
+const (
+	ChatRoleAdmin  = "admin"
+	ChatRoleMember = "member"
+)
+
+// Типы сообщений: обычное и системные о смене состава и названия группы.
+// В системных сообщениях UserID - кто сделал изменение, TargetUserID - с кем
+const (
+	MessageTypeText          = "text"
+	MessageTypeChatCreated   = "chat_created"
+	MessageTypeMemberAdded   = "member_added"
+	MessageTypeMemberRemoved = "member_removed"
+	MessageTypeMemberLeft    = "member_left"
+	MessageTypeRoleChanged   = "role_changed"
+	MessageTypeTitleChanged  = "title_changed"
+)
+
+// Виды вложений: файл, изображение с превью и CV отправителя
+const (
+	AttachmentKindFile  = "file"
+	AttachmentKindImage = "image"
+	AttachmentKindCV    = "cv"
+)
+
+// Chat - личный чат двух пользователей или группа. JobID - вакансия, по
+// которой создана комната команды найма
 type Chat struct {
-	ID        string    `json:"id"`
-	Users     []string  `json:"users"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string       `json:"id"`
+	Type      string       `json:"type"`
+	Title     *string      `json:"title"`
+	JobID     *string      `json:"job_id"`
+	Users     []string     `json:"users"`
+	Members   []ChatMember `json:"members"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type ChatMember struct {
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// CreateChatRequest - новый чат. Создатель добавляется в участники сам и
+// становится администратором группы
+type CreateChatRequest struct {
+	Type  string   `json:"type"`
+	Title *string  `json:"title"`
+	JobID *string  `json:"job_id"`
+	Users []string `json:"users"`
 }
 
+// Message - сообщение чата. Text системного сообщения о смене названия -
+// новое название, о смене роли - новая роль. У удалённого сообщения
+// (DeletedAt) текст и вложения пусты
 type Message struct {
-	ID        string    `json:"id"`
+	ID           string        `json:"id"`
+	ChatID       string        `json:"chat_id"`
+	UserID       string        `json:"user_id"`
+	Type         string        `json:"type"`
+	TargetUserID *string       `json:"target_user_id"`
+	Text         string        `json:"text"`
+	Attachments  []Attachment  `json:"attachments"`
+	ReplyTo      *MessageReply `json:"reply_to"`
+	Reactions    []Reaction    `json:"reactions"`
+	EditedAt     *time.Time    `json:"edited_at"`
+	DeletedAt    *time.Time    `json:"deleted_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// MessageReply - цитата сообщения, на которое отвечают
+type MessageReply struct {
+	ID      string `json:"id"`
+	UserID  string `json:"user_id"`
+	Text    string `json:"text"`
+	Deleted bool   `json:"deleted"`
+}
+
+// Reaction - эмодзи под сообщением и поставившие его участники
+type Reaction struct {
+	Emoji   string   `json:"emoji"`
+	Count   int      `json:"count"`
+	UserIDs []string `json:"user_ids"`
+}
+
+// MessageEdit - прежний текст сообщения и время, когда его заменили
+type MessageEdit struct {
+	Text       string    `json:"text"`
+	ReplacedAt time.Time `json:"replaced_at"`
+}
+
+// MessageSearchResult - сообщение, найденное поиском по чатам. Snippet -
+// текст сообщения с выделенными совпадениями
+type MessageSearchResult struct {
+	Message Message
+	Score   float64
+	Snippet []SnippetFragment
+}
+
+// SendMessageRequest - новое сообщение. AttachmentIDs - файлы, загруженные
+// отправителем в этот чат и ещё не отправленные; ShareCV прикладывает основное
+// CV отправителя; ReplyToID - сообщение этого чата, на которое отвечают
+type SendMessageRequest struct {
+	Text          string
+	AttachmentIDs []string
+	ShareCV       bool
+	ReplyToID     string
+}
+
+// Attachment - вложение сообщения. Ссылки отдают файл только участникам
+// чата; превью есть только у изображений
+type Attachment struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+	PreviewURL  *string   `json:"preview_url"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	ObjectName        string  `json:"-"`
+	PreviewObjectName *string `json:"-"`
+}
+
+type ChatWithLastMessage struct {
+	Chat         Chat          `json:"chat"`
+	LastMessage  *Message      `json:"last_message,omitempty"`
+	UnreadCount  int           `json:"unread_count"`
+	ReadReceipts []ReadReceipt `json:"read_receipts"`
+	Presence     []Presence    `json:"presence"`
+}
+
+// ReadReceipt - последнее прочитанное участником сообщение чата
+type ReadReceipt struct {
 	ChatID    string    `json:"chat_id"`
 	UserID    string    `json:"user_id"`
-	Text      string    `json:"text"`
-	CreatedAt time.Time `json:"created_at"`
+	MessageID string    `json:"message_id"`
+	ReadAt    time.Time `json:"read_at"`
 }
 
-type ChatWithLastMessage struct {
-	Chat        Chat     `json:"chat"`
-	LastMessage *Message `json:"last_message,omitempty"`
-	UnreadCount int      `json:"unread_count"`
+// Presence - в сети ли участник чата. LastSeenAt - время закрытия последнего
+// соединения; пусто, пока пользователь в сети или не подключался с момента
+// запуска сервиса
+type Presence struct {
+	UserID     string     `json:"user_id"`
+	Online     bool       `json:"online"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
 }
 
 type MessageAPI struct {
-	ChatId    string      `json:"chat_id"`
-	CreatedAt time.Time   `json:"created_at"`
-	Id        string      `json:"id"`
-	Text      string      `json:"text"`
-	User      ChatUserAPI `json:"user"`
+	Attachments  []Attachment  `json:"attachments"`
+	ChatId       string        `json:"chat_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+	DeletedAt    *time.Time    `json:"deleted_at,omitempty"`
+	EditedAt     *time.Time    `json:"edited_at,omitempty"`
+	Id           string        `json:"id"`
+	Reactions    []Reaction    `json:"reactions"`
+	ReplyTo      *MessageReply `json:"reply_to,omitempty"`
+	TargetUserId *string       `json:"target_user_id,omitempty"`
+	Text         string        `json:"text"`
+	Type         string        `json:"type"`
+	User         ChatUserAPI   `json:"user"`
 }
 
 type ChatUserAPI struct {
@@ -31,13 +31,51 @@ type JobWithApplications struct {
 	ApplicationsCount int `json:"applications_count"`
 }
 
+// JobApplication - заявка на вакансию. Заявка кандидата из базы резюме
+// (Source = "database") не привязана к пользователю, пока кандидат не примет
+// приглашение, и ApplicantID у неё пустой.
 type JobApplication struct {
-	ID               string           `json:"id"`
-	JobID            string           `json:"job_id"`
-	ApplicantID      string           `json:"applicant_id"`
-	ApplicantProfile ApplicantProfile `json:"applicant_profile"`
-	AppliedAt        time.Time        `json:"applied_at"`
-	Status           string           `json:"status"`
+	ID               string            `json:"id"`
+	JobID            string            `json:"job_id"`
+	ApplicantID      *string           `json:"applicant_id"`
+	ApplicantProfile *ApplicantProfile `json:"applicant_profile"`
+	AppliedAt        time.Time         `json:"applied_at"`
+	Status           string            `json:"status"`
+	Source           string            `json:"source"`
+	SourcedCandidate *SourcedCandidate `json:"sourced_candidate,omitempty"`
+	CV               *ApplicationCV    `json:"cv,omitempty"`
+}
+
+// ApplicationCV - версия CV, с которой кандидат откликнулся на вакансию
+type ApplicationCV struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Link  string `json:"link"`
+}
+
+// SourcedCandidate - кандидат из базы резюме, добавленный HR в воронку вакансии
+type SourcedCandidate struct {
+	ResumeID  *string    `json:"resume_id"`
+	Name      string     `json:"name"`
+	Email     *string    `json:"email"`
+	InvitedAt *time.Time `json:"invited_at"`
+	ClaimedAt *time.Time `json:"claimed_at"`
+}
+
+// RecommendedJob - вакансия из подборки для кандидата с объяснением, чем она
+// ему подходит
+type RecommendedJob struct {
+	Job        Job                 `json:"job"`
+	MatchScore int                 `json:"match_score"`
+	Scores     MatchScoreBreakdown `json:"scores"`
+	Reasoning  string              `json:"reasoning"`
+}
+
+type RecommendedJobsResponse struct {
+	Jobs          []RecommendedJob `json:"jobs"`
+	ShortlistSize int              `json:"shortlist_size"`
+	Cached        bool             `json:"cached"`
+	MatchedAt     time.Time        `json:"matched_at"`
 }
 
 type ApplicantProfile struct {
@@ -76,6 +114,29 @@ type UpdateJobRequest struct {
 	Status         string `json:"status"`
 }
 
+// ApplyToJobRequest - отклик на вакансию; без CVID прикладывается основная версия CV
+type ApplyToJobRequest struct {
+	CVID *string `json:"cv_id"`
+}
+
 type UpdateApplicationStatusRequest struct {
 	Status string `json:"status"`
 }
+
+type SourceCandidateRequest struct {
+	ResumeID  string  `json:"resume_id"`
+	SendEmail bool    `json:"send_email"`
+	Message   *string `json:"message"`
+}
+
+// SourcedApplication - заявка кандидата из базы резюме. Ссылка на принятие
+// приглашения возвращается только при создании заявки.
+type SourcedApplication struct {
+	Application JobApplication `json:"application"`
+	ClaimURL    string         `json:"claim_url"`
+	EmailSent   bool           `json:"email_sent"`
+}
+
+type ClaimApplicationRequest struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// ImageThumbnails - ссылки на уменьшенные копии загруженного изображения
+type ImageThumbnails struct {
+	Small  string `json:"small"`
+	Medium string `json:"medium"`
+	Large  string `json:"large"`
+}
@@ -3,18 +3,20 @@ package models
 import "time"
 
 type Profile struct {
-	Guid        string    `json:"guid"`
-	IsHr        bool      `json:"is_hr"`
-	Description string    `json:"description"`
-	Phone       *string   `json:"phone"`
-	Email       string    `json:"email"`
-	Birthdate   string    `json:"birthdate"`
-	Gender      string    `json:"gender"`
-	Avatar      *string   `json:"avatar,omitempty"`
-	Cv          *string   `json:"cv,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	CompanyName *string   `json:"company_name,omitempty"`
+	Guid             string           `json:"guid"`
+	IsHr             bool             `json:"is_hr"`
+	Description      string           `json:"description"`
+	Phone            *string          `json:"phone"`
+	Email            string           `json:"email"`
+	Birthdate        string           `json:"birthdate"`
+	Gender           string           `json:"gender"`
+	Avatar           *string          `json:"avatar,omitempty"`
+	AvatarThumbnails *ImageThumbnails `json:"avatar_thumbnails,omitempty"`
+	Cv               *string          `json:"cv,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	CompanyName      *string          `json:"company_name,omitempty"`
+	OpenToOffers     *bool            `json:"open_to_offers,omitempty"`
 }
 
 type Experience struct {
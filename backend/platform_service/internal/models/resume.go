@@ -12,27 +12,142 @@ type ResumeRecord struct {
 	ExperienceYears string    `json:"experience_years"`
 	FileURL         string    `json:"file_url"`
 	Analysis        string    `json:"analysis"`
+	PromptVersion   string    `json:"prompt_version"`
+	CandidateID     *string   `json:"candidate_id"`
+	Email           *string   `json:"email"`
+	Phone           *string   `json:"phone"`
+	Tags            []string  `json:"tags"`
+	Notes           string    `json:"notes"`
+	FolderIDs       []string  `json:"folder_ids,omitempty"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
-type UploadDatabaseResponse struct {
-	ProcessedCount  int            `json:"processed_count"`
-	SuccessfulCount int            `json:"successful_count"`
-	FailedCount     int            `json:"failed_count"`
-	Resumes         []ResumeRecord `json:"resumes"`
+// ResumeListFilter отбирает резюме базы по тегу и папке; nil - без фильтра
+type ResumeListFilter struct {
+	Tag      *string
+	FolderID *string
+}
+
+// ResumeUpdate - изменение тегов и заметок рекрутера; nil оставляет поле без изменений
+type ResumeUpdate struct {
+	Tags  *[]string
+	Notes *string
+}
+
+// ResumeFolder - папка (кадровый резерв) в базе резюме
+type ResumeFolder struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	ResumeCount int       `json:"resume_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Candidate объединяет версии резюме одного человека
+type Candidate struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Email     *string        `json:"email"`
+	Phone     *string        `json:"phone"`
+	Resumes   []ResumeRecord `json:"resumes"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type ResumeSearchFilter struct {
+	Query         string
+	MinAge        *int
+	MaxAge        *int
+	MinExperience *float64
+	MaxExperience *float64
+}
+
+type ResumeSearchResult struct {
+	Resume  ResumeRecord      `json:"resume"`
+	Score   float64           `json:"score"`
+	Snippet []SnippetFragment `json:"snippet"`
+}
+
+// SnippetFragment - часть фрагмента текста (анализа резюме, сообщения чата);
+// Highlight отмечает совпадение с запросом
+type SnippetFragment struct {
+	Text      string `json:"text"`
+	Highlight bool   `json:"highlight"`
+}
+
+type IngestionJob struct {
+	ID              string          `json:"id"`
+	ArchiveName     string          `json:"archive_name"`
+	Status          string          `json:"status"`
+	TotalCount      int             `json:"total_count"`
+	ProcessedCount  int             `json:"processed_count"`
+	SuccessfulCount int             `json:"successful_count"`
+	FailedCount     int             `json:"failed_count"`
+	SkippedCount    int             `json:"skipped_count"`
+	Files           []IngestionFile `json:"files"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	FinishedAt      *time.Time      `json:"finished_at"`
+}
+
+type IngestionFile struct {
+	ID        string    `json:"id"`
+	FileName  string    `json:"file_name"`
+	Status    string    `json:"status"`
+	Stage     *string   `json:"stage"`
+	Error     *string   `json:"error"`
+	Retryable bool      `json:"retryable"`
+	Attempts  int       `json:"attempts"`
+	ResumeID  *string   `json:"resume_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MatchScoreBreakdown - оценки кандидата по отдельным критериям от 1 до 100.
+// Location равен nil, если в резюме нет данных о местоположении.
+type MatchScoreBreakdown struct {
+	Skills     int  `json:"skills"`
+	Experience int  `json:"experience"`
+	Seniority  int  `json:"seniority"`
+	Location   *int `json:"location"`
 }
 
 type MatchedCandidate struct {
-	ResumeID      string `json:"resume_id"`
-	CandidateName string `json:"candidate_name"`
-	FileURL       string `json:"file_url"`
-	MatchScore    int    `json:"match_score"`
-	Reasoning     string `json:"reasoning"`
+	ResumeID      string              `json:"resume_id"`
+	CandidateName string              `json:"candidate_name"`
+	FileURL       string              `json:"file_url"`
+	MatchScore    int                 `json:"match_score"`
+	Scores        MatchScoreBreakdown `json:"scores"`
+	Reasoning     string              `json:"reasoning"`
 }
 
 type MatchCandidatesResponse struct {
-	Candidates []MatchedCandidate `json:"candidates"`
+	Candidates    []MatchedCandidate `json:"candidates"`
+	ShortlistSize int                `json:"shortlist_size"`
+	Cached        bool               `json:"cached"`
+	MatchedAt     time.Time          `json:"matched_at"`
+}
+
+// MatchedApplicant - пользователь платформы, оценённый для вакансии: откликнувшийся
+// на неё или открытый к предложениям
+type MatchedApplicant struct {
+	ProfileID         string              `json:"profile_id"`
+	Name              string              `json:"name"`
+	Email             string              `json:"email"`
+	Avatar            *string             `json:"avatar"`
+	CVLink            *string             `json:"cv_link"`
+	ApplicationID     *string             `json:"application_id"`
+	ApplicationStatus *string             `json:"application_status"`
+	MatchScore        int                 `json:"match_score"`
+	Scores            MatchScoreBreakdown `json:"scores"`
+	Reasoning         string              `json:"reasoning"`
+}
+
+type MatchApplicantsResponse struct {
+	Candidates    []MatchedApplicant `json:"candidates"`
+	ShortlistSize int                `json:"shortlist_size"`
+	Cached        bool               `json:"cached"`
+	MatchedAt     time.Time          `json:"matched_at"`
 }
 
 type DeepSeekAnalysisResponse struct {
@@ -40,14 +155,56 @@ type DeepSeekAnalysisResponse struct {
 	CandidateAge    *int   `json:"candidate_age"`
 	ExperienceYears string `json:"experience_years"`
 	Analysis        string `json:"analysis"`
+	PromptVersion   string `json:"-"`
 }
 
 type DeepSeekMatchResponse struct {
 	Candidates []struct {
-		ResumeID      string `json:"resume_id"`
-		CandidateName string `json:"candidate_name"`
-		FileURL       string `json:"file_url"`
-		MatchScore    int    `json:"match_score"`
-		Reasoning     string `json:"reasoning"`
+		ResumeID   string              `json:"resume_id"`
+		MatchScore int                 `json:"match_score"`
+		Scores     MatchScoreBreakdown `json:"scores"`
+		Reasoning  string              `json:"reasoning"`
 	} `json:"candidates"`
+	PromptVersion string `json:"-"`
+}
+
+type DeepSeekRecommendResponse struct {
+	Jobs []struct {
+		JobID      string              `json:"job_id"`
+		MatchScore int                 `json:"match_score"`
+		Scores     MatchScoreBreakdown `json:"scores"`
+		Reasoning  string              `json:"reasoning"`
+	} `json:"jobs"`
+	PromptVersion string `json:"-"`
+}
+
+// StoredFile - файл резюме в хранилище с метаданными для скачивания
+type StoredFile struct {
+	ObjectName       string
+	OriginalFilename string
+	ContentType      string
+}
+
+type SignedFileURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CVVersion - версия CV пользователя платформы. Основная версия показывается
+// в профиле и прикладывается к отклику, если кандидат не выбрал другую
+type CVVersion struct {
+	ID               string    `json:"id"`
+	Label            string    `json:"label"`
+	Link             string    `json:"link"`
+	OriginalFilename *string   `json:"original_filename"`
+	IsPrimary        bool      `json:"is_primary"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// CVVersionUpdate - изменение подписи версии или выбор её основной; nil
+// оставляет поле без изменений
+type CVVersionUpdate struct {
+	Label     *string
+	IsPrimary *bool
 }
@@ -1,11 +1,11 @@
 -- name: CreateChat :one
-INSERT INTO chat.chats (created_at, updated_at)
-VALUES (NOW(), NOW())
+INSERT INTO chat.chats (type, title, job_id, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
 RETURNING *;
 
 -- name: AddUserToChat :exec
-INSERT INTO chat.chat_users (chat_id, user_id)
-VALUES ($1, $2);
+INSERT INTO chat.chat_users (chat_id, user_id, role)
+VALUES ($1, $2, $3);
 
 -- name: GetChatByID :one
 SELECT c.*, array_agg(cu.user_id) as users
@@ -27,8 +27,8 @@ GROUP BY c.id
 ORDER BY c.updated_at DESC;
 
 -- name: CreateMessage :one
-INSERT INTO chat.messages (chat_id, user_id, text)
-VALUES ($1, $2, $3)
+INSERT INTO chat.messages (chat_id, user_id, text, type, target_user_id, reply_to_id)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetChatMessages :many
@@ -48,11 +48,10 @@ LIMIT 1;
 -- name: GetUnreadCount :one
 SELECT COUNT(*)
 FROM chat.messages m
-WHERE m.chat_id = $1 AND m.user_id != $2 AND m.created_at > (
-    SELECT COALESCE(MAX(created_at), '1970-01-01'::timestamp)
-    FROM chat.messages
-    WHERE chat_id = $1 AND user_id = $2
-);
+JOIN chat.chat_users cu ON cu.chat_id = m.chat_id AND cu.user_id = $2
+LEFT JOIN chat.messages r ON r.id = cu.last_read_message_id
+WHERE m.chat_id = $1 AND m.user_id != $2 AND m.type = 'text' AND m.deleted_at IS NULL
+  AND (r.id IS NULL OR (m.created_at, m.id) > (r.created_at, r.id));
 
 -- name: UpdateChatUpdatedAt :exec
 UPDATE chat.chats
@@ -60,16 +59,184 @@ SET updated_at = NOW()
 WHERE id = $1; 
 
 -- name: GetChatByUsersIDs :one
+SELECT c.*
+FROM chat.chats c
+WHERE c.type = 'direct'
+  AND EXISTS (SELECT 1 FROM chat.chat_users WHERE chat_id = c.id AND user_id = $1)
+  AND EXISTS (SELECT 1 FROM chat.chat_users WHERE chat_id = c.id AND user_id = $2)
+ORDER BY c.updated_at DESC
+LIMIT 1;
+
+-- name: GetChatMessageByID :one
+SELECT *
+FROM chat.messages
+WHERE id = $1 AND chat_id = $2;
+
+-- name: MarkChatRead :execrows
+UPDATE chat.chat_users cu
+SET last_read_message_id = m.id, last_read_at = NOW()
+FROM chat.messages m
+WHERE cu.chat_id = $1 AND cu.user_id = $2 AND m.id = $3 AND m.chat_id = cu.chat_id
+  AND NOT EXISTS (
+    SELECT 1
+    FROM chat.messages r
+    WHERE r.id = cu.last_read_message_id AND (r.created_at, r.id) >= (m.created_at, m.id)
+  );
+
+-- name: GetChatReadReceipts :many
+SELECT user_id, last_read_message_id, last_read_at
+FROM chat.chat_users
+WHERE chat_id = $1 AND last_read_message_id IS NOT NULL
+ORDER BY user_id;
+
+-- name: GetUserChatIDs :many
+SELECT chat_id
+FROM chat.chat_users
+WHERE user_id = $1;
+
+-- name: GetChatMembers :many
+SELECT user_id, role, created_at
+FROM chat.chat_users
+WHERE chat_id = $1
+ORDER BY created_at, user_id;
+
+-- name: GetChatMember :one
+SELECT user_id, role, created_at
+FROM chat.chat_users
+WHERE chat_id = $1 AND user_id = $2;
+
+-- name: RemoveUserFromChat :execrows
+DELETE FROM chat.chat_users
+WHERE chat_id = $1 AND user_id = $2;
+
+-- name: UpdateChatMemberRole :execrows
+UPDATE chat.chat_users
+SET role = $3
+WHERE chat_id = $1 AND user_id = $2;
+
+-- name: UpdateChatTitle :execrows
+UPDATE chat.chats
+SET title = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CreateAttachment :one
+INSERT INTO chat.attachments (chat_id, message_id, user_id, kind, object_name, preview_object_name, filename, content_type, size)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetAttachment :one
+SELECT *
+FROM chat.attachments
+WHERE id = $1 AND chat_id = $2;
+
+-- name: GetMessagesAttachments :many
+SELECT *
+FROM chat.attachments
+WHERE message_id = ANY(sqlc.arg(message_ids)::uuid[])
+ORDER BY created_at, id;
+
+-- name: AttachToMessage :execrows
+UPDATE chat.attachments
+SET message_id = sqlc.arg(message_id)
+WHERE id = ANY(sqlc.arg(ids)::uuid[])
+  AND chat_id = sqlc.arg(chat_id)
+  AND user_id = sqlc.arg(user_id)
+  AND message_id IS NULL;
+
+-- name: DeleteUnsentAttachment :one
+DELETE FROM chat.attachments
+WHERE id = $1 AND chat_id = $2 AND user_id = $3 AND message_id IS NULL
+RETURNING *;
+
+-- name: GetChatMessageForUpdate :one
+SELECT *
+FROM chat.messages
+WHERE id = $1 AND chat_id = $2
+FOR UPDATE;
+
+-- name: GetMessagesByIDs :many
 SELECT *
-FROM chat.chats
-where id = (SELECT chat_id
-			FROM chat.chat_users
-			WHERE user_id IN ($1, $2)
-			GROUP BY chat_id
-			HAVING COUNT(DISTINCT user_id) = 2
-			ORDER BY MAX(created_at) DESC
-			LIMIT 1
-			)
-ORDER BY updated_at DESC
-LIMIT 1
-;
\ No newline at end of file
+FROM chat.messages
+WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: UpdateMessageText :one
+UPDATE chat.messages
+SET text = $2, edited_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: CreateMessageEdit :exec
+INSERT INTO chat.message_edits (message_id, text)
+VALUES ($1, $2);
+
+-- name: GetMessageEdits :many
+SELECT *
+FROM chat.message_edits
+WHERE message_id = $1
+ORDER BY replaced_at, id;
+
+-- name: DeleteMessage :one
+UPDATE chat.messages
+SET text = '', deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteMessageEdits :exec
+DELETE FROM chat.message_edits
+WHERE message_id = $1;
+
+-- name: DeleteMessageReactions :exec
+DELETE FROM chat.message_reactions
+WHERE message_id = $1;
+
+-- name: DeleteMessageAttachments :many
+DELETE FROM chat.attachments
+WHERE message_id = $1
+RETURNING *;
+
+-- name: AddMessageReaction :exec
+INSERT INTO chat.message_reactions (message_id, user_id, emoji)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveMessageReaction :execrows
+DELETE FROM chat.message_reactions
+WHERE message_id = $1 AND user_id = $2 AND emoji = $3;
+
+-- name: GetMessagesReactions :many
+SELECT *
+FROM chat.message_reactions
+WHERE message_id = ANY(sqlc.arg(message_ids)::uuid[])
+ORDER BY created_at, user_id;
+
+-- name: SearchMessages :many
+WITH q AS (
+    SELECT plainto_tsquery('russian', sqlc.arg(query)::text) || plainto_tsquery('english', sqlc.arg(query)::text) AS tsq
+)
+SELECT m.*,
+    ts_rank_cd(to_tsvector('russian', m.text) || to_tsvector('english', m.text), q.tsq, 32)::float8 AS score,
+    ts_headline('russian', m.text, q.tsq, sqlc.arg(headline_options)::text) AS snippet
+FROM chat.messages m
+CROSS JOIN q
+JOIN chat.chat_users cu ON cu.chat_id = m.chat_id AND cu.user_id = sqlc.arg(user_id)
+WHERE m.type = 'text' AND m.deleted_at IS NULL
+    AND (to_tsvector('russian', m.text) || to_tsvector('english', m.text)) @@ q.tsq
+    AND (sqlc.narg(chat_id)::uuid IS NULL OR m.chat_id = sqlc.narg(chat_id)::uuid)
+ORDER BY score DESC, m.created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: GetMessagesBefore :many
+SELECT *
+FROM chat.messages
+WHERE chat_id = sqlc.arg(chat_id)
+    AND (created_at, id) < (sqlc.arg(created_at)::timestamptz, sqlc.arg(id)::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetMessagesAfter :many
+SELECT *
+FROM chat.messages
+WHERE chat_id = sqlc.arg(chat_id)
+    AND (created_at, id) > (sqlc.arg(created_at)::timestamptz, sqlc.arg(id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg(limit_count);
@@ -7,53 +7,211 @@ package chat
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const addMessageReaction = `-- name: AddMessageReaction :exec
+INSERT INTO chat.message_reactions (message_id, user_id, emoji)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+`
+
+type AddMessageReactionParams struct {
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+	Emoji     string
+}
+
+func (q *Queries) AddMessageReaction(ctx context.Context, db DBTX, arg AddMessageReactionParams) error {
+	_, err := db.Exec(ctx, addMessageReaction, arg.MessageID, arg.UserID, arg.Emoji)
+	return err
+}
+
 const addUserToChat = `-- name: AddUserToChat :exec
-INSERT INTO chat.chat_users (chat_id, user_id)
-VALUES ($1, $2)
+INSERT INTO chat.chat_users (chat_id, user_id, role)
+VALUES ($1, $2, $3)
 `
 
 type AddUserToChatParams struct {
 	ChatID uuid.UUID
 	UserID uuid.UUID
+	Role   string
 }
 
 func (q *Queries) AddUserToChat(ctx context.Context, db DBTX, arg AddUserToChatParams) error {
-	_, err := db.Exec(ctx, addUserToChat, arg.ChatID, arg.UserID)
+	_, err := db.Exec(ctx, addUserToChat, arg.ChatID, arg.UserID, arg.Role)
 	return err
 }
 
+const attachToMessage = `-- name: AttachToMessage :execrows
+UPDATE chat.attachments
+SET message_id = $1
+WHERE id = ANY($2::uuid[])
+  AND chat_id = $3
+  AND user_id = $4
+  AND message_id IS NULL
+`
+
+type AttachToMessageParams struct {
+	MessageID uuid.NullUUID
+	Ids       []uuid.UUID
+	ChatID    uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (q *Queries) AttachToMessage(ctx context.Context, db DBTX, arg AttachToMessageParams) (int64, error) {
+	result, err := db.Exec(ctx, attachToMessage,
+		arg.MessageID,
+		arg.Ids,
+		arg.ChatID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createAttachment = `-- name: CreateAttachment :one
+INSERT INTO chat.attachments (chat_id, message_id, user_id, kind, object_name, preview_object_name, filename, content_type, size)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, chat_id, message_id, user_id, kind, object_name, preview_object_name, filename, content_type, size, created_at
+`
+
+type CreateAttachmentParams struct {
+	ChatID            uuid.UUID
+	MessageID         uuid.NullUUID
+	UserID            uuid.UUID
+	Kind              string
+	ObjectName        string
+	PreviewObjectName sql.NullString
+	Filename          string
+	ContentType       string
+	Size              int64
+}
+
+func (q *Queries) CreateAttachment(ctx context.Context, db DBTX, arg CreateAttachmentParams) (ChatAttachment, error) {
+	row := db.QueryRow(ctx, createAttachment,
+		arg.ChatID,
+		arg.MessageID,
+		arg.UserID,
+		arg.Kind,
+		arg.ObjectName,
+		arg.PreviewObjectName,
+		arg.Filename,
+		arg.ContentType,
+		arg.Size,
+	)
+	var i ChatAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.MessageID,
+		&i.UserID,
+		&i.Kind,
+		&i.ObjectName,
+		&i.PreviewObjectName,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createChat = `-- name: CreateChat :one
-INSERT INTO chat.chats (created_at, updated_at)
-VALUES (NOW(), NOW())
-RETURNING id, created_at, updated_at
+INSERT INTO chat.chats (type, title, job_id, created_at, updated_at)
+VALUES ($1, $2, $3, NOW(), NOW())
+RETURNING id, created_at, updated_at, type, title, job_id
 `
 
-func (q *Queries) CreateChat(ctx context.Context, db DBTX) (ChatChat, error) {
-	row := db.QueryRow(ctx, createChat)
+type CreateChatParams struct {
+	Type  string
+	Title sql.NullString
+	JobID uuid.NullUUID
+}
+
+func (q *Queries) CreateChat(ctx context.Context, db DBTX, arg CreateChatParams) (ChatChat, error) {
+	row := db.QueryRow(ctx, createChat, arg.Type, arg.Title, arg.JobID)
 	var i ChatChat
-	err := row.Scan(&i.ID, &i.CreatedAt, &i.UpdatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Type,
+		&i.Title,
+		&i.JobID,
+	)
 	return i, err
 }
 
 const createMessage = `-- name: CreateMessage :one
-INSERT INTO chat.messages (chat_id, user_id, text)
-VALUES ($1, $2, $3)
-RETURNING id, chat_id, user_id, text, created_at
+INSERT INTO chat.messages (chat_id, user_id, text, type, target_user_id, reply_to_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
 `
 
 type CreateMessageParams struct {
-	ChatID uuid.UUID
-	UserID uuid.UUID
-	Text   string
+	ChatID       uuid.UUID
+	UserID       uuid.UUID
+	Text         string
+	Type         string
+	TargetUserID uuid.NullUUID
+	ReplyToID    uuid.NullUUID
 }
 
 func (q *Queries) CreateMessage(ctx context.Context, db DBTX, arg CreateMessageParams) (ChatMessage, error) {
-	row := db.QueryRow(ctx, createMessage, arg.ChatID, arg.UserID, arg.Text)
+	row := db.QueryRow(ctx, createMessage,
+		arg.ChatID,
+		arg.UserID,
+		arg.Text,
+		arg.Type,
+		arg.TargetUserID,
+		arg.ReplyToID,
+	)
+	var i ChatMessage
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.UserID,
+		&i.Text,
+		&i.CreatedAt,
+		&i.Type,
+		&i.TargetUserID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ReplyToID,
+	)
+	return i, err
+}
+
+const createMessageEdit = `-- name: CreateMessageEdit :exec
+INSERT INTO chat.message_edits (message_id, text)
+VALUES ($1, $2)
+`
+
+type CreateMessageEditParams struct {
+	MessageID uuid.UUID
+	Text      string
+}
+
+func (q *Queries) CreateMessageEdit(ctx context.Context, db DBTX, arg CreateMessageEditParams) error {
+	_, err := db.Exec(ctx, createMessageEdit, arg.MessageID, arg.Text)
+	return err
+}
+
+const deleteMessage = `-- name: DeleteMessage :one
+UPDATE chat.messages
+SET text = '', deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
+`
+
+func (q *Queries) DeleteMessage(ctx context.Context, db DBTX, id uuid.UUID) (ChatMessage, error) {
+	row := db.QueryRow(ctx, deleteMessage, id)
 	var i ChatMessage
 	err := row.Scan(
 		&i.ID,
@@ -61,12 +219,136 @@ func (q *Queries) CreateMessage(ctx context.Context, db DBTX, arg CreateMessageP
 		&i.UserID,
 		&i.Text,
 		&i.CreatedAt,
+		&i.Type,
+		&i.TargetUserID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ReplyToID,
+	)
+	return i, err
+}
+
+const deleteMessageAttachments = `-- name: DeleteMessageAttachments :many
+DELETE FROM chat.attachments
+WHERE message_id = $1
+RETURNING id, chat_id, message_id, user_id, kind, object_name, preview_object_name, filename, content_type, size, created_at
+`
+
+func (q *Queries) DeleteMessageAttachments(ctx context.Context, db DBTX, messageID uuid.NullUUID) ([]ChatAttachment, error) {
+	rows, err := db.Query(ctx, deleteMessageAttachments, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatAttachment
+	for rows.Next() {
+		var i ChatAttachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.MessageID,
+			&i.UserID,
+			&i.Kind,
+			&i.ObjectName,
+			&i.PreviewObjectName,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteMessageEdits = `-- name: DeleteMessageEdits :exec
+DELETE FROM chat.message_edits
+WHERE message_id = $1
+`
+
+func (q *Queries) DeleteMessageEdits(ctx context.Context, db DBTX, messageID uuid.UUID) error {
+	_, err := db.Exec(ctx, deleteMessageEdits, messageID)
+	return err
+}
+
+const deleteMessageReactions = `-- name: DeleteMessageReactions :exec
+DELETE FROM chat.message_reactions
+WHERE message_id = $1
+`
+
+func (q *Queries) DeleteMessageReactions(ctx context.Context, db DBTX, messageID uuid.UUID) error {
+	_, err := db.Exec(ctx, deleteMessageReactions, messageID)
+	return err
+}
+
+const deleteUnsentAttachment = `-- name: DeleteUnsentAttachment :one
+DELETE FROM chat.attachments
+WHERE id = $1 AND chat_id = $2 AND user_id = $3 AND message_id IS NULL
+RETURNING id, chat_id, message_id, user_id, kind, object_name, preview_object_name, filename, content_type, size, created_at
+`
+
+type DeleteUnsentAttachmentParams struct {
+	ID     uuid.UUID
+	ChatID uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) DeleteUnsentAttachment(ctx context.Context, db DBTX, arg DeleteUnsentAttachmentParams) (ChatAttachment, error) {
+	row := db.QueryRow(ctx, deleteUnsentAttachment, arg.ID, arg.ChatID, arg.UserID)
+	var i ChatAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.MessageID,
+		&i.UserID,
+		&i.Kind,
+		&i.ObjectName,
+		&i.PreviewObjectName,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAttachment = `-- name: GetAttachment :one
+SELECT id, chat_id, message_id, user_id, kind, object_name, preview_object_name, filename, content_type, size, created_at
+FROM chat.attachments
+WHERE id = $1 AND chat_id = $2
+`
+
+type GetAttachmentParams struct {
+	ID     uuid.UUID
+	ChatID uuid.UUID
+}
+
+func (q *Queries) GetAttachment(ctx context.Context, db DBTX, arg GetAttachmentParams) (ChatAttachment, error) {
+	row := db.QueryRow(ctx, getAttachment, arg.ID, arg.ChatID)
+	var i ChatAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.MessageID,
+		&i.UserID,
+		&i.Kind,
+		&i.ObjectName,
+		&i.PreviewObjectName,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const getChatByID = `-- name: GetChatByID :one
-SELECT c.id, c.created_at, c.updated_at, array_agg(cu.user_id) as users
+SELECT c.id, c.created_at, c.updated_at, c.type, c.title, c.job_id, array_agg(cu.user_id) as users
 FROM chat.chats c
 JOIN chat.chat_users cu ON c.id = cu.chat_id
 WHERE c.id = $1
@@ -77,6 +359,9 @@ type GetChatByIDRow struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	Type      string
+	Title     sql.NullString
+	JobID     uuid.NullUUID
 	Users     interface{}
 }
 
@@ -87,23 +372,21 @@ func (q *Queries) GetChatByID(ctx context.Context, db DBTX, id uuid.UUID) (GetCh
 		&i.ID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Type,
+		&i.Title,
+		&i.JobID,
 		&i.Users,
 	)
 	return i, err
 }
 
 const getChatByUsersIDs = `-- name: GetChatByUsersIDs :one
-SELECT id, created_at, updated_at
-FROM chat.chats
-where id = (SELECT chat_id
-			FROM chat.chat_users
-			WHERE user_id IN ($1, $2)
-			GROUP BY chat_id
-			HAVING COUNT(DISTINCT user_id) = 2
-			ORDER BY MAX(created_at) DESC
-			LIMIT 1
-			)
-ORDER BY updated_at DESC
+SELECT c.id, c.created_at, c.updated_at, c.type, c.title, c.job_id
+FROM chat.chats c
+WHERE c.type = 'direct'
+  AND EXISTS (SELECT 1 FROM chat.chat_users WHERE chat_id = c.id AND user_id = $1)
+  AND EXISTS (SELECT 1 FROM chat.chat_users WHERE chat_id = c.id AND user_id = $2)
+ORDER BY c.updated_at DESC
 LIMIT 1
 `
 
@@ -115,12 +398,135 @@ type GetChatByUsersIDsParams struct {
 func (q *Queries) GetChatByUsersIDs(ctx context.Context, db DBTX, arg GetChatByUsersIDsParams) (ChatChat, error) {
 	row := db.QueryRow(ctx, getChatByUsersIDs, arg.UserID, arg.UserID_2)
 	var i ChatChat
-	err := row.Scan(&i.ID, &i.CreatedAt, &i.UpdatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Type,
+		&i.Title,
+		&i.JobID,
+	)
+	return i, err
+}
+
+const getChatMember = `-- name: GetChatMember :one
+SELECT user_id, role, created_at
+FROM chat.chat_users
+WHERE chat_id = $1 AND user_id = $2
+`
+
+type GetChatMemberParams struct {
+	ChatID uuid.UUID
+	UserID uuid.UUID
+}
+
+type GetChatMemberRow struct {
+	UserID    uuid.UUID
+	Role      string
+	CreatedAt time.Time
+}
+
+func (q *Queries) GetChatMember(ctx context.Context, db DBTX, arg GetChatMemberParams) (GetChatMemberRow, error) {
+	row := db.QueryRow(ctx, getChatMember, arg.ChatID, arg.UserID)
+	var i GetChatMemberRow
+	err := row.Scan(&i.UserID, &i.Role, &i.CreatedAt)
+	return i, err
+}
+
+const getChatMembers = `-- name: GetChatMembers :many
+SELECT user_id, role, created_at
+FROM chat.chat_users
+WHERE chat_id = $1
+ORDER BY created_at, user_id
+`
+
+type GetChatMembersRow struct {
+	UserID    uuid.UUID
+	Role      string
+	CreatedAt time.Time
+}
+
+func (q *Queries) GetChatMembers(ctx context.Context, db DBTX, chatID uuid.UUID) ([]GetChatMembersRow, error) {
+	rows, err := db.Query(ctx, getChatMembers, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetChatMembersRow
+	for rows.Next() {
+		var i GetChatMembersRow
+		if err := rows.Scan(&i.UserID, &i.Role, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getChatMessageByID = `-- name: GetChatMessageByID :one
+SELECT id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
+FROM chat.messages
+WHERE id = $1 AND chat_id = $2
+`
+
+type GetChatMessageByIDParams struct {
+	ID     uuid.UUID
+	ChatID uuid.UUID
+}
+
+func (q *Queries) GetChatMessageByID(ctx context.Context, db DBTX, arg GetChatMessageByIDParams) (ChatMessage, error) {
+	row := db.QueryRow(ctx, getChatMessageByID, arg.ID, arg.ChatID)
+	var i ChatMessage
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.UserID,
+		&i.Text,
+		&i.CreatedAt,
+		&i.Type,
+		&i.TargetUserID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ReplyToID,
+	)
+	return i, err
+}
+
+const getChatMessageForUpdate = `-- name: GetChatMessageForUpdate :one
+SELECT id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
+FROM chat.messages
+WHERE id = $1 AND chat_id = $2
+FOR UPDATE
+`
+
+type GetChatMessageForUpdateParams struct {
+	ID     uuid.UUID
+	ChatID uuid.UUID
+}
+
+func (q *Queries) GetChatMessageForUpdate(ctx context.Context, db DBTX, arg GetChatMessageForUpdateParams) (ChatMessage, error) {
+	row := db.QueryRow(ctx, getChatMessageForUpdate, arg.ID, arg.ChatID)
+	var i ChatMessage
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.UserID,
+		&i.Text,
+		&i.CreatedAt,
+		&i.Type,
+		&i.TargetUserID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ReplyToID,
+	)
 	return i, err
 }
 
 const getChatMessages = `-- name: GetChatMessages :many
-SELECT id, chat_id, user_id, text, created_at
+SELECT id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
 FROM chat.messages
 WHERE chat_id = $1
 ORDER BY created_at ASC
@@ -148,6 +554,11 @@ func (q *Queries) GetChatMessages(ctx context.Context, db DBTX, arg GetChatMessa
 			&i.UserID,
 			&i.Text,
 			&i.CreatedAt,
+			&i.Type,
+			&i.TargetUserID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ReplyToID,
 		); err != nil {
 			return nil, err
 		}
@@ -159,8 +570,41 @@ func (q *Queries) GetChatMessages(ctx context.Context, db DBTX, arg GetChatMessa
 	return items, nil
 }
 
+const getChatReadReceipts = `-- name: GetChatReadReceipts :many
+SELECT user_id, last_read_message_id, last_read_at
+FROM chat.chat_users
+WHERE chat_id = $1 AND last_read_message_id IS NOT NULL
+ORDER BY user_id
+`
+
+type GetChatReadReceiptsRow struct {
+	UserID            uuid.UUID
+	LastReadMessageID uuid.NullUUID
+	LastReadAt        sql.NullTime
+}
+
+func (q *Queries) GetChatReadReceipts(ctx context.Context, db DBTX, chatID uuid.UUID) ([]GetChatReadReceiptsRow, error) {
+	rows, err := db.Query(ctx, getChatReadReceipts, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetChatReadReceiptsRow
+	for rows.Next() {
+		var i GetChatReadReceiptsRow
+		if err := rows.Scan(&i.UserID, &i.LastReadMessageID, &i.LastReadAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLastMessage = `-- name: GetLastMessage :one
-SELECT id, chat_id, user_id, text, created_at
+SELECT id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
 FROM chat.messages
 WHERE chat_id = $1
 ORDER BY created_at DESC
@@ -176,18 +620,266 @@ func (q *Queries) GetLastMessage(ctx context.Context, db DBTX, chatID uuid.UUID)
 		&i.UserID,
 		&i.Text,
 		&i.CreatedAt,
+		&i.Type,
+		&i.TargetUserID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ReplyToID,
 	)
 	return i, err
 }
 
+const getMessageEdits = `-- name: GetMessageEdits :many
+SELECT id, message_id, text, replaced_at
+FROM chat.message_edits
+WHERE message_id = $1
+ORDER BY replaced_at, id
+`
+
+func (q *Queries) GetMessageEdits(ctx context.Context, db DBTX, messageID uuid.UUID) ([]ChatMessageEdit, error) {
+	rows, err := db.Query(ctx, getMessageEdits, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatMessageEdit
+	for rows.Next() {
+		var i ChatMessageEdit
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.Text,
+			&i.ReplacedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesAfter = `-- name: GetMessagesAfter :many
+SELECT id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
+FROM chat.messages
+WHERE chat_id = $1
+    AND (created_at, id) > ($2::timestamptz, $3::uuid)
+ORDER BY created_at, id
+LIMIT $4
+`
+
+type GetMessagesAfterParams struct {
+	ChatID     uuid.UUID
+	CreatedAt  time.Time
+	ID         uuid.UUID
+	LimitCount int32
+}
+
+func (q *Queries) GetMessagesAfter(ctx context.Context, db DBTX, arg GetMessagesAfterParams) ([]ChatMessage, error) {
+	rows, err := db.Query(ctx, getMessagesAfter,
+		arg.ChatID,
+		arg.CreatedAt,
+		arg.ID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatMessage
+	for rows.Next() {
+		var i ChatMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.UserID,
+			&i.Text,
+			&i.CreatedAt,
+			&i.Type,
+			&i.TargetUserID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ReplyToID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesAttachments = `-- name: GetMessagesAttachments :many
+SELECT id, chat_id, message_id, user_id, kind, object_name, preview_object_name, filename, content_type, size, created_at
+FROM chat.attachments
+WHERE message_id = ANY($1::uuid[])
+ORDER BY created_at, id
+`
+
+func (q *Queries) GetMessagesAttachments(ctx context.Context, db DBTX, messageIds []uuid.UUID) ([]ChatAttachment, error) {
+	rows, err := db.Query(ctx, getMessagesAttachments, messageIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatAttachment
+	for rows.Next() {
+		var i ChatAttachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.MessageID,
+			&i.UserID,
+			&i.Kind,
+			&i.ObjectName,
+			&i.PreviewObjectName,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesBefore = `-- name: GetMessagesBefore :many
+SELECT id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
+FROM chat.messages
+WHERE chat_id = $1
+    AND (created_at, id) < ($2::timestamptz, $3::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT $4
+`
+
+type GetMessagesBeforeParams struct {
+	ChatID     uuid.UUID
+	CreatedAt  time.Time
+	ID         uuid.UUID
+	LimitCount int32
+}
+
+func (q *Queries) GetMessagesBefore(ctx context.Context, db DBTX, arg GetMessagesBeforeParams) ([]ChatMessage, error) {
+	rows, err := db.Query(ctx, getMessagesBefore,
+		arg.ChatID,
+		arg.CreatedAt,
+		arg.ID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatMessage
+	for rows.Next() {
+		var i ChatMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.UserID,
+			&i.Text,
+			&i.CreatedAt,
+			&i.Type,
+			&i.TargetUserID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ReplyToID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesByIDs = `-- name: GetMessagesByIDs :many
+SELECT id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
+FROM chat.messages
+WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) GetMessagesByIDs(ctx context.Context, db DBTX, ids []uuid.UUID) ([]ChatMessage, error) {
+	rows, err := db.Query(ctx, getMessagesByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatMessage
+	for rows.Next() {
+		var i ChatMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.UserID,
+			&i.Text,
+			&i.CreatedAt,
+			&i.Type,
+			&i.TargetUserID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ReplyToID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesReactions = `-- name: GetMessagesReactions :many
+SELECT message_id, user_id, emoji, created_at
+FROM chat.message_reactions
+WHERE message_id = ANY($1::uuid[])
+ORDER BY created_at, user_id
+`
+
+func (q *Queries) GetMessagesReactions(ctx context.Context, db DBTX, messageIds []uuid.UUID) ([]ChatMessageReaction, error) {
+	rows, err := db.Query(ctx, getMessagesReactions, messageIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatMessageReaction
+	for rows.Next() {
+		var i ChatMessageReaction
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.UserID,
+			&i.Emoji,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUnreadCount = `-- name: GetUnreadCount :one
 SELECT COUNT(*)
 FROM chat.messages m
-WHERE m.chat_id = $1 AND m.user_id != $2 AND m.created_at > (
-    SELECT COALESCE(MAX(created_at), '1970-01-01'::timestamp)
-    FROM chat.messages
-    WHERE chat_id = $1 AND user_id = $2
-)
+JOIN chat.chat_users cu ON cu.chat_id = m.chat_id AND cu.user_id = $2
+LEFT JOIN chat.messages r ON r.id = cu.last_read_message_id
+WHERE m.chat_id = $1 AND m.user_id != $2 AND m.type = 'text' AND m.deleted_at IS NULL
+  AND (r.id IS NULL OR (m.created_at, m.id) > (r.created_at, r.id))
 `
 
 type GetUnreadCountParams struct {
@@ -202,8 +894,34 @@ func (q *Queries) GetUnreadCount(ctx context.Context, db DBTX, arg GetUnreadCoun
 	return count, err
 }
 
+const getUserChatIDs = `-- name: GetUserChatIDs :many
+SELECT chat_id
+FROM chat.chat_users
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserChatIDs(ctx context.Context, db DBTX, userID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := db.Query(ctx, getUserChatIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var chat_id uuid.UUID
+		if err := rows.Scan(&chat_id); err != nil {
+			return nil, err
+		}
+		items = append(items, chat_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserChats = `-- name: GetUserChats :many
-SELECT c.id, c.created_at, c.updated_at, array_agg(cu.user_id) as users
+SELECT c.id, c.created_at, c.updated_at, c.type, c.title, c.job_id, array_agg(cu.user_id) as users
 FROM chat.chats c
 JOIN chat.chat_users cu ON c.id = cu.chat_id
 WHERE c.id IN (
@@ -219,6 +937,9 @@ type GetUserChatsRow struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	Type      string
+	Title     sql.NullString
+	JobID     uuid.NullUUID
 	Users     interface{}
 }
 
@@ -235,6 +956,9 @@ func (q *Queries) GetUserChats(ctx context.Context, db DBTX, userID uuid.UUID) (
 			&i.ID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Type,
+			&i.Title,
+			&i.JobID,
 			&i.Users,
 		); err != nil {
 			return nil, err
@@ -247,6 +971,189 @@ func (q *Queries) GetUserChats(ctx context.Context, db DBTX, userID uuid.UUID) (
 	return items, nil
 }
 
+const markChatRead = `-- name: MarkChatRead :execrows
+UPDATE chat.chat_users cu
+SET last_read_message_id = m.id, last_read_at = NOW()
+FROM chat.messages m
+WHERE cu.chat_id = $1 AND cu.user_id = $2 AND m.id = $3 AND m.chat_id = cu.chat_id
+  AND NOT EXISTS (
+    SELECT 1
+    FROM chat.messages r
+    WHERE r.id = cu.last_read_message_id AND (r.created_at, r.id) >= (m.created_at, m.id)
+  )
+`
+
+type MarkChatReadParams struct {
+	ChatID uuid.UUID
+	UserID uuid.UUID
+	ID     uuid.UUID
+}
+
+func (q *Queries) MarkChatRead(ctx context.Context, db DBTX, arg MarkChatReadParams) (int64, error) {
+	result, err := db.Exec(ctx, markChatRead, arg.ChatID, arg.UserID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const removeMessageReaction = `-- name: RemoveMessageReaction :execrows
+DELETE FROM chat.message_reactions
+WHERE message_id = $1 AND user_id = $2 AND emoji = $3
+`
+
+type RemoveMessageReactionParams struct {
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+	Emoji     string
+}
+
+func (q *Queries) RemoveMessageReaction(ctx context.Context, db DBTX, arg RemoveMessageReactionParams) (int64, error) {
+	result, err := db.Exec(ctx, removeMessageReaction, arg.MessageID, arg.UserID, arg.Emoji)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const removeUserFromChat = `-- name: RemoveUserFromChat :execrows
+DELETE FROM chat.chat_users
+WHERE chat_id = $1 AND user_id = $2
+`
+
+type RemoveUserFromChatParams struct {
+	ChatID uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) RemoveUserFromChat(ctx context.Context, db DBTX, arg RemoveUserFromChatParams) (int64, error) {
+	result, err := db.Exec(ctx, removeUserFromChat, arg.ChatID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const searchMessages = `-- name: SearchMessages :many
+WITH q AS (
+    SELECT plainto_tsquery('russian', $1::text) || plainto_tsquery('english', $1::text) AS tsq
+)
+SELECT m.id, m.chat_id, m.user_id, m.text, m.created_at, m.type, m.target_user_id, m.edited_at, m.deleted_at, m.reply_to_id,
+    ts_rank_cd(to_tsvector('russian', m.text) || to_tsvector('english', m.text), q.tsq, 32)::float8 AS score,
+    ts_headline('russian', m.text, q.tsq, $2::text) AS snippet
+FROM chat.messages m
+CROSS JOIN q
+JOIN chat.chat_users cu ON cu.chat_id = m.chat_id AND cu.user_id = $3
+WHERE m.type = 'text' AND m.deleted_at IS NULL
+    AND (to_tsvector('russian', m.text) || to_tsvector('english', m.text)) @@ q.tsq
+    AND ($4::uuid IS NULL OR m.chat_id = $4::uuid)
+ORDER BY score DESC, m.created_at DESC
+LIMIT $5 OFFSET $6
+`
+
+type SearchMessagesParams struct {
+	Query           string
+	HeadlineOptions string
+	UserID          uuid.UUID
+	ChatID          uuid.NullUUID
+	LimitCount      int32
+	OffsetCount     int32
+}
+
+type SearchMessagesRow struct {
+	ID           uuid.UUID
+	ChatID       uuid.UUID
+	UserID       uuid.UUID
+	Text         string
+	CreatedAt    time.Time
+	Type         string
+	TargetUserID uuid.NullUUID
+	EditedAt     sql.NullTime
+	DeletedAt    sql.NullTime
+	ReplyToID    uuid.NullUUID
+	Score        float64
+	Snippet      string
+}
+
+func (q *Queries) SearchMessages(ctx context.Context, db DBTX, arg SearchMessagesParams) ([]SearchMessagesRow, error) {
+	rows, err := db.Query(ctx, searchMessages,
+		arg.Query,
+		arg.HeadlineOptions,
+		arg.UserID,
+		arg.ChatID,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchMessagesRow
+	for rows.Next() {
+		var i SearchMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChatID,
+			&i.UserID,
+			&i.Text,
+			&i.CreatedAt,
+			&i.Type,
+			&i.TargetUserID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ReplyToID,
+			&i.Score,
+			&i.Snippet,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateChatMemberRole = `-- name: UpdateChatMemberRole :execrows
+UPDATE chat.chat_users
+SET role = $3
+WHERE chat_id = $1 AND user_id = $2
+`
+
+type UpdateChatMemberRoleParams struct {
+	ChatID uuid.UUID
+	UserID uuid.UUID
+	Role   string
+}
+
+func (q *Queries) UpdateChatMemberRole(ctx context.Context, db DBTX, arg UpdateChatMemberRoleParams) (int64, error) {
+	result, err := db.Exec(ctx, updateChatMemberRole, arg.ChatID, arg.UserID, arg.Role)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateChatTitle = `-- name: UpdateChatTitle :execrows
+UPDATE chat.chats
+SET title = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateChatTitleParams struct {
+	ID    uuid.UUID
+	Title sql.NullString
+}
+
+func (q *Queries) UpdateChatTitle(ctx context.Context, db DBTX, arg UpdateChatTitleParams) (int64, error) {
+	result, err := db.Exec(ctx, updateChatTitle, arg.ID, arg.Title)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateChatUpdatedAt = `-- name: UpdateChatUpdatedAt :exec
 UPDATE chat.chats
 SET updated_at = NOW()
@@ -257,3 +1164,33 @@ func (q *Queries) UpdateChatUpdatedAt(ctx context.Context, db DBTX, id uuid.UUID
 	_, err := db.Exec(ctx, updateChatUpdatedAt, id)
 	return err
 }
+
+const updateMessageText = `-- name: UpdateMessageText :one
+UPDATE chat.messages
+SET text = $2, edited_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, chat_id, user_id, text, created_at, type, target_user_id, edited_at, deleted_at, reply_to_id
+`
+
+type UpdateMessageTextParams struct {
+	ID   uuid.UUID
+	Text string
+}
+
+func (q *Queries) UpdateMessageText(ctx context.Context, db DBTX, arg UpdateMessageTextParams) (ChatMessage, error) {
+	row := db.QueryRow(ctx, updateMessageText, arg.ID, arg.Text)
+	var i ChatMessage
+	err := row.Scan(
+		&i.ID,
+		&i.ChatID,
+		&i.UserID,
+		&i.Text,
+		&i.CreatedAt,
+		&i.Type,
+		&i.TargetUserID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ReplyToID,
+	)
+	return i, err
+}
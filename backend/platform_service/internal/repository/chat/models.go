@@ -5,21 +5,58 @@
 package chat
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type ChatAttachment struct {
+	ID                uuid.UUID
+	ChatID            uuid.UUID
+	MessageID         uuid.NullUUID
+	UserID            uuid.UUID
+	Kind              string
+	ObjectName        string
+	PreviewObjectName sql.NullString
+	Filename          string
+	ContentType       string
+	Size              int64
+	CreatedAt         time.Time
+}
+
 type ChatChat struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	Type      string
+	Title     sql.NullString
+	JobID     uuid.NullUUID
 }
 
 type ChatMessage struct {
-	ID        uuid.UUID
-	ChatID    uuid.UUID
+	ID           uuid.UUID
+	ChatID       uuid.UUID
+	UserID       uuid.UUID
+	Text         string
+	CreatedAt    time.Time
+	Type         string
+	TargetUserID uuid.NullUUID
+	EditedAt     sql.NullTime
+	DeletedAt    sql.NullTime
+	ReplyToID    uuid.NullUUID
+}
+
+type ChatMessageEdit struct {
+	ID         uuid.UUID
+	MessageID  uuid.UUID
+	Text       string
+	ReplacedAt time.Time
+}
+
+type ChatMessageReaction struct {
+	MessageID uuid.UUID
 	UserID    uuid.UUID
-	Text      string
+	Emoji     string
 	CreatedAt time.Time
 }
@@ -11,16 +11,45 @@ import (
 )
 
 type Querier interface {
+	AddMessageReaction(ctx context.Context, db DBTX, arg AddMessageReactionParams) error
 	AddUserToChat(ctx context.Context, db DBTX, arg AddUserToChatParams) error
-	CreateChat(ctx context.Context, db DBTX) (ChatChat, error)
+	AttachToMessage(ctx context.Context, db DBTX, arg AttachToMessageParams) (int64, error)
+	CreateAttachment(ctx context.Context, db DBTX, arg CreateAttachmentParams) (ChatAttachment, error)
+	CreateChat(ctx context.Context, db DBTX, arg CreateChatParams) (ChatChat, error)
 	CreateMessage(ctx context.Context, db DBTX, arg CreateMessageParams) (ChatMessage, error)
+	CreateMessageEdit(ctx context.Context, db DBTX, arg CreateMessageEditParams) error
+	DeleteMessage(ctx context.Context, db DBTX, id uuid.UUID) (ChatMessage, error)
+	DeleteMessageAttachments(ctx context.Context, db DBTX, messageID uuid.NullUUID) ([]ChatAttachment, error)
+	DeleteMessageEdits(ctx context.Context, db DBTX, messageID uuid.UUID) error
+	DeleteMessageReactions(ctx context.Context, db DBTX, messageID uuid.UUID) error
+	DeleteUnsentAttachment(ctx context.Context, db DBTX, arg DeleteUnsentAttachmentParams) (ChatAttachment, error)
+	GetAttachment(ctx context.Context, db DBTX, arg GetAttachmentParams) (ChatAttachment, error)
 	GetChatByID(ctx context.Context, db DBTX, id uuid.UUID) (GetChatByIDRow, error)
 	GetChatByUsersIDs(ctx context.Context, db DBTX, arg GetChatByUsersIDsParams) (ChatChat, error)
+	GetChatMember(ctx context.Context, db DBTX, arg GetChatMemberParams) (GetChatMemberRow, error)
+	GetChatMembers(ctx context.Context, db DBTX, chatID uuid.UUID) ([]GetChatMembersRow, error)
+	GetChatMessageByID(ctx context.Context, db DBTX, arg GetChatMessageByIDParams) (ChatMessage, error)
+	GetChatMessageForUpdate(ctx context.Context, db DBTX, arg GetChatMessageForUpdateParams) (ChatMessage, error)
 	GetChatMessages(ctx context.Context, db DBTX, arg GetChatMessagesParams) ([]ChatMessage, error)
+	GetChatReadReceipts(ctx context.Context, db DBTX, chatID uuid.UUID) ([]GetChatReadReceiptsRow, error)
 	GetLastMessage(ctx context.Context, db DBTX, chatID uuid.UUID) (ChatMessage, error)
+	GetMessageEdits(ctx context.Context, db DBTX, messageID uuid.UUID) ([]ChatMessageEdit, error)
+	GetMessagesAfter(ctx context.Context, db DBTX, arg GetMessagesAfterParams) ([]ChatMessage, error)
+	GetMessagesAttachments(ctx context.Context, db DBTX, messageIds []uuid.UUID) ([]ChatAttachment, error)
+	GetMessagesBefore(ctx context.Context, db DBTX, arg GetMessagesBeforeParams) ([]ChatMessage, error)
+	GetMessagesByIDs(ctx context.Context, db DBTX, ids []uuid.UUID) ([]ChatMessage, error)
+	GetMessagesReactions(ctx context.Context, db DBTX, messageIds []uuid.UUID) ([]ChatMessageReaction, error)
 	GetUnreadCount(ctx context.Context, db DBTX, arg GetUnreadCountParams) (int64, error)
+	GetUserChatIDs(ctx context.Context, db DBTX, userID uuid.UUID) ([]uuid.UUID, error)
 	GetUserChats(ctx context.Context, db DBTX, userID uuid.UUID) ([]GetUserChatsRow, error)
+	MarkChatRead(ctx context.Context, db DBTX, arg MarkChatReadParams) (int64, error)
+	RemoveMessageReaction(ctx context.Context, db DBTX, arg RemoveMessageReactionParams) (int64, error)
+	RemoveUserFromChat(ctx context.Context, db DBTX, arg RemoveUserFromChatParams) (int64, error)
+	SearchMessages(ctx context.Context, db DBTX, arg SearchMessagesParams) ([]SearchMessagesRow, error)
+	UpdateChatMemberRole(ctx context.Context, db DBTX, arg UpdateChatMemberRoleParams) (int64, error)
+	UpdateChatTitle(ctx context.Context, db DBTX, arg UpdateChatTitleParams) (int64, error)
 	UpdateChatUpdatedAt(ctx context.Context, db DBTX, id uuid.UUID) error
+	UpdateMessageText(ctx context.Context, db DBTX, arg UpdateMessageTextParams) (ChatMessage, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -37,7 +37,8 @@ func (tm *transactionManager) WithTransaction(ctx context.Context, fn func(ctx c
 		IsoLevel: pgx.ReadCommitted,
 	}
 
-	ctx, _ = context.WithTimeout(ctx, time.Duration(tm.cfg.DBTxTimeout)*time.Millisecond)
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(tm.cfg.DBTxTimeout)*time.Millisecond)
+	defer cancel()
 	tx, err := clnt.BeginTx(ctx, opts)
 	if err != nil {
 		return err
@@ -33,6 +33,12 @@ SET
 WHERE guid = $9
 RETURNING *;
 
+-- name: UpdateCompanyAvatar :one
+UPDATE company.companies
+SET avatar = $1
+WHERE guid = $2
+RETURNING *;
+
 -- name: DeleteCompany :exec
 DELETE FROM company.companies WHERE guid = $1;
 
@@ -336,6 +336,35 @@ func (q *Queries) UpdateCompany(ctx context.Context, db DBTX, arg UpdateCompanyP
 	return i, err
 }
 
+const updateCompanyAvatar = `-- name: UpdateCompanyAvatar :one
+UPDATE company.companies
+SET avatar = $1
+WHERE guid = $2
+RETURNING guid, name, description, email, phone, website, address, avatar, short_link_name
+`
+
+type UpdateCompanyAvatarParams struct {
+	Avatar sql.NullString
+	Guid   uuid.UUID
+}
+
+func (q *Queries) UpdateCompanyAvatar(ctx context.Context, db DBTX, arg UpdateCompanyAvatarParams) (CompanyCompany, error) {
+	row := db.QueryRow(ctx, updateCompanyAvatar, arg.Avatar, arg.Guid)
+	var i CompanyCompany
+	err := row.Scan(
+		&i.Guid,
+		&i.Name,
+		&i.Description,
+		&i.Email,
+		&i.Phone,
+		&i.Website,
+		&i.Address,
+		&i.Avatar,
+		&i.ShortLinkName,
+	)
+	return i, err
+}
+
 const updateProfileCompany = `-- name: UpdateProfileCompany :exec
 INSERT INTO company.profile_company (
     guid,
@@ -23,6 +23,7 @@ type Querier interface {
 	GetProfileCompany(ctx context.Context, db DBTX, arg GetProfileCompanyParams) (CompanyProfileCompany, error)
 	SearchCompanies(ctx context.Context, db DBTX, dollar_1 sql.NullString) ([]CompanyCompany, error)
 	UpdateCompany(ctx context.Context, db DBTX, arg UpdateCompanyParams) (CompanyCompany, error)
+	UpdateCompanyAvatar(ctx context.Context, db DBTX, arg UpdateCompanyAvatarParams) (CompanyCompany, error)
 	UpdateProfileCompany(ctx context.Context, db DBTX, arg UpdateProfileCompanyParams) error
 }
 
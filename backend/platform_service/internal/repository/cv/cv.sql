@@ -2,15 +2,20 @@
 SELECT * FROM cv.cv WHERE guid = $1;
 
 -- name: GetCVByUserGUID :one
-SELECT * FROM cv.cv WHERE user_guid = $1 ORDER BY created_at DESC LIMIT 1;
+SELECT * FROM cv.cv WHERE user_guid = $1 ORDER BY is_primary DESC, created_at DESC LIMIT 1;
+
+-- name: GetCVByLink :one
+SELECT * FROM cv.cv WHERE user_guid = $1 AND link = $2 ORDER BY created_at DESC LIMIT 1;
 
 -- name: CreateCV :one
 INSERT INTO cv.cv (
     guid,
     user_guid,
-    link
+    link,
+    label,
+    is_primary
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: UpdateCV :one
@@ -23,42 +28,543 @@ RETURNING *;
 -- name: DeleteCV :exec
 DELETE FROM cv.cv WHERE guid = $1;
 
--- name: SaveCVLink :exec
-INSERT INTO cv.cv (guid, user_guid, link, created_at, updated_at)
-VALUES (gen_random_uuid(), $1, $2, NOW(), NOW())
-ON CONFLICT (user_guid) DO UPDATE
-SET link = $2, updated_at = NOW();
+-- name: ListCVVersions :many
+SELECT c.*, f.original_filename
+FROM cv.cv c
+LEFT JOIN cv.files f ON f.object_name = substring(c.link FROM '/api/v1/cv/([^/?]+)$')
+WHERE c.user_guid = $1
+ORDER BY c.is_primary DESC, c.created_at DESC;
+
+-- name: GetCVVersion :one
+SELECT c.*, f.original_filename
+FROM cv.cv c
+LEFT JOIN cv.files f ON f.object_name = substring(c.link FROM '/api/v1/cv/([^/?]+)$')
+WHERE c.guid = $1 AND c.user_guid = $2;
+
+-- name: UpdateCVLabel :execrows
+UPDATE cv.cv
+SET label = $1, updated_at = (now() AT TIME ZONE 'utc')
+WHERE guid = $2 AND user_guid = $3;
+
+-- name: UnsetPrimaryCV :exec
+UPDATE cv.cv SET is_primary = false WHERE user_guid = $1 AND is_primary;
 
--- name: GetCVLink :one
-SELECT link FROM cv.cv WHERE user_guid = $1 ORDER BY created_at DESC LIMIT 1;
+-- name: SetPrimaryCV :execrows
+UPDATE cv.cv
+SET is_primary = true, updated_at = (now() AT TIME ZONE 'utc')
+WHERE guid = $1 AND user_guid = $2;
 
--- name: DeleteCVLink :exec
-DELETE FROM cv.cv WHERE user_guid = $1;
+-- name: PromoteLatestCV :exec
+UPDATE cv.cv SET is_primary = true
+WHERE guid = (
+    SELECT l.guid FROM cv.cv l WHERE l.user_guid = $1 ORDER BY l.created_at DESC LIMIT 1
+) AND NOT EXISTS (
+    SELECT 1 FROM cv.cv p WHERE p.user_guid = $1 AND p.is_primary
+);
+
+-- name: IsCVInActiveApplication :one
+SELECT EXISTS (
+    SELECT 1 FROM job.job_applications
+    WHERE cv_id = $1 AND status IN ('pending', 'reviewed')
+) AS in_use;
 
 -- name: CreateResumeRecord :one
-INSERT INTO cv.resume_database (user_id, candidate_name, candidate_age, experience_years, file_url, analysis)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO cv.resume_database (user_id, candidate_name, candidate_age, experience_years, file_url, analysis, prompt_version, content_hash, email, phone, candidate_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (user_id, content_hash) DO NOTHING
 RETURNING *;
 
--- name: GetResumesByUserID :many
+-- name: GetResumeByContentHash :one
 SELECT * FROM cv.resume_database
-WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE user_id = $1 AND content_hash = $2;
+
+-- name: GetResumesByCandidateIDs :many
+SELECT * FROM cv.resume_database
+WHERE candidate_id = ANY(sqlc.arg(ids)::uuid[])
+ORDER BY created_at DESC;
+
+-- name: GetResumesByUserID :many
+SELECT r.* FROM cv.resume_database r
+WHERE r.user_id = sqlc.arg(user_id)
+    AND (sqlc.narg(tag)::text IS NULL OR sqlc.narg(tag)::text = ANY(r.tags))
+    AND (sqlc.narg(folder_id)::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM cv.resume_folder_items i
+        WHERE i.resume_id = r.id AND i.folder_id = sqlc.narg(folder_id)::uuid
+    ))
+ORDER BY r.created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
 
 -- name: GetResumeByID :one
 SELECT * FROM cv.resume_database
 WHERE id = $1;
 
--- name: DeleteResumeRecord :exec
+-- name: DeleteResumeRecord :one
 DELETE FROM cv.resume_database
-WHERE id = $1 AND user_id = $2;
+WHERE id = $1 AND user_id = $2
+RETURNING *;
 
--- name: SearchResumesByUserID :many
-SELECT * FROM cv.resume_database
-WHERE user_id = $1 AND (
-    to_tsvector('russian', analysis) @@ plainto_tsquery('russian', $2)
-    OR candidate_name ILIKE '%' || $2 || '%'
+-- name: UpdateResumeAnnotations :one
+UPDATE cv.resume_database
+SET tags = COALESCE(sqlc.narg(tags)::text[], tags),
+    notes = COALESCE(sqlc.narg(notes)::text, notes)
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: IsStoredObjectInUse :one
+SELECT EXISTS (
+    SELECT 1 FROM cv.resume_database WHERE file_url LIKE '%/' || sqlc.arg(object_name)::text
+) OR EXISTS (
+    SELECT 1 FROM cv.ingestion_files
+    WHERE object_name = sqlc.arg(object_name)::text AND status IN ('pending', 'processing')
+) OR EXISTS (
+    SELECT 1 FROM cv.cv WHERE link LIKE '%/' || sqlc.arg(object_name)::text
+) OR EXISTS (
+    SELECT 1 FROM chat.attachments WHERE object_name = sqlc.arg(object_name)::text
+) AS in_use;
+
+-- name: ReleaseStoredObject :exec
+UPDATE cv.ingestion_files
+SET object_name = NULL, updated_at = NOW()
+WHERE object_name = $1;
+
+-- name: SearchResumes :many
+WITH q AS (
+    SELECT plainto_tsquery('russian', sqlc.arg(query)::text) AS tsq
+), matched AS (
+    SELECT r.*,
+        ts_rank_cd(to_tsvector('russian', r.analysis), q.tsq, 32)::float8 AS text_score,
+        (to_tsvector('russian', r.analysis) @@ q.tsq OR r.candidate_name ILIKE '%' || sqlc.arg(query)::text || '%') AS text_match,
+        cv.embedding_similarity(e.embedding, sqlc.narg(embedding)::real[]) AS vector_score
+    FROM cv.resume_database r
+    CROSS JOIN q
+    LEFT JOIN cv.resume_embeddings e ON e.resume_id = r.id AND e.model = sqlc.arg(model)::text
+    WHERE r.user_id = sqlc.arg(user_id)
+        AND (sqlc.narg(min_age)::int IS NULL OR r.candidate_age >= sqlc.narg(min_age)::int)
+        AND (sqlc.narg(max_age)::int IS NULL OR r.candidate_age <= sqlc.narg(max_age)::int)
+        AND (sqlc.narg(min_experience)::float8 IS NULL OR cv.experience_years_value(r.experience_years) >= sqlc.narg(min_experience)::float8)
+        AND (sqlc.narg(max_experience)::float8 IS NULL OR cv.experience_years_value(r.experience_years) <= sqlc.narg(max_experience)::float8)
+), ranked AS (
+    SELECT matched.*,
+        CASE WHEN text_match THEN rank() OVER (PARTITION BY text_match ORDER BY text_score DESC) END AS text_rank,
+        CASE WHEN vector_score >= sqlc.arg(min_similarity)::float8 THEN
+            rank() OVER (PARTITION BY vector_score >= sqlc.arg(min_similarity)::float8 ORDER BY vector_score DESC)
+        END AS vector_rank
+    FROM matched
+    WHERE sqlc.arg(query)::text = '' OR text_match OR vector_score >= sqlc.arg(min_similarity)::float8
+)
+SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id, tags, notes,
+    (COALESCE(1.0 / (60 + text_rank), 0) + COALESCE(1.0 / (60 + vector_rank), 0))::float8 AS score,
+    ts_headline('russian', analysis, (SELECT tsq FROM q), sqlc.arg(headline_options)::text) AS snippet
+FROM ranked
+ORDER BY score DESC, created_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: GetResumesWithoutEmbedding :many
+SELECT r.* FROM cv.resume_database r
+LEFT JOIN cv.resume_embeddings e ON e.resume_id = r.id AND e.model = $1
+WHERE e.resume_id IS NULL
+ORDER BY r.created_at
+LIMIT $2;
+
+-- name: UpsertResumeEmbedding :exec
+INSERT INTO cv.resume_embeddings (resume_id, model, embedding)
+VALUES ($1, $2, $3)
+ON CONFLICT (resume_id) DO UPDATE
+SET model = EXCLUDED.model, embedding = EXCLUDED.embedding, updated_at = NOW();
+
+-- name: ShortlistResumes :many
+WITH q AS (
+    SELECT replace(plainto_tsquery('russian', sqlc.arg(query)::text)::text, ' & ', ' | ')::tsquery AS tsq
+), matched AS (
+    SELECT r.*,
+        ts_rank_cd(to_tsvector('russian', r.analysis), q.tsq, 32)::float8 AS text_score,
+        cv.embedding_similarity(e.embedding, sqlc.narg(embedding)::real[]) AS vector_score
+    FROM cv.resume_database r
+    CROSS JOIN q
+    LEFT JOIN cv.resume_embeddings e ON e.resume_id = r.id AND e.model = sqlc.arg(model)::text
+    WHERE r.user_id = sqlc.arg(user_id)
+), ranked AS (
+    SELECT matched.*,
+        CASE WHEN text_score > 0 THEN rank() OVER (PARTITION BY text_score > 0 ORDER BY text_score DESC) END AS text_rank,
+        CASE WHEN vector_score IS NOT NULL THEN rank() OVER (PARTITION BY vector_score IS NOT NULL ORDER BY vector_score DESC) END AS vector_rank
+    FROM matched
+)
+SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id,
+    (COALESCE(1.0 / (60 + text_rank), 0) + COALESCE(1.0 / (60 + vector_rank), 0))::float8 AS score
+FROM ranked
+ORDER BY score DESC, created_at DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetJobMatchRun :one
+SELECT * FROM cv.job_match_runs WHERE job_id = $1;
+
+-- name: UpsertJobMatchRun :exec
+INSERT INTO cv.job_match_runs (job_id, job_updated_at, shortlist_hash, shortlist_size, prompt_version)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (job_id) DO UPDATE
+SET job_updated_at = EXCLUDED.job_updated_at, shortlist_hash = EXCLUDED.shortlist_hash,
+    shortlist_size = EXCLUDED.shortlist_size, prompt_version = EXCLUDED.prompt_version, matched_at = NOW();
+
+-- name: DeleteJobMatchResults :exec
+DELETE FROM cv.job_match_results WHERE job_id = $1;
+
+-- name: CreateJobMatchResult :exec
+INSERT INTO cv.job_match_results (job_id, resume_id, match_score, skills_score, experience_score, seniority_score, location_score, reasoning)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetJobMatchResults :many
+SELECT m.resume_id, r.candidate_name, r.file_url, m.match_score, m.skills_score, m.experience_score, m.seniority_score, m.location_score, m.reasoning
+FROM cv.job_match_results m
+JOIN cv.resume_database r ON r.id = m.resume_id
+WHERE m.job_id = $1
+ORDER BY m.match_score DESC, m.skills_score DESC
+LIMIT $2;
+
+-- name: ShortlistApplicants :many
+WITH q AS (
+    SELECT replace(plainto_tsquery('russian', sqlc.arg(query)::text)::text, ' & ', ' | ')::tsquery AS tsq
+), experience AS (
+    SELECT pc.user_guid,
+        string_agg(pc.position || ', ' || c.name || ' (' || COALESCE(to_char(pc.started_at, 'YYYY-MM'), '?') || ' - ' || COALESCE(to_char(pc.finished_at, 'YYYY-MM'), 'н.в.') || ')', E'\n' ORDER BY pc.started_at DESC)::text AS positions
+    FROM company.profile_company pc
+    JOIN company.companies c ON c.guid = pc.company_guid
+    GROUP BY pc.user_guid
+)
+SELECT p.guid, p.description, p.email, p.birthday, p.avatar, p.updated_at,
+    ja.id AS application_id, ja.status AS application_status,
+    COALESCE(e.positions, '')::text AS experience, cvl.link AS cv_link, f.extracted_text AS cv_text,
+    ts_rank_cd(to_tsvector('russian', COALESCE(e.positions, '')), q.tsq, 32)::float8 AS score
+FROM profile.profiles p
+CROSS JOIN q
+LEFT JOIN job.job_applications ja ON ja.applicant_id = p.guid AND ja.job_id = sqlc.arg(job_id)
+LEFT JOIN experience e ON e.user_guid = p.guid
+LEFT JOIN LATERAL (
+    SELECT link FROM cv.cv
+    WHERE user_guid = p.guid::text
+    ORDER BY guid IS NOT DISTINCT FROM ja.cv_id DESC, is_primary DESC, created_at DESC
+    LIMIT 1
+) cvl ON true
+LEFT JOIN cv.files f ON f.object_name = substring(cvl.link FROM '/api/v1/cv/([^/?]+)$')
+WHERE ja.id IS NOT NULL
+    OR (sqlc.arg(include_profiles)::bool AND p.open_to_offers AND p.is_active
+        AND NOT COALESCE(p.is_hr, false) AND p.guid <> sqlc.arg(author_id))
+ORDER BY ja.id IS NULL, score DESC, p.updated_at DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetJobApplicantMatchRun :one
+SELECT * FROM cv.job_applicant_match_runs WHERE job_id = $1;
+
+-- name: UpsertJobApplicantMatchRun :exec
+INSERT INTO cv.job_applicant_match_runs (job_id, job_updated_at, shortlist_hash, shortlist_size, prompt_version)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (job_id) DO UPDATE
+SET job_updated_at = EXCLUDED.job_updated_at, shortlist_hash = EXCLUDED.shortlist_hash,
+    shortlist_size = EXCLUDED.shortlist_size, prompt_version = EXCLUDED.prompt_version, matched_at = NOW();
+
+-- name: DeleteJobApplicantMatchResults :exec
+DELETE FROM cv.job_applicant_match_results WHERE job_id = $1;
+
+-- name: CreateJobApplicantMatchResult :exec
+INSERT INTO cv.job_applicant_match_results (job_id, profile_id, match_score, skills_score, experience_score, seniority_score, location_score, reasoning)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetJobApplicantMatchResults :many
+SELECT m.profile_id, p.description, p.email, p.avatar,
+    ja.id AS application_id, ja.status AS application_status, cvl.link AS cv_link,
+    m.match_score, m.skills_score, m.experience_score, m.seniority_score, m.location_score, m.reasoning
+FROM cv.job_applicant_match_results m
+JOIN profile.profiles p ON p.guid = m.profile_id
+LEFT JOIN job.job_applications ja ON ja.applicant_id = m.profile_id AND ja.job_id = m.job_id
+LEFT JOIN LATERAL (
+    SELECT link FROM cv.cv
+    WHERE user_guid = p.guid::text
+    ORDER BY guid IS NOT DISTINCT FROM ja.cv_id DESC, is_primary DESC, created_at DESC
+    LIMIT 1
+) cvl ON true
+WHERE m.job_id = $1
+ORDER BY m.match_score DESC, m.skills_score DESC
+LIMIT $2;
+
+-- name: GetApplicantProfile :one
+SELECT p.guid, p.description, p.birthday, p.updated_at,
+    COALESCE((
+        SELECT string_agg(pc.position || ', ' || c.name || ' (' || COALESCE(to_char(pc.started_at, 'YYYY-MM'), '?') || ' - ' || COALESCE(to_char(pc.finished_at, 'YYYY-MM'), 'н.в.') || ')', E'\n' ORDER BY pc.started_at DESC)
+        FROM company.profile_company pc
+        JOIN company.companies c ON c.guid = pc.company_guid
+        WHERE pc.user_guid = p.guid
+    ), '')::text AS experience,
+    cvl.link AS cv_link, f.extracted_text AS cv_text
+FROM profile.profiles p
+LEFT JOIN LATERAL (
+    SELECT link FROM cv.cv WHERE user_guid = p.guid::text ORDER BY is_primary DESC, created_at DESC LIMIT 1
+) cvl ON true
+LEFT JOIN cv.files f ON f.object_name = substring(cvl.link FROM '/api/v1/cv/([^/?]+)$')
+WHERE p.guid = $1;
+
+-- name: ShortlistRecommendedJobs :many
+WITH q AS (
+    SELECT replace(plainto_tsquery('russian', sqlc.arg(query)::text)::text, ' & ', ' | ')::tsquery AS tsq
+)
+SELECT j.id, j.title, j.company_name, j.location, j.employment_type, j.salary_from, j.salary_to,
+    j.description, j.requirements, j.author_id, j.created_at, j.updated_at, j.status,
+    ts_rank_cd(to_tsvector('russian', j.title || ' ' || j.description || ' ' || j.requirements), q.tsq, 32)::float8 AS score
+FROM job.jobs j
+CROSS JOIN q
+WHERE j.status = 'active'
+    AND j.author_id <> sqlc.arg(profile_id)
+    AND NOT EXISTS (
+        SELECT 1 FROM job.job_applications ja WHERE ja.job_id = j.id AND ja.applicant_id = sqlc.arg(profile_id)
+    )
+ORDER BY score DESC, j.created_at DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetJobRecommendationRun :one
+SELECT * FROM cv.job_recommendation_runs WHERE profile_id = $1;
+
+-- name: UpsertJobRecommendationRun :exec
+INSERT INTO cv.job_recommendation_runs (profile_id, shortlist_hash, shortlist_size, prompt_version)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (profile_id) DO UPDATE
+SET shortlist_hash = EXCLUDED.shortlist_hash, shortlist_size = EXCLUDED.shortlist_size,
+    prompt_version = EXCLUDED.prompt_version, matched_at = NOW();
+
+-- name: DeleteJobRecommendationResults :exec
+DELETE FROM cv.job_recommendation_results WHERE profile_id = $1;
+
+-- name: CreateJobRecommendationResult :exec
+INSERT INTO cv.job_recommendation_results (profile_id, job_id, match_score, skills_score, experience_score, seniority_score, location_score, reasoning)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetJobRecommendations :many
+SELECT j.id, j.title, j.company_name, j.location, j.employment_type, j.salary_from, j.salary_to,
+    j.description, j.requirements, j.author_id, j.created_at, j.updated_at, j.status,
+    r.match_score, r.skills_score, r.experience_score, r.seniority_score, r.location_score, r.reasoning
+FROM cv.job_recommendation_results r
+JOIN job.jobs j ON j.id = r.job_id
+WHERE r.profile_id = $1
+    AND j.status = 'active'
+    AND NOT EXISTS (
+        SELECT 1 FROM job.job_applications ja WHERE ja.job_id = j.id AND ja.applicant_id = r.profile_id
+    )
+ORDER BY r.match_score DESC, r.skills_score DESC
+LIMIT $2;
+
+-- name: CreateIngestionJob :one
+INSERT INTO cv.ingestion_jobs (user_id, archive_name, archive_object)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetIngestionJob :one
+SELECT j.*,
+    COUNT(f.id) AS total_files,
+    COUNT(f.id) FILTER (WHERE f.status = 'succeeded') AS succeeded_files,
+    COUNT(f.id) FILTER (WHERE f.status = 'failed') AS failed_files,
+    COUNT(f.id) FILTER (WHERE f.status = 'skipped') AS skipped_files
+FROM cv.ingestion_jobs j
+LEFT JOIN cv.ingestion_files f ON f.job_id = j.id
+WHERE j.id = $1 AND j.user_id = $2
+GROUP BY j.id;
+
+-- name: ReopenIngestionJob :exec
+UPDATE cv.ingestion_jobs
+SET status = 'processing', finished_at = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: FinishIngestionJob :one
+UPDATE cv.ingestion_jobs j
+SET status = 'completed', finished_at = NOW(), updated_at = NOW(),
+    archive_object = CASE WHEN EXISTS (
+        SELECT 1 FROM cv.ingestion_files
+        WHERE job_id = $1 AND status = 'failed' AND object_name IS NULL AND archive_entry IS NOT NULL AND attempts > 0
+    ) THEN j.archive_object END
+FROM cv.ingestion_jobs old
+WHERE j.id = $1 AND old.id = j.id AND j.status = 'processing' AND NOT EXISTS (
+    SELECT 1 FROM cv.ingestion_files
+    WHERE job_id = $1 AND status IN ('pending', 'processing')
+)
+RETURNING old.archive_object, j.archive_object AS retained_archive_object;
+
+-- name: CreateIngestionFile :one
+INSERT INTO cv.ingestion_files (job_id, file_name, extension, object_name, status, error, stage, content_hash, resume_id, archive_entry)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: GetStoredObjectByContentHash :one
+SELECT f.object_name FROM cv.ingestion_files f
+JOIN cv.ingestion_jobs j ON j.id = f.job_id
+WHERE j.user_id = $1 AND f.content_hash = $2 AND f.object_name IS NOT NULL
+ORDER BY f.created_at DESC
+LIMIT 1;
+
+-- name: GetIngestionFiles :many
+SELECT * FROM cv.ingestion_files
+WHERE job_id = $1
+ORDER BY created_at, file_name;
+
+-- name: ClaimIngestionFile :one
+UPDATE cv.ingestion_files
+SET status = 'processing', attempts = attempts + 1, lease_expires_at = $1, updated_at = NOW()
+WHERE id = (
+    SELECT id FROM cv.ingestion_files
+    WHERE status = 'pending' OR (status = 'processing' AND lease_expires_at < NOW() AND attempts < $2)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
 )
-ORDER BY created_at DESC
-LIMIT $3 OFFSET $4; 
\ No newline at end of file
+RETURNING *;
+
+-- name: SetIngestionFileObject :exec
+UPDATE cv.ingestion_files
+SET object_name = $2, content_hash = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CompleteIngestionFile :exec
+UPDATE cv.ingestion_files
+SET status = 'succeeded', resume_id = $2, error = NULL, stage = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SkipIngestionFile :exec
+UPDATE cv.ingestion_files
+SET status = 'skipped', resume_id = $2, error = NULL, stage = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: FailAbandonedIngestionFiles :many
+UPDATE cv.ingestion_files
+SET status = 'failed', error = 'processing was interrupted too many times',
+    stage = CASE WHEN object_name IS NULL THEN 'archive' ELSE 'extraction' END, updated_at = NOW()
+WHERE status = 'processing' AND lease_expires_at < NOW() AND attempts >= $1
+RETURNING job_id;
+
+-- name: FailIngestionFile :exec
+UPDATE cv.ingestion_files
+SET status = 'failed', error = $2, stage = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: RetryFailedIngestionFiles :execrows
+UPDATE cv.ingestion_files
+SET status = 'pending', error = NULL, stage = NULL, updated_at = NOW()
+WHERE job_id = $1 AND status = 'failed' AND (
+    object_name IS NOT NULL
+    OR archive_entry IS NOT NULL AND attempts > 0 AND EXISTS (
+        SELECT 1 FROM cv.ingestion_jobs
+        WHERE id = $1 AND archive_object IS NOT NULL
+    )
+);
+
+-- name: GetIngestionJobOwner :one
+SELECT user_id, archive_object FROM cv.ingestion_jobs
+WHERE id = $1;
+
+-- name: LockUserCandidates :exec
+SELECT pg_advisory_xact_lock(hashtextextended(sqlc.arg(user_id)::text, 0));
+
+-- name: CreateCandidate :one
+INSERT INTO cv.candidates (user_id, name, email, phone)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetCandidatesByUserID :many
+SELECT * FROM cv.candidates
+WHERE user_id = $1
+ORDER BY updated_at DESC;
+
+-- name: GetCandidatesByIDs :many
+SELECT * FROM cv.candidates
+WHERE user_id = sqlc.arg(user_id) AND id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: ListCandidates :many
+SELECT * FROM cv.candidates
+WHERE user_id = $1
+ORDER BY updated_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateCandidateContacts :exec
+UPDATE cv.candidates
+SET email = COALESCE(email, $2), phone = COALESCE(phone, $3), updated_at = NOW()
+WHERE id = $1;
+
+-- name: MoveCandidateResumes :exec
+UPDATE cv.resume_database
+SET candidate_id = sqlc.arg(target_id), updated_at = NOW()
+WHERE candidate_id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: DeleteCandidates :exec
+DELETE FROM cv.candidates
+WHERE user_id = sqlc.arg(user_id) AND id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: DeleteEmptyCandidate :exec
+DELETE FROM cv.candidates c
+WHERE c.id = $1 AND NOT EXISTS (
+    SELECT 1 FROM cv.resume_database WHERE candidate_id = c.id
+);
+
+-- name: CreateResumeFolder :one
+INSERT INTO cv.resume_folders (user_id, name)
+VALUES ($1, $2)
+ON CONFLICT (user_id, name) DO NOTHING
+RETURNING *;
+
+-- name: GetResumeFolder :one
+SELECT f.*, COUNT(i.resume_id) AS resume_count
+FROM cv.resume_folders f
+LEFT JOIN cv.resume_folder_items i ON i.folder_id = f.id
+WHERE f.id = $1 AND f.user_id = $2
+GROUP BY f.id;
+
+-- name: ListResumeFolders :many
+SELECT f.*, COUNT(i.resume_id) AS resume_count
+FROM cv.resume_folders f
+LEFT JOIN cv.resume_folder_items i ON i.folder_id = f.id
+WHERE f.user_id = $1
+GROUP BY f.id
+ORDER BY f.name;
+
+-- name: RenameResumeFolder :execrows
+UPDATE cv.resume_folders f
+SET name = sqlc.arg(name), updated_at = NOW()
+WHERE f.id = sqlc.arg(id) AND f.user_id = sqlc.arg(user_id) AND NOT EXISTS (
+    SELECT 1 FROM cv.resume_folders
+    WHERE user_id = sqlc.arg(user_id) AND name = sqlc.arg(name) AND id <> sqlc.arg(id)
+);
+
+-- name: DeleteResumeFolder :execrows
+DELETE FROM cv.resume_folders
+WHERE id = $1 AND user_id = $2;
+
+-- name: AddResumeToFolder :exec
+INSERT INTO cv.resume_folder_items (folder_id, resume_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveResumeFromFolder :exec
+DELETE FROM cv.resume_folder_items
+WHERE folder_id = $1 AND resume_id = $2;
+
+-- name: GetResumeFolderItems :many
+SELECT * FROM cv.resume_folder_items
+WHERE resume_id = ANY(sqlc.arg(resume_ids)::uuid[])
+ORDER BY added_at;
+
+-- name: CreateStoredFile :exec
+INSERT INTO cv.files (object_name, owner_id, kind, original_filename, content_type)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (object_name) DO NOTHING;
+
+-- name: GetStoredFile :one
+SELECT * FROM cv.files
+WHERE object_name = $1;
+
+-- name: SetStoredFileText :exec
+UPDATE cv.files SET extracted_text = $2
+WHERE object_name = $1;
+
+-- name: DeleteStoredFile :exec
+DELETE FROM cv.files
+WHERE object_name = $1;
+
+-- name: HasApplicationToAuthorJob :one
+SELECT EXISTS (
+    SELECT 1 FROM job.job_applications ja
+    JOIN job.jobs j ON j.id = ja.job_id
+    WHERE ja.applicant_id = sqlc.arg(applicant_id)::uuid AND j.author_id = sqlc.arg(author_id)::uuid
+) AS has_application;
@@ -8,28 +8,112 @@ package cv
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const addResumeToFolder = `-- name: AddResumeToFolder :exec
+INSERT INTO cv.resume_folder_items (folder_id, resume_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddResumeToFolderParams struct {
+	FolderID uuid.UUID
+	ResumeID uuid.UUID
+}
+
+func (q *Queries) AddResumeToFolder(ctx context.Context, db DBTX, arg AddResumeToFolderParams) error {
+	_, err := db.Exec(ctx, addResumeToFolder, arg.FolderID, arg.ResumeID)
+	return err
+}
+
+const claimIngestionFile = `-- name: ClaimIngestionFile :one
+UPDATE cv.ingestion_files
+SET status = 'processing', attempts = attempts + 1, lease_expires_at = $1, updated_at = NOW()
+WHERE id = (
+    SELECT id FROM cv.ingestion_files
+    WHERE status = 'pending' OR (status = 'processing' AND lease_expires_at < NOW() AND attempts < $2)
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, job_id, file_name, extension, object_name, status, error, attempts, resume_id, created_at, updated_at, stage, content_hash, archive_entry, lease_expires_at
+`
+
+type ClaimIngestionFileParams struct {
+	LeaseExpiresAt sql.NullTime
+	Attempts       int32
+}
+
+func (q *Queries) ClaimIngestionFile(ctx context.Context, db DBTX, arg ClaimIngestionFileParams) (CvIngestionFile, error) {
+	row := db.QueryRow(ctx, claimIngestionFile, arg.LeaseExpiresAt, arg.Attempts)
+	var i CvIngestionFile
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.FileName,
+		&i.Extension,
+		&i.ObjectName,
+		&i.Status,
+		&i.Error,
+		&i.Attempts,
+		&i.ResumeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Stage,
+		&i.ContentHash,
+		&i.ArchiveEntry,
+		&i.LeaseExpiresAt,
+	)
+	return i, err
+}
+
+const completeIngestionFile = `-- name: CompleteIngestionFile :exec
+UPDATE cv.ingestion_files
+SET status = 'succeeded', resume_id = $2, error = NULL, stage = NULL, updated_at = NOW()
+WHERE id = $1
+`
+
+type CompleteIngestionFileParams struct {
+	ID       uuid.UUID
+	ResumeID uuid.NullUUID
+}
+
+func (q *Queries) CompleteIngestionFile(ctx context.Context, db DBTX, arg CompleteIngestionFileParams) error {
+	_, err := db.Exec(ctx, completeIngestionFile, arg.ID, arg.ResumeID)
+	return err
+}
+
 const createCV = `-- name: CreateCV :one
 INSERT INTO cv.cv (
     guid,
     user_guid,
-    link
+    link,
+    label,
+    is_primary
 ) VALUES (
-    $1, $2, $3
-) RETURNING guid, user_guid, link, created_at, updated_at
+    $1, $2, $3, $4, $5
+) RETURNING guid, user_guid, link, created_at, updated_at, label, is_primary
 `
 
 type CreateCVParams struct {
-	Guid     uuid.UUID
-	UserGuid string
-	Link     string
+	Guid      uuid.UUID
+	UserGuid  string
+	Link      string
+	Label     string
+	IsPrimary bool
 }
 
 func (q *Queries) CreateCV(ctx context.Context, db DBTX, arg CreateCVParams) (CvCv, error) {
-	row := db.QueryRow(ctx, createCV, arg.Guid, arg.UserGuid, arg.Link)
+	row := db.QueryRow(ctx, createCV,
+		arg.Guid,
+		arg.UserGuid,
+		arg.Link,
+		arg.Label,
+		arg.IsPrimary,
+	)
 	var i CvCv
 	err := row.Scan(
 		&i.Guid,
@@ -37,14 +121,246 @@ func (q *Queries) CreateCV(ctx context.Context, db DBTX, arg CreateCVParams) (Cv
 		&i.Link,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Label,
+		&i.IsPrimary,
+	)
+	return i, err
+}
+
+const createCandidate = `-- name: CreateCandidate :one
+INSERT INTO cv.candidates (user_id, name, email, phone)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, name, email, phone, created_at, updated_at
+`
+
+type CreateCandidateParams struct {
+	UserID uuid.UUID
+	Name   string
+	Email  sql.NullString
+	Phone  sql.NullString
+}
+
+func (q *Queries) CreateCandidate(ctx context.Context, db DBTX, arg CreateCandidateParams) (CvCandidate, error) {
+	row := db.QueryRow(ctx, createCandidate,
+		arg.UserID,
+		arg.Name,
+		arg.Email,
+		arg.Phone,
+	)
+	var i CvCandidate
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Email,
+		&i.Phone,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createIngestionFile = `-- name: CreateIngestionFile :one
+INSERT INTO cv.ingestion_files (job_id, file_name, extension, object_name, status, error, stage, content_hash, resume_id, archive_entry)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, job_id, file_name, extension, object_name, status, error, attempts, resume_id, created_at, updated_at, stage, content_hash, archive_entry, lease_expires_at
+`
+
+type CreateIngestionFileParams struct {
+	JobID        uuid.UUID
+	FileName     string
+	Extension    string
+	ObjectName   sql.NullString
+	Status       string
+	Error        sql.NullString
+	Stage        sql.NullString
+	ContentHash  sql.NullString
+	ResumeID     uuid.NullUUID
+	ArchiveEntry sql.NullString
+}
+
+func (q *Queries) CreateIngestionFile(ctx context.Context, db DBTX, arg CreateIngestionFileParams) (CvIngestionFile, error) {
+	row := db.QueryRow(ctx, createIngestionFile,
+		arg.JobID,
+		arg.FileName,
+		arg.Extension,
+		arg.ObjectName,
+		arg.Status,
+		arg.Error,
+		arg.Stage,
+		arg.ContentHash,
+		arg.ResumeID,
+		arg.ArchiveEntry,
+	)
+	var i CvIngestionFile
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.FileName,
+		&i.Extension,
+		&i.ObjectName,
+		&i.Status,
+		&i.Error,
+		&i.Attempts,
+		&i.ResumeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Stage,
+		&i.ContentHash,
+		&i.ArchiveEntry,
+		&i.LeaseExpiresAt,
+	)
+	return i, err
+}
+
+const createIngestionJob = `-- name: CreateIngestionJob :one
+INSERT INTO cv.ingestion_jobs (user_id, archive_name, archive_object)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, archive_name, status, created_at, updated_at, finished_at, archive_object
+`
+
+type CreateIngestionJobParams struct {
+	UserID        uuid.UUID
+	ArchiveName   string
+	ArchiveObject sql.NullString
+}
+
+func (q *Queries) CreateIngestionJob(ctx context.Context, db DBTX, arg CreateIngestionJobParams) (CvIngestionJob, error) {
+	row := db.QueryRow(ctx, createIngestionJob, arg.UserID, arg.ArchiveName, arg.ArchiveObject)
+	var i CvIngestionJob
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ArchiveName,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.ArchiveObject,
+	)
+	return i, err
+}
+
+const createJobApplicantMatchResult = `-- name: CreateJobApplicantMatchResult :exec
+INSERT INTO cv.job_applicant_match_results (job_id, profile_id, match_score, skills_score, experience_score, seniority_score, location_score, reasoning)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateJobApplicantMatchResultParams struct {
+	JobID           uuid.UUID
+	ProfileID       uuid.UUID
+	MatchScore      int32
+	SkillsScore     int32
+	ExperienceScore int32
+	SeniorityScore  int32
+	LocationScore   sql.NullInt32
+	Reasoning       string
+}
+
+func (q *Queries) CreateJobApplicantMatchResult(ctx context.Context, db DBTX, arg CreateJobApplicantMatchResultParams) error {
+	_, err := db.Exec(ctx, createJobApplicantMatchResult,
+		arg.JobID,
+		arg.ProfileID,
+		arg.MatchScore,
+		arg.SkillsScore,
+		arg.ExperienceScore,
+		arg.SeniorityScore,
+		arg.LocationScore,
+		arg.Reasoning,
+	)
+	return err
+}
+
+const createJobMatchResult = `-- name: CreateJobMatchResult :exec
+INSERT INTO cv.job_match_results (job_id, resume_id, match_score, skills_score, experience_score, seniority_score, location_score, reasoning)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateJobMatchResultParams struct {
+	JobID           uuid.UUID
+	ResumeID        uuid.UUID
+	MatchScore      int32
+	SkillsScore     int32
+	ExperienceScore int32
+	SeniorityScore  int32
+	LocationScore   sql.NullInt32
+	Reasoning       string
+}
+
+func (q *Queries) CreateJobMatchResult(ctx context.Context, db DBTX, arg CreateJobMatchResultParams) error {
+	_, err := db.Exec(ctx, createJobMatchResult,
+		arg.JobID,
+		arg.ResumeID,
+		arg.MatchScore,
+		arg.SkillsScore,
+		arg.ExperienceScore,
+		arg.SeniorityScore,
+		arg.LocationScore,
+		arg.Reasoning,
+	)
+	return err
+}
+
+const createJobRecommendationResult = `-- name: CreateJobRecommendationResult :exec
+INSERT INTO cv.job_recommendation_results (profile_id, job_id, match_score, skills_score, experience_score, seniority_score, location_score, reasoning)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateJobRecommendationResultParams struct {
+	ProfileID       uuid.UUID
+	JobID           uuid.UUID
+	MatchScore      int32
+	SkillsScore     int32
+	ExperienceScore int32
+	SeniorityScore  int32
+	LocationScore   sql.NullInt32
+	Reasoning       string
+}
+
+func (q *Queries) CreateJobRecommendationResult(ctx context.Context, db DBTX, arg CreateJobRecommendationResultParams) error {
+	_, err := db.Exec(ctx, createJobRecommendationResult,
+		arg.ProfileID,
+		arg.JobID,
+		arg.MatchScore,
+		arg.SkillsScore,
+		arg.ExperienceScore,
+		arg.SeniorityScore,
+		arg.LocationScore,
+		arg.Reasoning,
+	)
+	return err
+}
+
+const createResumeFolder = `-- name: CreateResumeFolder :one
+INSERT INTO cv.resume_folders (user_id, name)
+VALUES ($1, $2)
+ON CONFLICT (user_id, name) DO NOTHING
+RETURNING id, user_id, name, created_at, updated_at
+`
+
+type CreateResumeFolderParams struct {
+	UserID uuid.UUID
+	Name   string
+}
+
+func (q *Queries) CreateResumeFolder(ctx context.Context, db DBTX, arg CreateResumeFolderParams) (CvResumeFolder, error) {
+	row := db.QueryRow(ctx, createResumeFolder, arg.UserID, arg.Name)
+	var i CvResumeFolder
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const createResumeRecord = `-- name: CreateResumeRecord :one
-INSERT INTO cv.resume_database (user_id, candidate_name, candidate_age, experience_years, file_url, analysis)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at
+INSERT INTO cv.resume_database (user_id, candidate_name, candidate_age, experience_years, file_url, analysis, prompt_version, content_hash, email, phone, candidate_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (user_id, content_hash) DO NOTHING
+RETURNING id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id, tags, notes
 `
 
 type CreateResumeRecordParams struct {
@@ -54,6 +370,11 @@ type CreateResumeRecordParams struct {
 	ExperienceYears string
 	FileUrl         string
 	Analysis        string
+	PromptVersion   string
+	ContentHash     sql.NullString
+	Email           sql.NullString
+	Phone           sql.NullString
+	CandidateID     uuid.NullUUID
 }
 
 func (q *Queries) CreateResumeRecord(ctx context.Context, db DBTX, arg CreateResumeRecordParams) (CvResumeDatabase, error) {
@@ -64,6 +385,11 @@ func (q *Queries) CreateResumeRecord(ctx context.Context, db DBTX, arg CreateRes
 		arg.ExperienceYears,
 		arg.FileUrl,
 		arg.Analysis,
+		arg.PromptVersion,
+		arg.ContentHash,
+		arg.Email,
+		arg.Phone,
+		arg.CandidateID,
 	)
 	var i CvResumeDatabase
 	err := row.Scan(
@@ -76,10 +402,42 @@ func (q *Queries) CreateResumeRecord(ctx context.Context, db DBTX, arg CreateRes
 		&i.Analysis,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PromptVersion,
+		&i.ContentHash,
+		&i.Email,
+		&i.Phone,
+		&i.CandidateID,
+		&i.Tags,
+		&i.Notes,
 	)
 	return i, err
 }
 
+const createStoredFile = `-- name: CreateStoredFile :exec
+INSERT INTO cv.files (object_name, owner_id, kind, original_filename, content_type)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (object_name) DO NOTHING
+`
+
+type CreateStoredFileParams struct {
+	ObjectName       string
+	OwnerID          uuid.UUID
+	Kind             string
+	OriginalFilename string
+	ContentType      string
+}
+
+func (q *Queries) CreateStoredFile(ctx context.Context, db DBTX, arg CreateStoredFileParams) error {
+	_, err := db.Exec(ctx, createStoredFile,
+		arg.ObjectName,
+		arg.OwnerID,
+		arg.Kind,
+		arg.OriginalFilename,
+		arg.ContentType,
+	)
+	return err
+}
+
 const deleteCV = `-- name: DeleteCV :exec
 DELETE FROM cv.cv WHERE guid = $1
 `
@@ -89,82 +447,91 @@ func (q *Queries) DeleteCV(ctx context.Context, db DBTX, guid uuid.UUID) error {
 	return err
 }
 
-const deleteCVLink = `-- name: DeleteCVLink :exec
-DELETE FROM cv.cv WHERE user_guid = $1
+const deleteCandidates = `-- name: DeleteCandidates :exec
+DELETE FROM cv.candidates
+WHERE user_id = $1 AND id = ANY($2::uuid[])
 `
 
-func (q *Queries) DeleteCVLink(ctx context.Context, db DBTX, userGuid string) error {
-	_, err := db.Exec(ctx, deleteCVLink, userGuid)
+type DeleteCandidatesParams struct {
+	UserID uuid.UUID
+	Ids    []uuid.UUID
+}
+
+func (q *Queries) DeleteCandidates(ctx context.Context, db DBTX, arg DeleteCandidatesParams) error {
+	_, err := db.Exec(ctx, deleteCandidates, arg.UserID, arg.Ids)
 	return err
 }
 
-const deleteResumeRecord = `-- name: DeleteResumeRecord :exec
-DELETE FROM cv.resume_database
-WHERE id = $1 AND user_id = $2
+const deleteEmptyCandidate = `-- name: DeleteEmptyCandidate :exec
+DELETE FROM cv.candidates c
+WHERE c.id = $1 AND NOT EXISTS (
+    SELECT 1 FROM cv.resume_database WHERE candidate_id = c.id
+)
 `
 
-type DeleteResumeRecordParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
+func (q *Queries) DeleteEmptyCandidate(ctx context.Context, db DBTX, id uuid.UUID) error {
+	_, err := db.Exec(ctx, deleteEmptyCandidate, id)
+	return err
 }
 
-func (q *Queries) DeleteResumeRecord(ctx context.Context, db DBTX, arg DeleteResumeRecordParams) error {
-	_, err := db.Exec(ctx, deleteResumeRecord, arg.ID, arg.UserID)
+const deleteJobApplicantMatchResults = `-- name: DeleteJobApplicantMatchResults :exec
+DELETE FROM cv.job_applicant_match_results WHERE job_id = $1
+`
+
+func (q *Queries) DeleteJobApplicantMatchResults(ctx context.Context, db DBTX, jobID uuid.UUID) error {
+	_, err := db.Exec(ctx, deleteJobApplicantMatchResults, jobID)
 	return err
 }
 
-const getCVByGUID = `-- name: GetCVByGUID :one
-SELECT guid, user_guid, link, created_at, updated_at FROM cv.cv WHERE guid = $1
+const deleteJobMatchResults = `-- name: DeleteJobMatchResults :exec
+DELETE FROM cv.job_match_results WHERE job_id = $1
 `
 
-func (q *Queries) GetCVByGUID(ctx context.Context, db DBTX, guid uuid.UUID) (CvCv, error) {
-	row := db.QueryRow(ctx, getCVByGUID, guid)
-	var i CvCv
-	err := row.Scan(
-		&i.Guid,
-		&i.UserGuid,
-		&i.Link,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) DeleteJobMatchResults(ctx context.Context, db DBTX, jobID uuid.UUID) error {
+	_, err := db.Exec(ctx, deleteJobMatchResults, jobID)
+	return err
 }
 
-const getCVByUserGUID = `-- name: GetCVByUserGUID :one
-SELECT guid, user_guid, link, created_at, updated_at FROM cv.cv WHERE user_guid = $1 ORDER BY created_at DESC LIMIT 1
+const deleteJobRecommendationResults = `-- name: DeleteJobRecommendationResults :exec
+DELETE FROM cv.job_recommendation_results WHERE profile_id = $1
 `
 
-func (q *Queries) GetCVByUserGUID(ctx context.Context, db DBTX, userGuid string) (CvCv, error) {
-	row := db.QueryRow(ctx, getCVByUserGUID, userGuid)
-	var i CvCv
-	err := row.Scan(
-		&i.Guid,
-		&i.UserGuid,
-		&i.Link,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) DeleteJobRecommendationResults(ctx context.Context, db DBTX, profileID uuid.UUID) error {
+	_, err := db.Exec(ctx, deleteJobRecommendationResults, profileID)
+	return err
 }
 
-const getCVLink = `-- name: GetCVLink :one
-SELECT link FROM cv.cv WHERE user_guid = $1 ORDER BY created_at DESC LIMIT 1
+const deleteResumeFolder = `-- name: DeleteResumeFolder :execrows
+DELETE FROM cv.resume_folders
+WHERE id = $1 AND user_id = $2
 `
 
-func (q *Queries) GetCVLink(ctx context.Context, db DBTX, userGuid string) (string, error) {
-	row := db.QueryRow(ctx, getCVLink, userGuid)
-	var link string
-	err := row.Scan(&link)
-	return link, err
+type DeleteResumeFolderParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) DeleteResumeFolder(ctx context.Context, db DBTX, arg DeleteResumeFolderParams) (int64, error) {
+	result, err := db.Exec(ctx, deleteResumeFolder, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const getResumeByID = `-- name: GetResumeByID :one
-SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at FROM cv.resume_database
-WHERE id = $1
+const deleteResumeRecord = `-- name: DeleteResumeRecord :one
+DELETE FROM cv.resume_database
+WHERE id = $1 AND user_id = $2
+RETURNING id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id, tags, notes
 `
 
-func (q *Queries) GetResumeByID(ctx context.Context, db DBTX, id uuid.UUID) (CvResumeDatabase, error) {
-	row := db.QueryRow(ctx, getResumeByID, id)
+type DeleteResumeRecordParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) DeleteResumeRecord(ctx context.Context, db DBTX, arg DeleteResumeRecordParams) (CvResumeDatabase, error) {
+	row := db.QueryRow(ctx, deleteResumeRecord, arg.ID, arg.UserID)
 	var i CvResumeDatabase
 	err := row.Scan(
 		&i.ID,
@@ -176,46 +543,48 @@ func (q *Queries) GetResumeByID(ctx context.Context, db DBTX, id uuid.UUID) (CvR
 		&i.Analysis,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PromptVersion,
+		&i.ContentHash,
+		&i.Email,
+		&i.Phone,
+		&i.CandidateID,
+		&i.Tags,
+		&i.Notes,
 	)
 	return i, err
 }
 
-const getResumesByUserID = `-- name: GetResumesByUserID :many
-SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at FROM cv.resume_database
-WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+const deleteStoredFile = `-- name: DeleteStoredFile :exec
+DELETE FROM cv.files
+WHERE object_name = $1
 `
 
-type GetResumesByUserIDParams struct {
-	UserID uuid.UUID
-	Limit  int32
-	Offset int32
+func (q *Queries) DeleteStoredFile(ctx context.Context, db DBTX, objectName string) error {
+	_, err := db.Exec(ctx, deleteStoredFile, objectName)
+	return err
 }
 
-func (q *Queries) GetResumesByUserID(ctx context.Context, db DBTX, arg GetResumesByUserIDParams) ([]CvResumeDatabase, error) {
-	rows, err := db.Query(ctx, getResumesByUserID, arg.UserID, arg.Limit, arg.Offset)
+const failAbandonedIngestionFiles = `-- name: FailAbandonedIngestionFiles :many
+UPDATE cv.ingestion_files
+SET status = 'failed', error = 'processing was interrupted too many times',
+    stage = CASE WHEN object_name IS NULL THEN 'archive' ELSE 'extraction' END, updated_at = NOW()
+WHERE status = 'processing' AND lease_expires_at < NOW() AND attempts >= $1
+RETURNING job_id
+`
+
+func (q *Queries) FailAbandonedIngestionFiles(ctx context.Context, db DBTX, attempts int32) ([]uuid.UUID, error) {
+	rows, err := db.Query(ctx, failAbandonedIngestionFiles, attempts)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CvResumeDatabase
+	var items []uuid.UUID
 	for rows.Next() {
-		var i CvResumeDatabase
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.CandidateName,
-			&i.CandidateAge,
-			&i.ExperienceYears,
-			&i.FileUrl,
-			&i.Analysis,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var job_id uuid.UUID
+		if err := rows.Scan(&job_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, job_id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -223,97 +592,1929 @@ func (q *Queries) GetResumesByUserID(ctx context.Context, db DBTX, arg GetResume
 	return items, nil
 }
 
-const saveCVLink = `-- name: SaveCVLink :exec
-INSERT INTO cv.cv (guid, user_guid, link, created_at, updated_at)
-VALUES (gen_random_uuid(), $1, $2, NOW(), NOW())
-ON CONFLICT (user_guid) DO UPDATE
-SET link = $2, updated_at = NOW()
+const failIngestionFile = `-- name: FailIngestionFile :exec
+UPDATE cv.ingestion_files
+SET status = 'failed', error = $2, stage = $3, updated_at = NOW()
+WHERE id = $1
 `
 
-type SaveCVLinkParams struct {
-	UserGuid string
-	Link     string
+type FailIngestionFileParams struct {
+	ID    uuid.UUID
+	Error sql.NullString
+	Stage sql.NullString
 }
 
-func (q *Queries) SaveCVLink(ctx context.Context, db DBTX, arg SaveCVLinkParams) error {
-	_, err := db.Exec(ctx, saveCVLink, arg.UserGuid, arg.Link)
+func (q *Queries) FailIngestionFile(ctx context.Context, db DBTX, arg FailIngestionFileParams) error {
+	_, err := db.Exec(ctx, failIngestionFile, arg.ID, arg.Error, arg.Stage)
 	return err
 }
 
-const searchResumesByUserID = `-- name: SearchResumesByUserID :many
-SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at FROM cv.resume_database
-WHERE user_id = $1 AND (
-    to_tsvector('russian', analysis) @@ plainto_tsquery('russian', $2)
-    OR candidate_name ILIKE '%' || $2 || '%'
+const finishIngestionJob = `-- name: FinishIngestionJob :one
+UPDATE cv.ingestion_jobs j
+SET status = 'completed', finished_at = NOW(), updated_at = NOW(),
+    archive_object = CASE WHEN EXISTS (
+        SELECT 1 FROM cv.ingestion_files
+        WHERE job_id = $1 AND status = 'failed' AND object_name IS NULL AND archive_entry IS NOT NULL AND attempts > 0
+    ) THEN j.archive_object END
+FROM cv.ingestion_jobs old
+WHERE j.id = $1 AND old.id = j.id AND j.status = 'processing' AND NOT EXISTS (
+    SELECT 1 FROM cv.ingestion_files
+    WHERE job_id = $1 AND status IN ('pending', 'processing')
 )
-ORDER BY created_at DESC
-LIMIT $3 OFFSET $4
+RETURNING old.archive_object, j.archive_object AS retained_archive_object
 `
 
-type SearchResumesByUserIDParams struct {
-	UserID         uuid.UUID
-	PlaintoTsquery string
-	Limit          int32
-	Offset         int32
+type FinishIngestionJobRow struct {
+	ArchiveObject         sql.NullString
+	RetainedArchiveObject sql.NullString
 }
 
-func (q *Queries) SearchResumesByUserID(ctx context.Context, db DBTX, arg SearchResumesByUserIDParams) ([]CvResumeDatabase, error) {
-	rows, err := db.Query(ctx, searchResumesByUserID,
-		arg.UserID,
-		arg.PlaintoTsquery,
-		arg.Limit,
-		arg.Offset,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []CvResumeDatabase
-	for rows.Next() {
-		var i CvResumeDatabase
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.CandidateName,
-			&i.CandidateAge,
-			&i.ExperienceYears,
-			&i.FileUrl,
-			&i.Analysis,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) FinishIngestionJob(ctx context.Context, db DBTX, id uuid.UUID) (FinishIngestionJobRow, error) {
+	row := db.QueryRow(ctx, finishIngestionJob, id)
+	var i FinishIngestionJobRow
+	err := row.Scan(&i.ArchiveObject, &i.RetainedArchiveObject)
+	return i, err
 }
 
-const updateCV = `-- name: UpdateCV :one
-UPDATE cv.cv 
-SET 
-    link = $1
-WHERE guid = $2
-RETURNING guid, user_guid, link, created_at, updated_at
+const getApplicantProfile = `-- name: GetApplicantProfile :one
+SELECT p.guid, p.description, p.birthday, p.updated_at,
+    COALESCE((
+        SELECT string_agg(pc.position || ', ' || c.name || ' (' || COALESCE(to_char(pc.started_at, 'YYYY-MM'), '?') || ' - ' || COALESCE(to_char(pc.finished_at, 'YYYY-MM'), 'н.в.') || ')', E'\n' ORDER BY pc.started_at DESC)
+        FROM company.profile_company pc
+        JOIN company.companies c ON c.guid = pc.company_guid
+        WHERE pc.user_guid = p.guid
+    ), '')::text AS experience,
+    cvl.link AS cv_link, f.extracted_text AS cv_text
+FROM profile.profiles p
+LEFT JOIN LATERAL (
+    SELECT link FROM cv.cv WHERE user_guid = p.guid::text ORDER BY is_primary DESC, created_at DESC LIMIT 1
+) cvl ON true
+LEFT JOIN cv.files f ON f.object_name = substring(cvl.link FROM '/api/v1/cv/([^/?]+)$')
+WHERE p.guid = $1
 `
 
-type UpdateCVParams struct {
-	Link string
-	Guid uuid.UUID
+type GetApplicantProfileRow struct {
+	Guid        uuid.UUID
+	Description string
+	Birthday    string
+	UpdatedAt   sql.NullTime
+	Experience  string
+	CvLink      sql.NullString
+	CvText      sql.NullString
 }
 
-func (q *Queries) UpdateCV(ctx context.Context, db DBTX, arg UpdateCVParams) (CvCv, error) {
-	row := db.QueryRow(ctx, updateCV, arg.Link, arg.Guid)
-	var i CvCv
+func (q *Queries) GetApplicantProfile(ctx context.Context, db DBTX, guid uuid.UUID) (GetApplicantProfileRow, error) {
+	row := db.QueryRow(ctx, getApplicantProfile, guid)
+	var i GetApplicantProfileRow
 	err := row.Scan(
 		&i.Guid,
-		&i.UserGuid,
-		&i.Link,
-		&i.CreatedAt,
+		&i.Description,
+		&i.Birthday,
 		&i.UpdatedAt,
+		&i.Experience,
+		&i.CvLink,
+		&i.CvText,
 	)
 	return i, err
 }
+
+const getCVByGUID = `-- name: GetCVByGUID :one
+SELECT guid, user_guid, link, created_at, updated_at, label, is_primary FROM cv.cv WHERE guid = $1
+`
+
+func (q *Queries) GetCVByGUID(ctx context.Context, db DBTX, guid uuid.UUID) (CvCv, error) {
+	row := db.QueryRow(ctx, getCVByGUID, guid)
+	var i CvCv
+	err := row.Scan(
+		&i.Guid,
+		&i.UserGuid,
+		&i.Link,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Label,
+		&i.IsPrimary,
+	)
+	return i, err
+}
+
+const getCVByLink = `-- name: GetCVByLink :one
+SELECT guid, user_guid, link, created_at, updated_at, label, is_primary FROM cv.cv WHERE user_guid = $1 AND link = $2 ORDER BY created_at DESC LIMIT 1
+`
+
+type GetCVByLinkParams struct {
+	UserGuid string
+	Link     string
+}
+
+func (q *Queries) GetCVByLink(ctx context.Context, db DBTX, arg GetCVByLinkParams) (CvCv, error) {
+	row := db.QueryRow(ctx, getCVByLink, arg.UserGuid, arg.Link)
+	var i CvCv
+	err := row.Scan(
+		&i.Guid,
+		&i.UserGuid,
+		&i.Link,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Label,
+		&i.IsPrimary,
+	)
+	return i, err
+}
+
+const getCVByUserGUID = `-- name: GetCVByUserGUID :one
+SELECT guid, user_guid, link, created_at, updated_at, label, is_primary FROM cv.cv WHERE user_guid = $1 ORDER BY is_primary DESC, created_at DESC LIMIT 1
+`
+
+func (q *Queries) GetCVByUserGUID(ctx context.Context, db DBTX, userGuid string) (CvCv, error) {
+	row := db.QueryRow(ctx, getCVByUserGUID, userGuid)
+	var i CvCv
+	err := row.Scan(
+		&i.Guid,
+		&i.UserGuid,
+		&i.Link,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Label,
+		&i.IsPrimary,
+	)
+	return i, err
+}
+
+const getCVVersion = `-- name: GetCVVersion :one
+SELECT c.guid, c.user_guid, c.link, c.created_at, c.updated_at, c.label, c.is_primary, f.original_filename
+FROM cv.cv c
+LEFT JOIN cv.files f ON f.object_name = substring(c.link FROM '/api/v1/cv/([^/?]+)$')
+WHERE c.guid = $1 AND c.user_guid = $2
+`
+
+type GetCVVersionParams struct {
+	Guid     uuid.UUID
+	UserGuid string
+}
+
+type GetCVVersionRow struct {
+	Guid             uuid.UUID
+	UserGuid         string
+	Link             string
+	CreatedAt        sql.NullTime
+	UpdatedAt        sql.NullTime
+	Label            string
+	IsPrimary        bool
+	OriginalFilename sql.NullString
+}
+
+func (q *Queries) GetCVVersion(ctx context.Context, db DBTX, arg GetCVVersionParams) (GetCVVersionRow, error) {
+	row := db.QueryRow(ctx, getCVVersion, arg.Guid, arg.UserGuid)
+	var i GetCVVersionRow
+	err := row.Scan(
+		&i.Guid,
+		&i.UserGuid,
+		&i.Link,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Label,
+		&i.IsPrimary,
+		&i.OriginalFilename,
+	)
+	return i, err
+}
+
+const getCandidatesByIDs = `-- name: GetCandidatesByIDs :many
+SELECT id, user_id, name, email, phone, created_at, updated_at FROM cv.candidates
+WHERE user_id = $1 AND id = ANY($2::uuid[])
+`
+
+type GetCandidatesByIDsParams struct {
+	UserID uuid.UUID
+	Ids    []uuid.UUID
+}
+
+func (q *Queries) GetCandidatesByIDs(ctx context.Context, db DBTX, arg GetCandidatesByIDsParams) ([]CvCandidate, error) {
+	rows, err := db.Query(ctx, getCandidatesByIDs, arg.UserID, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CvCandidate
+	for rows.Next() {
+		var i CvCandidate
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Email,
+			&i.Phone,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCandidatesByUserID = `-- name: GetCandidatesByUserID :many
+SELECT id, user_id, name, email, phone, created_at, updated_at FROM cv.candidates
+WHERE user_id = $1
+ORDER BY updated_at DESC
+`
+
+func (q *Queries) GetCandidatesByUserID(ctx context.Context, db DBTX, userID uuid.UUID) ([]CvCandidate, error) {
+	rows, err := db.Query(ctx, getCandidatesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CvCandidate
+	for rows.Next() {
+		var i CvCandidate
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Email,
+			&i.Phone,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getIngestionFiles = `-- name: GetIngestionFiles :many
+SELECT id, job_id, file_name, extension, object_name, status, error, attempts, resume_id, created_at, updated_at, stage, content_hash, archive_entry, lease_expires_at FROM cv.ingestion_files
+WHERE job_id = $1
+ORDER BY created_at, file_name
+`
+
+func (q *Queries) GetIngestionFiles(ctx context.Context, db DBTX, jobID uuid.UUID) ([]CvIngestionFile, error) {
+	rows, err := db.Query(ctx, getIngestionFiles, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CvIngestionFile
+	for rows.Next() {
+		var i CvIngestionFile
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobID,
+			&i.FileName,
+			&i.Extension,
+			&i.ObjectName,
+			&i.Status,
+			&i.Error,
+			&i.Attempts,
+			&i.ResumeID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Stage,
+			&i.ContentHash,
+			&i.ArchiveEntry,
+			&i.LeaseExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getIngestionJob = `-- name: GetIngestionJob :one
+SELECT j.id, j.user_id, j.archive_name, j.status, j.created_at, j.updated_at, j.finished_at, j.archive_object,
+    COUNT(f.id) AS total_files,
+    COUNT(f.id) FILTER (WHERE f.status = 'succeeded') AS succeeded_files,
+    COUNT(f.id) FILTER (WHERE f.status = 'failed') AS failed_files,
+    COUNT(f.id) FILTER (WHERE f.status = 'skipped') AS skipped_files
+FROM cv.ingestion_jobs j
+LEFT JOIN cv.ingestion_files f ON f.job_id = j.id
+WHERE j.id = $1 AND j.user_id = $2
+GROUP BY j.id
+`
+
+type GetIngestionJobParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+type GetIngestionJobRow struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	ArchiveName    string
+	Status         string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	FinishedAt     sql.NullTime
+	ArchiveObject  sql.NullString
+	TotalFiles     int64
+	SucceededFiles int64
+	FailedFiles    int64
+	SkippedFiles   int64
+}
+
+func (q *Queries) GetIngestionJob(ctx context.Context, db DBTX, arg GetIngestionJobParams) (GetIngestionJobRow, error) {
+	row := db.QueryRow(ctx, getIngestionJob, arg.ID, arg.UserID)
+	var i GetIngestionJobRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ArchiveName,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.ArchiveObject,
+		&i.TotalFiles,
+		&i.SucceededFiles,
+		&i.FailedFiles,
+		&i.SkippedFiles,
+	)
+	return i, err
+}
+
+const getIngestionJobOwner = `-- name: GetIngestionJobOwner :one
+SELECT user_id, archive_object FROM cv.ingestion_jobs
+WHERE id = $1
+`
+
+type GetIngestionJobOwnerRow struct {
+	UserID        uuid.UUID
+	ArchiveObject sql.NullString
+}
+
+func (q *Queries) GetIngestionJobOwner(ctx context.Context, db DBTX, id uuid.UUID) (GetIngestionJobOwnerRow, error) {
+	row := db.QueryRow(ctx, getIngestionJobOwner, id)
+	var i GetIngestionJobOwnerRow
+	err := row.Scan(&i.UserID, &i.ArchiveObject)
+	return i, err
+}
+
+const getJobApplicantMatchResults = `-- name: GetJobApplicantMatchResults :many
+SELECT m.profile_id, p.description, p.email, p.avatar,
+    ja.id AS application_id, ja.status AS application_status, cvl.link AS cv_link,
+    m.match_score, m.skills_score, m.experience_score, m.seniority_score, m.location_score, m.reasoning
+FROM cv.job_applicant_match_results m
+JOIN profile.profiles p ON p.guid = m.profile_id
+LEFT JOIN job.job_applications ja ON ja.applicant_id = m.profile_id AND ja.job_id = m.job_id
+LEFT JOIN LATERAL (
+    SELECT link FROM cv.cv
+    WHERE user_guid = p.guid::text
+    ORDER BY guid IS NOT DISTINCT FROM ja.cv_id DESC, is_primary DESC, created_at DESC
+    LIMIT 1
+) cvl ON true
+WHERE m.job_id = $1
+ORDER BY m.match_score DESC, m.skills_score DESC
+LIMIT $2
+`
+
+type GetJobApplicantMatchResultsParams struct {
+	JobID uuid.UUID
+	Limit int32
+}
+
+type GetJobApplicantMatchResultsRow struct {
+	ProfileID         uuid.UUID
+	Description       string
+	Email             string
+	Avatar            sql.NullString
+	ApplicationID     uuid.NullUUID
+	ApplicationStatus sql.NullString
+	CvLink            sql.NullString
+	MatchScore        int32
+	SkillsScore       int32
+	ExperienceScore   int32
+	SeniorityScore    int32
+	LocationScore     sql.NullInt32
+	Reasoning         string
+}
+
+func (q *Queries) GetJobApplicantMatchResults(ctx context.Context, db DBTX, arg GetJobApplicantMatchResultsParams) ([]GetJobApplicantMatchResultsRow, error) {
+	rows, err := db.Query(ctx, getJobApplicantMatchResults, arg.JobID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetJobApplicantMatchResultsRow
+	for rows.Next() {
+		var i GetJobApplicantMatchResultsRow
+		if err := rows.Scan(
+			&i.ProfileID,
+			&i.Description,
+			&i.Email,
+			&i.Avatar,
+			&i.ApplicationID,
+			&i.ApplicationStatus,
+			&i.CvLink,
+			&i.MatchScore,
+			&i.SkillsScore,
+			&i.ExperienceScore,
+			&i.SeniorityScore,
+			&i.LocationScore,
+			&i.Reasoning,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getJobApplicantMatchRun = `-- name: GetJobApplicantMatchRun :one
+SELECT job_id, job_updated_at, shortlist_hash, shortlist_size, prompt_version, matched_at FROM cv.job_applicant_match_runs WHERE job_id = $1
+`
+
+func (q *Queries) GetJobApplicantMatchRun(ctx context.Context, db DBTX, jobID uuid.UUID) (CvJobApplicantMatchRun, error) {
+	row := db.QueryRow(ctx, getJobApplicantMatchRun, jobID)
+	var i CvJobApplicantMatchRun
+	err := row.Scan(
+		&i.JobID,
+		&i.JobUpdatedAt,
+		&i.ShortlistHash,
+		&i.ShortlistSize,
+		&i.PromptVersion,
+		&i.MatchedAt,
+	)
+	return i, err
+}
+
+const getJobMatchResults = `-- name: GetJobMatchResults :many
+SELECT m.resume_id, r.candidate_name, r.file_url, m.match_score, m.skills_score, m.experience_score, m.seniority_score, m.location_score, m.reasoning
+FROM cv.job_match_results m
+JOIN cv.resume_database r ON r.id = m.resume_id
+WHERE m.job_id = $1
+ORDER BY m.match_score DESC, m.skills_score DESC
+LIMIT $2
+`
+
+type GetJobMatchResultsParams struct {
+	JobID uuid.UUID
+	Limit int32
+}
+
+type GetJobMatchResultsRow struct {
+	ResumeID        uuid.UUID
+	CandidateName   string
+	FileUrl         string
+	MatchScore      int32
+	SkillsScore     int32
+	ExperienceScore int32
+	SeniorityScore  int32
+	LocationScore   sql.NullInt32
+	Reasoning       string
+}
+
+func (q *Queries) GetJobMatchResults(ctx context.Context, db DBTX, arg GetJobMatchResultsParams) ([]GetJobMatchResultsRow, error) {
+	rows, err := db.Query(ctx, getJobMatchResults, arg.JobID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetJobMatchResultsRow
+	for rows.Next() {
+		var i GetJobMatchResultsRow
+		if err := rows.Scan(
+			&i.ResumeID,
+			&i.CandidateName,
+			&i.FileUrl,
+			&i.MatchScore,
+			&i.SkillsScore,
+			&i.ExperienceScore,
+			&i.SeniorityScore,
+			&i.LocationScore,
+			&i.Reasoning,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getJobMatchRun = `-- name: GetJobMatchRun :one
+SELECT job_id, job_updated_at, shortlist_hash, shortlist_size, prompt_version, matched_at FROM cv.job_match_runs WHERE job_id = $1
+`
+
+func (q *Queries) GetJobMatchRun(ctx context.Context, db DBTX, jobID uuid.UUID) (CvJobMatchRun, error) {
+	row := db.QueryRow(ctx, getJobMatchRun, jobID)
+	var i CvJobMatchRun
+	err := row.Scan(
+		&i.JobID,
+		&i.JobUpdatedAt,
+		&i.ShortlistHash,
+		&i.ShortlistSize,
+		&i.PromptVersion,
+		&i.MatchedAt,
+	)
+	return i, err
+}
+
+const getJobRecommendationRun = `-- name: GetJobRecommendationRun :one
+SELECT profile_id, shortlist_hash, shortlist_size, prompt_version, matched_at FROM cv.job_recommendation_runs WHERE profile_id = $1
+`
+
+func (q *Queries) GetJobRecommendationRun(ctx context.Context, db DBTX, profileID uuid.UUID) (CvJobRecommendationRun, error) {
+	row := db.QueryRow(ctx, getJobRecommendationRun, profileID)
+	var i CvJobRecommendationRun
+	err := row.Scan(
+		&i.ProfileID,
+		&i.ShortlistHash,
+		&i.ShortlistSize,
+		&i.PromptVersion,
+		&i.MatchedAt,
+	)
+	return i, err
+}
+
+const getJobRecommendations = `-- name: GetJobRecommendations :many
+SELECT j.id, j.title, j.company_name, j.location, j.employment_type, j.salary_from, j.salary_to,
+    j.description, j.requirements, j.author_id, j.created_at, j.updated_at, j.status,
+    r.match_score, r.skills_score, r.experience_score, r.seniority_score, r.location_score, r.reasoning
+FROM cv.job_recommendation_results r
+JOIN job.jobs j ON j.id = r.job_id
+WHERE r.profile_id = $1
+    AND j.status = 'active'
+    AND NOT EXISTS (
+        SELECT 1 FROM job.job_applications ja WHERE ja.job_id = j.id AND ja.applicant_id = r.profile_id
+    )
+ORDER BY r.match_score DESC, r.skills_score DESC
+LIMIT $2
+`
+
+type GetJobRecommendationsParams struct {
+	ProfileID uuid.UUID
+	Limit     int32
+}
+
+type GetJobRecommendationsRow struct {
+	ID              uuid.UUID
+	Title           string
+	CompanyName     string
+	Location        string
+	EmploymentType  string
+	SalaryFrom      sql.NullInt32
+	SalaryTo        sql.NullInt32
+	Description     string
+	Requirements    string
+	AuthorID        uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Status          string
+	MatchScore      int32
+	SkillsScore     int32
+	ExperienceScore int32
+	SeniorityScore  int32
+	LocationScore   sql.NullInt32
+	Reasoning       string
+}
+
+func (q *Queries) GetJobRecommendations(ctx context.Context, db DBTX, arg GetJobRecommendationsParams) ([]GetJobRecommendationsRow, error) {
+	rows, err := db.Query(ctx, getJobRecommendations, arg.ProfileID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetJobRecommendationsRow
+	for rows.Next() {
+		var i GetJobRecommendationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.CompanyName,
+			&i.Location,
+			&i.EmploymentType,
+			&i.SalaryFrom,
+			&i.SalaryTo,
+			&i.Description,
+			&i.Requirements,
+			&i.AuthorID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.MatchScore,
+			&i.SkillsScore,
+			&i.ExperienceScore,
+			&i.SeniorityScore,
+			&i.LocationScore,
+			&i.Reasoning,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getResumeByContentHash = `-- name: GetResumeByContentHash :one
+SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id, tags, notes FROM cv.resume_database
+WHERE user_id = $1 AND content_hash = $2
+`
+
+type GetResumeByContentHashParams struct {
+	UserID      uuid.UUID
+	ContentHash sql.NullString
+}
+
+func (q *Queries) GetResumeByContentHash(ctx context.Context, db DBTX, arg GetResumeByContentHashParams) (CvResumeDatabase, error) {
+	row := db.QueryRow(ctx, getResumeByContentHash, arg.UserID, arg.ContentHash)
+	var i CvResumeDatabase
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CandidateName,
+		&i.CandidateAge,
+		&i.ExperienceYears,
+		&i.FileUrl,
+		&i.Analysis,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PromptVersion,
+		&i.ContentHash,
+		&i.Email,
+		&i.Phone,
+		&i.CandidateID,
+		&i.Tags,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const getResumeByID = `-- name: GetResumeByID :one
+SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id, tags, notes FROM cv.resume_database
+WHERE id = $1
+`
+
+func (q *Queries) GetResumeByID(ctx context.Context, db DBTX, id uuid.UUID) (CvResumeDatabase, error) {
+	row := db.QueryRow(ctx, getResumeByID, id)
+	var i CvResumeDatabase
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CandidateName,
+		&i.CandidateAge,
+		&i.ExperienceYears,
+		&i.FileUrl,
+		&i.Analysis,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PromptVersion,
+		&i.ContentHash,
+		&i.Email,
+		&i.Phone,
+		&i.CandidateID,
+		&i.Tags,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const getResumeFolder = `-- name: GetResumeFolder :one
+SELECT f.id, f.user_id, f.name, f.created_at, f.updated_at, COUNT(i.resume_id) AS resume_count
+FROM cv.resume_folders f
+LEFT JOIN cv.resume_folder_items i ON i.folder_id = f.id
+WHERE f.id = $1 AND f.user_id = $2
+GROUP BY f.id
+`
+
+type GetResumeFolderParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+type GetResumeFolderRow struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ResumeCount int64
+}
+
+func (q *Queries) GetResumeFolder(ctx context.Context, db DBTX, arg GetResumeFolderParams) (GetResumeFolderRow, error) {
+	row := db.QueryRow(ctx, getResumeFolder, arg.ID, arg.UserID)
+	var i GetResumeFolderRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResumeCount,
+	)
+	return i, err
+}
+
+const getResumeFolderItems = `-- name: GetResumeFolderItems :many
+SELECT folder_id, resume_id, added_at FROM cv.resume_folder_items
+WHERE resume_id = ANY($1::uuid[])
+ORDER BY added_at
+`
+
+func (q *Queries) GetResumeFolderItems(ctx context.Context, db DBTX, resumeIds []uuid.UUID) ([]CvResumeFolderItem, error) {
+	rows, err := db.Query(ctx, getResumeFolderItems, resumeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CvResumeFolderItem
+	for rows.Next() {
+		var i CvResumeFolderItem
+		if err := rows.Scan(&i.FolderID, &i.ResumeID, &i.AddedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getResumesByCandidateIDs = `-- name: GetResumesByCandidateIDs :many
+SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id, tags, notes FROM cv.resume_database
+WHERE candidate_id = ANY($1::uuid[])
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetResumesByCandidateIDs(ctx context.Context, db DBTX, ids []uuid.UUID) ([]CvResumeDatabase, error) {
+	rows, err := db.Query(ctx, getResumesByCandidateIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CvResumeDatabase
+	for rows.Next() {
+		var i CvResumeDatabase
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CandidateName,
+			&i.CandidateAge,
+			&i.ExperienceYears,
+			&i.FileUrl,
+			&i.Analysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PromptVersion,
+			&i.ContentHash,
+			&i.Email,
+			&i.Phone,
+			&i.CandidateID,
+			&i.Tags,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getResumesByUserID = `-- name: GetResumesByUserID :many
+SELECT r.id, r.user_id, r.candidate_name, r.candidate_age, r.experience_years, r.file_url, r.analysis, r.created_at, r.updated_at, r.prompt_version, r.content_hash, r.email, r.phone, r.candidate_id, r.tags, r.notes FROM cv.resume_database r
+WHERE r.user_id = $1
+    AND ($2::text IS NULL OR $2::text = ANY(r.tags))
+    AND ($3::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM cv.resume_folder_items i
+        WHERE i.resume_id = r.id AND i.folder_id = $3::uuid
+    ))
+ORDER BY r.created_at DESC
+LIMIT $4 OFFSET $5
+`
+
+type GetResumesByUserIDParams struct {
+	UserID      uuid.UUID
+	Tag         sql.NullString
+	FolderID    uuid.NullUUID
+	LimitCount  int32
+	OffsetCount int32
+}
+
+func (q *Queries) GetResumesByUserID(ctx context.Context, db DBTX, arg GetResumesByUserIDParams) ([]CvResumeDatabase, error) {
+	rows, err := db.Query(ctx, getResumesByUserID,
+		arg.UserID,
+		arg.Tag,
+		arg.FolderID,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CvResumeDatabase
+	for rows.Next() {
+		var i CvResumeDatabase
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CandidateName,
+			&i.CandidateAge,
+			&i.ExperienceYears,
+			&i.FileUrl,
+			&i.Analysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PromptVersion,
+			&i.ContentHash,
+			&i.Email,
+			&i.Phone,
+			&i.CandidateID,
+			&i.Tags,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getResumesWithoutEmbedding = `-- name: GetResumesWithoutEmbedding :many
+SELECT r.id, r.user_id, r.candidate_name, r.candidate_age, r.experience_years, r.file_url, r.analysis, r.created_at, r.updated_at, r.prompt_version, r.content_hash, r.email, r.phone, r.candidate_id, r.tags, r.notes FROM cv.resume_database r
+LEFT JOIN cv.resume_embeddings e ON e.resume_id = r.id AND e.model = $1
+WHERE e.resume_id IS NULL
+ORDER BY r.created_at
+LIMIT $2
+`
+
+type GetResumesWithoutEmbeddingParams struct {
+	Model string
+	Limit int32
+}
+
+func (q *Queries) GetResumesWithoutEmbedding(ctx context.Context, db DBTX, arg GetResumesWithoutEmbeddingParams) ([]CvResumeDatabase, error) {
+	rows, err := db.Query(ctx, getResumesWithoutEmbedding, arg.Model, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CvResumeDatabase
+	for rows.Next() {
+		var i CvResumeDatabase
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CandidateName,
+			&i.CandidateAge,
+			&i.ExperienceYears,
+			&i.FileUrl,
+			&i.Analysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PromptVersion,
+			&i.ContentHash,
+			&i.Email,
+			&i.Phone,
+			&i.CandidateID,
+			&i.Tags,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStoredFile = `-- name: GetStoredFile :one
+SELECT object_name, owner_id, kind, original_filename, content_type, created_at, extracted_text FROM cv.files
+WHERE object_name = $1
+`
+
+func (q *Queries) GetStoredFile(ctx context.Context, db DBTX, objectName string) (CvFile, error) {
+	row := db.QueryRow(ctx, getStoredFile, objectName)
+	var i CvFile
+	err := row.Scan(
+		&i.ObjectName,
+		&i.OwnerID,
+		&i.Kind,
+		&i.OriginalFilename,
+		&i.ContentType,
+		&i.CreatedAt,
+		&i.ExtractedText,
+	)
+	return i, err
+}
+
+const getStoredObjectByContentHash = `-- name: GetStoredObjectByContentHash :one
+SELECT f.object_name FROM cv.ingestion_files f
+JOIN cv.ingestion_jobs j ON j.id = f.job_id
+WHERE j.user_id = $1 AND f.content_hash = $2 AND f.object_name IS NOT NULL
+ORDER BY f.created_at DESC
+LIMIT 1
+`
+
+type GetStoredObjectByContentHashParams struct {
+	UserID      uuid.UUID
+	ContentHash sql.NullString
+}
+
+func (q *Queries) GetStoredObjectByContentHash(ctx context.Context, db DBTX, arg GetStoredObjectByContentHashParams) (sql.NullString, error) {
+	row := db.QueryRow(ctx, getStoredObjectByContentHash, arg.UserID, arg.ContentHash)
+	var object_name sql.NullString
+	err := row.Scan(&object_name)
+	return object_name, err
+}
+
+const hasApplicationToAuthorJob = `-- name: HasApplicationToAuthorJob :one
+SELECT EXISTS (
+    SELECT 1 FROM job.job_applications ja
+    JOIN job.jobs j ON j.id = ja.job_id
+    WHERE ja.applicant_id = $1::uuid AND j.author_id = $2::uuid
+) AS has_application
+`
+
+type HasApplicationToAuthorJobParams struct {
+	ApplicantID uuid.UUID
+	AuthorID    uuid.UUID
+}
+
+func (q *Queries) HasApplicationToAuthorJob(ctx context.Context, db DBTX, arg HasApplicationToAuthorJobParams) (bool, error) {
+	row := db.QueryRow(ctx, hasApplicationToAuthorJob, arg.ApplicantID, arg.AuthorID)
+	var has_application bool
+	err := row.Scan(&has_application)
+	return has_application, err
+}
+
+const isCVInActiveApplication = `-- name: IsCVInActiveApplication :one
+SELECT EXISTS (
+    SELECT 1 FROM job.job_applications
+    WHERE cv_id = $1 AND status IN ('pending', 'reviewed')
+) AS in_use
+`
+
+func (q *Queries) IsCVInActiveApplication(ctx context.Context, db DBTX, cvID uuid.NullUUID) (bool, error) {
+	row := db.QueryRow(ctx, isCVInActiveApplication, cvID)
+	var in_use bool
+	err := row.Scan(&in_use)
+	return in_use, err
+}
+
+const isStoredObjectInUse = `-- name: IsStoredObjectInUse :one
+SELECT EXISTS (
+    SELECT 1 FROM cv.resume_database WHERE file_url LIKE '%/' || $1::text
+) OR EXISTS (
+    SELECT 1 FROM cv.ingestion_files
+    WHERE object_name = $1::text AND status IN ('pending', 'processing')
+) OR EXISTS (
+    SELECT 1 FROM cv.cv WHERE link LIKE '%/' || $1::text
+) OR EXISTS (
+    SELECT 1 FROM chat.attachments WHERE object_name = $1::text
+) AS in_use
+`
+
+func (q *Queries) IsStoredObjectInUse(ctx context.Context, db DBTX, objectName string) (bool, error) {
+	row := db.QueryRow(ctx, isStoredObjectInUse, objectName)
+	var in_use bool
+	err := row.Scan(&in_use)
+	return in_use, err
+}
+
+const listCVVersions = `-- name: ListCVVersions :many
+SELECT c.guid, c.user_guid, c.link, c.created_at, c.updated_at, c.label, c.is_primary, f.original_filename
+FROM cv.cv c
+LEFT JOIN cv.files f ON f.object_name = substring(c.link FROM '/api/v1/cv/([^/?]+)$')
+WHERE c.user_guid = $1
+ORDER BY c.is_primary DESC, c.created_at DESC
+`
+
+type ListCVVersionsRow struct {
+	Guid             uuid.UUID
+	UserGuid         string
+	Link             string
+	CreatedAt        sql.NullTime
+	UpdatedAt        sql.NullTime
+	Label            string
+	IsPrimary        bool
+	OriginalFilename sql.NullString
+}
+
+func (q *Queries) ListCVVersions(ctx context.Context, db DBTX, userGuid string) ([]ListCVVersionsRow, error) {
+	rows, err := db.Query(ctx, listCVVersions, userGuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCVVersionsRow
+	for rows.Next() {
+		var i ListCVVersionsRow
+		if err := rows.Scan(
+			&i.Guid,
+			&i.UserGuid,
+			&i.Link,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Label,
+			&i.IsPrimary,
+			&i.OriginalFilename,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCandidates = `-- name: ListCandidates :many
+SELECT id, user_id, name, email, phone, created_at, updated_at FROM cv.candidates
+WHERE user_id = $1
+ORDER BY updated_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListCandidatesParams struct {
+	UserID uuid.UUID
+	Limit  int32
+	Offset int32
+}
+
+func (q *Queries) ListCandidates(ctx context.Context, db DBTX, arg ListCandidatesParams) ([]CvCandidate, error) {
+	rows, err := db.Query(ctx, listCandidates, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CvCandidate
+	for rows.Next() {
+		var i CvCandidate
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Email,
+			&i.Phone,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResumeFolders = `-- name: ListResumeFolders :many
+SELECT f.id, f.user_id, f.name, f.created_at, f.updated_at, COUNT(i.resume_id) AS resume_count
+FROM cv.resume_folders f
+LEFT JOIN cv.resume_folder_items i ON i.folder_id = f.id
+WHERE f.user_id = $1
+GROUP BY f.id
+ORDER BY f.name
+`
+
+type ListResumeFoldersRow struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ResumeCount int64
+}
+
+func (q *Queries) ListResumeFolders(ctx context.Context, db DBTX, userID uuid.UUID) ([]ListResumeFoldersRow, error) {
+	rows, err := db.Query(ctx, listResumeFolders, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResumeFoldersRow
+	for rows.Next() {
+		var i ListResumeFoldersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResumeCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockUserCandidates = `-- name: LockUserCandidates :exec
+SELECT pg_advisory_xact_lock(hashtextextended($1::text, 0))
+`
+
+func (q *Queries) LockUserCandidates(ctx context.Context, db DBTX, userID string) error {
+	_, err := db.Exec(ctx, lockUserCandidates, userID)
+	return err
+}
+
+const moveCandidateResumes = `-- name: MoveCandidateResumes :exec
+UPDATE cv.resume_database
+SET candidate_id = $1, updated_at = NOW()
+WHERE candidate_id = ANY($2::uuid[])
+`
+
+type MoveCandidateResumesParams struct {
+	TargetID uuid.NullUUID
+	Ids      []uuid.UUID
+}
+
+func (q *Queries) MoveCandidateResumes(ctx context.Context, db DBTX, arg MoveCandidateResumesParams) error {
+	_, err := db.Exec(ctx, moveCandidateResumes, arg.TargetID, arg.Ids)
+	return err
+}
+
+const promoteLatestCV = `-- name: PromoteLatestCV :exec
+UPDATE cv.cv SET is_primary = true
+WHERE guid = (
+    SELECT l.guid FROM cv.cv l WHERE l.user_guid = $1 ORDER BY l.created_at DESC LIMIT 1
+) AND NOT EXISTS (
+    SELECT 1 FROM cv.cv p WHERE p.user_guid = $1 AND p.is_primary
+)
+`
+
+func (q *Queries) PromoteLatestCV(ctx context.Context, db DBTX, userGuid string) error {
+	_, err := db.Exec(ctx, promoteLatestCV, userGuid)
+	return err
+}
+
+const releaseStoredObject = `-- name: ReleaseStoredObject :exec
+UPDATE cv.ingestion_files
+SET object_name = NULL, updated_at = NOW()
+WHERE object_name = $1
+`
+
+func (q *Queries) ReleaseStoredObject(ctx context.Context, db DBTX, objectName sql.NullString) error {
+	_, err := db.Exec(ctx, releaseStoredObject, objectName)
+	return err
+}
+
+const removeResumeFromFolder = `-- name: RemoveResumeFromFolder :exec
+DELETE FROM cv.resume_folder_items
+WHERE folder_id = $1 AND resume_id = $2
+`
+
+type RemoveResumeFromFolderParams struct {
+	FolderID uuid.UUID
+	ResumeID uuid.UUID
+}
+
+func (q *Queries) RemoveResumeFromFolder(ctx context.Context, db DBTX, arg RemoveResumeFromFolderParams) error {
+	_, err := db.Exec(ctx, removeResumeFromFolder, arg.FolderID, arg.ResumeID)
+	return err
+}
+
+const renameResumeFolder = `-- name: RenameResumeFolder :execrows
+UPDATE cv.resume_folders f
+SET name = $1, updated_at = NOW()
+WHERE f.id = $2 AND f.user_id = $3 AND NOT EXISTS (
+    SELECT 1 FROM cv.resume_folders
+    WHERE user_id = $3 AND name = $1 AND id <> $2
+)
+`
+
+type RenameResumeFolderParams struct {
+	Name   string
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) RenameResumeFolder(ctx context.Context, db DBTX, arg RenameResumeFolderParams) (int64, error) {
+	result, err := db.Exec(ctx, renameResumeFolder, arg.Name, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reopenIngestionJob = `-- name: ReopenIngestionJob :exec
+UPDATE cv.ingestion_jobs
+SET status = 'processing', finished_at = NULL, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) ReopenIngestionJob(ctx context.Context, db DBTX, id uuid.UUID) error {
+	_, err := db.Exec(ctx, reopenIngestionJob, id)
+	return err
+}
+
+const retryFailedIngestionFiles = `-- name: RetryFailedIngestionFiles :execrows
+UPDATE cv.ingestion_files
+SET status = 'pending', error = NULL, stage = NULL, updated_at = NOW()
+WHERE job_id = $1 AND status = 'failed' AND (
+    object_name IS NOT NULL
+    OR archive_entry IS NOT NULL AND attempts > 0 AND EXISTS (
+        SELECT 1 FROM cv.ingestion_jobs
+        WHERE id = $1 AND archive_object IS NOT NULL
+    )
+)
+`
+
+func (q *Queries) RetryFailedIngestionFiles(ctx context.Context, db DBTX, jobID uuid.UUID) (int64, error) {
+	result, err := db.Exec(ctx, retryFailedIngestionFiles, jobID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const searchResumes = `-- name: SearchResumes :many
+WITH q AS (
+    SELECT plainto_tsquery('russian', $1::text) AS tsq
+), matched AS (
+    SELECT r.id, r.user_id, r.candidate_name, r.candidate_age, r.experience_years, r.file_url, r.analysis, r.created_at, r.updated_at, r.prompt_version, r.content_hash, r.email, r.phone, r.candidate_id, r.tags, r.notes,
+        ts_rank_cd(to_tsvector('russian', r.analysis), q.tsq, 32)::float8 AS text_score,
+        (to_tsvector('russian', r.analysis) @@ q.tsq OR r.candidate_name ILIKE '%' || $1::text || '%') AS text_match,
+        cv.embedding_similarity(e.embedding, $2::real[]) AS vector_score
+    FROM cv.resume_database r
+    CROSS JOIN q
+    LEFT JOIN cv.resume_embeddings e ON e.resume_id = r.id AND e.model = $3::text
+    WHERE r.user_id = $4
+        AND ($5::int IS NULL OR r.candidate_age >= $5::int)
+        AND ($6::int IS NULL OR r.candidate_age <= $6::int)
+        AND ($7::float8 IS NULL OR cv.experience_years_value(r.experience_years) >= $7::float8)
+        AND ($8::float8 IS NULL OR cv.experience_years_value(r.experience_years) <= $8::float8)
+), ranked AS (
+    SELECT matched.id, matched.user_id, matched.candidate_name, matched.candidate_age, matched.experience_years, matched.file_url, matched.analysis, matched.created_at, matched.updated_at, matched.prompt_version, matched.content_hash, matched.email, matched.phone, matched.candidate_id, matched.tags, matched.notes, matched.text_score, matched.text_match, matched.vector_score,
+        CASE WHEN text_match THEN rank() OVER (PARTITION BY text_match ORDER BY text_score DESC) END AS text_rank,
+        CASE WHEN vector_score >= $9::float8 THEN
+            rank() OVER (PARTITION BY vector_score >= $9::float8 ORDER BY vector_score DESC)
+        END AS vector_rank
+    FROM matched
+    WHERE $1::text = '' OR text_match OR vector_score >= $9::float8
+)
+SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id, tags, notes,
+    (COALESCE(1.0 / (60 + text_rank), 0) + COALESCE(1.0 / (60 + vector_rank), 0))::float8 AS score,
+    ts_headline('russian', analysis, (SELECT tsq FROM q), $10::text) AS snippet
+FROM ranked
+ORDER BY score DESC, created_at DESC
+LIMIT $11 OFFSET $12
+`
+
+type SearchResumesParams struct {
+	Query           string
+	Embedding       []float32
+	Model           string
+	UserID          uuid.UUID
+	MinAge          sql.NullInt32
+	MaxAge          sql.NullInt32
+	MinExperience   sql.NullFloat64
+	MaxExperience   sql.NullFloat64
+	MinSimilarity   float64
+	HeadlineOptions string
+	LimitCount      int32
+	OffsetCount     int32
+}
+
+type SearchResumesRow struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	CandidateName   string
+	CandidateAge    sql.NullInt32
+	ExperienceYears string
+	FileUrl         string
+	Analysis        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	PromptVersion   string
+	ContentHash     sql.NullString
+	Email           sql.NullString
+	Phone           sql.NullString
+	CandidateID     uuid.NullUUID
+	Tags            []string
+	Notes           string
+	Score           float64
+	Snippet         string
+}
+
+func (q *Queries) SearchResumes(ctx context.Context, db DBTX, arg SearchResumesParams) ([]SearchResumesRow, error) {
+	rows, err := db.Query(ctx, searchResumes,
+		arg.Query,
+		arg.Embedding,
+		arg.Model,
+		arg.UserID,
+		arg.MinAge,
+		arg.MaxAge,
+		arg.MinExperience,
+		arg.MaxExperience,
+		arg.MinSimilarity,
+		arg.HeadlineOptions,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchResumesRow
+	for rows.Next() {
+		var i SearchResumesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CandidateName,
+			&i.CandidateAge,
+			&i.ExperienceYears,
+			&i.FileUrl,
+			&i.Analysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PromptVersion,
+			&i.ContentHash,
+			&i.Email,
+			&i.Phone,
+			&i.CandidateID,
+			&i.Tags,
+			&i.Notes,
+			&i.Score,
+			&i.Snippet,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setIngestionFileObject = `-- name: SetIngestionFileObject :exec
+UPDATE cv.ingestion_files
+SET object_name = $2, content_hash = $3, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetIngestionFileObjectParams struct {
+	ID          uuid.UUID
+	ObjectName  sql.NullString
+	ContentHash sql.NullString
+}
+
+func (q *Queries) SetIngestionFileObject(ctx context.Context, db DBTX, arg SetIngestionFileObjectParams) error {
+	_, err := db.Exec(ctx, setIngestionFileObject, arg.ID, arg.ObjectName, arg.ContentHash)
+	return err
+}
+
+const setPrimaryCV = `-- name: SetPrimaryCV :execrows
+UPDATE cv.cv
+SET is_primary = true, updated_at = (now() AT TIME ZONE 'utc')
+WHERE guid = $1 AND user_guid = $2
+`
+
+type SetPrimaryCVParams struct {
+	Guid     uuid.UUID
+	UserGuid string
+}
+
+func (q *Queries) SetPrimaryCV(ctx context.Context, db DBTX, arg SetPrimaryCVParams) (int64, error) {
+	result, err := db.Exec(ctx, setPrimaryCV, arg.Guid, arg.UserGuid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setStoredFileText = `-- name: SetStoredFileText :exec
+UPDATE cv.files SET extracted_text = $2
+WHERE object_name = $1
+`
+
+type SetStoredFileTextParams struct {
+	ObjectName    string
+	ExtractedText sql.NullString
+}
+
+func (q *Queries) SetStoredFileText(ctx context.Context, db DBTX, arg SetStoredFileTextParams) error {
+	_, err := db.Exec(ctx, setStoredFileText, arg.ObjectName, arg.ExtractedText)
+	return err
+}
+
+const shortlistApplicants = `-- name: ShortlistApplicants :many
+WITH q AS (
+    SELECT replace(plainto_tsquery('russian', $1::text)::text, ' & ', ' | ')::tsquery AS tsq
+), experience AS (
+    SELECT pc.user_guid,
+        string_agg(pc.position || ', ' || c.name || ' (' || COALESCE(to_char(pc.started_at, 'YYYY-MM'), '?') || ' - ' || COALESCE(to_char(pc.finished_at, 'YYYY-MM'), 'н.в.') || ')', E'\n' ORDER BY pc.started_at DESC)::text AS positions
+    FROM company.profile_company pc
+    JOIN company.companies c ON c.guid = pc.company_guid
+    GROUP BY pc.user_guid
+)
+SELECT p.guid, p.description, p.email, p.birthday, p.avatar, p.updated_at,
+    ja.id AS application_id, ja.status AS application_status,
+    COALESCE(e.positions, '')::text AS experience, cvl.link AS cv_link, f.extracted_text AS cv_text,
+    ts_rank_cd(to_tsvector('russian', COALESCE(e.positions, '')), q.tsq, 32)::float8 AS score
+FROM profile.profiles p
+CROSS JOIN q
+LEFT JOIN job.job_applications ja ON ja.applicant_id = p.guid AND ja.job_id = $2
+LEFT JOIN experience e ON e.user_guid = p.guid
+LEFT JOIN LATERAL (
+    SELECT link FROM cv.cv
+    WHERE user_guid = p.guid::text
+    ORDER BY guid IS NOT DISTINCT FROM ja.cv_id DESC, is_primary DESC, created_at DESC
+    LIMIT 1
+) cvl ON true
+LEFT JOIN cv.files f ON f.object_name = substring(cvl.link FROM '/api/v1/cv/([^/?]+)$')
+WHERE ja.id IS NOT NULL
+    OR ($3::bool AND p.open_to_offers AND p.is_active
+        AND NOT COALESCE(p.is_hr, false) AND p.guid <> $4)
+ORDER BY ja.id IS NULL, score DESC, p.updated_at DESC
+LIMIT $5
+`
+
+type ShortlistApplicantsParams struct {
+	Query           string
+	JobID           uuid.UUID
+	IncludeProfiles bool
+	AuthorID        uuid.UUID
+	LimitCount      int32
+}
+
+type ShortlistApplicantsRow struct {
+	Guid              uuid.UUID
+	Description       string
+	Email             string
+	Birthday          string
+	Avatar            sql.NullString
+	UpdatedAt         sql.NullTime
+	ApplicationID     uuid.NullUUID
+	ApplicationStatus sql.NullString
+	Experience        string
+	CvLink            sql.NullString
+	CvText            sql.NullString
+	Score             float64
+}
+
+func (q *Queries) ShortlistApplicants(ctx context.Context, db DBTX, arg ShortlistApplicantsParams) ([]ShortlistApplicantsRow, error) {
+	rows, err := db.Query(ctx, shortlistApplicants,
+		arg.Query,
+		arg.JobID,
+		arg.IncludeProfiles,
+		arg.AuthorID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortlistApplicantsRow
+	for rows.Next() {
+		var i ShortlistApplicantsRow
+		if err := rows.Scan(
+			&i.Guid,
+			&i.Description,
+			&i.Email,
+			&i.Birthday,
+			&i.Avatar,
+			&i.UpdatedAt,
+			&i.ApplicationID,
+			&i.ApplicationStatus,
+			&i.Experience,
+			&i.CvLink,
+			&i.CvText,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const shortlistRecommendedJobs = `-- name: ShortlistRecommendedJobs :many
+WITH q AS (
+    SELECT replace(plainto_tsquery('russian', $1::text)::text, ' & ', ' | ')::tsquery AS tsq
+)
+SELECT j.id, j.title, j.company_name, j.location, j.employment_type, j.salary_from, j.salary_to,
+    j.description, j.requirements, j.author_id, j.created_at, j.updated_at, j.status,
+    ts_rank_cd(to_tsvector('russian', j.title || ' ' || j.description || ' ' || j.requirements), q.tsq, 32)::float8 AS score
+FROM job.jobs j
+CROSS JOIN q
+WHERE j.status = 'active'
+    AND j.author_id <> $2
+    AND NOT EXISTS (
+        SELECT 1 FROM job.job_applications ja WHERE ja.job_id = j.id AND ja.applicant_id = $2
+    )
+ORDER BY score DESC, j.created_at DESC
+LIMIT $3
+`
+
+type ShortlistRecommendedJobsParams struct {
+	Query      string
+	ProfileID  uuid.UUID
+	LimitCount int32
+}
+
+type ShortlistRecommendedJobsRow struct {
+	ID             uuid.UUID
+	Title          string
+	CompanyName    string
+	Location       string
+	EmploymentType string
+	SalaryFrom     sql.NullInt32
+	SalaryTo       sql.NullInt32
+	Description    string
+	Requirements   string
+	AuthorID       uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Status         string
+	Score          float64
+}
+
+func (q *Queries) ShortlistRecommendedJobs(ctx context.Context, db DBTX, arg ShortlistRecommendedJobsParams) ([]ShortlistRecommendedJobsRow, error) {
+	rows, err := db.Query(ctx, shortlistRecommendedJobs, arg.Query, arg.ProfileID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortlistRecommendedJobsRow
+	for rows.Next() {
+		var i ShortlistRecommendedJobsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.CompanyName,
+			&i.Location,
+			&i.EmploymentType,
+			&i.SalaryFrom,
+			&i.SalaryTo,
+			&i.Description,
+			&i.Requirements,
+			&i.AuthorID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const shortlistResumes = `-- name: ShortlistResumes :many
+WITH q AS (
+    SELECT replace(plainto_tsquery('russian', $1::text)::text, ' & ', ' | ')::tsquery AS tsq
+), matched AS (
+    SELECT r.id, r.user_id, r.candidate_name, r.candidate_age, r.experience_years, r.file_url, r.analysis, r.created_at, r.updated_at, r.prompt_version, r.content_hash, r.email, r.phone, r.candidate_id, r.tags, r.notes,
+        ts_rank_cd(to_tsvector('russian', r.analysis), q.tsq, 32)::float8 AS text_score,
+        cv.embedding_similarity(e.embedding, $2::real[]) AS vector_score
+    FROM cv.resume_database r
+    CROSS JOIN q
+    LEFT JOIN cv.resume_embeddings e ON e.resume_id = r.id AND e.model = $3::text
+    WHERE r.user_id = $4
+), ranked AS (
+    SELECT matched.id, matched.user_id, matched.candidate_name, matched.candidate_age, matched.experience_years, matched.file_url, matched.analysis, matched.created_at, matched.updated_at, matched.prompt_version, matched.content_hash, matched.email, matched.phone, matched.candidate_id, matched.tags, matched.notes, matched.text_score, matched.vector_score,
+        CASE WHEN text_score > 0 THEN rank() OVER (PARTITION BY text_score > 0 ORDER BY text_score DESC) END AS text_rank,
+        CASE WHEN vector_score IS NOT NULL THEN rank() OVER (PARTITION BY vector_score IS NOT NULL ORDER BY vector_score DESC) END AS vector_rank
+    FROM matched
+)
+SELECT id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id,
+    (COALESCE(1.0 / (60 + text_rank), 0) + COALESCE(1.0 / (60 + vector_rank), 0))::float8 AS score
+FROM ranked
+ORDER BY score DESC, created_at DESC
+LIMIT $5
+`
+
+type ShortlistResumesParams struct {
+	Query      string
+	Embedding  []float32
+	Model      string
+	UserID     uuid.UUID
+	LimitCount int32
+}
+
+type ShortlistResumesRow struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	CandidateName   string
+	CandidateAge    sql.NullInt32
+	ExperienceYears string
+	FileUrl         string
+	Analysis        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	PromptVersion   string
+	ContentHash     sql.NullString
+	Email           sql.NullString
+	Phone           sql.NullString
+	CandidateID     uuid.NullUUID
+	Score           float64
+}
+
+func (q *Queries) ShortlistResumes(ctx context.Context, db DBTX, arg ShortlistResumesParams) ([]ShortlistResumesRow, error) {
+	rows, err := db.Query(ctx, shortlistResumes,
+		arg.Query,
+		arg.Embedding,
+		arg.Model,
+		arg.UserID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ShortlistResumesRow
+	for rows.Next() {
+		var i ShortlistResumesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CandidateName,
+			&i.CandidateAge,
+			&i.ExperienceYears,
+			&i.FileUrl,
+			&i.Analysis,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PromptVersion,
+			&i.ContentHash,
+			&i.Email,
+			&i.Phone,
+			&i.CandidateID,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const skipIngestionFile = `-- name: SkipIngestionFile :exec
+UPDATE cv.ingestion_files
+SET status = 'skipped', resume_id = $2, error = NULL, stage = NULL, updated_at = NOW()
+WHERE id = $1
+`
+
+type SkipIngestionFileParams struct {
+	ID       uuid.UUID
+	ResumeID uuid.NullUUID
+}
+
+func (q *Queries) SkipIngestionFile(ctx context.Context, db DBTX, arg SkipIngestionFileParams) error {
+	_, err := db.Exec(ctx, skipIngestionFile, arg.ID, arg.ResumeID)
+	return err
+}
+
+const unsetPrimaryCV = `-- name: UnsetPrimaryCV :exec
+UPDATE cv.cv SET is_primary = false WHERE user_guid = $1 AND is_primary
+`
+
+func (q *Queries) UnsetPrimaryCV(ctx context.Context, db DBTX, userGuid string) error {
+	_, err := db.Exec(ctx, unsetPrimaryCV, userGuid)
+	return err
+}
+
+const updateCV = `-- name: UpdateCV :one
+UPDATE cv.cv 
+SET 
+    link = $1
+WHERE guid = $2
+RETURNING guid, user_guid, link, created_at, updated_at, label, is_primary
+`
+
+type UpdateCVParams struct {
+	Link string
+	Guid uuid.UUID
+}
+
+func (q *Queries) UpdateCV(ctx context.Context, db DBTX, arg UpdateCVParams) (CvCv, error) {
+	row := db.QueryRow(ctx, updateCV, arg.Link, arg.Guid)
+	var i CvCv
+	err := row.Scan(
+		&i.Guid,
+		&i.UserGuid,
+		&i.Link,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Label,
+		&i.IsPrimary,
+	)
+	return i, err
+}
+
+const updateCVLabel = `-- name: UpdateCVLabel :execrows
+UPDATE cv.cv
+SET label = $1, updated_at = (now() AT TIME ZONE 'utc')
+WHERE guid = $2 AND user_guid = $3
+`
+
+type UpdateCVLabelParams struct {
+	Label    string
+	Guid     uuid.UUID
+	UserGuid string
+}
+
+func (q *Queries) UpdateCVLabel(ctx context.Context, db DBTX, arg UpdateCVLabelParams) (int64, error) {
+	result, err := db.Exec(ctx, updateCVLabel, arg.Label, arg.Guid, arg.UserGuid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateCandidateContacts = `-- name: UpdateCandidateContacts :exec
+UPDATE cv.candidates
+SET email = COALESCE(email, $2), phone = COALESCE(phone, $3), updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateCandidateContactsParams struct {
+	ID    uuid.UUID
+	Email sql.NullString
+	Phone sql.NullString
+}
+
+func (q *Queries) UpdateCandidateContacts(ctx context.Context, db DBTX, arg UpdateCandidateContactsParams) error {
+	_, err := db.Exec(ctx, updateCandidateContacts, arg.ID, arg.Email, arg.Phone)
+	return err
+}
+
+const updateResumeAnnotations = `-- name: UpdateResumeAnnotations :one
+UPDATE cv.resume_database
+SET tags = COALESCE($1::text[], tags),
+    notes = COALESCE($2::text, notes)
+WHERE id = $3 AND user_id = $4
+RETURNING id, user_id, candidate_name, candidate_age, experience_years, file_url, analysis, created_at, updated_at, prompt_version, content_hash, email, phone, candidate_id, tags, notes
+`
+
+type UpdateResumeAnnotationsParams struct {
+	Tags   []string
+	Notes  sql.NullString
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) UpdateResumeAnnotations(ctx context.Context, db DBTX, arg UpdateResumeAnnotationsParams) (CvResumeDatabase, error) {
+	row := db.QueryRow(ctx, updateResumeAnnotations,
+		arg.Tags,
+		arg.Notes,
+		arg.ID,
+		arg.UserID,
+	)
+	var i CvResumeDatabase
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CandidateName,
+		&i.CandidateAge,
+		&i.ExperienceYears,
+		&i.FileUrl,
+		&i.Analysis,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PromptVersion,
+		&i.ContentHash,
+		&i.Email,
+		&i.Phone,
+		&i.CandidateID,
+		&i.Tags,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const upsertJobApplicantMatchRun = `-- name: UpsertJobApplicantMatchRun :exec
+INSERT INTO cv.job_applicant_match_runs (job_id, job_updated_at, shortlist_hash, shortlist_size, prompt_version)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (job_id) DO UPDATE
+SET job_updated_at = EXCLUDED.job_updated_at, shortlist_hash = EXCLUDED.shortlist_hash,
+    shortlist_size = EXCLUDED.shortlist_size, prompt_version = EXCLUDED.prompt_version, matched_at = NOW()
+`
+
+type UpsertJobApplicantMatchRunParams struct {
+	JobID         uuid.UUID
+	JobUpdatedAt  time.Time
+	ShortlistHash string
+	ShortlistSize int32
+	PromptVersion string
+}
+
+func (q *Queries) UpsertJobApplicantMatchRun(ctx context.Context, db DBTX, arg UpsertJobApplicantMatchRunParams) error {
+	_, err := db.Exec(ctx, upsertJobApplicantMatchRun,
+		arg.JobID,
+		arg.JobUpdatedAt,
+		arg.ShortlistHash,
+		arg.ShortlistSize,
+		arg.PromptVersion,
+	)
+	return err
+}
+
+const upsertJobMatchRun = `-- name: UpsertJobMatchRun :exec
+INSERT INTO cv.job_match_runs (job_id, job_updated_at, shortlist_hash, shortlist_size, prompt_version)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (job_id) DO UPDATE
+SET job_updated_at = EXCLUDED.job_updated_at, shortlist_hash = EXCLUDED.shortlist_hash,
+    shortlist_size = EXCLUDED.shortlist_size, prompt_version = EXCLUDED.prompt_version, matched_at = NOW()
+`
+
+type UpsertJobMatchRunParams struct {
+	JobID         uuid.UUID
+	JobUpdatedAt  time.Time
+	ShortlistHash string
+	ShortlistSize int32
+	PromptVersion string
+}
+
+func (q *Queries) UpsertJobMatchRun(ctx context.Context, db DBTX, arg UpsertJobMatchRunParams) error {
+	_, err := db.Exec(ctx, upsertJobMatchRun,
+		arg.JobID,
+		arg.JobUpdatedAt,
+		arg.ShortlistHash,
+		arg.ShortlistSize,
+		arg.PromptVersion,
+	)
+	return err
+}
+
+const upsertJobRecommendationRun = `-- name: UpsertJobRecommendationRun :exec
+INSERT INTO cv.job_recommendation_runs (profile_id, shortlist_hash, shortlist_size, prompt_version)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (profile_id) DO UPDATE
+SET shortlist_hash = EXCLUDED.shortlist_hash, shortlist_size = EXCLUDED.shortlist_size,
+    prompt_version = EXCLUDED.prompt_version, matched_at = NOW()
+`
+
+type UpsertJobRecommendationRunParams struct {
+	ProfileID     uuid.UUID
+	ShortlistHash string
+	ShortlistSize int32
+	PromptVersion string
+}
+
+func (q *Queries) UpsertJobRecommendationRun(ctx context.Context, db DBTX, arg UpsertJobRecommendationRunParams) error {
+	_, err := db.Exec(ctx, upsertJobRecommendationRun,
+		arg.ProfileID,
+		arg.ShortlistHash,
+		arg.ShortlistSize,
+		arg.PromptVersion,
+	)
+	return err
+}
+
+const upsertResumeEmbedding = `-- name: UpsertResumeEmbedding :exec
+INSERT INTO cv.resume_embeddings (resume_id, model, embedding)
+VALUES ($1, $2, $3)
+ON CONFLICT (resume_id) DO UPDATE
+SET model = EXCLUDED.model, embedding = EXCLUDED.embedding, updated_at = NOW()
+`
+
+type UpsertResumeEmbeddingParams struct {
+	ResumeID  uuid.UUID
+	Model     string
+	Embedding []float32
+}
+
+func (q *Queries) UpsertResumeEmbedding(ctx context.Context, db DBTX, arg UpsertResumeEmbeddingParams) error {
+	_, err := db.Exec(ctx, upsertResumeEmbedding, arg.ResumeID, arg.Model, arg.Embedding)
+	return err
+}
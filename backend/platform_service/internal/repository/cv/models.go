@@ -11,12 +11,89 @@ import (
 	"github.com/google/uuid"
 )
 
+type CvCandidate struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	Email     sql.NullString
+	Phone     sql.NullString
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 type CvCv struct {
 	Guid      uuid.UUID
 	UserGuid  string
 	Link      string
 	CreatedAt sql.NullTime
 	UpdatedAt sql.NullTime
+	Label     string
+	IsPrimary bool
+}
+
+type CvFile struct {
+	ObjectName       string
+	OwnerID          uuid.UUID
+	Kind             string
+	OriginalFilename string
+	ContentType      string
+	CreatedAt        time.Time
+	ExtractedText    sql.NullString
+}
+
+type CvIngestionFile struct {
+	ID             uuid.UUID
+	JobID          uuid.UUID
+	FileName       string
+	Extension      string
+	ObjectName     sql.NullString
+	Status         string
+	Error          sql.NullString
+	Attempts       int32
+	ResumeID       uuid.NullUUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Stage          sql.NullString
+	ContentHash    sql.NullString
+	ArchiveEntry   sql.NullString
+	LeaseExpiresAt sql.NullTime
+}
+
+type CvIngestionJob struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	ArchiveName   string
+	Status        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	FinishedAt    sql.NullTime
+	ArchiveObject sql.NullString
+}
+
+type CvJobApplicantMatchRun struct {
+	JobID         uuid.UUID
+	JobUpdatedAt  time.Time
+	ShortlistHash string
+	ShortlistSize int32
+	PromptVersion string
+	MatchedAt     time.Time
+}
+
+type CvJobMatchRun struct {
+	JobID         uuid.UUID
+	JobUpdatedAt  time.Time
+	ShortlistHash string
+	ShortlistSize int32
+	PromptVersion string
+	MatchedAt     time.Time
+}
+
+type CvJobRecommendationRun struct {
+	ProfileID     uuid.UUID
+	ShortlistHash string
+	ShortlistSize int32
+	PromptVersion string
+	MatchedAt     time.Time
 }
 
 type CvResumeDatabase struct {
@@ -29,4 +106,25 @@ type CvResumeDatabase struct {
 	Analysis        string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	PromptVersion   string
+	ContentHash     sql.NullString
+	Email           sql.NullString
+	Phone           sql.NullString
+	CandidateID     uuid.NullUUID
+	Tags            []string
+	Notes           string
+}
+
+type CvResumeFolder struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CvResumeFolderItem struct {
+	FolderID uuid.UUID
+	ResumeID uuid.UUID
+	AddedAt  time.Time
 }
@@ -6,24 +6,93 @@ package cv
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddResumeToFolder(ctx context.Context, db DBTX, arg AddResumeToFolderParams) error
+	ClaimIngestionFile(ctx context.Context, db DBTX, arg ClaimIngestionFileParams) (CvIngestionFile, error)
+	CompleteIngestionFile(ctx context.Context, db DBTX, arg CompleteIngestionFileParams) error
 	CreateCV(ctx context.Context, db DBTX, arg CreateCVParams) (CvCv, error)
+	CreateCandidate(ctx context.Context, db DBTX, arg CreateCandidateParams) (CvCandidate, error)
+	CreateIngestionFile(ctx context.Context, db DBTX, arg CreateIngestionFileParams) (CvIngestionFile, error)
+	CreateIngestionJob(ctx context.Context, db DBTX, arg CreateIngestionJobParams) (CvIngestionJob, error)
+	CreateJobApplicantMatchResult(ctx context.Context, db DBTX, arg CreateJobApplicantMatchResultParams) error
+	CreateJobMatchResult(ctx context.Context, db DBTX, arg CreateJobMatchResultParams) error
+	CreateJobRecommendationResult(ctx context.Context, db DBTX, arg CreateJobRecommendationResultParams) error
+	CreateResumeFolder(ctx context.Context, db DBTX, arg CreateResumeFolderParams) (CvResumeFolder, error)
 	CreateResumeRecord(ctx context.Context, db DBTX, arg CreateResumeRecordParams) (CvResumeDatabase, error)
+	CreateStoredFile(ctx context.Context, db DBTX, arg CreateStoredFileParams) error
 	DeleteCV(ctx context.Context, db DBTX, guid uuid.UUID) error
-	DeleteCVLink(ctx context.Context, db DBTX, userGuid string) error
-	DeleteResumeRecord(ctx context.Context, db DBTX, arg DeleteResumeRecordParams) error
+	DeleteCandidates(ctx context.Context, db DBTX, arg DeleteCandidatesParams) error
+	DeleteEmptyCandidate(ctx context.Context, db DBTX, id uuid.UUID) error
+	DeleteJobApplicantMatchResults(ctx context.Context, db DBTX, jobID uuid.UUID) error
+	DeleteJobMatchResults(ctx context.Context, db DBTX, jobID uuid.UUID) error
+	DeleteJobRecommendationResults(ctx context.Context, db DBTX, profileID uuid.UUID) error
+	DeleteResumeFolder(ctx context.Context, db DBTX, arg DeleteResumeFolderParams) (int64, error)
+	DeleteResumeRecord(ctx context.Context, db DBTX, arg DeleteResumeRecordParams) (CvResumeDatabase, error)
+	DeleteStoredFile(ctx context.Context, db DBTX, objectName string) error
+	FailAbandonedIngestionFiles(ctx context.Context, db DBTX, attempts int32) ([]uuid.UUID, error)
+	FailIngestionFile(ctx context.Context, db DBTX, arg FailIngestionFileParams) error
+	FinishIngestionJob(ctx context.Context, db DBTX, id uuid.UUID) (FinishIngestionJobRow, error)
+	GetApplicantProfile(ctx context.Context, db DBTX, guid uuid.UUID) (GetApplicantProfileRow, error)
 	GetCVByGUID(ctx context.Context, db DBTX, guid uuid.UUID) (CvCv, error)
+	GetCVByLink(ctx context.Context, db DBTX, arg GetCVByLinkParams) (CvCv, error)
 	GetCVByUserGUID(ctx context.Context, db DBTX, userGuid string) (CvCv, error)
-	GetCVLink(ctx context.Context, db DBTX, userGuid string) (string, error)
+	GetCVVersion(ctx context.Context, db DBTX, arg GetCVVersionParams) (GetCVVersionRow, error)
+	GetCandidatesByIDs(ctx context.Context, db DBTX, arg GetCandidatesByIDsParams) ([]CvCandidate, error)
+	GetCandidatesByUserID(ctx context.Context, db DBTX, userID uuid.UUID) ([]CvCandidate, error)
+	GetIngestionFiles(ctx context.Context, db DBTX, jobID uuid.UUID) ([]CvIngestionFile, error)
+	GetIngestionJob(ctx context.Context, db DBTX, arg GetIngestionJobParams) (GetIngestionJobRow, error)
+	GetIngestionJobOwner(ctx context.Context, db DBTX, id uuid.UUID) (GetIngestionJobOwnerRow, error)
+	GetJobApplicantMatchResults(ctx context.Context, db DBTX, arg GetJobApplicantMatchResultsParams) ([]GetJobApplicantMatchResultsRow, error)
+	GetJobApplicantMatchRun(ctx context.Context, db DBTX, jobID uuid.UUID) (CvJobApplicantMatchRun, error)
+	GetJobMatchResults(ctx context.Context, db DBTX, arg GetJobMatchResultsParams) ([]GetJobMatchResultsRow, error)
+	GetJobMatchRun(ctx context.Context, db DBTX, jobID uuid.UUID) (CvJobMatchRun, error)
+	GetJobRecommendationRun(ctx context.Context, db DBTX, profileID uuid.UUID) (CvJobRecommendationRun, error)
+	GetJobRecommendations(ctx context.Context, db DBTX, arg GetJobRecommendationsParams) ([]GetJobRecommendationsRow, error)
+	GetResumeByContentHash(ctx context.Context, db DBTX, arg GetResumeByContentHashParams) (CvResumeDatabase, error)
 	GetResumeByID(ctx context.Context, db DBTX, id uuid.UUID) (CvResumeDatabase, error)
+	GetResumeFolder(ctx context.Context, db DBTX, arg GetResumeFolderParams) (GetResumeFolderRow, error)
+	GetResumeFolderItems(ctx context.Context, db DBTX, resumeIds []uuid.UUID) ([]CvResumeFolderItem, error)
+	GetResumesByCandidateIDs(ctx context.Context, db DBTX, ids []uuid.UUID) ([]CvResumeDatabase, error)
 	GetResumesByUserID(ctx context.Context, db DBTX, arg GetResumesByUserIDParams) ([]CvResumeDatabase, error)
-	SaveCVLink(ctx context.Context, db DBTX, arg SaveCVLinkParams) error
-	SearchResumesByUserID(ctx context.Context, db DBTX, arg SearchResumesByUserIDParams) ([]CvResumeDatabase, error)
+	GetResumesWithoutEmbedding(ctx context.Context, db DBTX, arg GetResumesWithoutEmbeddingParams) ([]CvResumeDatabase, error)
+	GetStoredFile(ctx context.Context, db DBTX, objectName string) (CvFile, error)
+	GetStoredObjectByContentHash(ctx context.Context, db DBTX, arg GetStoredObjectByContentHashParams) (sql.NullString, error)
+	HasApplicationToAuthorJob(ctx context.Context, db DBTX, arg HasApplicationToAuthorJobParams) (bool, error)
+	IsCVInActiveApplication(ctx context.Context, db DBTX, cvID uuid.NullUUID) (bool, error)
+	IsStoredObjectInUse(ctx context.Context, db DBTX, objectName string) (bool, error)
+	ListCVVersions(ctx context.Context, db DBTX, userGuid string) ([]ListCVVersionsRow, error)
+	ListCandidates(ctx context.Context, db DBTX, arg ListCandidatesParams) ([]CvCandidate, error)
+	ListResumeFolders(ctx context.Context, db DBTX, userID uuid.UUID) ([]ListResumeFoldersRow, error)
+	LockUserCandidates(ctx context.Context, db DBTX, userID string) error
+	MoveCandidateResumes(ctx context.Context, db DBTX, arg MoveCandidateResumesParams) error
+	PromoteLatestCV(ctx context.Context, db DBTX, userGuid string) error
+	ReleaseStoredObject(ctx context.Context, db DBTX, objectName sql.NullString) error
+	RemoveResumeFromFolder(ctx context.Context, db DBTX, arg RemoveResumeFromFolderParams) error
+	RenameResumeFolder(ctx context.Context, db DBTX, arg RenameResumeFolderParams) (int64, error)
+	ReopenIngestionJob(ctx context.Context, db DBTX, id uuid.UUID) error
+	RetryFailedIngestionFiles(ctx context.Context, db DBTX, jobID uuid.UUID) (int64, error)
+	SearchResumes(ctx context.Context, db DBTX, arg SearchResumesParams) ([]SearchResumesRow, error)
+	SetIngestionFileObject(ctx context.Context, db DBTX, arg SetIngestionFileObjectParams) error
+	SetPrimaryCV(ctx context.Context, db DBTX, arg SetPrimaryCVParams) (int64, error)
+	SetStoredFileText(ctx context.Context, db DBTX, arg SetStoredFileTextParams) error
+	ShortlistApplicants(ctx context.Context, db DBTX, arg ShortlistApplicantsParams) ([]ShortlistApplicantsRow, error)
+	ShortlistRecommendedJobs(ctx context.Context, db DBTX, arg ShortlistRecommendedJobsParams) ([]ShortlistRecommendedJobsRow, error)
+	ShortlistResumes(ctx context.Context, db DBTX, arg ShortlistResumesParams) ([]ShortlistResumesRow, error)
+	SkipIngestionFile(ctx context.Context, db DBTX, arg SkipIngestionFileParams) error
+	UnsetPrimaryCV(ctx context.Context, db DBTX, userGuid string) error
 	UpdateCV(ctx context.Context, db DBTX, arg UpdateCVParams) (CvCv, error)
+	UpdateCVLabel(ctx context.Context, db DBTX, arg UpdateCVLabelParams) (int64, error)
+	UpdateCandidateContacts(ctx context.Context, db DBTX, arg UpdateCandidateContactsParams) error
+	UpdateResumeAnnotations(ctx context.Context, db DBTX, arg UpdateResumeAnnotationsParams) (CvResumeDatabase, error)
+	UpsertJobApplicantMatchRun(ctx context.Context, db DBTX, arg UpsertJobApplicantMatchRunParams) error
+	UpsertJobMatchRun(ctx context.Context, db DBTX, arg UpsertJobMatchRunParams) error
+	UpsertJobRecommendationRun(ctx context.Context, db DBTX, arg UpsertJobRecommendationRunParams) error
+	UpsertResumeEmbedding(ctx context.Context, db DBTX, arg UpsertResumeEmbeddingParams) error
 }
 
 var _ Querier = (*Queries)(nil)
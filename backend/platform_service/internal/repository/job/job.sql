@@ -39,26 +39,63 @@ RETURNING *;
 DELETE FROM job.jobs WHERE id = $1 AND author_id = $2;
 
 -- name: CreateJobApplication :one
-INSERT INTO job.job_applications (job_id, applicant_id)
-VALUES ($1, $2)
+INSERT INTO job.job_applications (job_id, applicant_id, cv_id)
+VALUES ($1, $2, $3)
 RETURNING *;
 
+-- name: GetApplicantCVID :one
+SELECT guid FROM cv.cv
+WHERE user_guid = sqlc.arg(user_guid)::text
+    AND (sqlc.narg(cv_id)::uuid IS NULL OR guid = sqlc.narg(cv_id)::uuid)
+ORDER BY is_primary DESC, created_at DESC
+LIMIT 1;
+
 -- name: GetJobApplication :one
 SELECT * FROM job.job_applications 
 WHERE job_id = $1 AND applicant_id = $2;
 
 -- name: GetJobApplications :many
-SELECT ja.*, p.description as applicant_description, p.email as applicant_email, p.avatar as applicant_avatar
+SELECT ja.*, p.description as applicant_description, p.email as applicant_email, p.avatar as applicant_avatar,
+    c.label as cv_label, c.link as cv_link
 FROM job.job_applications ja
-JOIN profile.profiles p ON ja.applicant_id = p.guid
+LEFT JOIN profile.profiles p ON ja.applicant_id = p.guid
+LEFT JOIN cv.cv c ON c.guid = ja.cv_id
 WHERE ja.job_id = $1
 ORDER BY ja.applied_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: GetJobApplicationByID :one
+SELECT ja.*, p.description as applicant_description, p.email as applicant_email, p.avatar as applicant_avatar,
+    c.label as cv_label, c.link as cv_link
+FROM job.job_applications ja
+LEFT JOIN profile.profiles p ON ja.applicant_id = p.guid
+LEFT JOIN cv.cv c ON c.guid = ja.cv_id
+WHERE ja.id = $1;
+
 -- name: UpdateJobApplicationStatus :one
 UPDATE job.job_applications 
-SET status = $3
-WHERE job_id = $1 AND applicant_id = $2
+SET status = sqlc.arg(status)
+WHERE job_id = sqlc.arg(job_id)
+  AND (applicant_id = sqlc.arg(applicant_id)::uuid OR id = sqlc.arg(applicant_id)::uuid)
+RETURNING *;
+
+-- name: CreateSourcedApplication :one
+INSERT INTO job.job_applications (
+    job_id, source, resume_id, candidate_name, candidate_email, invite_token_hash, invited_at
+) VALUES (
+    $1, 'database', $2, $3, $4, $5, $6
+)
+ON CONFLICT (job_id, resume_id) DO NOTHING
+RETURNING *;
+
+-- name: GetSourcedApplicationByToken :one
+SELECT * FROM job.job_applications
+WHERE invite_token_hash = $1 AND applicant_id IS NULL;
+
+-- name: ClaimSourcedApplication :one
+UPDATE job.job_applications
+SET applicant_id = $2, claimed_at = NOW(), invite_token_hash = NULL
+WHERE id = $1 AND applicant_id IS NULL
 RETURNING *;
 
 -- name: GetApplicationsCount :one
@@ -24,6 +24,39 @@ func (q *Queries) CheckJobExists(ctx context.Context, db DBTX, id uuid.UUID) (bo
 	return exists, err
 }
 
+const claimSourcedApplication = `-- name: ClaimSourcedApplication :one
+UPDATE job.job_applications
+SET applicant_id = $2, claimed_at = NOW(), invite_token_hash = NULL
+WHERE id = $1 AND applicant_id IS NULL
+RETURNING id, job_id, applicant_id, applied_at, status, source, resume_id, candidate_name, candidate_email, invite_token_hash, invited_at, claimed_at, cv_id
+`
+
+type ClaimSourcedApplicationParams struct {
+	ID          uuid.UUID
+	ApplicantID uuid.NullUUID
+}
+
+func (q *Queries) ClaimSourcedApplication(ctx context.Context, db DBTX, arg ClaimSourcedApplicationParams) (JobJobApplication, error) {
+	row := db.QueryRow(ctx, claimSourcedApplication, arg.ID, arg.ApplicantID)
+	var i JobJobApplication
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.ApplicantID,
+		&i.AppliedAt,
+		&i.Status,
+		&i.Source,
+		&i.ResumeID,
+		&i.CandidateName,
+		&i.CandidateEmail,
+		&i.InviteTokenHash,
+		&i.InvitedAt,
+		&i.ClaimedAt,
+		&i.CvID,
+	)
+	return i, err
+}
+
 const createJob = `-- name: CreateJob :one
 INSERT INTO job.jobs (
     title, company_name, location, employment_type, 
@@ -77,18 +110,19 @@ func (q *Queries) CreateJob(ctx context.Context, db DBTX, arg CreateJobParams) (
 }
 
 const createJobApplication = `-- name: CreateJobApplication :one
-INSERT INTO job.job_applications (job_id, applicant_id)
-VALUES ($1, $2)
-RETURNING id, job_id, applicant_id, applied_at, status
+INSERT INTO job.job_applications (job_id, applicant_id, cv_id)
+VALUES ($1, $2, $3)
+RETURNING id, job_id, applicant_id, applied_at, status, source, resume_id, candidate_name, candidate_email, invite_token_hash, invited_at, claimed_at, cv_id
 `
 
 type CreateJobApplicationParams struct {
 	JobID       uuid.UUID
-	ApplicantID uuid.UUID
+	ApplicantID uuid.NullUUID
+	CvID        uuid.NullUUID
 }
 
 func (q *Queries) CreateJobApplication(ctx context.Context, db DBTX, arg CreateJobApplicationParams) (JobJobApplication, error) {
-	row := db.QueryRow(ctx, createJobApplication, arg.JobID, arg.ApplicantID)
+	row := db.QueryRow(ctx, createJobApplication, arg.JobID, arg.ApplicantID, arg.CvID)
 	var i JobJobApplication
 	err := row.Scan(
 		&i.ID,
@@ -96,6 +130,61 @@ func (q *Queries) CreateJobApplication(ctx context.Context, db DBTX, arg CreateJ
 		&i.ApplicantID,
 		&i.AppliedAt,
 		&i.Status,
+		&i.Source,
+		&i.ResumeID,
+		&i.CandidateName,
+		&i.CandidateEmail,
+		&i.InviteTokenHash,
+		&i.InvitedAt,
+		&i.ClaimedAt,
+		&i.CvID,
+	)
+	return i, err
+}
+
+const createSourcedApplication = `-- name: CreateSourcedApplication :one
+INSERT INTO job.job_applications (
+    job_id, source, resume_id, candidate_name, candidate_email, invite_token_hash, invited_at
+) VALUES (
+    $1, 'database', $2, $3, $4, $5, $6
+)
+ON CONFLICT (job_id, resume_id) DO NOTHING
+RETURNING id, job_id, applicant_id, applied_at, status, source, resume_id, candidate_name, candidate_email, invite_token_hash, invited_at, claimed_at, cv_id
+`
+
+type CreateSourcedApplicationParams struct {
+	JobID           uuid.UUID
+	ResumeID        uuid.NullUUID
+	CandidateName   sql.NullString
+	CandidateEmail  sql.NullString
+	InviteTokenHash sql.NullString
+	InvitedAt       sql.NullTime
+}
+
+func (q *Queries) CreateSourcedApplication(ctx context.Context, db DBTX, arg CreateSourcedApplicationParams) (JobJobApplication, error) {
+	row := db.QueryRow(ctx, createSourcedApplication,
+		arg.JobID,
+		arg.ResumeID,
+		arg.CandidateName,
+		arg.CandidateEmail,
+		arg.InviteTokenHash,
+		arg.InvitedAt,
+	)
+	var i JobJobApplication
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.ApplicantID,
+		&i.AppliedAt,
+		&i.Status,
+		&i.Source,
+		&i.ResumeID,
+		&i.CandidateName,
+		&i.CandidateEmail,
+		&i.InviteTokenHash,
+		&i.InvitedAt,
+		&i.ClaimedAt,
+		&i.CvID,
 	)
 	return i, err
 }
@@ -114,6 +203,26 @@ func (q *Queries) DeleteJob(ctx context.Context, db DBTX, arg DeleteJobParams) e
 	return err
 }
 
+const getApplicantCVID = `-- name: GetApplicantCVID :one
+SELECT guid FROM cv.cv
+WHERE user_guid = $1::text
+    AND ($2::uuid IS NULL OR guid = $2::uuid)
+ORDER BY is_primary DESC, created_at DESC
+LIMIT 1
+`
+
+type GetApplicantCVIDParams struct {
+	UserGuid string
+	CvID     uuid.NullUUID
+}
+
+func (q *Queries) GetApplicantCVID(ctx context.Context, db DBTX, arg GetApplicantCVIDParams) (uuid.UUID, error) {
+	row := db.QueryRow(ctx, getApplicantCVID, arg.UserGuid, arg.CvID)
+	var guid uuid.UUID
+	err := row.Scan(&guid)
+	return guid, err
+}
+
 const getApplicationsCount = `-- name: GetApplicationsCount :one
 SELECT COUNT(*) FROM job.job_applications WHERE job_id = $1
 `
@@ -126,13 +235,13 @@ func (q *Queries) GetApplicationsCount(ctx context.Context, db DBTX, jobID uuid.
 }
 
 const getJobApplication = `-- name: GetJobApplication :one
-SELECT id, job_id, applicant_id, applied_at, status FROM job.job_applications 
+SELECT id, job_id, applicant_id, applied_at, status, source, resume_id, candidate_name, candidate_email, invite_token_hash, invited_at, claimed_at, cv_id FROM job.job_applications 
 WHERE job_id = $1 AND applicant_id = $2
 `
 
 type GetJobApplicationParams struct {
 	JobID       uuid.UUID
-	ApplicantID uuid.UUID
+	ApplicantID uuid.NullUUID
 }
 
 func (q *Queries) GetJobApplication(ctx context.Context, db DBTX, arg GetJobApplicationParams) (JobJobApplication, error) {
@@ -144,14 +253,80 @@ func (q *Queries) GetJobApplication(ctx context.Context, db DBTX, arg GetJobAppl
 		&i.ApplicantID,
 		&i.AppliedAt,
 		&i.Status,
+		&i.Source,
+		&i.ResumeID,
+		&i.CandidateName,
+		&i.CandidateEmail,
+		&i.InviteTokenHash,
+		&i.InvitedAt,
+		&i.ClaimedAt,
+		&i.CvID,
+	)
+	return i, err
+}
+
+const getJobApplicationByID = `-- name: GetJobApplicationByID :one
+SELECT ja.id, ja.job_id, ja.applicant_id, ja.applied_at, ja.status, ja.source, ja.resume_id, ja.candidate_name, ja.candidate_email, ja.invite_token_hash, ja.invited_at, ja.claimed_at, ja.cv_id, p.description as applicant_description, p.email as applicant_email, p.avatar as applicant_avatar,
+    c.label as cv_label, c.link as cv_link
+FROM job.job_applications ja
+LEFT JOIN profile.profiles p ON ja.applicant_id = p.guid
+LEFT JOIN cv.cv c ON c.guid = ja.cv_id
+WHERE ja.id = $1
+`
+
+type GetJobApplicationByIDRow struct {
+	ID                   uuid.UUID
+	JobID                uuid.UUID
+	ApplicantID          uuid.NullUUID
+	AppliedAt            time.Time
+	Status               string
+	Source               string
+	ResumeID             uuid.NullUUID
+	CandidateName        sql.NullString
+	CandidateEmail       sql.NullString
+	InviteTokenHash      sql.NullString
+	InvitedAt            sql.NullTime
+	ClaimedAt            sql.NullTime
+	CvID                 uuid.NullUUID
+	ApplicantDescription sql.NullString
+	ApplicantEmail       sql.NullString
+	ApplicantAvatar      sql.NullString
+	CvLabel              sql.NullString
+	CvLink               sql.NullString
+}
+
+func (q *Queries) GetJobApplicationByID(ctx context.Context, db DBTX, id uuid.UUID) (GetJobApplicationByIDRow, error) {
+	row := db.QueryRow(ctx, getJobApplicationByID, id)
+	var i GetJobApplicationByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.ApplicantID,
+		&i.AppliedAt,
+		&i.Status,
+		&i.Source,
+		&i.ResumeID,
+		&i.CandidateName,
+		&i.CandidateEmail,
+		&i.InviteTokenHash,
+		&i.InvitedAt,
+		&i.ClaimedAt,
+		&i.CvID,
+		&i.ApplicantDescription,
+		&i.ApplicantEmail,
+		&i.ApplicantAvatar,
+		&i.CvLabel,
+		&i.CvLink,
 	)
 	return i, err
 }
 
 const getJobApplications = `-- name: GetJobApplications :many
-SELECT ja.id, ja.job_id, ja.applicant_id, ja.applied_at, ja.status, p.description as applicant_description, p.email as applicant_email, p.avatar as applicant_avatar
+SELECT ja.id, ja.job_id, ja.applicant_id, ja.applied_at, ja.status, ja.source, ja.resume_id, ja.candidate_name, ja.candidate_email, ja.invite_token_hash, ja.invited_at, ja.claimed_at, ja.cv_id, p.description as applicant_description, p.email as applicant_email, p.avatar as applicant_avatar,
+    c.label as cv_label, c.link as cv_link
 FROM job.job_applications ja
-JOIN profile.profiles p ON ja.applicant_id = p.guid
+LEFT JOIN profile.profiles p ON ja.applicant_id = p.guid
+LEFT JOIN cv.cv c ON c.guid = ja.cv_id
 WHERE ja.job_id = $1
 ORDER BY ja.applied_at DESC
 LIMIT $2 OFFSET $3
@@ -166,12 +341,22 @@ type GetJobApplicationsParams struct {
 type GetJobApplicationsRow struct {
 	ID                   uuid.UUID
 	JobID                uuid.UUID
-	ApplicantID          uuid.UUID
+	ApplicantID          uuid.NullUUID
 	AppliedAt            time.Time
 	Status               string
-	ApplicantDescription string
-	ApplicantEmail       string
+	Source               string
+	ResumeID             uuid.NullUUID
+	CandidateName        sql.NullString
+	CandidateEmail       sql.NullString
+	InviteTokenHash      sql.NullString
+	InvitedAt            sql.NullTime
+	ClaimedAt            sql.NullTime
+	CvID                 uuid.NullUUID
+	ApplicantDescription sql.NullString
+	ApplicantEmail       sql.NullString
 	ApplicantAvatar      sql.NullString
+	CvLabel              sql.NullString
+	CvLink               sql.NullString
 }
 
 func (q *Queries) GetJobApplications(ctx context.Context, db DBTX, arg GetJobApplicationsParams) ([]GetJobApplicationsRow, error) {
@@ -189,9 +374,19 @@ func (q *Queries) GetJobApplications(ctx context.Context, db DBTX, arg GetJobApp
 			&i.ApplicantID,
 			&i.AppliedAt,
 			&i.Status,
+			&i.Source,
+			&i.ResumeID,
+			&i.CandidateName,
+			&i.CandidateEmail,
+			&i.InviteTokenHash,
+			&i.InvitedAt,
+			&i.ClaimedAt,
+			&i.CvID,
 			&i.ApplicantDescription,
 			&i.ApplicantEmail,
 			&i.ApplicantAvatar,
+			&i.CvLabel,
+			&i.CvLink,
 		); err != nil {
 			return nil, err
 		}
@@ -347,6 +542,32 @@ func (q *Queries) GetJobsByAuthor(ctx context.Context, db DBTX, arg GetJobsByAut
 	return items, nil
 }
 
+const getSourcedApplicationByToken = `-- name: GetSourcedApplicationByToken :one
+SELECT id, job_id, applicant_id, applied_at, status, source, resume_id, candidate_name, candidate_email, invite_token_hash, invited_at, claimed_at, cv_id FROM job.job_applications
+WHERE invite_token_hash = $1 AND applicant_id IS NULL
+`
+
+func (q *Queries) GetSourcedApplicationByToken(ctx context.Context, db DBTX, inviteTokenHash sql.NullString) (JobJobApplication, error) {
+	row := db.QueryRow(ctx, getSourcedApplicationByToken, inviteTokenHash)
+	var i JobJobApplication
+	err := row.Scan(
+		&i.ID,
+		&i.JobID,
+		&i.ApplicantID,
+		&i.AppliedAt,
+		&i.Status,
+		&i.Source,
+		&i.ResumeID,
+		&i.CandidateName,
+		&i.CandidateEmail,
+		&i.InviteTokenHash,
+		&i.InvitedAt,
+		&i.ClaimedAt,
+		&i.CvID,
+	)
+	return i, err
+}
+
 const updateJob = `-- name: UpdateJob :one
 UPDATE job.jobs 
 SET title = $2, company_name = $3, location = $4, employment_type = $5,
@@ -404,19 +625,20 @@ func (q *Queries) UpdateJob(ctx context.Context, db DBTX, arg UpdateJobParams) (
 
 const updateJobApplicationStatus = `-- name: UpdateJobApplicationStatus :one
 UPDATE job.job_applications 
-SET status = $3
-WHERE job_id = $1 AND applicant_id = $2
-RETURNING id, job_id, applicant_id, applied_at, status
+SET status = $1
+WHERE job_id = $2
+  AND (applicant_id = $3::uuid OR id = $3::uuid)
+RETURNING id, job_id, applicant_id, applied_at, status, source, resume_id, candidate_name, candidate_email, invite_token_hash, invited_at, claimed_at, cv_id
 `
 
 type UpdateJobApplicationStatusParams struct {
+	Status      string
 	JobID       uuid.UUID
 	ApplicantID uuid.UUID
-	Status      string
 }
 
 func (q *Queries) UpdateJobApplicationStatus(ctx context.Context, db DBTX, arg UpdateJobApplicationStatusParams) (JobJobApplication, error) {
-	row := db.QueryRow(ctx, updateJobApplicationStatus, arg.JobID, arg.ApplicantID, arg.Status)
+	row := db.QueryRow(ctx, updateJobApplicationStatus, arg.Status, arg.JobID, arg.ApplicantID)
 	var i JobJobApplication
 	err := row.Scan(
 		&i.ID,
@@ -424,6 +646,14 @@ func (q *Queries) UpdateJobApplicationStatus(ctx context.Context, db DBTX, arg U
 		&i.ApplicantID,
 		&i.AppliedAt,
 		&i.Status,
+		&i.Source,
+		&i.ResumeID,
+		&i.CandidateName,
+		&i.CandidateEmail,
+		&i.InviteTokenHash,
+		&i.InvitedAt,
+		&i.ClaimedAt,
+		&i.CvID,
 	)
 	return i, err
 }
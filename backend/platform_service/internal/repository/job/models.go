@@ -28,9 +28,17 @@ type JobJob struct {
 }
 
 type JobJobApplication struct {
-	ID          uuid.UUID
-	JobID       uuid.UUID
-	ApplicantID uuid.UUID
-	AppliedAt   time.Time
-	Status      string
+	ID              uuid.UUID
+	JobID           uuid.UUID
+	ApplicantID     uuid.NullUUID
+	AppliedAt       time.Time
+	Status          string
+	Source          string
+	ResumeID        uuid.NullUUID
+	CandidateName   sql.NullString
+	CandidateEmail  sql.NullString
+	InviteTokenHash sql.NullString
+	InvitedAt       sql.NullTime
+	ClaimedAt       sql.NullTime
+	CvID            uuid.NullUUID
 }
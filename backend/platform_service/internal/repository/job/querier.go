@@ -6,21 +6,27 @@ package job
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	CheckJobExists(ctx context.Context, db DBTX, id uuid.UUID) (bool, error)
+	ClaimSourcedApplication(ctx context.Context, db DBTX, arg ClaimSourcedApplicationParams) (JobJobApplication, error)
 	CreateJob(ctx context.Context, db DBTX, arg CreateJobParams) (JobJob, error)
 	CreateJobApplication(ctx context.Context, db DBTX, arg CreateJobApplicationParams) (JobJobApplication, error)
+	CreateSourcedApplication(ctx context.Context, db DBTX, arg CreateSourcedApplicationParams) (JobJobApplication, error)
 	DeleteJob(ctx context.Context, db DBTX, arg DeleteJobParams) error
+	GetApplicantCVID(ctx context.Context, db DBTX, arg GetApplicantCVIDParams) (uuid.UUID, error)
 	GetApplicationsCount(ctx context.Context, db DBTX, jobID uuid.UUID) (int64, error)
 	GetJobApplication(ctx context.Context, db DBTX, arg GetJobApplicationParams) (JobJobApplication, error)
+	GetJobApplicationByID(ctx context.Context, db DBTX, id uuid.UUID) (GetJobApplicationByIDRow, error)
 	GetJobApplications(ctx context.Context, db DBTX, arg GetJobApplicationsParams) ([]GetJobApplicationsRow, error)
 	GetJobByID(ctx context.Context, db DBTX, id uuid.UUID) (JobJob, error)
 	GetJobs(ctx context.Context, db DBTX, arg GetJobsParams) ([]JobJob, error)
 	GetJobsByAuthor(ctx context.Context, db DBTX, arg GetJobsByAuthorParams) ([]GetJobsByAuthorRow, error)
+	GetSourcedApplicationByToken(ctx context.Context, db DBTX, inviteTokenHash sql.NullString) (JobJobApplication, error)
 	UpdateJob(ctx context.Context, db DBTX, arg UpdateJobParams) (JobJob, error)
 	UpdateJobApplicationStatus(ctx context.Context, db DBTX, arg UpdateJobApplicationStatusParams) (JobJobApplication, error)
 }
@@ -33,4 +33,5 @@ type ProfileProfile struct {
 	VerificationToken sql.NullString
 	CreatedAt         sql.NullTime
 	UpdatedAt         sql.NullTime
+	OpenToOffers      bool
 }
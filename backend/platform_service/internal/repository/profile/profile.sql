@@ -36,8 +36,17 @@ SET
     password_hash = $8,
     is_active = $9,
     verification_token = $10,
-    updated_at = $11
-WHERE guid = $12
+    updated_at = $11,
+    open_to_offers = $12
+WHERE guid = $13
+RETURNING *;
+
+-- name: UpdateProfileAvatar :one
+UPDATE profile.profiles
+SET
+    avatar = $1,
+    updated_at = $2
+WHERE guid = $3
 RETURNING *;
 
 -- name: DeleteProfile :exec
@@ -29,7 +29,7 @@ INSERT INTO profile.profiles (
     updated_at
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
-) RETURNING guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at
+) RETURNING guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at, open_to_offers
 `
 
 type CreateProfileParams struct {
@@ -79,6 +79,7 @@ func (q *Queries) CreateProfile(ctx context.Context, db DBTX, arg CreateProfileP
 		&i.VerificationToken,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OpenToOffers,
 	)
 	return i, err
 }
@@ -93,7 +94,7 @@ func (q *Queries) DeleteProfile(ctx context.Context, db DBTX, guid uuid.UUID) er
 }
 
 const getProfileByEmail = `-- name: GetProfileByEmail :one
-SELECT guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at FROM profile.profiles WHERE email = $1
+SELECT guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at, open_to_offers FROM profile.profiles WHERE email = $1
 `
 
 func (q *Queries) GetProfileByEmail(ctx context.Context, db DBTX, email string) (ProfileProfile, error) {
@@ -113,12 +114,13 @@ func (q *Queries) GetProfileByEmail(ctx context.Context, db DBTX, email string)
 		&i.VerificationToken,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OpenToOffers,
 	)
 	return i, err
 }
 
 const getProfileByGUID = `-- name: GetProfileByGUID :one
-SELECT guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at FROM profile.profiles WHERE guid = $1
+SELECT guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at, open_to_offers FROM profile.profiles WHERE guid = $1
 `
 
 func (q *Queries) GetProfileByGUID(ctx context.Context, db DBTX, guid uuid.UUID) (ProfileProfile, error) {
@@ -138,6 +140,7 @@ func (q *Queries) GetProfileByGUID(ctx context.Context, db DBTX, guid uuid.UUID)
 		&i.VerificationToken,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OpenToOffers,
 	)
 	return i, err
 }
@@ -174,7 +177,7 @@ func (q *Queries) GetProfileCompanies(ctx context.Context, db DBTX, userGuid uui
 }
 
 const searchProfiles = `-- name: SearchProfiles :many
-SELECT guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at FROM profile.profiles 
+SELECT guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at, open_to_offers FROM profile.profiles 
 WHERE description ILIKE '%' || $1 || '%'
 ORDER BY updated_at DESC
 `
@@ -202,6 +205,7 @@ func (q *Queries) SearchProfiles(ctx context.Context, db DBTX, dollar_1 sql.Null
 			&i.VerificationToken,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.OpenToOffers,
 		); err != nil {
 			return nil, err
 		}
@@ -226,9 +230,10 @@ SET
     password_hash = $8,
     is_active = $9,
     verification_token = $10,
-    updated_at = $11
-WHERE guid = $12
-RETURNING guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at
+    updated_at = $11,
+    open_to_offers = $12
+WHERE guid = $13
+RETURNING guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at, open_to_offers
 `
 
 type UpdateProfileParams struct {
@@ -243,6 +248,7 @@ type UpdateProfileParams struct {
 	IsActive          bool
 	VerificationToken sql.NullString
 	UpdatedAt         sql.NullTime
+	OpenToOffers      bool
 	Guid              uuid.UUID
 }
 
@@ -259,6 +265,7 @@ func (q *Queries) UpdateProfile(ctx context.Context, db DBTX, arg UpdateProfileP
 		arg.IsActive,
 		arg.VerificationToken,
 		arg.UpdatedAt,
+		arg.OpenToOffers,
 		arg.Guid,
 	)
 	var i ProfileProfile
@@ -276,6 +283,44 @@ func (q *Queries) UpdateProfile(ctx context.Context, db DBTX, arg UpdateProfileP
 		&i.VerificationToken,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OpenToOffers,
+	)
+	return i, err
+}
+
+const updateProfileAvatar = `-- name: UpdateProfileAvatar :one
+UPDATE profile.profiles
+SET
+    avatar = $1,
+    updated_at = $2
+WHERE guid = $3
+RETURNING guid, is_hr, description, email, phone, gender, birthday, avatar, password_hash, is_active, verification_token, created_at, updated_at, open_to_offers
+`
+
+type UpdateProfileAvatarParams struct {
+	Avatar    sql.NullString
+	UpdatedAt sql.NullTime
+	Guid      uuid.UUID
+}
+
+func (q *Queries) UpdateProfileAvatar(ctx context.Context, db DBTX, arg UpdateProfileAvatarParams) (ProfileProfile, error) {
+	row := db.QueryRow(ctx, updateProfileAvatar, arg.Avatar, arg.UpdatedAt, arg.Guid)
+	var i ProfileProfile
+	err := row.Scan(
+		&i.Guid,
+		&i.IsHr,
+		&i.Description,
+		&i.Email,
+		&i.Phone,
+		&i.Gender,
+		&i.Birthday,
+		&i.Avatar,
+		&i.PasswordHash,
+		&i.IsActive,
+		&i.VerificationToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OpenToOffers,
 	)
 	return i, err
 }
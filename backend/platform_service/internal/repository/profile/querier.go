@@ -19,6 +19,7 @@ type Querier interface {
 	GetProfileCompanies(ctx context.Context, db DBTX, userGuid uuid.UUID) ([]CompanyProfileCompany, error)
 	SearchProfiles(ctx context.Context, db DBTX, dollar_1 sql.NullString) ([]ProfileProfile, error)
 	UpdateProfile(ctx context.Context, db DBTX, arg UpdateProfileParams) (ProfileProfile, error)
+	UpdateProfileAvatar(ctx context.Context, db DBTX, arg UpdateProfileAvatarParams) (ProfileProfile, error)
 	UpdateProfileCompany(ctx context.Context, db DBTX, arg UpdateProfileCompanyParams) (CompanyProfileCompany, error)
 }
 
@@ -10,6 +10,37 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for AttachmentKind.
+const (
+	Cv    AttachmentKind = "cv"
+	File  AttachmentKind = "file"
+	Image AttachmentKind = "image"
+)
+
+// Defines values for ChatRole.
+const (
+	Admin  ChatRole = "admin"
+	Member ChatRole = "member"
+)
+
+// Defines values for ChatType.
+const (
+	Direct ChatType = "direct"
+	Group  ChatType = "group"
+)
+
+// Defines values for MessageType.
+const (
+	ChatCreated   MessageType = "chat_created"
+	MemberAdded   MessageType = "member_added"
+	MemberLeft    MessageType = "member_left"
+	MemberRemoved MessageType = "member_removed"
+	RoleChanged   MessageType = "role_changed"
+	Text          MessageType = "text"
+	TitleChanged  MessageType = "title_changed"
 )
 
 // Defines values for HandleWebSocketParamsConnection.
@@ -27,144 +58,985 @@ const (
 	N13 HandleWebSocketParamsSecWebSocketVersion = "13"
 )
 
-// Chat defines model for Chat.
-type Chat struct {
-	CreatedAt time.Time  `json:"created_at"`
-	Id        string     `json:"id"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	Users     []ChatUser `json:"users"`
+// AddChatMemberRequest defines model for AddChatMemberRequest.
+type AddChatMemberRequest struct {
+	// Role Role of a group chat member
+	Role   *ChatRole `json:"role,omitempty"`
+	UserId string    `json:"user_id"`
+}
+
+// Attachment File attached to a message; url and preview_url are available to chat members only
+type Attachment struct {
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	Filename    string    `json:"filename"`
+	Id          string    `json:"id"`
+
+	// Kind Uploaded file, image with a preview or CV of the sender
+	Kind AttachmentKind `json:"kind"`
+
+	// PreviewUrl Reduced copy of an image; null for other attachments
+	PreviewUrl *string `json:"preview_url"`
+
+	// Size File size in bytes
+	Size int64  `json:"size"`
+	Url  string `json:"url"`
+}
+
+// AttachmentKind Uploaded file, image with a preview or CV of the sender
+type AttachmentKind string
+
+// Chat defines model for Chat.
+type Chat struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+
+	// JobId Job of a hiring-team room
+	JobId *string `json:"job_id"`
+
+	// Title Title of a group chat; null for direct chats
+	Title *string `json:"title"`
+
+	// Type Direct chat of two users or a group chat
+	Type      ChatType   `json:"type"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Users     []ChatUser `json:"users"`
+}
+
+// ChatRole Role of a group chat member
+type ChatRole string
+
+// ChatType Direct chat of two users or a group chat
+type ChatType string
+
+// ChatUser defines model for ChatUser.
+type ChatUser struct {
+	Avatar      *string `json:"avatar"`
+	Description string  `json:"description"`
+	Id          string  `json:"id"`
+
+	// Role Role of a group chat member
+	Role *ChatRole `json:"role,omitempty"`
+}
+
+// ChatWithLastMessage defines model for ChatWithLastMessage.
+type ChatWithLastMessage struct {
+	Chat        Chat     `json:"chat"`
+	LastMessage *Message `json:"last_message,omitempty"`
+
+	// Presence Online status of the chat participants
+	Presence []UserPresence `json:"presence"`
+
+	// ReadReceipts Last read message of each participant who has read the chat
+	ReadReceipts []ReadReceipt `json:"read_receipts"`
+
+	// UnreadCount Messages from other participants after the last message read by the current user
+	UnreadCount int `json:"unread_count"`
+}
+
+// CreateChatRequest defines model for CreateChatRequest.
+type CreateChatRequest struct {
+	// JobId Job of a hiring-team room; group chats only
+	JobId *string `json:"job_id,omitempty"`
+
+	// Title Required for group chats
+	Title *string `json:"title,omitempty"`
+
+	// Type Direct chat of two users or a group chat
+	Type  *ChatType `json:"type,omitempty"`
+	Users []string  `json:"users"`
+}
+
+// EditMessageRequest defines model for EditMessageRequest.
+type EditMessageRequest struct {
+	// Text May be empty if the message has attachments
+	Text string `json:"text"`
+}
+
+// MarkChatReadRequest defines model for MarkChatReadRequest.
+type MarkChatReadRequest struct {
+	// MessageId Last read message; the latest message of the chat is used if omitted
+	MessageId *string `json:"message_id,omitempty"`
+}
+
+// Message defines model for Message.
+type Message struct {
+	Attachments []Attachment `json:"attachments"`
+	ChatId      string       `json:"chat_id"`
+	CreatedAt   time.Time    `json:"created_at"`
+
+	// DeletedAt Set for a deleted message; its text and attachments are empty
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// EditedAt Time of the last edit
+	EditedAt  *time.Time        `json:"edited_at,omitempty"`
+	Id        string            `json:"id"`
+	Reactions []MessageReaction `json:"reactions"`
+
+	// ReplyTo Quote of the message being replied to
+	ReplyTo *MessageReply `json:"reply_to,omitempty"`
+
+	// TargetUserId Member affected by a system message
+	TargetUserId *string `json:"target_user_id,omitempty"`
+
+	// Text Message text; new title for title_changed, new role for role_changed
+	Text string `json:"text"`
+
+	// Type Regular message or a system message about a group change
+	Type MessageType `json:"type"`
+	User ChatUser    `json:"user"`
+}
+
+// MessageEdit Previous text of a message and the time it was replaced
+type MessageEdit struct {
+	ReplacedAt time.Time `json:"replaced_at"`
+	Text       string    `json:"text"`
+}
+
+// MessageReaction Emoji reaction and the members who left it
+type MessageReaction struct {
+	Count   int      `json:"count"`
+	Emoji   string   `json:"emoji"`
+	UserIds []string `json:"user_ids"`
+}
+
+// MessageReactionRequest defines model for MessageReactionRequest.
+type MessageReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+// MessageReply Quote of the message being replied to
+type MessageReply struct {
+	// Deleted The quoted message was deleted; its text is empty
+	Deleted bool   `json:"deleted"`
+	Id      string `json:"id"`
+	Text    string `json:"text"`
+	UserId  string `json:"user_id"`
+}
+
+// MessageSearchResult Message found by the search; snippet is its text split into matching and non-matching parts
+type MessageSearchResult struct {
+	Message Message `json:"message"`
+
+	// Score Relevance of the message to the query
+	Score   float64           `json:"score"`
+	Snippet []SnippetFragment `json:"snippet"`
+}
+
+// MessageType Regular message or a system message about a group change
+type MessageType string
+
+// ReadReceipt Last message read by a chat participant; every earlier message is read as well
+type ReadReceipt struct {
+	ChatId    string    `json:"chat_id"`
+	MessageId string    `json:"message_id"`
+	ReadAt    time.Time `json:"read_at"`
+	UserId    string    `json:"user_id"`
+}
+
+// SendMessageRequest defines model for SendMessageRequest.
+type SendMessageRequest struct {
+	// AttachmentIds Files uploaded by the sender to this chat and not sent yet
+	AttachmentIds *[]string `json:"attachment_ids,omitempty"`
+
+	// ReplyToId Message of this chat being replied to
+	ReplyToId *string `json:"reply_to_id,omitempty"`
+
+	// ShareCv Attach the primary CV of the sender
+	ShareCv *bool `json:"share_cv,omitempty"`
+
+	// Text May be empty if the message has attachments
+	Text string `json:"text"`
+}
+
+// SnippetFragment defines model for SnippetFragment.
+type SnippetFragment struct {
+	// Highlight The fragment matches the search query
+	Highlight bool   `json:"highlight"`
+	Text      string `json:"text"`
+}
+
+// UpdateChatMemberRequest defines model for UpdateChatMemberRequest.
+type UpdateChatMemberRequest struct {
+	// Role Role of a group chat member
+	Role ChatRole `json:"role"`
+}
+
+// UpdateChatRequest defines model for UpdateChatRequest.
+type UpdateChatRequest struct {
+	Title string `json:"title"`
+}
+
+// UserPresence Online status of a chat participant
+type UserPresence struct {
+	// LastSeenAt When the last connection of the user was closed; null while the user is online or has not connected since the service started
+	LastSeenAt *time.Time `json:"last_seen_at"`
+	Online     bool       `json:"online"`
+	UserId     string     `json:"user_id"`
+}
+
+// SearchChatMessagesParams defines parameters for SearchChatMessages.
+type SearchChatMessagesParams struct {
+	// Q Search query
+	Q string `form:"q" json:"q"`
+
+	// ChatId Search only in this chat
+	ChatId *string `form:"chat_id,omitempty" json:"chat_id,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int    `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// UploadChatAttachmentMultipartBody defines parameters for UploadChatAttachment.
+type UploadChatAttachmentMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// GetChatAttachmentParams defines parameters for GetChatAttachment.
+type GetChatAttachmentParams struct {
+	// Preview Return the reduced copy of an image instead of the file
+	Preview *bool `form:"preview,omitempty" json:"preview,omitempty"`
+}
+
+// GetChatMessagesParams defines parameters for GetChatMessages.
+type GetChatMessagesParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetMessageContextParams defines parameters for GetMessageContext.
+type GetMessageContextParams struct {
+	// Limit Number of messages to return before and after the message
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// RemoveMessageReactionParams defines parameters for RemoveMessageReaction.
+type RemoveMessageReactionParams struct {
+	Emoji string `form:"emoji" json:"emoji"`
+}
+
+// HandleWebSocketParams defines parameters for HandleWebSocket.
+type HandleWebSocketParams struct {
+	Token               string                                   `form:"token" json:"token"`
+	Connection          HandleWebSocketParamsConnection          `json:"Connection"`
+	Upgrade             HandleWebSocketParamsUpgrade             `json:"Upgrade"`
+	SecWebSocketVersion HandleWebSocketParamsSecWebSocketVersion `json:"Sec-WebSocket-Version"`
+	SecWebSocketKey     string                                   `json:"Sec-WebSocket-Key"`
+}
+
+// HandleWebSocketParamsConnection defines parameters for HandleWebSocket.
+type HandleWebSocketParamsConnection string
+
+// HandleWebSocketParamsUpgrade defines parameters for HandleWebSocket.
+type HandleWebSocketParamsUpgrade string
+
+// HandleWebSocketParamsSecWebSocketVersion defines parameters for HandleWebSocket.
+type HandleWebSocketParamsSecWebSocketVersion string
+
+// CreateChatJSONRequestBody defines body for CreateChat for application/json ContentType.
+type CreateChatJSONRequestBody = CreateChatRequest
+
+// UpdateChatJSONRequestBody defines body for UpdateChat for application/json ContentType.
+type UpdateChatJSONRequestBody = UpdateChatRequest
+
+// UploadChatAttachmentMultipartRequestBody defines body for UploadChatAttachment for multipart/form-data ContentType.
+type UploadChatAttachmentMultipartRequestBody UploadChatAttachmentMultipartBody
+
+// AddChatMemberJSONRequestBody defines body for AddChatMember for application/json ContentType.
+type AddChatMemberJSONRequestBody = AddChatMemberRequest
+
+// UpdateChatMemberJSONRequestBody defines body for UpdateChatMember for application/json ContentType.
+type UpdateChatMemberJSONRequestBody = UpdateChatMemberRequest
+
+// SendMessageJSONRequestBody defines body for SendMessage for application/json ContentType.
+type SendMessageJSONRequestBody = SendMessageRequest
+
+// EditMessageJSONRequestBody defines body for EditMessage for application/json ContentType.
+type EditMessageJSONRequestBody = EditMessageRequest
+
+// AddMessageReactionJSONRequestBody defines body for AddMessageReaction for application/json ContentType.
+type AddMessageReactionJSONRequestBody = MessageReactionRequest
+
+// MarkChatReadJSONRequestBody defines body for MarkChatRead for application/json ContentType.
+type MarkChatReadJSONRequestBody = MarkChatReadRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get user chats
+	// (GET /api/v1/chat)
+	GetUserChats(w http.ResponseWriter, r *http.Request)
+	// Create new chat
+	// (POST /api/v1/chat)
+	CreateChat(w http.ResponseWriter, r *http.Request)
+	// Search messages in chats of the user
+	// (GET /api/v1/chat/search)
+	SearchChatMessages(w http.ResponseWriter, r *http.Request, params SearchChatMessagesParams)
+	// Rename group chat
+	// (PATCH /api/v1/chat/{chat_id})
+	UpdateChat(w http.ResponseWriter, r *http.Request, chatId string)
+	// Upload a file to attach to the next message
+	// (POST /api/v1/chat/{chat_id}/attachments)
+	UploadChatAttachment(w http.ResponseWriter, r *http.Request, chatId string)
+	// Delete an attachment that was not sent
+	// (DELETE /api/v1/chat/{chat_id}/attachments/{attachment_id})
+	DeleteChatAttachment(w http.ResponseWriter, r *http.Request, chatId string, attachmentId string)
+	// Download a message attachment
+	// (GET /api/v1/chat/{chat_id}/attachments/{attachment_id})
+	GetChatAttachment(w http.ResponseWriter, r *http.Request, chatId string, attachmentId string, params GetChatAttachmentParams)
+	// Add group chat member
+	// (POST /api/v1/chat/{chat_id}/members)
+	AddChatMember(w http.ResponseWriter, r *http.Request, chatId string)
+	// Remove group chat member or leave the group
+	// (DELETE /api/v1/chat/{chat_id}/members/{user_id})
+	RemoveChatMember(w http.ResponseWriter, r *http.Request, chatId string, userId string)
+	// Change role of a group chat member
+	// (PATCH /api/v1/chat/{chat_id}/members/{user_id})
+	UpdateChatMember(w http.ResponseWriter, r *http.Request, chatId string, userId string)
+	// Get chat messages
+	// (GET /api/v1/chat/{chat_id}/messages)
+	GetChatMessages(w http.ResponseWriter, r *http.Request, chatId string, params GetChatMessagesParams)
+	// Send message
+	// (POST /api/v1/chat/{chat_id}/messages)
+	SendMessage(w http.ResponseWriter, r *http.Request, chatId string)
+	// Delete own message
+	// (DELETE /api/v1/chat/{chat_id}/messages/{message_id})
+	DeleteMessage(w http.ResponseWriter, r *http.Request, chatId string, messageId string)
+	// Edit own message
+	// (PATCH /api/v1/chat/{chat_id}/messages/{message_id})
+	EditMessage(w http.ResponseWriter, r *http.Request, chatId string, messageId string)
+	// Get a message with the messages around it
+	// (GET /api/v1/chat/{chat_id}/messages/{message_id}/context)
+	GetMessageContext(w http.ResponseWriter, r *http.Request, chatId string, messageId string, params GetMessageContextParams)
+	// Get edit history of a message
+	// (GET /api/v1/chat/{chat_id}/messages/{message_id}/edits)
+	GetMessageEdits(w http.ResponseWriter, r *http.Request, chatId string, messageId string)
+	// Remove own reaction from a message
+	// (DELETE /api/v1/chat/{chat_id}/messages/{message_id}/reactions)
+	RemoveMessageReaction(w http.ResponseWriter, r *http.Request, chatId string, messageId string, params RemoveMessageReactionParams)
+	// React to a message with an emoji
+	// (PUT /api/v1/chat/{chat_id}/messages/{message_id}/reactions)
+	AddMessageReaction(w http.ResponseWriter, r *http.Request, chatId string, messageId string)
+	// Mark chat messages as read
+	// (POST /api/v1/chat/{chat_id}/read)
+	MarkChatRead(w http.ResponseWriter, r *http.Request, chatId string)
+	// WebSocket connection for real-time chat
+	// (GET /api/v1/chat/{chat_id}/ws)
+	HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId string, params HandleWebSocketParams)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Get user chats
+// (GET /api/v1/chat)
+func (_ Unimplemented) GetUserChats(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create new chat
+// (POST /api/v1/chat)
+func (_ Unimplemented) CreateChat(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search messages in chats of the user
+// (GET /api/v1/chat/search)
+func (_ Unimplemented) SearchChatMessages(w http.ResponseWriter, r *http.Request, params SearchChatMessagesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename group chat
+// (PATCH /api/v1/chat/{chat_id})
+func (_ Unimplemented) UpdateChat(w http.ResponseWriter, r *http.Request, chatId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload a file to attach to the next message
+// (POST /api/v1/chat/{chat_id}/attachments)
+func (_ Unimplemented) UploadChatAttachment(w http.ResponseWriter, r *http.Request, chatId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete an attachment that was not sent
+// (DELETE /api/v1/chat/{chat_id}/attachments/{attachment_id})
+func (_ Unimplemented) DeleteChatAttachment(w http.ResponseWriter, r *http.Request, chatId string, attachmentId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download a message attachment
+// (GET /api/v1/chat/{chat_id}/attachments/{attachment_id})
+func (_ Unimplemented) GetChatAttachment(w http.ResponseWriter, r *http.Request, chatId string, attachmentId string, params GetChatAttachmentParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add group chat member
+// (POST /api/v1/chat/{chat_id}/members)
+func (_ Unimplemented) AddChatMember(w http.ResponseWriter, r *http.Request, chatId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove group chat member or leave the group
+// (DELETE /api/v1/chat/{chat_id}/members/{user_id})
+func (_ Unimplemented) RemoveChatMember(w http.ResponseWriter, r *http.Request, chatId string, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change role of a group chat member
+// (PATCH /api/v1/chat/{chat_id}/members/{user_id})
+func (_ Unimplemented) UpdateChatMember(w http.ResponseWriter, r *http.Request, chatId string, userId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get chat messages
+// (GET /api/v1/chat/{chat_id}/messages)
+func (_ Unimplemented) GetChatMessages(w http.ResponseWriter, r *http.Request, chatId string, params GetChatMessagesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Send message
+// (POST /api/v1/chat/{chat_id}/messages)
+func (_ Unimplemented) SendMessage(w http.ResponseWriter, r *http.Request, chatId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete own message
+// (DELETE /api/v1/chat/{chat_id}/messages/{message_id})
+func (_ Unimplemented) DeleteMessage(w http.ResponseWriter, r *http.Request, chatId string, messageId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Edit own message
+// (PATCH /api/v1/chat/{chat_id}/messages/{message_id})
+func (_ Unimplemented) EditMessage(w http.ResponseWriter, r *http.Request, chatId string, messageId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a message with the messages around it
+// (GET /api/v1/chat/{chat_id}/messages/{message_id}/context)
+func (_ Unimplemented) GetMessageContext(w http.ResponseWriter, r *http.Request, chatId string, messageId string, params GetMessageContextParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get edit history of a message
+// (GET /api/v1/chat/{chat_id}/messages/{message_id}/edits)
+func (_ Unimplemented) GetMessageEdits(w http.ResponseWriter, r *http.Request, chatId string, messageId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove own reaction from a message
+// (DELETE /api/v1/chat/{chat_id}/messages/{message_id}/reactions)
+func (_ Unimplemented) RemoveMessageReaction(w http.ResponseWriter, r *http.Request, chatId string, messageId string, params RemoveMessageReactionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// React to a message with an emoji
+// (PUT /api/v1/chat/{chat_id}/messages/{message_id}/reactions)
+func (_ Unimplemented) AddMessageReaction(w http.ResponseWriter, r *http.Request, chatId string, messageId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Mark chat messages as read
+// (POST /api/v1/chat/{chat_id}/read)
+func (_ Unimplemented) MarkChatRead(w http.ResponseWriter, r *http.Request, chatId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// WebSocket connection for real-time chat
+// (GET /api/v1/chat/{chat_id}/ws)
+func (_ Unimplemented) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId string, params HandleWebSocketParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetUserChats operation middleware
+func (siw *ServerInterfaceWrapper) GetUserChats(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserChats(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateChat operation middleware
+func (siw *ServerInterfaceWrapper) CreateChat(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateChat(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchChatMessages operation middleware
+func (siw *ServerInterfaceWrapper) SearchChatMessages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchChatMessagesParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "chat_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "chat_id", r.URL.Query(), &params.ChatId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchChatMessages(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateChat operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChat(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateChat(w, r, chatId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadChatAttachment operation middleware
+func (siw *ServerInterfaceWrapper) UploadChatAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadChatAttachment(w, r, chatId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteChatAttachment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteChatAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachment_id" -------------
+	var attachmentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachment_id", chi.URLParam(r, "attachment_id"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachment_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteChatAttachment(w, r, chatId, attachmentId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetChatAttachment operation middleware
+func (siw *ServerInterfaceWrapper) GetChatAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachment_id" -------------
+	var attachmentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachment_id", chi.URLParam(r, "attachment_id"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachment_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChatAttachmentParams
+
+	// ------------- Optional query parameter "preview" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "preview", r.URL.Query(), &params.Preview)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "preview", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetChatAttachment(w, r, chatId, attachmentId, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddChatMember operation middleware
+func (siw *ServerInterfaceWrapper) AddChatMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddChatMember(w, r, chatId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveChatMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveChatMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveChatMember(w, r, chatId, userId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateChatMember operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChatMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateChatMember(w, r, chatId, userId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetChatMessages operation middleware
+func (siw *ServerInterfaceWrapper) GetChatMessages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChatMessagesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetChatMessages(w, r, chatId, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SendMessage operation middleware
+func (siw *ServerInterfaceWrapper) SendMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SendMessage(w, r, chatId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// ChatUser defines model for ChatUser.
-type ChatUser struct {
-	Avatar      *string `json:"avatar"`
-	Description string  `json:"description"`
-	Id          string  `json:"id"`
-}
+// DeleteMessage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMessage(w http.ResponseWriter, r *http.Request) {
 
-// ChatWithLastMessage defines model for ChatWithLastMessage.
-type ChatWithLastMessage struct {
-	Chat        Chat     `json:"chat"`
-	LastMessage *Message `json:"last_message,omitempty"`
-	UnreadCount int      `json:"unread_count"`
-}
+	var err error
 
-// CreateChatRequest defines model for CreateChatRequest.
-type CreateChatRequest struct {
-	Users []string `json:"users"`
-}
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
 
-// Message defines model for Message.
-type Message struct {
-	ChatId    string    `json:"chat_id"`
-	CreatedAt time.Time `json:"created_at"`
-	Id        string    `json:"id"`
-	Text      string    `json:"text"`
-	User      ChatUser  `json:"user"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
 
-// SendMessageRequest defines model for SendMessageRequest.
-type SendMessageRequest struct {
-	Text string `json:"text"`
-}
+	// ------------- Path parameter "message_id" -------------
+	var messageId string
 
-// GetChatMessagesParams defines parameters for GetChatMessages.
-type GetChatMessagesParams struct {
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
 
-// HandleWebSocketParams defines parameters for HandleWebSocket.
-type HandleWebSocketParams struct {
-	Token               string                                   `form:"token" json:"token"`
-	Connection          HandleWebSocketParamsConnection          `json:"Connection"`
-	Upgrade             HandleWebSocketParamsUpgrade             `json:"Upgrade"`
-	SecWebSocketVersion HandleWebSocketParamsSecWebSocketVersion `json:"Sec-WebSocket-Version"`
-	SecWebSocketKey     string                                   `json:"Sec-WebSocket-Key"`
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteMessage(w, r, chatId, messageId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandleWebSocketParamsConnection defines parameters for HandleWebSocket.
-type HandleWebSocketParamsConnection string
+// EditMessage operation middleware
+func (siw *ServerInterfaceWrapper) EditMessage(w http.ResponseWriter, r *http.Request) {
 
-// HandleWebSocketParamsUpgrade defines parameters for HandleWebSocket.
-type HandleWebSocketParamsUpgrade string
+	var err error
 
-// HandleWebSocketParamsSecWebSocketVersion defines parameters for HandleWebSocket.
-type HandleWebSocketParamsSecWebSocketVersion string
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
 
-// CreateChatJSONRequestBody defines body for CreateChat for application/json ContentType.
-type CreateChatJSONRequestBody = CreateChatRequest
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
 
-// SendMessageJSONRequestBody defines body for SendMessage for application/json ContentType.
-type SendMessageJSONRequestBody = SendMessageRequest
+	// ------------- Path parameter "message_id" -------------
+	var messageId string
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get user chats
-	// (GET /api/v1/chat)
-	GetUserChats(w http.ResponseWriter, r *http.Request)
-	// Create new chat
-	// (POST /api/v1/chat)
-	CreateChat(w http.ResponseWriter, r *http.Request)
-	// Get chat messages
-	// (GET /api/v1/chat/{chat_id}/messages)
-	GetChatMessages(w http.ResponseWriter, r *http.Request, chatId string, params GetChatMessagesParams)
-	// Send message
-	// (POST /api/v1/chat/{chat_id}/messages)
-	SendMessage(w http.ResponseWriter, r *http.Request, chatId string)
-	// WebSocket connection for real-time chat
-	// (GET /api/v1/chat/{chat_id}/ws)
-	HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId string, params HandleWebSocketParams)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EditMessage(w, r, chatId, messageId)
+	}))
 
-type Unimplemented struct{}
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
 
-// Get user chats
-// (GET /api/v1/chat)
-func (_ Unimplemented) GetUserChats(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r)
 }
 
-// Create new chat
-// (POST /api/v1/chat)
-func (_ Unimplemented) CreateChat(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// GetMessageContext operation middleware
+func (siw *ServerInterfaceWrapper) GetMessageContext(w http.ResponseWriter, r *http.Request) {
 
-// Get chat messages
-// (GET /api/v1/chat/{chat_id}/messages)
-func (_ Unimplemented) GetChatMessages(w http.ResponseWriter, r *http.Request, chatId string, params GetChatMessagesParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	var err error
 
-// Send message
-// (POST /api/v1/chat/{chat_id}/messages)
-func (_ Unimplemented) SendMessage(w http.ResponseWriter, r *http.Request, chatId string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
 
-// WebSocket connection for real-time chat
-// (GET /api/v1/chat/{chat_id}/ws)
-func (_ Unimplemented) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId string, params HandleWebSocketParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	// ------------- Path parameter "message_id" -------------
+	var messageId string
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
 
-// GetUserChats operation middleware
-func (siw *ServerInterfaceWrapper) GetUserChats(w http.ResponseWriter, r *http.Request) {
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMessageContextParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserChats(w, r)
+		siw.Handler.GetMessageContext(w, r, chatId, messageId, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -174,11 +1046,31 @@ func (siw *ServerInterfaceWrapper) GetUserChats(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// CreateChat operation middleware
-func (siw *ServerInterfaceWrapper) CreateChat(w http.ResponseWriter, r *http.Request) {
+// GetMessageEdits operation middleware
+func (siw *ServerInterfaceWrapper) GetMessageEdits(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateChat(w, r)
+		siw.Handler.GetMessageEdits(w, r, chatId, messageId)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -188,8 +1080,8 @@ func (siw *ServerInterfaceWrapper) CreateChat(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetChatMessages operation middleware
-func (siw *ServerInterfaceWrapper) GetChatMessages(w http.ResponseWriter, r *http.Request) {
+// RemoveMessageReaction operation middleware
+func (siw *ServerInterfaceWrapper) RemoveMessageReaction(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -202,27 +1094,69 @@ func (siw *ServerInterfaceWrapper) GetChatMessages(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// ------------- Path parameter "message_id" -------------
+	var messageId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetChatMessagesParams
+	var params RemoveMessageReactionParams
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Required query parameter "emoji" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if paramValue := r.URL.Query().Get("emoji"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "emoji"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "emoji", r.URL.Query(), &params.Emoji)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "emoji", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveMessageReaction(w, r, chatId, messageId, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddMessageReaction operation middleware
+func (siw *ServerInterfaceWrapper) AddMessageReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "chat_id" -------------
+	var chatId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "chat_id", chi.URLParam(r, "chat_id"), &chatId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chat_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "message_id", chi.URLParam(r, "message_id"), &messageId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "message_id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetChatMessages(w, r, chatId, params)
+		siw.Handler.AddMessageReaction(w, r, chatId, messageId)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -232,8 +1166,8 @@ func (siw *ServerInterfaceWrapper) GetChatMessages(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// SendMessage operation middleware
-func (siw *ServerInterfaceWrapper) SendMessage(w http.ResponseWriter, r *http.Request) {
+// MarkChatRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkChatRead(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -247,7 +1181,7 @@ func (siw *ServerInterfaceWrapper) SendMessage(w http.ResponseWriter, r *http.Re
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SendMessage(w, r, chatId)
+		siw.Handler.MarkChatRead(w, r, chatId)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -513,12 +1447,57 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/chat", wrapper.CreateChat)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/chat/search", wrapper.SearchChatMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/chat/{chat_id}", wrapper.UpdateChat)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/chat/{chat_id}/attachments", wrapper.UploadChatAttachment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/chat/{chat_id}/attachments/{attachment_id}", wrapper.DeleteChatAttachment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/chat/{chat_id}/attachments/{attachment_id}", wrapper.GetChatAttachment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/chat/{chat_id}/members", wrapper.AddChatMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/chat/{chat_id}/members/{user_id}", wrapper.RemoveChatMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/chat/{chat_id}/members/{user_id}", wrapper.UpdateChatMember)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/chat/{chat_id}/messages", wrapper.GetChatMessages)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/chat/{chat_id}/messages", wrapper.SendMessage)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/chat/{chat_id}/messages/{message_id}", wrapper.DeleteMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/chat/{chat_id}/messages/{message_id}", wrapper.EditMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/chat/{chat_id}/messages/{message_id}/context", wrapper.GetMessageContext)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/chat/{chat_id}/messages/{message_id}/edits", wrapper.GetMessageEdits)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/chat/{chat_id}/messages/{message_id}/reactions", wrapper.RemoveMessageReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/chat/{chat_id}/messages/{message_id}/reactions", wrapper.AddMessageReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/chat/{chat_id}/read", wrapper.MarkChatRead)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/chat/{chat_id}/ws", wrapper.HandleWebSocket)
 	})
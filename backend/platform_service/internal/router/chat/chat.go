@@ -2,19 +2,27 @@ package chat
 
 import (
 	"PlatformService/internal/config"
+	"PlatformService/internal/models"
 	"PlatformService/internal/router/mw"
 	"PlatformService/internal/service"
 	"PlatformService/internal/service/auth"
 	"PlatformService/internal/service/chat"
+	"PlatformService/internal/service/storage"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"log"
 	"log/slog"
+	"mime"
 	"net/http"
 	"sync"
 
 	"github.com/gorilla/websocket"
 )
 
+const multipartOverhead = 1 << 20
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
@@ -45,38 +53,127 @@ func (s *Server) CreateChat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	chat, err := s.services.Chat.CreateChat(ctx, req.Users)
+	create := &models.CreateChatRequest{
+		Title: req.Title,
+		JobID: req.JobId,
+		Users: req.Users,
+	}
+	if req.Type != nil {
+		create.Type = string(*req.Type)
+	}
+
+	chat, err := s.services.Chat.CreateChat(ctx, userGUID, create)
 	if err != nil {
-		s.log.ErrorContext(ctx, "chatServer.CreateChat failed to create chat", "error", err)
+		s.writeChatError(ctx, w, "CreateChat", err)
+		return
+	}
+
+	resp, err := s.mapChat(ctx, *chat)
+	if err != nil {
+		s.log.ErrorContext(ctx, "chatServer.CreateChat failed to get profile", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	users := make([]ChatUser, len(chat.Users))
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
 
-	for i, user := range chat.Users {
-		profile, err := s.services.Profile.GetProfile(ctx, user)
-		if err != nil {
-			s.log.ErrorContext(ctx, "chatServer.CreateChat failed to get profile", "error", err)
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			return
-		}
-		users[i] = ChatUser{
-			Id:          profile.Guid,
-			Description: profile.Description,
-			Avatar:      profile.Avatar,
-		}
+// UpdateChat implements ServerInterface.
+func (s *Server) UpdateChat(w http.ResponseWriter, r *http.Request, chatId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
 	}
 
-	resp := Chat{
-		CreatedAt: chat.CreatedAt,
-		Id:        chat.ID,
-		UpdatedAt: chat.UpdatedAt,
-		Users:     users,
+	var req UpdateChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log.ErrorContext(ctx, "chatServer.UpdateChat failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(resp)
+	chat, err := s.services.Chat.UpdateChatTitle(ctx, chatId, userGUID, req.Title)
+	if err != nil {
+		s.writeChatError(ctx, w, "UpdateChat", err)
+		return
+	}
+
+	s.writeChat(ctx, w, "UpdateChat", chat)
+}
+
+// AddChatMember implements ServerInterface.
+func (s *Server) AddChatMember(w http.ResponseWriter, r *http.Request, chatId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req AddChatMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log.ErrorContext(ctx, "chatServer.AddChatMember failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var role string
+	if req.Role != nil {
+		role = string(*req.Role)
+	}
+
+	chat, err := s.services.Chat.AddChatMember(ctx, chatId, userGUID, req.UserId, role)
+	if err != nil {
+		s.writeChatError(ctx, w, "AddChatMember", err)
+		return
+	}
+
+	s.writeChat(ctx, w, "AddChatMember", chat)
+}
+
+// RemoveChatMember implements ServerInterface.
+func (s *Server) RemoveChatMember(w http.ResponseWriter, r *http.Request, chatId string, userId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.services.Chat.RemoveChatMember(ctx, chatId, userGUID, userId); err != nil {
+		s.writeChatError(ctx, w, "RemoveChatMember", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateChatMember implements ServerInterface.
+func (s *Server) UpdateChatMember(w http.ResponseWriter, r *http.Request, chatId string, userId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req UpdateChatMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log.ErrorContext(ctx, "chatServer.UpdateChatMember failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	chat, err := s.services.Chat.UpdateChatMemberRole(ctx, chatId, userGUID, userId, string(req.Role))
+	if err != nil {
+		s.writeChatError(ctx, w, "UpdateChatMember", err)
+		return
+	}
+
+	s.writeChat(ctx, w, "UpdateChatMember", chat)
 }
 
 // GetChatMessages implements ServerInterface.
@@ -97,32 +194,20 @@ func (s *Server) GetChatMessages(w http.ResponseWriter, r *http.Request, chatId
 		offset = *params.Offset
 	}
 
-	messages, err := s.services.Chat.GetChatMessages(ctx, chatId, limit, offset)
+	messages, err := s.services.Chat.GetChatMessages(ctx, chatId, userGUID, limit, offset)
 	if err != nil {
-		s.log.ErrorContext(ctx, "chatServer.GetChatMessages failed to get chat messages", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		s.writeChatError(ctx, w, "GetChatMessages", err)
 		return
 	}
 
 	resp := make([]Message, len(messages))
 	for i, message := range messages {
-		profile, err := s.services.Profile.GetProfile(ctx, message.UserID)
+		resp[i], err = s.mapMessage(ctx, message)
 		if err != nil {
 			s.log.ErrorContext(ctx, "chatServer.GetChatMessages failed to get profile", "error", err)
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			return
 		}
-		resp[i] = Message{
-			ChatId:    message.ChatID,
-			CreatedAt: message.CreatedAt,
-			Id:        message.ID,
-			Text:      message.Text,
-			User: ChatUser{
-				Id:          profile.Guid,
-				Description: profile.Description,
-				Avatar:      profile.Avatar,
-			},
-		}
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -149,49 +234,42 @@ func (s *Server) GetUserChats(w http.ResponseWriter, r *http.Request) {
 	for i, chat := range chats {
 		var lastMessage *Message
 		if chat.LastMessage != nil {
-			profile, err := s.services.Profile.GetProfile(ctx, chat.LastMessage.UserID)
+			message, err := s.mapMessage(ctx, *chat.LastMessage)
 			if err != nil {
 				s.log.ErrorContext(ctx, "chatServer.GetUserChats failed to get profile", "error", err)
 				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 				return
 			}
-			lastMessage = &Message{
-				ChatId:    chat.LastMessage.ChatID,
-				CreatedAt: chat.LastMessage.CreatedAt,
-				Id:        chat.LastMessage.ID,
-				Text:      chat.LastMessage.Text,
-				User: ChatUser{
-					Id:          profile.Guid,
-					Description: profile.Description,
-					Avatar:      profile.Avatar,
-				},
-			}
+			lastMessage = &message
 		}
 
-		users := make([]ChatUser, len(chat.Chat.Users))
-		for j, user := range chat.Chat.Users {
-			profile, err := s.services.Profile.GetProfile(ctx, user)
-			if err != nil {
-				s.log.ErrorContext(ctx, "chatServer.GetUserChats failed to get profile", "error", err)
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-				return
-			}
-			users[j] = ChatUser{
-				Id:          profile.Guid,
-				Description: profile.Description,
-				Avatar:      profile.Avatar,
+		userChat, err := s.mapChat(ctx, chat.Chat)
+		if err != nil {
+			s.log.ErrorContext(ctx, "chatServer.GetUserChats failed to get profile", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		receipts := make([]ReadReceipt, len(chat.ReadReceipts))
+		for j, receipt := range chat.ReadReceipts {
+			receipts[j] = mapReadReceipt(receipt)
+		}
+
+		presence := make([]UserPresence, len(chat.Presence))
+		for j, p := range chat.Presence {
+			presence[j] = UserPresence{
+				LastSeenAt: p.LastSeenAt,
+				Online:     p.Online,
+				UserId:     p.UserID,
 			}
 		}
 
 		resp[i] = ChatWithLastMessage{
-			Chat: Chat{
-				CreatedAt: chat.Chat.CreatedAt,
-				Id:        chat.Chat.ID,
-				UpdatedAt: chat.Chat.UpdatedAt,
-				Users:     users,
-			},
-			LastMessage: lastMessage,
-			UnreadCount: chat.UnreadCount,
+			Chat:         userChat,
+			LastMessage:  lastMessage,
+			Presence:     presence,
+			ReadReceipts: receipts,
+			UnreadCount:  chat.UnreadCount,
 		}
 	}
 
@@ -215,36 +293,382 @@ func (s *Server) SendMessage(w http.ResponseWriter, r *http.Request, chatId stri
 		return
 	}
 
-	message, err := s.services.Chat.SendMessage(ctx, chatId, userGUID, req.Text)
+	send := &models.SendMessageRequest{Text: req.Text}
+	if req.AttachmentIds != nil {
+		send.AttachmentIDs = *req.AttachmentIds
+	}
+	if req.ShareCv != nil {
+		send.ShareCV = *req.ShareCv
+	}
+	if req.ReplyToId != nil {
+		send.ReplyToID = *req.ReplyToId
+	}
+
+	message, err := s.services.Chat.SendMessage(ctx, chatId, userGUID, send)
 	if err != nil {
-		s.log.ErrorContext(ctx, "chatServer.SendMessage failed to send message", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		s.writeChatError(ctx, w, "SendMessage", err)
 		return
 	}
 
-	profile, err := s.services.Profile.GetProfile(ctx, message.UserID)
+	resp, err := s.mapMessage(ctx, *message)
 	if err != nil {
 		s.log.ErrorContext(ctx, "chatServer.SendMessage failed to get profile", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	resp := Message{
-		ChatId:    message.ChatID,
-		CreatedAt: message.CreatedAt,
-		Id:        message.ID,
-		Text:      message.Text,
-		User: ChatUser{
-			Id:          profile.Guid,
-			Description: profile.Description,
-			Avatar:      profile.Avatar,
-		},
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// UploadChatAttachment implements ServerInterface.
+func (s *Server) UploadChatAttachment(w http.ResponseWriter, r *http.Request, chatId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Ограничиваем тело запроса: запас на заголовки multipart
+	r.Body = http.MaxBytesReader(w, r.Body, s.services.Chat.MaxAttachmentSize()+multipartOverhead)
+
+	// Parse multipart form with 10MB max memory, larger files are kept in temporary files
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		s.log.ErrorContext(ctx, "chatServer.UploadChatAttachment failed to parse form", "error", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "File is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	file, handler, err := r.FormFile("file")
+	if err != nil {
+		s.log.ErrorContext(ctx, "chatServer.UploadChatAttachment failed to retrieve file", "error", err)
+		http.Error(w, "Error retrieving file", http.StatusBadRequest)
+		return
+	}
+	file.Close()
+
+	attachment, err := s.services.Chat.UploadAttachment(ctx, chatId, userGUID, handler)
+	if err != nil {
+		s.writeChatError(ctx, w, "UploadChatAttachment", err)
+		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(mapAttachment(*attachment))
+}
+
+// GetChatAttachment implements ServerInterface.
+func (s *Server) GetChatAttachment(w http.ResponseWriter, r *http.Request, chatId string, attachmentId string, params GetChatAttachmentParams) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	attachment, err := s.services.Chat.GetAttachment(ctx, chatId, userGUID, attachmentId)
+	if err != nil {
+		s.writeChatError(ctx, w, "GetChatAttachment", err)
+		return
+	}
+
+	objectName := attachment.ObjectName
+	contentType := attachment.ContentType
+	preview := params.Preview != nil && *params.Preview
+	if preview {
+		if attachment.PreviewObjectName == nil {
+			http.Error(w, "Attachment has no preview", http.StatusNotFound)
+			return
+		}
+		objectName = *attachment.PreviewObjectName
+		contentType = storage.ContentType(objectName)
+	}
+
+	object, info, err := s.services.Storage.Get(ctx, objectName)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.log.ErrorContext(ctx, "chatServer.GetChatAttachment failed to get file", "error", err)
+		http.Error(w, "Failed to get file", http.StatusInternalServerError)
+		return
+	}
+	defer object.Close()
+
+	// Изображения показываются в чате, остальные файлы только скачиваются.
+	// Имя файла кодируется по RFC 2231
+	dispositionType := "attachment"
+	if preview || attachment.Kind == models.AttachmentKindImage {
+		dispositionType = "inline"
+	}
+	disposition := mime.FormatMediaType(dispositionType, map[string]string{"filename": attachment.Filename})
+	if disposition == "" {
+		disposition = dispositionType
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	http.ServeContent(w, r, "", info.LastModified, object)
+}
+
+// DeleteChatAttachment implements ServerInterface.
+func (s *Server) DeleteChatAttachment(w http.ResponseWriter, r *http.Request, chatId string, attachmentId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.services.Chat.DeleteAttachment(ctx, chatId, userGUID, attachmentId); err != nil {
+		s.writeChatError(ctx, w, "DeleteChatAttachment", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EditMessage implements ServerInterface.
+func (s *Server) EditMessage(w http.ResponseWriter, r *http.Request, chatId string, messageId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req EditMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log.ErrorContext(ctx, "chatServer.EditMessage failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	message, err := s.services.Chat.EditMessage(ctx, chatId, userGUID, messageId, req.Text)
+	if err != nil {
+		s.writeChatError(ctx, w, "EditMessage", err)
+		return
+	}
+
+	s.writeMessage(ctx, w, "EditMessage", message)
+}
+
+// DeleteMessage implements ServerInterface.
+func (s *Server) DeleteMessage(w http.ResponseWriter, r *http.Request, chatId string, messageId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.services.Chat.DeleteMessage(ctx, chatId, userGUID, messageId); err != nil {
+		s.writeChatError(ctx, w, "DeleteMessage", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMessageContext implements ServerInterface.
+func (s *Server) GetMessageContext(w http.ResponseWriter, r *http.Request, chatId string, messageId string, params GetMessageContextParams) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 10
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	messages, err := s.services.Chat.GetMessageContext(ctx, chatId, userGUID, messageId, limit)
+	if err != nil {
+		s.writeChatError(ctx, w, "GetMessageContext", err)
+		return
+	}
+
+	resp := make([]Message, len(messages))
+	for i, message := range messages {
+		resp[i], err = s.mapMessage(ctx, message)
+		if err != nil {
+			s.log.ErrorContext(ctx, "chatServer.GetMessageContext failed to get profile", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetMessageEdits implements ServerInterface.
+func (s *Server) GetMessageEdits(w http.ResponseWriter, r *http.Request, chatId string, messageId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	edits, err := s.services.Chat.GetMessageEdits(ctx, chatId, userGUID, messageId)
+	if err != nil {
+		s.writeChatError(ctx, w, "GetMessageEdits", err)
+		return
+	}
+
+	resp := make([]MessageEdit, len(edits))
+	for i, edit := range edits {
+		resp[i] = MessageEdit{
+			ReplacedAt: edit.ReplacedAt,
+			Text:       edit.Text,
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// AddMessageReaction implements ServerInterface.
+func (s *Server) AddMessageReaction(w http.ResponseWriter, r *http.Request, chatId string, messageId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req MessageReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.log.ErrorContext(ctx, "chatServer.AddMessageReaction failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	message, err := s.services.Chat.AddReaction(ctx, chatId, userGUID, messageId, req.Emoji)
+	if err != nil {
+		s.writeChatError(ctx, w, "AddMessageReaction", err)
+		return
+	}
+
+	s.writeMessage(ctx, w, "AddMessageReaction", message)
+}
+
+// RemoveMessageReaction implements ServerInterface.
+func (s *Server) RemoveMessageReaction(w http.ResponseWriter, r *http.Request, chatId string, messageId string, params RemoveMessageReactionParams) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	message, err := s.services.Chat.RemoveReaction(ctx, chatId, userGUID, messageId, params.Emoji)
+	if err != nil {
+		s.writeChatError(ctx, w, "RemoveMessageReaction", err)
+		return
+	}
+
+	s.writeMessage(ctx, w, "RemoveMessageReaction", message)
+}
+
+// SearchChatMessages implements ServerInterface.
+func (s *Server) SearchChatMessages(w http.ResponseWriter, r *http.Request, params SearchChatMessagesParams) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 20
+	offset := 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	var chatID string
+	if params.ChatId != nil {
+		chatID = *params.ChatId
+	}
+
+	results, err := s.services.Chat.SearchMessages(ctx, userGUID, params.Q, chatID, limit, offset)
+	if err != nil {
+		s.writeChatError(ctx, w, "SearchChatMessages", err)
+		return
+	}
+
+	resp := make([]MessageSearchResult, len(results))
+	for i, result := range results {
+		message, err := s.mapMessage(ctx, result.Message)
+		if err != nil {
+			s.log.ErrorContext(ctx, "chatServer.SearchChatMessages failed to get profile", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		snippet := make([]SnippetFragment, len(result.Snippet))
+		for j, fragment := range result.Snippet {
+			snippet[j] = SnippetFragment{Text: fragment.Text, Highlight: fragment.Highlight}
+		}
+
+		resp[i] = MessageSearchResult{
+			Message: message,
+			Score:   result.Score,
+			Snippet: snippet,
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
 }
 
+// MarkChatRead implements ServerInterface.
+func (s *Server) MarkChatRead(w http.ResponseWriter, r *http.Request, chatId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Тело необязательно: без message_id отмечается последнее сообщение
+	var req MarkChatReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		s.log.ErrorContext(ctx, "chatServer.MarkChatRead failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var messageID string
+	if req.MessageId != nil {
+		messageID = *req.MessageId
+	}
+
+	receipt, err := s.services.Chat.MarkRead(ctx, chatId, userGUID, messageID)
+	if err != nil {
+		s.writeChatError(ctx, w, "MarkChatRead", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(mapReadReceipt(*receipt))
+}
+
 // HandleWebSocket implements ServerInterface.
 func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId string, params HandleWebSocketParams) {
 	ctx := r.Context()
@@ -255,12 +679,17 @@ func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId
 		return
 	}
 
+	// Проверяем участие до апгрейда, чтобы ответить обычным HTTP-статусом
+	if err := s.services.Chat.CheckChatMember(ctx, chatId, claims.UserGUID); err != nil {
+		s.writeChatError(ctx, w, "HandleWebSocket", err)
+		return
+	}
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("Failed to upgrade connection: %v", err)
 		return
 	}
-	defer conn.Close()
 
 	wsConn := &chat.WebSocketConnection{
 		UserID: claims.UserGUID,
@@ -272,10 +701,20 @@ func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId
 
 	s.log.Info("Chat WebSocket connection established", "user_id", claims.UserGUID, "chat_id", chatId)
 
+	// Читатель закрывает done, когда клиент отключился. Соединение
+	// закрывается до ожидания читателя, иначе он не выйдет из ReadMessage;
+	// отписка и уход из сети - после
+	done := make(chan struct{})
 	var wg sync.WaitGroup
+	defer func() {
+		conn.Close()
+		wg.Wait()
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		defer close(done)
 		for {
 			_, message, err := conn.ReadMessage()
 			if err != nil {
@@ -288,22 +727,15 @@ func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId
 
 			s.log.Info("Chat WebSocket message received", "user_id", claims.UserGUID, "chat_id", chatId, "message", string(message))
 
-			// Проверяем, является ли сообщение сигналом видеозвонка
-			var signal map[string]interface{}
-			if err := json.Unmarshal(message, &signal); err == nil {
-				if signalType, ok := signal["type"].(string); ok {
-					s.log.Info("Processing chat signal", "type", signalType, "user_id", claims.UserGUID, "chat_id", chatId)
-					// Обрабатываем уведомления о видеозвонках
-					if signalType == "incoming-video-call" || signalType == "call-accepted" || signalType == "call-declined" {
-						s.log.Info("Broadcasting video call signal", "type", signalType, "from_user", claims.UserGUID, "chat_id", chatId)
-						// Рассылаем уведомление всем участникам чата (кроме отправителя)
-						s.services.Chat.BroadcastMessage(chatId, message, claims.UserGUID)
-						continue
-					}
-				}
+			// События с типом: сигналы видеозвонков, прочтение, набор текста.
+			// Сообщения чата тоже приходят с типом text
+			var event chat.IncomingEvent
+			if err := json.Unmarshal(message, &event); err == nil && event.Type != "" && event.Type != models.MessageTypeText {
+				s.handleChatEvent(r.Context(), chatId, claims.UserGUID, event, message)
+				continue
 			}
 
-			// Если это не сигнал видеозвонка, обрабатываем как обычное сообщение
+			// Сообщение без типа обрабатываем как обычное сообщение
 			parsedMessage := new(Message)
 			err = json.Unmarshal(message, parsedMessage)
 			if err != nil {
@@ -312,7 +744,7 @@ func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId
 			}
 
 			if parsedMessage.User.Id != claims.UserGUID {
-				_, err = s.services.Chat.SendMessage(r.Context(), chatId, claims.UserGUID, string(message))
+				_, err = s.services.Chat.SendMessage(r.Context(), chatId, claims.UserGUID, &models.SendMessageRequest{Text: string(message)})
 				if err != nil {
 					s.log.Error("Error sending chat message", "error", err)
 				}
@@ -322,6 +754,8 @@ func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId
 
 	for {
 		select {
+		case <-done:
+			return
 		case message, ok := <-wsConn.Send:
 			if !ok {
 				s.log.Info("Chat WebSocket send channel closed", "user_id", claims.UserGUID, "chat_id", chatId)
@@ -337,6 +771,186 @@ func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request, chatId
 	}
 }
 
+// handleChatEvent обрабатывает событие с типом, присланное по WebSocket чата.
+// Отправитель события - владелец соединения, а не поле из тела события
+func (s *Server) handleChatEvent(ctx context.Context, chatID, userID string, event chat.IncomingEvent, raw []byte) {
+	s.log.Info("Processing chat signal", "type", event.Type, "user_id", userID, "chat_id", chatID)
+
+	switch event.Type {
+	case "incoming-video-call", "call-accepted", "call-declined":
+		// Рассылаем уведомление всем участникам чата (кроме отправителя)
+		s.services.Chat.BroadcastMessage(chatID, raw, userID)
+	case chat.EventTypeRead:
+		// Остальных участников уведомляет сервис
+		if _, err := s.services.Chat.MarkRead(ctx, chatID, userID, event.MessageID); err != nil {
+			s.log.Error("Error marking chat as read", "error", err, "user_id", userID, "chat_id", chatID)
+		}
+	case chat.EventTypeTypingStart, chat.EventTypeTypingStop:
+		s.services.Chat.SetTyping(chatID, userID, event.Type == chat.EventTypeTypingStart)
+	default:
+		s.log.Warn("Unknown chat event type", "type", event.Type, "user_id", userID, "chat_id", chatID)
+	}
+}
+
+// writeChat отвечает чатом с профилями участников
+func (s *Server) writeChat(ctx context.Context, w http.ResponseWriter, method string, chat *models.Chat) {
+	resp, err := s.mapChat(ctx, *chat)
+	if err != nil {
+		s.log.ErrorContext(ctx, "chatServer."+method+" failed to get profile", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeMessage отвечает сообщением с профилем автора
+func (s *Server) writeMessage(ctx context.Context, w http.ResponseWriter, method string, message *models.Message) {
+	resp, err := s.mapMessage(ctx, *message)
+	if err != nil {
+		s.log.ErrorContext(ctx, "chatServer."+method+" failed to get profile", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeChatError отвечает статусом, соответствующим ошибке сервиса чатов
+func (s *Server) writeChatError(ctx context.Context, w http.ResponseWriter, method string, err error) {
+	switch {
+	case errors.Is(err, chat.ErrInvalidChat), errors.Is(err, chat.ErrInvalidMessage), errors.Is(err, chat.ErrInvalidSearchQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, chat.ErrNotChatMember):
+		http.Error(w, "User is not a chat member", http.StatusForbidden)
+	case errors.Is(err, chat.ErrNotChatAdmin):
+		http.Error(w, "Only chat admins can do this", http.StatusForbidden)
+	case errors.Is(err, chat.ErrNotMessageAuthor):
+		http.Error(w, "Only the author can change the message", http.StatusForbidden)
+	case errors.Is(err, chat.ErrNotJobAuthor):
+		http.Error(w, "Only the job author can attach a chat to the job", http.StatusForbidden)
+	case errors.Is(err, chat.ErrChatNotFound):
+		http.Error(w, "Chat not found", http.StatusNotFound)
+	case errors.Is(err, chat.ErrChatMemberNotFound):
+		http.Error(w, "Chat member not found", http.StatusNotFound)
+	case errors.Is(err, chat.ErrMessageNotFound):
+		http.Error(w, "Message not found", http.StatusNotFound)
+	case errors.Is(err, chat.ErrAttachmentNotFound):
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+	case errors.Is(err, chat.ErrChatMemberExists), errors.Is(err, chat.ErrLastChatAdmin):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, chat.ErrAttachmentTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, chat.ErrAttachmentInfected):
+		http.Error(w, "File rejected by malware scanner", http.StatusUnprocessableEntity)
+	default:
+		s.log.ErrorContext(ctx, "chatServer."+method+" failed", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// mapChat дополняет участников чата данными профилей
+func (s *Server) mapChat(ctx context.Context, c models.Chat) (Chat, error) {
+	users := make([]ChatUser, len(c.Members))
+	for i, member := range c.Members {
+		profile, err := s.services.Profile.GetProfile(ctx, member.UserID)
+		if err != nil {
+			return Chat{}, err
+		}
+		role := ChatRole(member.Role)
+		users[i] = ChatUser{
+			Id:          profile.Guid,
+			Description: profile.Description,
+			Avatar:      profile.Avatar,
+			Role:        &role,
+		}
+	}
+
+	return Chat{
+		CreatedAt: c.CreatedAt,
+		Id:        c.ID,
+		JobId:     c.JobID,
+		Title:     c.Title,
+		Type:      ChatType(c.Type),
+		UpdatedAt: c.UpdatedAt,
+		Users:     users,
+	}, nil
+}
+
+func (s *Server) mapMessage(ctx context.Context, message models.Message) (Message, error) {
+	profile, err := s.services.Profile.GetProfile(ctx, message.UserID)
+	if err != nil {
+		return Message{}, err
+	}
+
+	attachments := make([]Attachment, len(message.Attachments))
+	for i, attachment := range message.Attachments {
+		attachments[i] = mapAttachment(attachment)
+	}
+
+	reactions := make([]MessageReaction, len(message.Reactions))
+	for i, reaction := range message.Reactions {
+		reactions[i] = MessageReaction{
+			Count:   reaction.Count,
+			Emoji:   reaction.Emoji,
+			UserIds: reaction.UserIDs,
+		}
+	}
+
+	var replyTo *MessageReply
+	if message.ReplyTo != nil {
+		replyTo = &MessageReply{
+			Deleted: message.ReplyTo.Deleted,
+			Id:      message.ReplyTo.ID,
+			Text:    message.ReplyTo.Text,
+			UserId:  message.ReplyTo.UserID,
+		}
+	}
+
+	return Message{
+		Attachments:  attachments,
+		ChatId:       message.ChatID,
+		CreatedAt:    message.CreatedAt,
+		DeletedAt:    message.DeletedAt,
+		EditedAt:     message.EditedAt,
+		Id:           message.ID,
+		Reactions:    reactions,
+		ReplyTo:      replyTo,
+		TargetUserId: message.TargetUserID,
+		Text:         message.Text,
+		Type:         MessageType(message.Type),
+		User: ChatUser{
+			Id:          profile.Guid,
+			Description: profile.Description,
+			Avatar:      profile.Avatar,
+		},
+	}, nil
+}
+
+func mapAttachment(attachment models.Attachment) Attachment {
+	return Attachment{
+		ContentType: attachment.ContentType,
+		CreatedAt:   attachment.CreatedAt,
+		Filename:    attachment.Filename,
+		Id:          attachment.ID,
+		Kind:        AttachmentKind(attachment.Kind),
+		PreviewUrl:  attachment.PreviewURL,
+		Size:        attachment.Size,
+		Url:         attachment.URL,
+	}
+}
+
+func mapReadReceipt(receipt models.ReadReceipt) ReadReceipt {
+	return ReadReceipt{
+		ChatId:    receipt.ChatID,
+		MessageId: receipt.MessageID,
+		ReadAt:    receipt.ReadAt,
+		UserId:    receipt.UserID,
+	}
+}
+
 func NewServer(services *service.Services, log *slog.Logger, cfg *config.Config) ServerInterface {
 	return &Server{
 		services: services,
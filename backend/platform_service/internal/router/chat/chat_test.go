@@ -0,0 +1,137 @@
+package chat
+
+import (
+	"PlatformService/internal/config"
+	"PlatformService/internal/models"
+	"PlatformService/internal/repository"
+	repository_chat "PlatformService/internal/repository/chat"
+	"PlatformService/internal/service"
+	"PlatformService/internal/service/chat"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/jackc/pgx/v4"
+)
+
+const testTokenSecret = "test-secret"
+
+// fakeTxManager выполняет функцию без транзакции
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+// fakeQuerier - один чат, все пользователи в нём участники
+type fakeQuerier struct {
+	repository_chat.Querier
+	chatID uuid.UUID
+}
+
+func (f *fakeQuerier) GetChatMember(_ context.Context, _ repository_chat.DBTX, arg repository_chat.GetChatMemberParams) (repository_chat.GetChatMemberRow, error) {
+	if arg.ChatID != f.chatID {
+		return repository_chat.GetChatMemberRow{}, pgx.ErrNoRows
+	}
+	return repository_chat.GetChatMemberRow{UserID: arg.UserID, Role: models.ChatRoleMember}, nil
+}
+
+func (f *fakeQuerier) GetUserChatIDs(context.Context, repository_chat.DBTX, uuid.UUID) ([]uuid.UUID, error) {
+	return []uuid.UUID{f.chatID}, nil
+}
+
+func testToken(t *testing.T, userID string) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, models.Claims{
+		UserGUID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}).SignedString([]byte(testTokenSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// waitPresence ждёт событие присутствия пользователя с заданным статусом
+func waitPresence(t *testing.T, observer *chat.WebSocketConnection, userID string, online bool) models.Presence {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case raw := <-observer.Send:
+			var event struct {
+				Type string `json:"type"`
+				models.Presence
+			}
+			if err := json.Unmarshal(raw, &event); err != nil {
+				t.Fatalf("invalid event %s: %v", raw, err)
+			}
+			if event.Type == chat.EventTypePresence && event.UserID == userID && event.Online == online {
+				return event.Presence
+			}
+		case <-timeout:
+			t.Fatalf("no presence event with online=%v for user %s", online, userID)
+		}
+	}
+}
+
+func TestHandleWebSocketClientDisconnect(t *testing.T) {
+	chatID := uuid.New()
+	cfg := &config.Config{AccessTokenSecret: testTokenSecret}
+	chatService := chat.NewService(cfg, &repository.Repositories{
+		Chat:      &fakeQuerier{chatID: chatID},
+		TxManager: fakeTxManager{},
+	}, nil, nil, nil, nil)
+	server := &Server{
+		services: &service.Services{Chat: chatService},
+		log:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		cfg:      cfg,
+	}
+
+	returned := make(chan struct{})
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(returned)
+		server.HandleWebSocket(w, r, chatID.String(), HandleWebSocketParams{Token: r.URL.Query().Get("token")})
+	}))
+	defer httpServer.Close()
+
+	// Второй участник чата получает события присутствия
+	observer := &chat.WebSocketConnection{UserID: uuid.NewString(), Send: make(chan []byte, 16)}
+	chatService.Subscribe(chatID.String(), observer)
+	defer chatService.Unsubscribe(chatID.String(), observer)
+
+	userID := uuid.NewString()
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "?token=" + testToken(t, userID)
+	client, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	waitPresence(t, observer, userID, true)
+
+	// Клиент пропадает без закрывающего кадра
+	client.Close()
+
+	presence := waitPresence(t, observer, userID, false)
+	if presence.LastSeenAt == nil {
+		t.Error("offline event has no last_seen_at")
+	}
+
+	select {
+	case <-returned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("HandleWebSocket did not return after client disconnect")
+	}
+}
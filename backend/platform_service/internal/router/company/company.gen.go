@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
@@ -49,7 +50,8 @@ type ApiGetCompany struct {
 	Address *string `json:"address,omitempty"`
 
 	// Avatar Логотип компании
-	Avatar *string `json:"avatar,omitempty"`
+	Avatar           *string          `json:"avatar,omitempty"`
+	AvatarThumbnails *ImageThumbnails `json:"avatar_thumbnails,omitempty"`
 
 	// Description Описание компании
 	Description *string `json:"description,omitempty"`
@@ -105,6 +107,18 @@ type ApiUpdateCompany struct {
 	Website *string `json:"website,omitempty"`
 }
 
+// ImageThumbnails defines model for ImageThumbnails.
+type ImageThumbnails struct {
+	// Large Ссылка на копию 512x512
+	Large string `json:"large"`
+
+	// Medium Ссылка на копию 256x256
+	Medium string `json:"medium"`
+
+	// Small Ссылка на копию 64x64
+	Small string `json:"small"`
+}
+
 // ShortCompany defines model for ShortCompany.
 type ShortCompany struct {
 	// Guid GUID компании
@@ -123,12 +137,21 @@ type SearchCompanyByNameParams struct {
 	Name *string `form:"name,omitempty" json:"name,omitempty"`
 }
 
+// UploadCompanyLogoMultipartBody defines parameters for UploadCompanyLogo.
+type UploadCompanyLogoMultipartBody struct {
+	// File Изображение JPEG, PNG, GIF или WebP
+	File *openapi_types.File `json:"file,omitempty"`
+}
+
 // CreateCompanyProfileJSONRequestBody defines body for CreateCompanyProfile for application/json ContentType.
 type CreateCompanyProfileJSONRequestBody = ApiCreateCompany
 
 // UpdateCompanyProfileJSONRequestBody defines body for UpdateCompanyProfile for application/json ContentType.
 type UpdateCompanyProfileJSONRequestBody = ApiUpdateCompany
 
+// UploadCompanyLogoMultipartRequestBody defines body for UploadCompanyLogo for multipart/form-data ContentType.
+type UploadCompanyLogoMultipartRequestBody UploadCompanyLogoMultipartBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Создать компанию
@@ -146,6 +169,9 @@ type ServerInterface interface {
 	// Изменить данные компании
 	// (PUT /api/v1/company/{company_id})
 	UpdateCompanyProfile(w http.ResponseWriter, r *http.Request, companyId string)
+	// Загрузить логотип компании
+	// (POST /api/v1/company/{company_id}/logo)
+	UploadCompanyLogo(w http.ResponseWriter, r *http.Request, companyId string)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -182,6 +208,12 @@ func (_ Unimplemented) UpdateCompanyProfile(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Загрузить логотип компании
+// (POST /api/v1/company/{company_id}/logo)
+func (_ Unimplemented) UploadCompanyLogo(w http.ResponseWriter, r *http.Request, companyId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -337,6 +369,37 @@ func (siw *ServerInterfaceWrapper) UpdateCompanyProfile(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
+// UploadCompanyLogo operation middleware
+func (siw *ServerInterfaceWrapper) UploadCompanyLogo(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "company_id" -------------
+	var companyId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "company_id", chi.URLParam(r, "company_id"), &companyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "company_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadCompanyLogo(w, r, companyId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -465,6 +528,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/company/{company_id}", wrapper.UpdateCompanyProfile)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/company/{company_id}/logo", wrapper.UploadCompanyLogo)
+	})
 
 	return r
 }
@@ -4,11 +4,19 @@ import (
 	"PlatformService/internal/models"
 	"PlatformService/internal/router/mw"
 	"PlatformService/internal/service"
+	"PlatformService/internal/service/media"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
+// Запас на заголовки и границы multipart-формы сверх размера файла
+const multipartOverhead = 1 << 20
+
 type Server struct {
 	services *service.Services
 	log      *slog.Logger
@@ -85,6 +93,63 @@ func (s *Server) UpdateCompanyProfile(w http.ResponseWriter, r *http.Request, co
 	json.NewEncoder(w).Encode(updatedCompany)
 }
 
+// UploadCompanyLogo implements ServerInterface.
+func (s *Server) UploadCompanyLogo(w http.ResponseWriter, r *http.Request, companyId string) {
+	ctx := r.Context()
+	userGUID, _ := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := uuid.Parse(companyId); err != nil {
+		http.Error(w, "Invalid company ID", http.StatusBadRequest)
+		return
+	}
+
+	// Ограничиваем тело запроса: запас на заголовки multipart
+	maxFileSize := s.services.Media.MaxFileSize()
+	r.Body = http.MaxBytesReader(w, r.Body, maxFileSize+multipartOverhead)
+	if err := r.ParseMultipartForm(maxFileSize); err != nil {
+		s.log.ErrorContext(ctx, "companyServer.UploadCompanyLogo failed to parse form", "error", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "Image is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	file, handler, err := r.FormFile("file")
+	if err != nil {
+		s.log.ErrorContext(ctx, "companyServer.UploadCompanyLogo failed to retrieve file", "error", err)
+		http.Error(w, "Error retrieving file", http.StatusBadRequest)
+		return
+	}
+	file.Close()
+
+	company, err := s.services.Company.UpdateLogo(ctx, userGUID, companyId, handler)
+	if err != nil {
+		s.log.ErrorContext(ctx, "companyServer.UploadCompanyLogo failed to update logo", "error", err)
+		switch {
+		case errors.Is(err, media.ErrImageTooLarge):
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		case errors.Is(err, media.ErrInvalidImage):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, pgx.ErrNoRows):
+			http.Error(w, "Company not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to update logo", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(company)
+}
+
 // DeleteCompany implements ServerInterface.
 func (s *Server) DeleteCompany(w http.ResponseWriter, r *http.Request, companyId string) {
 	ctx := r.Context()
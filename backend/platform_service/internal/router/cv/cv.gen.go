@@ -18,15 +18,178 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for IngestionFileStatus.
+const (
+	IngestionFileStatusFailed     IngestionFileStatus = "failed"
+	IngestionFileStatusPending    IngestionFileStatus = "pending"
+	IngestionFileStatusProcessing IngestionFileStatus = "processing"
+	IngestionFileStatusSkipped    IngestionFileStatus = "skipped"
+	IngestionFileStatusSucceeded  IngestionFileStatus = "succeeded"
+)
+
+// Defines values for IngestionFileStage.
+const (
+	Analysis   IngestionFileStage = "analysis"
+	Archive    IngestionFileStage = "archive"
+	Database   IngestionFileStage = "database"
+	Extraction IngestionFileStage = "extraction"
+	Storage    IngestionFileStage = "storage"
+	Validation IngestionFileStage = "validation"
+)
+
+// Defines values for IngestionJobStatus.
+const (
+	IngestionJobStatusCompleted  IngestionJobStatus = "completed"
+	IngestionJobStatusProcessing IngestionJobStatus = "processing"
+)
+
 // ApiUploadCVResp defines model for ApiUploadCVResp.
 type ApiUploadCVResp struct {
 	// Link Ссылка на загруженное резюме
+	Link    string    `json:"link"`
+	Version CVVersion `json:"version"`
+}
+
+// CVVersion Версия CV пользователя
+type CVVersion struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+
+	// IsPrimary Основная версия показывается в профиле и прикладывается к откликам по умолчанию
+	IsPrimary bool `json:"is_primary"`
+
+	// Label Подпись версии, может быть пустой
+	Label string `json:"label"`
+
+	// Link Ссылка на файл резюме
 	Link string `json:"link"`
+
+	// OriginalFilename Исходное имя загруженного файла; null для внешних ссылок
+	OriginalFilename *string   `json:"original_filename"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Candidate defines model for Candidate.
+type Candidate struct {
+	CreatedAt time.Time `json:"created_at"`
+	Email     *string   `json:"email"`
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Phone     *string   `json:"phone"`
+
+	// Resumes Версии резюме кандидата, новые первыми
+	Resumes   []ResumeRecord `json:"resumes"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// IngestionFile defines model for IngestionFile.
+type IngestionFile struct {
+	// Attempts Количество попыток обработки
+	Attempts int `json:"attempts"`
+
+	// Error Причина ошибки обработки файла
+	Error    *string `json:"error"`
+	FileName string  `json:"file_name"`
+	Id       string  `json:"id"`
+
+	// ResumeId Идентификатор созданной записи в базе резюме (для пропущенного файла - уже существующей)
+	ResumeId *string `json:"resume_id"`
+
+	// Retryable Файл можно поставить на повторную обработку
+	Retryable bool `json:"retryable"`
+
+	// Stage Этап, на котором обработка файла завершилась ошибкой
+	Stage     *IngestionFileStage `json:"stage"`
+	Status    IngestionFileStatus `json:"status"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// IngestionFileStage Этап, на котором обработка файла завершилась ошибкой
+type IngestionFileStage string
+
+// IngestionFileStatus defines model for IngestionFile.Status.
+type IngestionFileStatus string
+
+// IngestionJob defines model for IngestionJob.
+type IngestionJob struct {
+	ArchiveName    string          `json:"archive_name"`
+	CreatedAt      time.Time       `json:"created_at"`
+	FailedCount    int             `json:"failed_count"`
+	Files          []IngestionFile `json:"files"`
+	FinishedAt     *time.Time      `json:"finished_at"`
+	Id             string          `json:"id"`
+	ProcessedCount int             `json:"processed_count"`
+
+	// SkippedCount Количество файлов, совпавших с уже загруженными резюме
+	SkippedCount    int                `json:"skipped_count"`
+	Status          IngestionJobStatus `json:"status"`
+	SuccessfulCount int                `json:"successful_count"`
+	TotalCount      int                `json:"total_count"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// IngestionJobStatus defines model for IngestionJob.Status.
+type IngestionJobStatus string
+
+// MatchApplicantsResponse defines model for MatchApplicantsResponse.
+type MatchApplicantsResponse struct {
+	// Cached Результат взят из сохранённого подбора: вакансия и профили отобранных пользователей не менялись
+	Cached     bool               `json:"cached"`
+	Candidates []MatchedApplicant `json:"candidates"`
+
+	// MatchedAt Время, когда кандидаты были оценены
+	MatchedAt time.Time `json:"matched_at"`
+
+	// ShortlistSize Количество пользователей, оценённых моделью
+	ShortlistSize int `json:"shortlist_size"`
 }
 
 // MatchCandidatesResponse defines model for MatchCandidatesResponse.
 type MatchCandidatesResponse struct {
+	// Cached Результат взят из сохранённого подбора: вакансия и отобранные резюме не менялись
+	Cached     bool               `json:"cached"`
 	Candidates []MatchedCandidate `json:"candidates"`
+
+	// MatchedAt Время, когда кандидаты были оценены
+	MatchedAt time.Time `json:"matched_at"`
+
+	// ShortlistSize Количество резюме, отобранных поиском и оценённых моделью
+	ShortlistSize int `json:"shortlist_size"`
+}
+
+// MatchScoreBreakdown Оценки кандидата по критериям от 1 до 100
+type MatchScoreBreakdown struct {
+	Experience int `json:"experience"`
+
+	// Location Соответствие местоположения; null, если в резюме нет данных
+	Location  *int `json:"location"`
+	Seniority int  `json:"seniority"`
+	Skills    int  `json:"skills"`
+}
+
+// MatchedApplicant defines model for MatchedApplicant.
+type MatchedApplicant struct {
+	// ApplicationId Идентификатор отклика; null, если пользователь не откликался на вакансию
+	ApplicationId *string `json:"application_id"`
+
+	// ApplicationStatus Статус отклика
+	ApplicationStatus *string `json:"application_status"`
+	Avatar            *string `json:"avatar"`
+
+	// CvLink Ссылка на резюме из профиля
+	CvLink *string `json:"cv_link"`
+	Email  string  `json:"email"`
+
+	// MatchScore Оценка соответствия от 1 до 100
+	MatchScore int `json:"match_score"`
+
+	// Name ФИО пользователя
+	Name      string `json:"name"`
+	ProfileId string `json:"profile_id"`
+
+	// Reasoning Объяснение почему кандидат подходит для вакансии
+	Reasoning string              `json:"reasoning"`
+	Scores    MatchScoreBreakdown `json:"scores"`
 }
 
 // MatchedCandidate defines model for MatchedCandidate.
@@ -38,118 +201,913 @@ type MatchedCandidate struct {
 	MatchScore int `json:"match_score"`
 
 	// Reasoning Объяснение почему кандидат подходит для вакансии
-	Reasoning string `json:"reasoning"`
-	ResumeId  string `json:"resume_id"`
+	Reasoning string              `json:"reasoning"`
+	ResumeId  string              `json:"resume_id"`
+	Scores    MatchScoreBreakdown `json:"scores"`
+}
+
+// MergeCandidatesRequest defines model for MergeCandidatesRequest.
+type MergeCandidatesRequest struct {
+	// SourceIds Кандидаты, резюме которых переносятся к целевому
+	SourceIds []string `json:"source_ids"`
+
+	// TargetId Кандидат, который остаётся после объединения
+	TargetId string `json:"target_id"`
+}
+
+// ResumeFolder Папка (кадровый резерв) в базе резюме
+type ResumeFolder struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+
+	// ResumeCount Количество резюме в папке
+	ResumeCount int       `json:"resume_count"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ResumeFolderRequest defines model for ResumeFolderRequest.
+type ResumeFolderRequest struct {
+	// Name Название папки, уникальное в базе резюме пользователя
+	Name string `json:"name"`
 }
 
 // ResumeRecord defines model for ResumeRecord.
 type ResumeRecord struct {
-	Analysis        string    `json:"analysis"`
-	CandidateAge    *int      `json:"candidate_age"`
+	Analysis     string `json:"analysis"`
+	CandidateAge *int   `json:"candidate_age"`
+
+	// CandidateId Кандидат, к которому относится резюме
+	CandidateId     *string   `json:"candidate_id"`
 	CandidateName   string    `json:"candidate_name"`
 	CreatedAt       time.Time `json:"created_at"`
+	Email           *string   `json:"email"`
 	ExperienceYears string    `json:"experience_years"`
 	FileUrl         string    `json:"file_url"`
-	Id              string    `json:"id"`
-	UpdatedAt       time.Time `json:"updated_at"`
-	UserId          string    `json:"user_id"`
+
+	// FolderIds Папки, в которых лежит резюме (только в списке базы резюме)
+	FolderIds *[]string `json:"folder_ids,omitempty"`
+	Id        string    `json:"id"`
+
+	// Notes Заметки рекрутера
+	Notes string  `json:"notes"`
+	Phone *string `json:"phone"`
+
+	// PromptVersion Версия промпта, которым получен анализ
+	PromptVersion *string `json:"prompt_version,omitempty"`
+
+	// Tags Теги рекрутера в нижнем регистре
+	Tags      []string  `json:"tags"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserId    string    `json:"user_id"`
+}
+
+// ResumeSearchResult defines model for ResumeSearchResult.
+type ResumeSearchResult struct {
+	Resume ResumeRecord `json:"resume"`
+
+	// Score Релевантность резюме запросу; чем больше, тем выше в выдаче
+	Score float64 `json:"score"`
+
+	// Snippet Фрагменты анализа с выделенными совпадениями
+	Snippet []SnippetFragment `json:"snippet"`
+}
+
+// SignedFileURL Временная ссылка на файл резюме
+type SignedFileURL struct {
+	// ExpiresAt Время, после которого ссылка перестаёт действовать
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Url Ссылка на скачивание, не требующая авторизации
+	Url string `json:"url"`
+}
+
+// SnippetFragment defines model for SnippetFragment.
+type SnippetFragment struct {
+	// Highlight Фрагмент совпадает с поисковым запросом
+	Highlight bool   `json:"highlight"`
+	Text      string `json:"text"`
+}
+
+// UpdateCVVersionRequest Отсутствующие поля не меняются
+type UpdateCVVersionRequest struct {
+	// IsPrimary Только true - сделать версию основной
+	IsPrimary *bool `json:"is_primary,omitempty"`
+
+	// Label Подпись версии, до 100 символов
+	Label *string `json:"label,omitempty"`
+}
+
+// UpdateResumeRequest Отсутствующие поля не меняются
+type UpdateResumeRequest struct {
+	// Notes Заметки рекрутера, до 10000 символов
+	Notes *string `json:"notes,omitempty"`
+
+	// Tags Теги резюме, заменяют текущие; не больше 20 тегов до 50 символов
+	Tags *[]string `json:"tags,omitempty"`
 }
 
-// UploadDatabaseResponse defines model for UploadDatabaseResponse.
-type UploadDatabaseResponse struct {
-	FailedCount     int            `json:"failed_count"`
-	ProcessedCount  int            `json:"processed_count"`
-	Resumes         []ResumeRecord `json:"resumes"`
-	SuccessfulCount int            `json:"successful_count"`
+// MatchApplicantsParams defines parameters for MatchApplicants.
+type MatchApplicantsParams struct {
+	// Limit Количество лучших кандидатов в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeProfiles Оценить также пользователей, открытых к предложениям, но не откликавшихся на вакансию
+	IncludeProfiles *bool `form:"include_profiles,omitempty" json:"include_profiles,omitempty"`
+
+	// Refresh Оценить кандидатов заново, не используя сохранённый подбор
+	Refresh *bool `form:"refresh,omitempty" json:"refresh,omitempty"`
 }
 
 // GetResumeDatabaseParams defines parameters for GetResumeDatabase.
 type GetResumeDatabaseParams struct {
+	// Tag Только резюме с этим тегом
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// FolderId Только резюме из этой папки
+	FolderId *string `form:"folder_id,omitempty" json:"folder_id,omitempty"`
+	Limit    *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset   *int    `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetCandidatesParams defines parameters for GetCandidates.
+type GetCandidatesParams struct {
 	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// MatchCandidatesFromDatabaseParams defines parameters for MatchCandidatesFromDatabase.
+type MatchCandidatesFromDatabaseParams struct {
+	// Limit Количество лучших кандидатов в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Refresh Оценить кандидатов заново, не используя сохранённый подбор
+	Refresh *bool `form:"refresh,omitempty" json:"refresh,omitempty"`
+}
+
+// SearchResumeDatabaseParams defines parameters for SearchResumeDatabase.
+type SearchResumeDatabaseParams struct {
+	// Q Поисковый запрос: навыки, должность, опыт
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// MinAge Минимальный возраст кандидата
+	MinAge *int `form:"min_age,omitempty" json:"min_age,omitempty"`
+
+	// MaxAge Максимальный возраст кандидата
+	MaxAge *int `form:"max_age,omitempty" json:"max_age,omitempty"`
+
+	// MinExperience Минимальный стаж в годах
+	MinExperience *float64 `form:"min_experience,omitempty" json:"min_experience,omitempty"`
+
+	// MaxExperience Максимальный стаж в годах
+	MaxExperience *float64 `form:"max_experience,omitempty" json:"max_experience,omitempty"`
+	Limit         *int     `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset        *int     `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetCVByFilenameParams defines parameters for GetCVByFilename.
+type GetCVByFilenameParams struct {
+	// Expires Срок действия подписанной ссылки (Unix time)
+	Expires *string `form:"expires,omitempty" json:"expires,omitempty"`
+
+	// Signature Подпись ссылки, выданной getCVSignedURL
+	Signature *string `form:"signature,omitempty" json:"signature,omitempty"`
+}
+
 // UploadResumeDatabaseMultipartBody defines parameters for UploadResumeDatabase.
 type UploadResumeDatabaseMultipartBody struct {
-	// Archive ZIP архив содержащий PDF, TXT, DOC, DOCX файлы резюме
+	// Archive ZIP архив содержащий PDF, TXT, DOC, DOCX, RTF, ODT файлы резюме
 	Archive *openapi_types.File `json:"archive,omitempty"`
 }
 
 // UploadCVMultipartBody defines parameters for UploadCV.
 type UploadCVMultipartBody struct {
 	File *openapi_types.File `json:"file,omitempty"`
+
+	// Label Подпись версии, например "Backend CV", до 100 символов
+	Label *string `json:"label,omitempty"`
+
+	// Primary Сделать версию основной; первая версия пользователя всегда основная
+	Primary *bool `json:"primary,omitempty"`
 }
 
+// MergeCandidatesJSONRequestBody defines body for MergeCandidates for application/json ContentType.
+type MergeCandidatesJSONRequestBody = MergeCandidatesRequest
+
+// CreateResumeFolderJSONRequestBody defines body for CreateResumeFolder for application/json ContentType.
+type CreateResumeFolderJSONRequestBody = ResumeFolderRequest
+
+// RenameResumeFolderJSONRequestBody defines body for RenameResumeFolder for application/json ContentType.
+type RenameResumeFolderJSONRequestBody = ResumeFolderRequest
+
 // UploadResumeDatabaseMultipartRequestBody defines body for UploadResumeDatabase for multipart/form-data ContentType.
 type UploadResumeDatabaseMultipartRequestBody UploadResumeDatabaseMultipartBody
 
+// UpdateResumeJSONRequestBody defines body for UpdateResume for application/json ContentType.
+type UpdateResumeJSONRequestBody = UpdateResumeRequest
+
 // UploadCVMultipartRequestBody defines body for UploadCV for multipart/form-data ContentType.
 type UploadCVMultipartRequestBody UploadCVMultipartBody
 
+// UpdateCVVersionJSONRequestBody defines body for UpdateCVVersion for application/json ContentType.
+type UpdateCVVersionJSONRequestBody = UpdateCVVersionRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Подобрать кандидатов среди пользователей платформы для вакансии
+	// (POST /api/v1/cv/applicants/match/{job_id})
+	MatchApplicants(w http.ResponseWriter, r *http.Request, jobId string, params MatchApplicantsParams)
 	// Получить базу резюме пользователя
 	// (GET /api/v1/cv/database)
 	GetResumeDatabase(w http.ResponseWriter, r *http.Request, params GetResumeDatabaseParams)
+	// Получить кандидатов из базы резюме
+	// (GET /api/v1/cv/database/candidates)
+	GetCandidates(w http.ResponseWriter, r *http.Request, params GetCandidatesParams)
+	// Объединить кандидатов
+	// (POST /api/v1/cv/database/candidates/merge)
+	MergeCandidates(w http.ResponseWriter, r *http.Request)
+	// Получить папки базы резюме
+	// (GET /api/v1/cv/database/folders)
+	GetResumeFolders(w http.ResponseWriter, r *http.Request)
+	// Создать папку в базе резюме
+	// (POST /api/v1/cv/database/folders)
+	CreateResumeFolder(w http.ResponseWriter, r *http.Request)
+	// Удалить папку
+	// (DELETE /api/v1/cv/database/folders/{id})
+	DeleteResumeFolder(w http.ResponseWriter, r *http.Request, id string)
+	// Переименовать папку
+	// (PUT /api/v1/cv/database/folders/{id})
+	RenameResumeFolder(w http.ResponseWriter, r *http.Request, id string)
+	// Убрать резюме из папки
+	// (DELETE /api/v1/cv/database/folders/{id}/resumes/{resume_id})
+	RemoveResumeFromFolder(w http.ResponseWriter, r *http.Request, id string, resumeId string)
+	// Добавить резюме в папку
+	// (PUT /api/v1/cv/database/folders/{id}/resumes/{resume_id})
+	AddResumeToFolder(w http.ResponseWriter, r *http.Request, id string, resumeId string)
+	// Получить статус обработки архива с резюме
+	// (GET /api/v1/cv/database/jobs/{id})
+	GetIngestionJob(w http.ResponseWriter, r *http.Request, id string)
+	// Повторить обработку файлов, завершившихся ошибкой
+	// (POST /api/v1/cv/database/jobs/{id}/retry)
+	RetryIngestionJob(w http.ResponseWriter, r *http.Request, id string)
 	// Подобрать кандидатов из базы резюме для вакансии
 	// (POST /api/v1/cv/database/match/{job_id})
-	MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Request, jobId string)
+	MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Request, jobId string, params MatchCandidatesFromDatabaseParams)
+	// Поиск по базе резюме
+	// (GET /api/v1/cv/database/search)
+	SearchResumeDatabase(w http.ResponseWriter, r *http.Request, params SearchResumeDatabaseParams)
 	// Загрузить архив с базой резюме
 	// (POST /api/v1/cv/database/upload)
 	UploadResumeDatabase(w http.ResponseWriter, r *http.Request)
+	// Удалить резюме из базы
+	// (DELETE /api/v1/cv/database/{id})
+	DeleteResume(w http.ResponseWriter, r *http.Request, id string)
+	// Изменить теги и заметки резюме
+	// (PATCH /api/v1/cv/database/{id})
+	UpdateResume(w http.ResponseWriter, r *http.Request, id string)
 	// Загрузить резюме
 	// (POST /api/v1/cv/upload)
 	UploadCV(w http.ResponseWriter, r *http.Request)
+	// Список версий CV
+	// (GET /api/v1/cv/versions)
+	ListCVVersions(w http.ResponseWriter, r *http.Request)
+	// Удалить версию CV
+	// (DELETE /api/v1/cv/versions/{id})
+	DeleteCVVersion(w http.ResponseWriter, r *http.Request, id string)
+	// Изменить подпись версии CV или сделать её основной
+	// (PATCH /api/v1/cv/versions/{id})
+	UpdateCVVersion(w http.ResponseWriter, r *http.Request, id string)
 	// Получить резюме по имени файла
 	// (GET /api/v1/cv/{filename})
-	GetCVByFilename(w http.ResponseWriter, r *http.Request, filename string)
+	GetCVByFilename(w http.ResponseWriter, r *http.Request, filename string, params GetCVByFilenameParams)
+	// Получить временную ссылку на резюме
+	// (GET /api/v1/cv/{filename}/url)
+	GetCVSignedURL(w http.ResponseWriter, r *http.Request, filename string)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Подобрать кандидатов среди пользователей платформы для вакансии
+// (POST /api/v1/cv/applicants/match/{job_id})
+func (_ Unimplemented) MatchApplicants(w http.ResponseWriter, r *http.Request, jobId string, params MatchApplicantsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить базу резюме пользователя
+// (GET /api/v1/cv/database)
+func (_ Unimplemented) GetResumeDatabase(w http.ResponseWriter, r *http.Request, params GetResumeDatabaseParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить кандидатов из базы резюме
+// (GET /api/v1/cv/database/candidates)
+func (_ Unimplemented) GetCandidates(w http.ResponseWriter, r *http.Request, params GetCandidatesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Объединить кандидатов
+// (POST /api/v1/cv/database/candidates/merge)
+func (_ Unimplemented) MergeCandidates(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить папки базы резюме
+// (GET /api/v1/cv/database/folders)
+func (_ Unimplemented) GetResumeFolders(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать папку в базе резюме
+// (POST /api/v1/cv/database/folders)
+func (_ Unimplemented) CreateResumeFolder(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить папку
+// (DELETE /api/v1/cv/database/folders/{id})
+func (_ Unimplemented) DeleteResumeFolder(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Переименовать папку
+// (PUT /api/v1/cv/database/folders/{id})
+func (_ Unimplemented) RenameResumeFolder(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// Убрать резюме из папки
+// (DELETE /api/v1/cv/database/folders/{id}/resumes/{resume_id})
+func (_ Unimplemented) RemoveResumeFromFolder(w http.ResponseWriter, r *http.Request, id string, resumeId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Добавить резюме в папку
+// (PUT /api/v1/cv/database/folders/{id}/resumes/{resume_id})
+func (_ Unimplemented) AddResumeToFolder(w http.ResponseWriter, r *http.Request, id string, resumeId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить статус обработки архива с резюме
+// (GET /api/v1/cv/database/jobs/{id})
+func (_ Unimplemented) GetIngestionJob(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Повторить обработку файлов, завершившихся ошибкой
+// (POST /api/v1/cv/database/jobs/{id}/retry)
+func (_ Unimplemented) RetryIngestionJob(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Подобрать кандидатов из базы резюме для вакансии
+// (POST /api/v1/cv/database/match/{job_id})
+func (_ Unimplemented) MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Request, jobId string, params MatchCandidatesFromDatabaseParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Поиск по базе резюме
+// (GET /api/v1/cv/database/search)
+func (_ Unimplemented) SearchResumeDatabase(w http.ResponseWriter, r *http.Request, params SearchResumeDatabaseParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Загрузить архив с базой резюме
+// (POST /api/v1/cv/database/upload)
+func (_ Unimplemented) UploadResumeDatabase(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить резюме из базы
+// (DELETE /api/v1/cv/database/{id})
+func (_ Unimplemented) DeleteResume(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить теги и заметки резюме
+// (PATCH /api/v1/cv/database/{id})
+func (_ Unimplemented) UpdateResume(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Загрузить резюме
+// (POST /api/v1/cv/upload)
+func (_ Unimplemented) UploadCV(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список версий CV
+// (GET /api/v1/cv/versions)
+func (_ Unimplemented) ListCVVersions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить версию CV
+// (DELETE /api/v1/cv/versions/{id})
+func (_ Unimplemented) DeleteCVVersion(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить подпись версии CV или сделать её основной
+// (PATCH /api/v1/cv/versions/{id})
+func (_ Unimplemented) UpdateCVVersion(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить резюме по имени файла
+// (GET /api/v1/cv/{filename})
+func (_ Unimplemented) GetCVByFilename(w http.ResponseWriter, r *http.Request, filename string, params GetCVByFilenameParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить временную ссылку на резюме
+// (GET /api/v1/cv/{filename}/url)
+func (_ Unimplemented) GetCVSignedURL(w http.ResponseWriter, r *http.Request, filename string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// MatchApplicants operation middleware
+func (siw *ServerInterfaceWrapper) MatchApplicants(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "job_id" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "job_id", chi.URLParam(r, "job_id"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "job_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MatchApplicantsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_profiles" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_profiles", r.URL.Query(), &params.IncludeProfiles)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_profiles", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "refresh" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "refresh", r.URL.Query(), &params.Refresh)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refresh", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MatchApplicants(w, r, jobId, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetResumeDatabase operation middleware
+func (siw *ServerInterfaceWrapper) GetResumeDatabase(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetResumeDatabaseParams
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "folder_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "folder_id", r.URL.Query(), &params.FolderId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "folder_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetResumeDatabase(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCandidates operation middleware
+func (siw *ServerInterfaceWrapper) GetCandidates(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCandidatesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCandidates(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MergeCandidates operation middleware
+func (siw *ServerInterfaceWrapper) MergeCandidates(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergeCandidates(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetResumeFolders operation middleware
+func (siw *ServerInterfaceWrapper) GetResumeFolders(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetResumeFolders(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateResumeFolder operation middleware
+func (siw *ServerInterfaceWrapper) CreateResumeFolder(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateResumeFolder(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteResumeFolder operation middleware
+func (siw *ServerInterfaceWrapper) DeleteResumeFolder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteResumeFolder(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameResumeFolder operation middleware
+func (siw *ServerInterfaceWrapper) RenameResumeFolder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameResumeFolder(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveResumeFromFolder operation middleware
+func (siw *ServerInterfaceWrapper) RemoveResumeFromFolder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "resume_id" -------------
+	var resumeId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resume_id", chi.URLParam(r, "resume_id"), &resumeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resume_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveResumeFromFolder(w, r, id, resumeId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddResumeToFolder operation middleware
+func (siw *ServerInterfaceWrapper) AddResumeToFolder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "resume_id" -------------
+	var resumeId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "resume_id", chi.URLParam(r, "resume_id"), &resumeId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "resume_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddResumeToFolder(w, r, id, resumeId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetIngestionJob operation middleware
+func (siw *ServerInterfaceWrapper) GetIngestionJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIngestionJob(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RetryIngestionJob operation middleware
+func (siw *ServerInterfaceWrapper) RetryIngestionJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryIngestionJob(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MatchCandidatesFromDatabase operation middleware
+func (siw *ServerInterfaceWrapper) MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "job_id" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "job_id", chi.URLParam(r, "job_id"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "job_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
-type Unimplemented struct{}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MatchCandidatesFromDatabaseParams
 
-// Получить базу резюме пользователя
-// (GET /api/v1/cv/database)
-func (_ Unimplemented) GetResumeDatabase(w http.ResponseWriter, r *http.Request, params GetResumeDatabaseParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Optional query parameter "limit" -------------
 
-// Подобрать кандидатов из базы резюме для вакансии
-// (POST /api/v1/cv/database/match/{job_id})
-func (_ Unimplemented) MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Request, jobId string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-// Загрузить архив с базой резюме
-// (POST /api/v1/cv/database/upload)
-func (_ Unimplemented) UploadResumeDatabase(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Optional query parameter "refresh" -------------
 
-// Загрузить резюме
-// (POST /api/v1/cv/upload)
-func (_ Unimplemented) UploadCV(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindQueryParameter("form", true, false, "refresh", r.URL.Query(), &params.Refresh)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refresh", Err: err})
+		return
+	}
 
-// Получить резюме по имени файла
-// (GET /api/v1/cv/{filename})
-func (_ Unimplemented) GetCVByFilename(w http.ResponseWriter, r *http.Request, filename string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MatchCandidatesFromDatabase(w, r, jobId, params)
+	}))
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	handler.ServeHTTP(w, r)
+}
 
-// GetResumeDatabase operation middleware
-func (siw *ServerInterfaceWrapper) GetResumeDatabase(w http.ResponseWriter, r *http.Request) {
+// SearchResumeDatabase operation middleware
+func (siw *ServerInterfaceWrapper) SearchResumeDatabase(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -160,7 +1118,47 @@ func (siw *ServerInterfaceWrapper) GetResumeDatabase(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetResumeDatabaseParams
+	var params SearchResumeDatabaseParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_age" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_age", r.URL.Query(), &params.MinAge)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_age", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_age" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_age", r.URL.Query(), &params.MaxAge)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_age", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "min_experience" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_experience", r.URL.Query(), &params.MinExperience)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "min_experience", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "max_experience" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_experience", r.URL.Query(), &params.MaxExperience)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "max_experience", Err: err})
+		return
+	}
 
 	// ------------- Optional query parameter "limit" -------------
 
@@ -179,7 +1177,7 @@ func (siw *ServerInterfaceWrapper) GetResumeDatabase(w http.ResponseWriter, r *h
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetResumeDatabase(w, r, params)
+		siw.Handler.SearchResumeDatabase(w, r, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -189,17 +1187,37 @@ func (siw *ServerInterfaceWrapper) GetResumeDatabase(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// MatchCandidatesFromDatabase operation middleware
-func (siw *ServerInterfaceWrapper) MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Request) {
+// UploadResumeDatabase operation middleware
+func (siw *ServerInterfaceWrapper) UploadResumeDatabase(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadResumeDatabase(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteResume operation middleware
+func (siw *ServerInterfaceWrapper) DeleteResume(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "job_id" -------------
-	var jobId string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "job_id", chi.URLParam(r, "job_id"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "job_id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -210,7 +1228,7 @@ func (siw *ServerInterfaceWrapper) MatchCandidatesFromDatabase(w http.ResponseWr
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.MatchCandidatesFromDatabase(w, r, jobId)
+		siw.Handler.DeleteResume(w, r, id)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -220,8 +1238,19 @@ func (siw *ServerInterfaceWrapper) MatchCandidatesFromDatabase(w http.ResponseWr
 	handler.ServeHTTP(w, r)
 }
 
-// UploadResumeDatabase operation middleware
-func (siw *ServerInterfaceWrapper) UploadResumeDatabase(w http.ResponseWriter, r *http.Request) {
+// UpdateResume operation middleware
+func (siw *ServerInterfaceWrapper) UpdateResume(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -230,7 +1259,7 @@ func (siw *ServerInterfaceWrapper) UploadResumeDatabase(w http.ResponseWriter, r
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UploadResumeDatabase(w, r)
+		siw.Handler.UpdateResume(w, r, id)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -260,6 +1289,88 @@ func (siw *ServerInterfaceWrapper) UploadCV(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
+// ListCVVersions operation middleware
+func (siw *ServerInterfaceWrapper) ListCVVersions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCVVersions(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCVVersion operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCVVersion(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCVVersion(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateCVVersion operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCVVersion(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateCVVersion(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetCVByFilename operation middleware
 func (siw *ServerInterfaceWrapper) GetCVByFilename(w http.ResponseWriter, r *http.Request) {
 
@@ -280,8 +1391,58 @@ func (siw *ServerInterfaceWrapper) GetCVByFilename(w http.ResponseWriter, r *htt
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCVByFilenameParams
+
+	// ------------- Optional query parameter "expires" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expires", r.URL.Query(), &params.Expires)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expires", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "signature" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "signature", r.URL.Query(), &params.Signature)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "signature", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCVByFilename(w, r, filename, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCVSignedURL operation middleware
+func (siw *ServerInterfaceWrapper) GetCVSignedURL(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "filename" -------------
+	var filename string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "filename", chi.URLParam(r, "filename"), &filename, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "filename", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCVByFilename(w, r, filename)
+		siw.Handler.GetCVSignedURL(w, r, filename)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -404,21 +1565,75 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/cv/applicants/match/{job_id}", wrapper.MatchApplicants)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/cv/database", wrapper.GetResumeDatabase)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/cv/database/candidates", wrapper.GetCandidates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/cv/database/candidates/merge", wrapper.MergeCandidates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/cv/database/folders", wrapper.GetResumeFolders)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/cv/database/folders", wrapper.CreateResumeFolder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/cv/database/folders/{id}", wrapper.DeleteResumeFolder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/cv/database/folders/{id}", wrapper.RenameResumeFolder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/cv/database/folders/{id}/resumes/{resume_id}", wrapper.RemoveResumeFromFolder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/cv/database/folders/{id}/resumes/{resume_id}", wrapper.AddResumeToFolder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/cv/database/jobs/{id}", wrapper.GetIngestionJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/cv/database/jobs/{id}/retry", wrapper.RetryIngestionJob)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/cv/database/match/{job_id}", wrapper.MatchCandidatesFromDatabase)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/cv/database/search", wrapper.SearchResumeDatabase)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/cv/database/upload", wrapper.UploadResumeDatabase)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/cv/database/{id}", wrapper.DeleteResume)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/cv/database/{id}", wrapper.UpdateResume)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/cv/upload", wrapper.UploadCV)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/cv/versions", wrapper.ListCVVersions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/cv/versions/{id}", wrapper.DeleteCVVersion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/cv/versions/{id}", wrapper.UpdateCVVersion)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/cv/{filename}", wrapper.GetCVByFilename)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/cv/{filename}/url", wrapper.GetCVSignedURL)
+	})
 
 	return r
 }
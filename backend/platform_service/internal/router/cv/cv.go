@@ -2,42 +2,120 @@ package cv
 
 import (
 	"PlatformService/internal/config"
+	"PlatformService/internal/models"
 	"PlatformService/internal/router/mw"
 	"PlatformService/internal/service"
+	service_cv "PlatformService/internal/service/cv"
+	"PlatformService/internal/service/deepseek"
+	"PlatformService/internal/service/storage"
 	"encoding/json"
-	"fmt"
-	"io"
+	"errors"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
 )
 
+// Запас на заголовки и границы multipart-формы сверх размера файла
+const multipartOverhead = 1 << 20
+
 type Server struct {
 	services *service.Services
 	log      *slog.Logger
 	cfg      *config.Config
+	limits   service_cv.UploadLimits
 }
 
 // GetCVByFilename implements ServerInterface.
-func (s *Server) GetCVByFilename(w http.ResponseWriter, r *http.Request, filename string) {
-	file, err := s.services.Storage.GetFile(r.Context(), filename)
+func (s *Server) GetCVByFilename(w http.ResponseWriter, r *http.Request, filename string, params GetCVByFilenameParams) {
+	ctx := r.Context()
+
+	var file *models.StoredFile
+	var err error
+	if params.Expires != nil || params.Signature != nil {
+		// Подписанная ссылка действует без авторизации
+		var expires, signature string
+		if params.Expires != nil {
+			expires = *params.Expires
+		}
+		if params.Signature != nil {
+			signature = *params.Signature
+		}
+		file, err = s.services.CV.GetSignedFile(ctx, filename, expires, signature)
+	} else {
+		userGUID, _ := ctx.Value(mw.UserIDKey).(string)
+		if userGUID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		file, err = s.services.CV.GetFile(ctx, userGUID, filename)
+	}
 	if err != nil {
-		s.log.ErrorContext(r.Context(), "cvServer.GetCVByFilename failed to get file", "error", err)
+		switch {
+		case errors.Is(err, service_cv.ErrFileNotFound):
+			http.Error(w, "CV file not found", http.StatusNotFound)
+		case errors.Is(err, service_cv.ErrFileAccessDenied):
+			http.Error(w, "Access denied", http.StatusForbidden)
+		case errors.Is(err, service_cv.ErrInvalidFileSignature):
+			http.Error(w, "Link is invalid or expired", http.StatusForbidden)
+		default:
+			s.log.ErrorContext(ctx, "cvServer.GetCVByFilename failed to get file", "error", err)
+			http.Error(w, "Failed to get file", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	object, info, err := s.services.Storage.Get(ctx, file.ObjectName)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		http.Error(w, "CV file not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.log.ErrorContext(ctx, "cvServer.GetCVByFilename failed to get file", "error", err)
 		http.Error(w, "Failed to get file", http.StatusInternalServerError)
 		return
 	}
-	defer file.Close()
+	defer object.Close()
+
+	// Заголовки выставляются до записи тела; имя файла кодируется по RFC 2231
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": file.OriginalFilename})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
 
-	// Copy file to response
-	_, err = io.Copy(w, file)
+	http.ServeContent(w, r, "", info.LastModified, object)
+}
+
+// GetCVSignedURL implements ServerInterface.
+func (s *Server) GetCVSignedURL(w http.ResponseWriter, r *http.Request, filename string) {
+	ctx := r.Context()
+	userGUID, _ := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	response, err := s.services.CV.GetSignedFileURL(ctx, userGUID, filename)
 	if err != nil {
-		s.log.ErrorContext(r.Context(), "cvServer.GetCVByFilename failed to copy file", "error", err)
-		http.Error(w, "Failed to copy file", http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, service_cv.ErrFileNotFound):
+			http.Error(w, "CV file not found", http.StatusNotFound)
+		case errors.Is(err, service_cv.ErrFileAccessDenied):
+			http.Error(w, "Access denied", http.StatusForbidden)
+		default:
+			s.log.ErrorContext(ctx, "cvServer.GetCVSignedURL failed to sign URL", "error", err)
+			http.Error(w, "Failed to get file URL", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/octet-stream")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
 }
 
 // UploadCV implements ServerInterface.
@@ -49,10 +127,18 @@ func (s *Server) UploadCV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Ограничиваем тело запроса: запас на заголовки multipart
+	r.Body = http.MaxBytesReader(w, r.Body, s.limits.MaxFileSize+multipartOverhead)
+
 	// Parse multipart form with 10MB max memory
 	err := r.ParseMultipartForm(10 << 20)
 	if err != nil {
 		s.log.ErrorContext(ctx, "cvServer.UploadCV failed to parse form", "error", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "File is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
 		http.Error(w, "Failed to parse form", http.StatusBadRequest)
 		return
 	}
@@ -64,30 +150,124 @@ func (s *Server) UploadCV(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Error retrieving file", http.StatusBadRequest)
 		return
 	}
-	defer file.Close()
+	file.Close()
 
-	// Upload file to MinIO
-	newFilename, err := s.services.Storage.UploadFile(r.Context(), file, handler.Filename)
+	// Новая версия по умолчанию становится основной
+	primary := true
+	if value := r.FormValue("primary"); value != "" {
+		primary, err = strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "Invalid primary value", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Validate file, upload it to MinIO and save CV version
+	version, err := s.services.CV.UploadCV(ctx, userGUID, handler, r.FormValue("label"), primary)
 	if err != nil {
-		s.log.ErrorContext(ctx, "cvServer.UploadCV failed to upload file", "error", err)
-		http.Error(w, "Failed to upload file", http.StatusInternalServerError)
+		if errors.Is(err, service_cv.ErrInvalidCVVersion) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.UploadCV failed to upload CV", "error", err)
+		writeUploadError(w, err, "Failed to upload CV")
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]any{
+		"link":    version.Link,
+		"version": version,
+	})
+}
+
+// ListCVVersions implements ServerInterface.
+func (s *Server) ListCVVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	// Save CV link to database
-	cvLink := fmt.Sprintf("%s/api/v1/cv/%s", s.cfg.ServerFullAddress, newFilename)
-	if err := s.services.CV.SaveCVLink(r.Context(), userGUID, cvLink); err != nil {
-		s.log.ErrorContext(ctx, "cvServer.UploadCV failed to save CV link", "error", err)
-		http.Error(w, "Failed to save CV link", http.StatusInternalServerError)
+	versions, err := s.services.CV.ListCVVersions(ctx, userGUID)
+	if err != nil {
+		s.log.ErrorContext(ctx, "cvServer.ListCVVersions failed to get cv versions", "error", err)
+		http.Error(w, "Failed to get CV versions", http.StatusInternalServerError)
 		return
 	}
 
 	// Return response
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]string{
-		"link": cvLink,
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(versions)
+}
+
+// UpdateCVVersion implements ServerInterface.
+func (s *Server) UpdateCVVersion(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req UpdateCVVersionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	version, err := s.services.CV.UpdateCVVersion(ctx, userGUID, id, models.CVVersionUpdate{
+		Label:     req.Label,
+		IsPrimary: req.IsPrimary,
 	})
+	if err != nil {
+		if errors.Is(err, service_cv.ErrInvalidCVVersion) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service_cv.ErrCVVersionNotFound) {
+			http.Error(w, "CV version not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.UpdateCVVersion failed to update cv version", "error", err)
+		http.Error(w, "Failed to update CV version", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(version)
+}
+
+// DeleteCVVersion implements ServerInterface.
+func (s *Server) DeleteCVVersion(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.services.CV.DeleteCVVersion(ctx, userGUID, id); err != nil {
+		if errors.Is(err, service_cv.ErrCVVersionNotFound) {
+			http.Error(w, "CV version not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, service_cv.ErrCVVersionInUse) {
+			http.Error(w, "CV version is attached to an active application", http.StatusConflict)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.DeleteCVVersion failed to delete cv version", "error", err)
+		http.Error(w, "Failed to delete CV version", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // UploadResumeDatabase implements ServerInterface.
@@ -99,10 +279,17 @@ func (s *Server) UploadResumeDatabase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse multipart form with 100MB max memory
-	err := r.ParseMultipartForm(100 << 20)
+	r.Body = http.MaxBytesReader(w, r.Body, s.limits.MaxArchiveSize+multipartOverhead)
+
+	// Parse multipart form with 32MB max memory, larger archives are kept in temporary files
+	err := r.ParseMultipartForm(32 << 20)
 	if err != nil {
 		s.log.ErrorContext(ctx, "cvServer.UploadResumeDatabase failed to parse form", "error", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "Archive is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
 		http.Error(w, "Failed to parse form", http.StatusBadRequest)
 		return
 	}
@@ -116,11 +303,37 @@ func (s *Server) UploadResumeDatabase(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	// Process archive through service
+	// Queue archive files for processing
 	response, err := s.services.CV.UploadResumeDatabase(ctx, userGUID, handler)
 	if err != nil {
 		s.log.ErrorContext(ctx, "cvServer.UploadResumeDatabase failed to process archive", "error", err)
-		http.Error(w, "Failed to process archive", http.StatusInternalServerError)
+		writeUploadError(w, err, "Failed to process archive")
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetIngestionJob implements ServerInterface.
+func (s *Server) GetIngestionJob(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	response, err := s.services.CV.GetIngestionJob(ctx, userGUID, id)
+	if err != nil {
+		if errors.Is(err, service_cv.ErrIngestionJobNotFound) {
+			http.Error(w, "Ingestion job not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.GetIngestionJob failed to get job", "error", err)
+		http.Error(w, "Failed to get ingestion job", http.StatusInternalServerError)
 		return
 	}
 
@@ -130,6 +343,32 @@ func (s *Server) UploadResumeDatabase(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// RetryIngestionJob implements ServerInterface.
+func (s *Server) RetryIngestionJob(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	response, err := s.services.CV.RetryIngestionJob(ctx, userGUID, id)
+	if err != nil {
+		if errors.Is(err, service_cv.ErrIngestionJobNotFound) {
+			http.Error(w, "Ingestion job not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.RetryIngestionJob failed to retry job", "error", err)
+		http.Error(w, "Failed to retry ingestion job", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(response)
+}
+
 // GetResumeDatabase implements ServerInterface.
 func (s *Server) GetResumeDatabase(w http.ResponseWriter, r *http.Request, params GetResumeDatabaseParams) {
 	ctx := r.Context()
@@ -149,9 +388,18 @@ func (s *Server) GetResumeDatabase(w http.ResponseWriter, r *http.Request, param
 		offset = *params.Offset
 	}
 
+	filter := models.ResumeListFilter{
+		Tag:      params.Tag,
+		FolderID: params.FolderId,
+	}
+
 	// Get resumes from service
-	resumes, err := s.services.CV.GetResumeDatabase(ctx, userGUID, limit, offset)
+	resumes, err := s.services.CV.GetResumeDatabase(ctx, userGUID, filter, limit, offset)
 	if err != nil {
+		if errors.Is(err, service_cv.ErrFolderNotFound) {
+			http.Error(w, "Folder not found", http.StatusNotFound)
+			return
+		}
 		s.log.ErrorContext(ctx, "cvServer.GetResumeDatabase failed to get resumes", "error", err)
 		http.Error(w, "Failed to get resumes", http.StatusInternalServerError)
 		return
@@ -163,8 +411,355 @@ func (s *Server) GetResumeDatabase(w http.ResponseWriter, r *http.Request, param
 	json.NewEncoder(w).Encode(resumes)
 }
 
+// UpdateResume implements ServerInterface.
+func (s *Server) UpdateResume(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req UpdateResumeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resume, err := s.services.CV.UpdateResume(ctx, userGUID, id, models.ResumeUpdate{
+		Tags:  req.Tags,
+		Notes: req.Notes,
+	})
+	if err != nil {
+		if errors.Is(err, service_cv.ErrInvalidResumeUpdate) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service_cv.ErrResumeNotFound) {
+			http.Error(w, "Resume not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.UpdateResume failed to update resume", "error", err)
+		http.Error(w, "Failed to update resume", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resume)
+}
+
+// DeleteResume implements ServerInterface.
+func (s *Server) DeleteResume(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.services.CV.DeleteResume(ctx, userGUID, id); err != nil {
+		if errors.Is(err, service_cv.ErrResumeNotFound) {
+			http.Error(w, "Resume not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.DeleteResume failed to delete resume", "error", err)
+		http.Error(w, "Failed to delete resume", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetResumeFolders implements ServerInterface.
+func (s *Server) GetResumeFolders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	folders, err := s.services.CV.GetResumeFolders(ctx, userGUID)
+	if err != nil {
+		s.log.ErrorContext(ctx, "cvServer.GetResumeFolders failed to get folders", "error", err)
+		http.Error(w, "Failed to get folders", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(folders)
+}
+
+// CreateResumeFolder implements ServerInterface.
+func (s *Server) CreateResumeFolder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateResumeFolderJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	folder, err := s.services.CV.CreateResumeFolder(ctx, userGUID, req.Name)
+	if err != nil {
+		if errors.Is(err, service_cv.ErrInvalidFolderName) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service_cv.ErrFolderExists) {
+			http.Error(w, "Folder with this name already exists", http.StatusConflict)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.CreateResumeFolder failed to create folder", "error", err)
+		http.Error(w, "Failed to create folder", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(folder)
+}
+
+// RenameResumeFolder implements ServerInterface.
+func (s *Server) RenameResumeFolder(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req RenameResumeFolderJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	folder, err := s.services.CV.RenameResumeFolder(ctx, userGUID, id, req.Name)
+	if err != nil {
+		if errors.Is(err, service_cv.ErrInvalidFolderName) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service_cv.ErrFolderNotFound) {
+			http.Error(w, "Folder not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, service_cv.ErrFolderExists) {
+			http.Error(w, "Folder with this name already exists", http.StatusConflict)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.RenameResumeFolder failed to rename folder", "error", err)
+		http.Error(w, "Failed to rename folder", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(folder)
+}
+
+// DeleteResumeFolder implements ServerInterface.
+func (s *Server) DeleteResumeFolder(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.services.CV.DeleteResumeFolder(ctx, userGUID, id); err != nil {
+		if errors.Is(err, service_cv.ErrFolderNotFound) {
+			http.Error(w, "Folder not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.DeleteResumeFolder failed to delete folder", "error", err)
+		http.Error(w, "Failed to delete folder", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddResumeToFolder implements ServerInterface.
+func (s *Server) AddResumeToFolder(w http.ResponseWriter, r *http.Request, id string, resumeId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.services.CV.AddResumeToFolder(ctx, userGUID, id, resumeId); err != nil {
+		if errors.Is(err, service_cv.ErrFolderNotFound) {
+			http.Error(w, "Folder not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, service_cv.ErrResumeNotFound) {
+			http.Error(w, "Resume not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.AddResumeToFolder failed to add resume", "error", err)
+		http.Error(w, "Failed to add resume to folder", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveResumeFromFolder implements ServerInterface.
+func (s *Server) RemoveResumeFromFolder(w http.ResponseWriter, r *http.Request, id string, resumeId string) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.services.CV.RemoveResumeFromFolder(ctx, userGUID, id, resumeId); err != nil {
+		if errors.Is(err, service_cv.ErrFolderNotFound) {
+			http.Error(w, "Folder not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, service_cv.ErrResumeNotFound) {
+			http.Error(w, "Resume not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.RemoveResumeFromFolder failed to remove resume", "error", err)
+		http.Error(w, "Failed to remove resume from folder", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SearchResumeDatabase implements ServerInterface.
+func (s *Server) SearchResumeDatabase(w http.ResponseWriter, r *http.Request, params SearchResumeDatabaseParams) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 20
+	offset := 0
+
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	filter := models.ResumeSearchFilter{
+		MinAge:        params.MinAge,
+		MaxAge:        params.MaxAge,
+		MinExperience: params.MinExperience,
+		MaxExperience: params.MaxExperience,
+	}
+	if params.Q != nil {
+		filter.Query = *params.Q
+	}
+
+	results, err := s.services.CV.SearchResumeDatabase(ctx, userGUID, filter, limit, offset)
+	if err != nil {
+		if errors.Is(err, service_cv.ErrInvalidSearchFilter) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.SearchResumeDatabase failed to search resumes", "error", err)
+		http.Error(w, "Failed to search resumes", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(results)
+}
+
+// GetCandidates implements ServerInterface.
+func (s *Server) GetCandidates(w http.ResponseWriter, r *http.Request, params GetCandidatesParams) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 20
+	offset := 0
+
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+
+	candidates, err := s.services.CV.GetCandidates(ctx, userGUID, limit, offset)
+	if err != nil {
+		s.log.ErrorContext(ctx, "cvServer.GetCandidates failed to get candidates", "error", err)
+		http.Error(w, "Failed to get candidates", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(candidates)
+}
+
+// MergeCandidates implements ServerInterface.
+func (s *Server) MergeCandidates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req MergeCandidatesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	candidate, err := s.services.CV.MergeCandidates(ctx, userGUID, req.TargetId, req.SourceIds)
+	if err != nil {
+		if errors.Is(err, service_cv.ErrInvalidMerge) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, service_cv.ErrCandidateNotFound) {
+			http.Error(w, "Candidate not found", http.StatusNotFound)
+			return
+		}
+		s.log.ErrorContext(ctx, "cvServer.MergeCandidates failed to merge candidates", "error", err)
+		http.Error(w, "Failed to merge candidates", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(candidate)
+}
+
 // MatchCandidatesFromDatabase implements ServerInterface.
-func (s *Server) MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Request, jobId string) {
+func (s *Server) MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Request, jobId string, params MatchCandidatesFromDatabaseParams) {
 	ctx := r.Context()
 	userGUID := ctx.Value(mw.UserIDKey).(string)
 	if userGUID == "" {
@@ -172,21 +767,46 @@ func (s *Server) MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	limit := 5
+	refresh := false
+
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Refresh != nil {
+		refresh = *params.Refresh
+	}
+	if limit <= 0 {
+		http.Error(w, "Limit must be positive", http.StatusBadRequest)
+		return
+	}
+
 	// Match candidates through service
-	response, err := s.services.CV.MatchCandidatesFromDatabase(ctx, userGUID, jobId)
+	response, err := s.services.CV.MatchCandidatesFromDatabase(ctx, userGUID, jobId, limit, refresh)
 	if err != nil {
 		s.log.ErrorContext(ctx, "cvServer.MatchCandidatesFromDatabase failed to match candidates", "error", err)
 
 		// Check for specific error types
-		if err.Error() == "access denied: not job author" {
+		if errors.Is(err, service_cv.ErrNotJobAuthor) {
 			http.Error(w, "Forbidden: you are not the author of this job", http.StatusForbidden)
 			return
 		}
-		if err.Error() == "job not found" {
+		if errors.Is(err, service_cv.ErrJobNotFound) {
 			http.Error(w, "Job not found", http.StatusNotFound)
 			return
 		}
 
+		var formatErr *deepseek.FormatError
+		if errors.As(err, &formatErr) {
+			http.Error(w, "AI service returned an invalid response", http.StatusBadGateway)
+			return
+		}
+		var transportErr *deepseek.TransportError
+		if errors.As(err, &transportErr) {
+			http.Error(w, "AI service is unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
 		http.Error(w, "Failed to match candidates", http.StatusInternalServerError)
 		return
 	}
@@ -197,10 +817,86 @@ func (s *Server) MatchCandidatesFromDatabase(w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(response)
 }
 
+// MatchApplicants implements ServerInterface.
+func (s *Server) MatchApplicants(w http.ResponseWriter, r *http.Request, jobId string, params MatchApplicantsParams) {
+	ctx := r.Context()
+	userGUID := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 5
+	includeProfiles := false
+	refresh := false
+
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.IncludeProfiles != nil {
+		includeProfiles = *params.IncludeProfiles
+	}
+	if params.Refresh != nil {
+		refresh = *params.Refresh
+	}
+	if limit <= 0 {
+		http.Error(w, "Limit must be positive", http.StatusBadRequest)
+		return
+	}
+
+	response, err := s.services.CV.MatchApplicants(ctx, userGUID, jobId, limit, includeProfiles, refresh)
+	if err != nil {
+		s.log.ErrorContext(ctx, "cvServer.MatchApplicants failed to match applicants", "error", err)
+
+		if errors.Is(err, service_cv.ErrNotJobAuthor) {
+			http.Error(w, "Forbidden: you are not the author of this job", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, service_cv.ErrJobNotFound) {
+			http.Error(w, "Job not found", http.StatusNotFound)
+			return
+		}
+
+		var formatErr *deepseek.FormatError
+		if errors.As(err, &formatErr) {
+			http.Error(w, "AI service returned an invalid response", http.StatusBadGateway)
+			return
+		}
+		var transportErr *deepseek.TransportError
+		if errors.As(err, &transportErr) {
+			http.Error(w, "AI service is unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		http.Error(w, "Failed to match applicants", http.StatusInternalServerError)
+		return
+	}
+
+	// Return response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
 func NewServer(services *service.Services, log *slog.Logger, cfg *config.Config) ServerInterface {
 	return &Server{
 		services: services,
 		log:      log,
 		cfg:      cfg,
+		limits:   service_cv.NewUploadLimits(cfg),
+	}
+}
+
+// writeUploadError отвечает клиенту на ошибку проверки загружаемого файла
+func writeUploadError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service_cv.ErrFileTooLarge), errors.Is(err, service_cv.ErrArchiveRejected):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, service_cv.ErrInvalidFile):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service_cv.ErrFileInfected):
+		http.Error(w, "File rejected by malware scanner", http.StatusUnprocessableEntity)
+	default:
+		http.Error(w, message, http.StatusInternalServerError)
 	}
 }
@@ -45,6 +45,12 @@ const (
 	JobStatusPaused JobStatus = "paused"
 )
 
+// Defines values for JobApplicationSource.
+const (
+	Database JobApplicationSource = "database"
+	Platform JobApplicationSource = "platform"
+)
+
 // Defines values for JobApplicationStatus.
 const (
 	JobApplicationStatusAccepted JobApplicationStatus = "accepted"
@@ -85,6 +91,15 @@ type ApplicantProfile struct {
 	Id          string  `json:"id"`
 }
 
+// ApplicationCV CV version the candidate applied with
+type ApplicationCV struct {
+	Id string `json:"id"`
+
+	// Label Version label set by the candidate, may be empty
+	Label string `json:"label"`
+	Link  string `json:"link"`
+}
+
 // ApplicationStatus defines model for ApplicationStatus.
 type ApplicationStatus struct {
 	ApplicationId *string                  `json:"application_id"`
@@ -95,6 +110,18 @@ type ApplicationStatus struct {
 // ApplicationStatusStatus defines model for ApplicationStatus.Status.
 type ApplicationStatusStatus string
 
+// ApplyToJobRequest defines model for ApplyToJobRequest.
+type ApplyToJobRequest struct {
+	// CvId CV version to attach; the primary version is used if omitted
+	CvId *string `json:"cv_id,omitempty"`
+}
+
+// ClaimApplicationRequest defines model for ClaimApplicationRequest.
+type ClaimApplicationRequest struct {
+	// Token Token from the invitation link
+	Token string `json:"token"`
+}
+
 // CreateJobRequest defines model for CreateJobRequest.
 type CreateJobRequest struct {
 	CompanyName    string                         `json:"company_name"`
@@ -135,14 +162,23 @@ type JobStatus string
 
 // JobApplication defines model for JobApplication.
 type JobApplication struct {
-	ApplicantId      string               `json:"applicant_id"`
-	ApplicantProfile ApplicantProfile     `json:"applicant_profile"`
-	AppliedAt        time.Time            `json:"applied_at"`
-	Id               string               `json:"id"`
-	JobId            string               `json:"job_id"`
+	// ApplicantId Null for a candidate from the resume database who has not accepted the invitation yet
+	ApplicantId      *string           `json:"applicant_id"`
+	ApplicantProfile *ApplicantProfile `json:"applicant_profile"`
+	AppliedAt        time.Time         `json:"applied_at"`
+	Cv               *ApplicationCV    `json:"cv,omitempty"`
+	Id               string            `json:"id"`
+	JobId            string            `json:"job_id"`
+
+	// Source platform - the user applied, database - HR added a candidate from the resume database
+	Source           JobApplicationSource `json:"source"`
+	SourcedCandidate *SourcedCandidate    `json:"sourced_candidate,omitempty"`
 	Status           JobApplicationStatus `json:"status"`
 }
 
+// JobApplicationSource platform - the user applied, database - HR added a candidate from the resume database
+type JobApplicationSource string
+
 // JobApplicationStatus defines model for JobApplication.Status.
 type JobApplicationStatus string
 
@@ -161,6 +197,78 @@ type JobWithApplications struct {
 	Job               Job `json:"job"`
 }
 
+// MatchScoreBreakdown Per-criterion scores from 1 to 100
+type MatchScoreBreakdown struct {
+	Experience int `json:"experience"`
+
+	// Location Location fit; null if the candidate's location is unknown
+	Location  *int `json:"location"`
+	Seniority int  `json:"seniority"`
+	Skills    int  `json:"skills"`
+}
+
+// RecommendedJob defines model for RecommendedJob.
+type RecommendedJob struct {
+	Job Job `json:"job"`
+
+	// MatchScore Match score from 1 to 100
+	MatchScore int `json:"match_score"`
+
+	// Reasoning Why the job was recommended
+	Reasoning string              `json:"reasoning"`
+	Scores    MatchScoreBreakdown `json:"scores"`
+}
+
+// RecommendedJobsResponse defines model for RecommendedJobsResponse.
+type RecommendedJobsResponse struct {
+	// Cached The result was reused: the profile and the shortlisted jobs have not changed
+	Cached bool             `json:"cached"`
+	Jobs   []RecommendedJob `json:"jobs"`
+
+	// MatchedAt When the jobs were scored
+	MatchedAt time.Time `json:"matched_at"`
+
+	// ShortlistSize Number of jobs shortlisted by search and scored by the model
+	ShortlistSize int `json:"shortlist_size"`
+}
+
+// SourceCandidateRequest defines model for SourceCandidateRequest.
+type SourceCandidateRequest struct {
+	// Message Personal message added to the invitation email
+	Message *string `json:"message"`
+
+	// ResumeId Resume from the resume database of the job author
+	ResumeId string `json:"resume_id"`
+
+	// SendEmail Send the candidate an invitation email with the claim link
+	SendEmail *bool `json:"send_email,omitempty"`
+}
+
+// SourcedApplicationResponse defines model for SourcedApplicationResponse.
+type SourcedApplicationResponse struct {
+	Application JobApplication `json:"application"`
+
+	// ClaimUrl Link for the candidate to claim the application. It is shown only once
+	ClaimUrl string `json:"claim_url"`
+
+	// EmailSent The invitation email was sent
+	EmailSent bool `json:"email_sent"`
+}
+
+// SourcedCandidate Candidate from the resume database added to the job by HR
+type SourcedCandidate struct {
+	// ClaimedAt When the candidate accepted the invitation
+	ClaimedAt *time.Time `json:"claimed_at"`
+	Email     *string    `json:"email"`
+
+	// InvitedAt When the invitation email was sent
+	InvitedAt *time.Time `json:"invited_at"`
+	Name      string     `json:"name"`
+
+	// ResumeId Null if the resume was deleted from the database
+	ResumeId *string `json:"resume_id"`
+}
+
 // UpdateApplicationStatusRequest defines model for UpdateApplicationStatusRequest.
 type UpdateApplicationStatusRequest struct {
 	Status UpdateApplicationStatusRequestStatus `json:"status"`
@@ -201,6 +309,15 @@ type GetMyJobsParams struct {
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// GetRecommendedJobsParams defines parameters for GetRecommendedJobs.
+type GetRecommendedJobsParams struct {
+	// Limit Number of best jobs in the response
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Refresh Score jobs again instead of reusing the saved recommendations
+	Refresh *bool `form:"refresh,omitempty" json:"refresh,omitempty"`
+}
+
 // GetJobApplicationsParams defines parameters for GetJobApplications.
 type GetJobApplicationsParams struct {
 	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
@@ -210,12 +327,21 @@ type GetJobApplicationsParams struct {
 // CreateJobJSONRequestBody defines body for CreateJob for application/json ContentType.
 type CreateJobJSONRequestBody = CreateJobRequest
 
+// ClaimSourcedApplicationJSONRequestBody defines body for ClaimSourcedApplication for application/json ContentType.
+type ClaimSourcedApplicationJSONRequestBody = ClaimApplicationRequest
+
 // UpdateJobJSONRequestBody defines body for UpdateJob for application/json ContentType.
 type UpdateJobJSONRequestBody = UpdateJobRequest
 
 // UpdateJobApplicationStatusJSONRequestBody defines body for UpdateJobApplicationStatus for application/json ContentType.
 type UpdateJobApplicationStatusJSONRequestBody = UpdateApplicationStatusRequest
 
+// ApplyToJobJSONRequestBody defines body for ApplyToJob for application/json ContentType.
+type ApplyToJobJSONRequestBody = ApplyToJobRequest
+
+// SourceCandidateJSONRequestBody defines body for SourceCandidate for application/json ContentType.
+type SourceCandidateJSONRequestBody = SourceCandidateRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Get all jobs with search
@@ -224,9 +350,15 @@ type ServerInterface interface {
 	// Create new job
 	// (POST /api/v1/job)
 	CreateJob(w http.ResponseWriter, r *http.Request)
+	// Claim an application from an invitation
+	// (POST /api/v1/job/applications/claim)
+	ClaimSourcedApplication(w http.ResponseWriter, r *http.Request)
 	// Get my published jobs
 	// (GET /api/v1/job/my)
 	GetMyJobs(w http.ResponseWriter, r *http.Request, params GetMyJobsParams)
+	// Get jobs recommended for me
+	// (GET /api/v1/job/recommended)
+	GetRecommendedJobs(w http.ResponseWriter, r *http.Request, params GetRecommendedJobsParams)
 	// Delete job
 	// (DELETE /api/v1/job/{job_id})
 	DeleteJob(w http.ResponseWriter, r *http.Request, jobId string)
@@ -248,6 +380,9 @@ type ServerInterface interface {
 	// Apply to job
 	// (POST /api/v1/job/{job_id}/apply)
 	ApplyToJob(w http.ResponseWriter, r *http.Request, jobId string)
+	// Add a candidate from the resume database to the job (for job author)
+	// (POST /api/v1/job/{job_id}/sourced)
+	SourceCandidate(w http.ResponseWriter, r *http.Request, jobId string)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -266,12 +401,24 @@ func (_ Unimplemented) CreateJob(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Claim an application from an invitation
+// (POST /api/v1/job/applications/claim)
+func (_ Unimplemented) ClaimSourcedApplication(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get my published jobs
 // (GET /api/v1/job/my)
 func (_ Unimplemented) GetMyJobs(w http.ResponseWriter, r *http.Request, params GetMyJobsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get jobs recommended for me
+// (GET /api/v1/job/recommended)
+func (_ Unimplemented) GetRecommendedJobs(w http.ResponseWriter, r *http.Request, params GetRecommendedJobsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Delete job
 // (DELETE /api/v1/job/{job_id})
 func (_ Unimplemented) DeleteJob(w http.ResponseWriter, r *http.Request, jobId string) {
@@ -314,6 +461,12 @@ func (_ Unimplemented) ApplyToJob(w http.ResponseWriter, r *http.Request, jobId
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Add a candidate from the resume database to the job (for job author)
+// (POST /api/v1/job/{job_id}/sourced)
+func (_ Unimplemented) SourceCandidate(w http.ResponseWriter, r *http.Request, jobId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -380,6 +533,20 @@ func (siw *ServerInterfaceWrapper) CreateJob(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// ClaimSourcedApplication operation middleware
+func (siw *ServerInterfaceWrapper) ClaimSourcedApplication(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClaimSourcedApplication(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetMyJobs operation middleware
 func (siw *ServerInterfaceWrapper) GetMyJobs(w http.ResponseWriter, r *http.Request) {
 
@@ -415,6 +582,41 @@ func (siw *ServerInterfaceWrapper) GetMyJobs(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// GetRecommendedJobs operation middleware
+func (siw *ServerInterfaceWrapper) GetRecommendedJobs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRecommendedJobsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "refresh" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "refresh", r.URL.Query(), &params.Refresh)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refresh", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRecommendedJobs(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // DeleteJob operation middleware
 func (siw *ServerInterfaceWrapper) DeleteJob(w http.ResponseWriter, r *http.Request) {
 
@@ -618,6 +820,31 @@ func (siw *ServerInterfaceWrapper) ApplyToJob(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
+// SourceCandidate operation middleware
+func (siw *ServerInterfaceWrapper) SourceCandidate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "job_id" -------------
+	var jobId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "job_id", chi.URLParam(r, "job_id"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "job_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SourceCandidate(w, r, jobId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -737,9 +964,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/job", wrapper.CreateJob)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/job/applications/claim", wrapper.ClaimSourcedApplication)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/job/my", wrapper.GetMyJobs)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/job/recommended", wrapper.GetRecommendedJobs)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/job/{job_id}", wrapper.DeleteJob)
 	})
@@ -761,6 +994,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/job/{job_id}/apply", wrapper.ApplyToJob)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/job/{job_id}/sourced", wrapper.SourceCandidate)
+	})
 
 	return r
 }
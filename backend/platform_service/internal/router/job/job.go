@@ -4,7 +4,11 @@ import (
 	"PlatformService/internal/models"
 	"PlatformService/internal/router/mw"
 	"PlatformService/internal/service"
+	service_cv "PlatformService/internal/service/cv"
+	"PlatformService/internal/service/deepseek"
 	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 )
@@ -90,6 +94,55 @@ func (s *Server) GetMyJobs(w http.ResponseWriter, r *http.Request, params GetMyJ
 	json.NewEncoder(w).Encode(jobs)
 }
 
+func (s *Server) GetRecommendedJobs(w http.ResponseWriter, r *http.Request, params GetRecommendedJobsParams) {
+	userGUID := r.Context().Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 10
+	refresh := false
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Refresh != nil {
+		refresh = *params.Refresh
+	}
+	if limit <= 0 {
+		http.Error(w, "Limit must be positive", http.StatusBadRequest)
+		return
+	}
+
+	response, err := s.services.CV.RecommendJobs(r.Context(), userGUID, limit, refresh)
+	if err != nil {
+		s.log.ErrorContext(r.Context(), "Failed to get recommended jobs", "error", err)
+
+		if errors.Is(err, service_cv.ErrProfileNotFound) {
+			http.Error(w, "Profile not found", http.StatusNotFound)
+			return
+		}
+
+		var formatErr *deepseek.FormatError
+		if errors.As(err, &formatErr) {
+			http.Error(w, "AI service returned an invalid response", http.StatusBadGateway)
+			return
+		}
+		var transportErr *deepseek.TransportError
+		if errors.As(err, &transportErr) {
+			http.Error(w, "AI service is unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
 func (s *Server) GetJobById(w http.ResponseWriter, r *http.Request, jobId string) {
 	userGUID := r.Context().Value(mw.UserIDKey).(string)
 	if userGUID == "" {
@@ -166,12 +219,21 @@ func (s *Server) ApplyToJob(w http.ResponseWriter, r *http.Request, jobId string
 		return
 	}
 
-	err := s.services.Job.ApplyToJob(r.Context(), jobId, userGUID)
+	// Тело запроса необязательно: без него прикладывается основная версия CV
+	var req models.ApplyToJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err := s.services.Job.ApplyToJob(r.Context(), jobId, userGUID, req.CVID)
 	if err != nil {
 		s.log.ErrorContext(r.Context(), "Failed to apply to job", "error", err, "job_id", jobId)
 		switch err.Error() {
 		case "job not found":
 			http.Error(w, "Job not found", http.StatusNotFound)
+		case "cv not found":
+			http.Error(w, "CV version not found", http.StatusBadRequest)
 		case "job is not active":
 			http.Error(w, "Job is not active", http.StatusBadRequest)
 		case "already applied to this job":
@@ -275,6 +337,84 @@ func (s *Server) UpdateJobApplicationStatus(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(application)
 }
 
+func (s *Server) SourceCandidate(w http.ResponseWriter, r *http.Request, jobId string) {
+	userGUID := r.Context().Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.SourceCandidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	application, err := s.services.Job.SourceCandidate(r.Context(), jobId, userGUID, &req)
+	if err != nil {
+		s.log.ErrorContext(r.Context(), "Failed to source candidate", "error", err, "job_id", jobId, "resume_id", req.ResumeID)
+		switch err.Error() {
+		case "job not found":
+			http.Error(w, "Job not found", http.StatusNotFound)
+		case "resume not found":
+			http.Error(w, "Resume not found", http.StatusNotFound)
+		case "access denied: not job author":
+			http.Error(w, "Access denied", http.StatusForbidden)
+		case "job is not active":
+			http.Error(w, "Job is not active", http.StatusBadRequest)
+		case "candidate has no email":
+			http.Error(w, "Candidate has no email", http.StatusBadRequest)
+		case "email is not configured":
+			http.Error(w, "Email is not configured", http.StatusBadRequest)
+		case "message is too long":
+			http.Error(w, "Message is too long", http.StatusBadRequest)
+		case "candidate already added to this job":
+			http.Error(w, "Candidate already added to this job", http.StatusConflict)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(application)
+}
+
+func (s *Server) ClaimSourcedApplication(w http.ResponseWriter, r *http.Request) {
+	userGUID := r.Context().Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ClaimApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := s.services.Job.ClaimSourcedApplication(r.Context(), userGUID, req.Token)
+	if err != nil {
+		s.log.ErrorContext(r.Context(), "Failed to claim application", "error", err)
+		switch err.Error() {
+		case "invitation not found":
+			http.Error(w, "Invitation not found or expired", http.StatusNotFound)
+		case "already applied to this job":
+			http.Error(w, "Already applied to this job", http.StatusConflict)
+		case "cannot apply to your own job":
+			http.Error(w, "Cannot apply to your own job", http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
+
 func NewServer(services *service.Services, log *slog.Logger) ServerInterface {
 	return &Server{services: services, log: log}
 }
@@ -0,0 +1,42 @@
+package media
+
+import (
+	"PlatformService/internal/service"
+	service_media "PlatformService/internal/service/media"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+type Server struct {
+	services *service.Services
+	log      *slog.Logger
+}
+
+// GetImage implements ServerInterface.
+func (s *Server) GetImage(w http.ResponseWriter, r *http.Request, filename string) {
+	ctx := r.Context()
+
+	object, info, err := s.services.Media.GetImage(ctx, filename)
+	if errors.Is(err, service_media.ErrImageNotFound) {
+		http.Error(w, "Image not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.log.ErrorContext(ctx, "mediaServer.GetImage failed to get image", "error", err)
+		http.Error(w, "Failed to get image", http.StatusInternalServerError)
+		return
+	}
+	defer object.Close()
+
+	// Имена изображений уникальны, новое изображение всегда получает новую ссылку
+	w.Header().Set("Content-Type", info.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+
+	http.ServeContent(w, r, "", info.LastModified, object)
+}
+
+func NewServer(services *service.Services, log *slog.Logger) ServerInterface {
+	return &Server{services: services, log: log}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
@@ -23,7 +24,8 @@ type ApiGetExperience = []Experience
 // ApiGetProfile defines model for ApiGetProfile.
 type ApiGetProfile struct {
 	// Avatar Ссылка на аватар пользователя
-	Avatar *string `json:"avatar,omitempty"`
+	Avatar           *string          `json:"avatar,omitempty"`
+	AvatarThumbnails *ImageThumbnails `json:"avatar_thumbnails,omitempty"`
 
 	// Birthdate Дата рождения пользователя
 	Birthdate string `json:"birthdate"`
@@ -50,6 +52,9 @@ type ApiGetProfile struct {
 	// IsHr Является ли пользователь HR'ом
 	IsHr bool `json:"is_hr"`
 
+	// OpenToOffers Профиль участвует в подборе кандидатов на вакансии, на которые пользователь не откликался
+	OpenToOffers *bool `json:"open_to_offers,omitempty"`
+
 	// Phone Номер телефона
 	Phone     string    `json:"phone"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -80,6 +85,9 @@ type ApiUpdateProfile struct {
 	// Gender Пол пользователя
 	Gender *string `json:"gender,omitempty"`
 
+	// OpenToOffers Профиль участвует в подборе кандидатов на вакансии, на которые пользователь не откликался
+	OpenToOffers *bool `json:"open_to_offers,omitempty"`
+
 	// Phone Номер телефона
 	Phone *string `json:"phone,omitempty"`
 }
@@ -105,6 +113,18 @@ type Experience struct {
 	StartDate string `json:"start_date"`
 }
 
+// ImageThumbnails defines model for ImageThumbnails.
+type ImageThumbnails struct {
+	// Large Ссылка на копию 512x512
+	Large string `json:"large"`
+
+	// Medium Ссылка на копию 256x256
+	Medium string `json:"medium"`
+
+	// Small Ссылка на копию 64x64
+	Small string `json:"small"`
+}
+
 // ShortProfile defines model for ShortProfile.
 type ShortProfile struct {
 	// CompanyName Название компании в которой работает пользователь
@@ -132,9 +152,18 @@ type SearchProfileByDescriptionParams struct {
 	Description string `form:"description" json:"description"`
 }
 
+// UploadOwnAvatarMultipartBody defines parameters for UploadOwnAvatar.
+type UploadOwnAvatarMultipartBody struct {
+	// File Изображение JPEG, PNG, GIF или WebP
+	File *openapi_types.File `json:"file,omitempty"`
+}
+
 // StoreOwnProfileJSONRequestBody defines body for StoreOwnProfile for application/json ContentType.
 type StoreOwnProfileJSONRequestBody = ApiUpdateProfile
 
+// UploadOwnAvatarMultipartRequestBody defines body for UploadOwnAvatar for multipart/form-data ContentType.
+type UploadOwnAvatarMultipartRequestBody UploadOwnAvatarMultipartBody
+
 // StoreOwnExperienceJSONRequestBody defines body for StoreOwnExperience for application/json ContentType.
 type StoreOwnExperienceJSONRequestBody = Experience
 
@@ -149,6 +178,9 @@ type ServerInterface interface {
 	// Изменить данных профиля
 	// (PUT /api/v1/profile)
 	StoreOwnProfile(w http.ResponseWriter, r *http.Request)
+	// Загрузить аватар
+	// (POST /api/v1/profile/avatar)
+	UploadOwnAvatar(w http.ResponseWriter, r *http.Request)
 	// Удалить опыт работы
 	// (DELETE /api/v1/profile/experience)
 	DeleteOwnExperience(w http.ResponseWriter, r *http.Request, params DeleteOwnExperienceParams)
@@ -191,6 +223,12 @@ func (_ Unimplemented) StoreOwnProfile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Загрузить аватар
+// (POST /api/v1/profile/avatar)
+func (_ Unimplemented) UploadOwnAvatar(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Удалить опыт работы
 // (DELETE /api/v1/profile/experience)
 func (_ Unimplemented) DeleteOwnExperience(w http.ResponseWriter, r *http.Request, params DeleteOwnExperienceParams) {
@@ -296,6 +334,26 @@ func (siw *ServerInterfaceWrapper) StoreOwnProfile(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
+// UploadOwnAvatar operation middleware
+func (siw *ServerInterfaceWrapper) UploadOwnAvatar(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadOwnAvatar(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // DeleteOwnExperience operation middleware
 func (siw *ServerInterfaceWrapper) DeleteOwnExperience(w http.ResponseWriter, r *http.Request) {
 
@@ -600,6 +658,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/profile", wrapper.StoreOwnProfile)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/profile/avatar", wrapper.UploadOwnAvatar)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/profile/experience", wrapper.DeleteOwnExperience)
 	})
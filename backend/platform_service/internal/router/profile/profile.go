@@ -4,6 +4,7 @@ import (
 	"PlatformService/internal/models"
 	"PlatformService/internal/router/mw"
 	"PlatformService/internal/service"
+	"PlatformService/internal/service/media"
 	"encoding/json"
 	"errors"
 	"github.com/jackc/pgx/v4"
@@ -11,6 +12,9 @@ import (
 	"net/http"
 )
 
+// Запас на заголовки и границы multipart-формы сверх размера файла
+const multipartOverhead = 1 << 20
+
 type Server struct {
 	services *service.Services
 	log      *slog.Logger
@@ -148,6 +152,58 @@ func (s *Server) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// UploadOwnAvatar implements ServerInterface.
+func (s *Server) UploadOwnAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userGUID, _ := ctx.Value(mw.UserIDKey).(string)
+	if userGUID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Ограничиваем тело запроса: запас на заголовки multipart
+	maxFileSize := s.services.Media.MaxFileSize()
+	r.Body = http.MaxBytesReader(w, r.Body, maxFileSize+multipartOverhead)
+	if err := r.ParseMultipartForm(maxFileSize); err != nil {
+		s.log.ErrorContext(ctx, "profileServer.UploadOwnAvatar failed to parse form", "error", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "Image is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	file, handler, err := r.FormFile("file")
+	if err != nil {
+		s.log.ErrorContext(ctx, "profileServer.UploadOwnAvatar failed to retrieve file", "error", err)
+		http.Error(w, "Error retrieving file", http.StatusBadRequest)
+		return
+	}
+	file.Close()
+
+	profile, err := s.services.Profile.UpdateAvatar(ctx, userGUID, handler)
+	if err != nil {
+		s.log.ErrorContext(ctx, "profileServer.UploadOwnAvatar failed to update avatar", "error", err)
+		switch {
+		case errors.Is(err, media.ErrImageTooLarge):
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		case errors.Is(err, media.ErrInvalidImage):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, pgx.ErrNoRows):
+			http.Error(w, "Profile not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to update avatar", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(profile)
+}
+
 // DeleteProfile implements ServerInterface.
 func (s *Server) DeleteProfile(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
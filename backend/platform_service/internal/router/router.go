@@ -8,6 +8,7 @@ import (
 	"PlatformService/internal/router/company"
 	"PlatformService/internal/router/cv"
 	"PlatformService/internal/router/job"
+	"PlatformService/internal/router/media"
 	"PlatformService/internal/router/mw"
 	"PlatformService/internal/router/profile"
 	"PlatformService/internal/service"
@@ -27,6 +28,7 @@ type servers struct {
 	chat    chat.ServerInterface
 	call    call.ServerInterface
 	job     job.ServerInterface
+	media   media.ServerInterface
 }
 
 type Handler struct {
@@ -47,6 +49,7 @@ func NewHandler(cfg *config.Config, log *slog.Logger, services *service.Services
 			chat:    chat.NewServer(services, log, cfg),
 			call:    call.NewServer(services, log, cfg),
 			job:     job.NewServer(services, log),
+			media:   media.NewServer(services, log),
 		},
 	}
 }
@@ -130,6 +133,15 @@ func (h *Handler) Init() *chi.Mux {
 		},
 	})
 
+	// Аватары и логотипы открываются в <img> без заголовка авторизации
+	media.HandlerWithOptions(h.servers.media, media.ChiServerOptions{
+		BaseRouter: router,
+		Middlewares: []media.MiddlewareFunc{
+			slogchiMW,
+			CORSMiddleware,
+		},
+	})
+
 	return router
 }
 
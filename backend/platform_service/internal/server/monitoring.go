@@ -79,7 +79,7 @@ func NewMonitoringServer(log *slog.Logger, cfg *config.Config) Server {
 
 func StartMonitoringPgxpool(ctx context.Context, db *pgxpool.Pool, cfg *config.Config) error {
 	go func() {
-		ticker := time.NewTicker(time.Duration(cfg.DatasyncParallelCnt) * time.Second)
+		ticker := time.NewTicker(time.Duration(max(1, cfg.VictoriaMetricsPushInterval)) * time.Second)
 		for {
 			select {
 			case <-ctx.Done():
@@ -0,0 +1,371 @@
+package chat
+
+import (
+	"PlatformService/internal/models"
+	"PlatformService/internal/repository/chat"
+	"PlatformService/internal/service/storage"
+	"PlatformService/internal/service/upload"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	defaultMaxAttachmentSize = 20 << 20
+	maxMessageAttachments    = 10
+	maxFilenameRunes         = 255
+
+	// Объекты вложений: chat/<chat_id>/<attachment_id>.<ext>
+	attachmentsPrefix = "chat/"
+	chatsPath         = "/api/v1/chat/"
+	cvFilesPath       = "/api/v1/cv/"
+)
+
+var (
+	ErrInvalidMessage     = errors.New("invalid message")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrAttachmentInfected = upload.ErrInfected
+)
+
+// Изображения, для которых строится превью. Тип определяется по содержимому
+var previewContentTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// Расширение имени объекта: остальные символы из имени файла не переносятся
+var objectExtPattern = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+// UploadAttachment сохраняет файл, который участник приложит к следующему
+// сообщению чата. Пока сообщение не отправлено, вложение видит только автор
+func (s *service) UploadAttachment(ctx context.Context, chatID, userID string, header *multipart.FileHeader) (*models.Attachment, error) {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if header.Size > s.maxAttachmentSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.checkChatMember(ctx, tx, chatGUID, userGUID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	head, err := upload.CheckContent(ctx, s.scannerService, file, nil)
+	if err != nil {
+		return nil, err
+	}
+	detectedType := http.DetectContentType(head)
+
+	filename := normalizeFilename(header.Filename)
+	id := uuid.New()
+	ext := strings.ToLower(path.Ext(filename))
+	if !objectExtPattern.MatchString(ext) {
+		ext = ""
+	}
+
+	params := chat.CreateAttachmentParams{
+		ChatID:      chatGUID,
+		UserID:      userGUID,
+		Kind:        models.AttachmentKindFile,
+		ObjectName:  fmt.Sprintf("%s%s/%s%s", attachmentsPrefix, chatGUID, id, ext),
+		Filename:    filename,
+		ContentType: storage.ContentType(filename),
+		Size:        header.Size,
+	}
+
+	// Превью строится по содержимому, а не по расширению; файл, который не
+	// удалось разобрать, сохраняется как обычный
+	var preview []byte
+	if slices.Contains(previewContentTypes, detectedType) {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to rewind file: %w", err)
+		}
+		content, format, err := s.mediaService.MakePreview(file)
+		if err == nil {
+			preview = content
+			params.Kind = models.AttachmentKindImage
+			params.ContentType = detectedType
+			params.PreviewObjectName = sql.NullString{
+				String: fmt.Sprintf("%s%s/%s_preview.%s", attachmentsPrefix, chatGUID, id, format),
+				Valid:  true,
+			}
+		} else {
+			log.Printf("Failed to make preview for attachment %s: %v", filename, err)
+		}
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind file: %w", err)
+	}
+	if err := s.storageService.Put(ctx, params.ObjectName, file, header.Size, params.ContentType); err != nil {
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+	objects := []string{params.ObjectName}
+	if preview != nil {
+		err := s.storageService.Put(ctx, params.PreviewObjectName.String, bytes.NewReader(preview), int64(len(preview)), storage.ContentType(params.PreviewObjectName.String))
+		if err != nil {
+			s.deleteObjects(ctx, objects)
+			return nil, fmt.Errorf("failed to save attachment preview: %w", err)
+		}
+		objects = append(objects, params.PreviewObjectName.String)
+	}
+
+	var result chat.ChatAttachment
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		result, err = s.repo.Chat.CreateAttachment(ctx, tx, params)
+		return err
+	})
+	if err != nil {
+		s.deleteObjects(ctx, objects)
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	attachment := s.mapAttachment(result)
+	return &attachment, nil
+}
+
+// GetAttachment проверяет, что пользователь может скачать вложение:
+// участник чата, а для неотправленного вложения - его автор
+func (s *service) GetAttachment(ctx context.Context, chatID, userID, attachmentID string) (*models.Attachment, error) {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	attachmentGUID, err := uuid.Parse(attachmentID)
+	if err != nil {
+		return nil, ErrAttachmentNotFound
+	}
+
+	var result chat.ChatAttachment
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = s.repo.Chat.GetAttachment(ctx, tx, chat.GetAttachmentParams{
+			ID:     attachmentGUID,
+			ChatID: chatGUID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && !result.MessageID.Valid && result.UserID != userGUID) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := s.mapAttachment(result)
+	return &attachment, nil
+}
+
+// DeleteAttachment удаляет вложение, которое автор так и не отправил
+func (s *service) DeleteAttachment(ctx context.Context, chatID, userID, attachmentID string) error {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
+	if err != nil {
+		return err
+	}
+	attachmentGUID, err := uuid.Parse(attachmentID)
+	if err != nil {
+		return ErrAttachmentNotFound
+	}
+
+	var result chat.ChatAttachment
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = s.repo.Chat.DeleteUnsentAttachment(ctx, tx, chat.DeleteUnsentAttachmentParams{
+			ID:     attachmentGUID,
+			ChatID: chatGUID,
+			UserID: userGUID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	objects := []string{result.ObjectName}
+	if result.PreviewObjectName.Valid {
+		objects = append(objects, result.PreviewObjectName.String)
+	}
+	s.deleteObjects(ctx, objects)
+	return nil
+}
+
+func (s *service) MaxAttachmentSize() int64 {
+	return s.maxAttachmentSize
+}
+
+// attachFiles привязывает к сообщению загруженные отправителем вложения и,
+// если нужно, его CV
+func (s *service) attachFiles(ctx context.Context, tx pgx.Tx, message chat.ChatMessage, attachmentGUIDs []uuid.UUID, shareCV bool) error {
+	if len(attachmentGUIDs) > 0 {
+		attached, err := s.repo.Chat.AttachToMessage(ctx, tx, chat.AttachToMessageParams{
+			MessageID: uuid.NullUUID{UUID: message.ID, Valid: true},
+			Ids:       attachmentGUIDs,
+			ChatID:    message.ChatID,
+			UserID:    message.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		if attached != int64(len(attachmentGUIDs)) {
+			return fmt.Errorf("%w: attachment not found or already sent", ErrInvalidMessage)
+		}
+	}
+
+	if shareCV {
+		return s.attachCV(ctx, tx, message)
+	}
+	return nil
+}
+
+// attachCV прикладывает основное CV отправителя. Объект не копируется:
+// вложение ссылается на файл CV, и файл не удаляется вместе с версией CV,
+// пока на него ссылается чат
+func (s *service) attachCV(ctx context.Context, tx pgx.Tx, message chat.ChatMessage) error {
+	cv, err := s.repo.CV.GetCVByUserGUID(ctx, tx, message.UserID.String())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: no CV to share", ErrInvalidMessage)
+	}
+	if err != nil {
+		return err
+	}
+
+	objectName, ok := strings.CutPrefix(cv.Link, s.serverFullAddress+cvFilesPath)
+	if !ok {
+		return fmt.Errorf("%w: CV is an external link", ErrInvalidMessage)
+	}
+	file, err := s.repo.CV.GetStoredFile(ctx, tx, objectName)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && file.OwnerID != message.UserID) {
+		return fmt.Errorf("%w: CV file not found", ErrInvalidMessage)
+	}
+	if err != nil {
+		return err
+	}
+
+	info, err := s.storageService.Stat(ctx, objectName)
+	if err != nil {
+		return fmt.Errorf("failed to get CV file: %w", err)
+	}
+
+	_, err = s.repo.Chat.CreateAttachment(ctx, tx, chat.CreateAttachmentParams{
+		ChatID:      message.ChatID,
+		MessageID:   uuid.NullUUID{UUID: message.ID, Valid: true},
+		UserID:      message.UserID,
+		Kind:        models.AttachmentKindCV,
+		ObjectName:  objectName,
+		Filename:    file.OriginalFilename,
+		ContentType: file.ContentType,
+		Size:        info.Size,
+	})
+	return err
+}
+
+// loadAttachments заполняет вложения сообщений одним запросом
+func (s *service) loadAttachments(ctx context.Context, tx pgx.Tx, messages []models.Message) error {
+	ids := make([]uuid.UUID, 0, len(messages))
+	for _, message := range messages {
+		id, err := uuid.Parse(message.ID)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	results, err := s.repo.Chat.GetMessagesAttachments(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	byMessage := make(map[string][]models.Attachment)
+	for _, result := range results {
+		messageID := result.MessageID.UUID.String()
+		byMessage[messageID] = append(byMessage[messageID], s.mapAttachment(result))
+	}
+	for i := range messages {
+		if attachments, ok := byMessage[messages[i].ID]; ok {
+			messages[i].Attachments = attachments
+		}
+	}
+	return nil
+}
+
+func (s *service) deleteObjects(ctx context.Context, names []string) {
+	for _, name := range names {
+		if err := s.storageService.Delete(ctx, name); err != nil {
+			log.Printf("Failed to delete attachment object %s: %v", name, err)
+		}
+	}
+}
+
+func (s *service) mapAttachment(a chat.ChatAttachment) models.Attachment {
+	url := s.serverFullAddress + chatsPath + a.ChatID.String() + "/attachments/" + a.ID.String()
+	ret := models.Attachment{
+		ID:          a.ID.String(),
+		Kind:        a.Kind,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		URL:         url,
+		CreatedAt:   a.CreatedAt,
+		ObjectName:  a.ObjectName,
+	}
+	if a.PreviewObjectName.Valid {
+		previewURL := url + "?preview=true"
+		ret.PreviewURL = &previewURL
+		ret.PreviewObjectName = &a.PreviewObjectName.String
+	}
+	return ret
+}
+
+// normalizeFilename оставляет от имени загруженного файла только базовое имя
+func normalizeFilename(filename string) string {
+	filename = strings.TrimSpace(path.Base(strings.ReplaceAll(filename, `\`, "/")))
+	if filename == "" || filename == "." || filename == "/" {
+		return "file"
+	}
+	if utf8.RuneCountInString(filename) > maxFilenameRunes {
+		runes := []rune(filename)
+		ext := []rune(path.Ext(filename))
+		if len(ext) >= maxFilenameRunes {
+			ext = nil
+		}
+		filename = string(runes[:maxFilenameRunes-len(ext)]) + string(ext)
+	}
+	return filename
+}
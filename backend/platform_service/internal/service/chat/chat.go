@@ -1,120 +1,168 @@
 package chat
 
 import (
+	"PlatformService/internal/config"
 	"PlatformService/internal/models"
 	"PlatformService/internal/repository"
 	"PlatformService/internal/repository/chat"
-	"PlatformService/internal/utils"
+	"PlatformService/internal/service/scanner"
+	"PlatformService/internal/service/storage"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log"
+	"mime/multipart"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 )
 
 type Service interface {
-	CreateChat(ctx context.Context, userIDs []string) (*models.Chat, error)
+	CreateChat(ctx context.Context, userID string, req *models.CreateChatRequest) (*models.Chat, error)
+	UpdateChatTitle(ctx context.Context, chatID, userID, title string) (*models.Chat, error)
+	AddChatMember(ctx context.Context, chatID, userID, memberID, role string) (*models.Chat, error)
+	UpdateChatMemberRole(ctx context.Context, chatID, userID, memberID, role string) (*models.Chat, error)
+	RemoveChatMember(ctx context.Context, chatID, userID, memberID string) error
 	GetUserChats(ctx context.Context, userID string) ([]models.ChatWithLastMessage, error)
-	GetChatMessages(ctx context.Context, chatID string, limit, offset int) ([]models.Message, error)
-	SendMessage(ctx context.Context, chatID, userID, text string) (*models.Message, error)
+	GetChatMessages(ctx context.Context, chatID, userID string, limit, offset int) ([]models.Message, error)
+	SendMessage(ctx context.Context, chatID, userID string, req *models.SendMessageRequest) (*models.Message, error)
+	EditMessage(ctx context.Context, chatID, userID, messageID, text string) (*models.Message, error)
+	DeleteMessage(ctx context.Context, chatID, userID, messageID string) error
+	GetMessageEdits(ctx context.Context, chatID, userID, messageID string) ([]models.MessageEdit, error)
+	AddReaction(ctx context.Context, chatID, userID, messageID, emoji string) (*models.Message, error)
+	RemoveReaction(ctx context.Context, chatID, userID, messageID, emoji string) (*models.Message, error)
+	SearchMessages(ctx context.Context, userID, query, chatID string, limit, offset int) ([]models.MessageSearchResult, error)
+	GetMessageContext(ctx context.Context, chatID, userID, messageID string, limit int) ([]models.Message, error)
+	UploadAttachment(ctx context.Context, chatID, userID string, header *multipart.FileHeader) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, chatID, userID, attachmentID string) (*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, chatID, userID, attachmentID string) error
+	MaxAttachmentSize() int64
+	MarkRead(ctx context.Context, chatID, userID, messageID string) (*models.ReadReceipt, error)
+	CheckChatMember(ctx context.Context, chatID, userID string) error
+	SetTyping(chatID, userID string, typing bool)
 	Subscribe(chatID string, conn *WebSocketConnection)
 	Unsubscribe(chatID string, conn *WebSocketConnection)
 	BroadcastMessage(chatID string, message []byte, excludeUserID string)
 }
 
+var (
+	ErrChatNotFound    = errors.New("chat not found")
+	ErrNotChatMember   = errors.New("user is not a chat member")
+	ErrMessageNotFound = errors.New("message not found")
+)
+
 type ProfileService interface {
 	GetProfile(ctx context.Context, userID string) (*models.Profile, error)
 }
 
+type MediaService interface {
+	MakePreview(file io.ReadSeeker) ([]byte, string, error)
+}
+
 type WebSocketConnection struct {
 	UserID string
 	Send   chan []byte
 }
 
 type service struct {
-	repo           *repository.Repositories
-	clients        map[string]map[*WebSocketConnection]bool
-	clientsMux     sync.RWMutex
-	profileService ProfileService
+	repo              *repository.Repositories
+	clients           map[string]map[*WebSocketConnection]bool
+	presence          map[string]*userPresence
+	clientsMux        sync.RWMutex
+	profileService    ProfileService
+	mediaService      MediaService
+	storageService    storage.Service
+	scannerService    scanner.Service
+	serverFullAddress string
+	maxAttachmentSize int64
 }
 
-func (s *service) CreateChat(ctx context.Context, userIDs []string) (*models.Chat, error) {
-	if len(userIDs) != 2 {
-		return nil, errors.New("chat must have exactly 2 users")
+// CreateChat создаёт чат. Для личного чата двух пользователей возвращается
+// уже существующий, если он есть
+func (s *service) CreateChat(ctx context.Context, userID string, req *models.CreateChatRequest) (*models.Chat, error) {
+	creatorGUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	var ret models.Chat
-	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
-		userGUID1, err := uuid.Parse(userIDs[0])
+	// Создатель всегда участник чата
+	userGUIDs := []uuid.UUID{creatorGUID}
+	for _, id := range req.Users {
+		userGUID, err := uuid.Parse(id)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("%w: invalid user id %s", ErrInvalidChat, id)
 		}
-		userGUID2, err := uuid.Parse(userIDs[1])
-		if err != nil {
-			return err
+		if !slices.Contains(userGUIDs, userGUID) {
+			userGUIDs = append(userGUIDs, userGUID)
+		}
+	}
+
+	switch req.Type {
+	case "", models.ChatTypeDirect:
+		if len(userGUIDs) != 2 {
+			return nil, fmt.Errorf("%w: chat must have exactly 2 users", ErrInvalidChat)
+		}
+		if req.Title != nil || req.JobID != nil {
+			return nil, fmt.Errorf("%w: direct chat cannot have a title or a job", ErrInvalidChat)
 		}
-		chat, err := s.repo.Chat.GetChatByUsersIDs(ctx, tx, chat.GetChatByUsersIDsParams{
+		return s.createDirectChat(ctx, userGUIDs[0], userGUIDs[1])
+	case models.ChatTypeGroup:
+		return s.createGroupChat(ctx, creatorGUID, userGUIDs, req)
+	default:
+		return nil, fmt.Errorf("%w: unknown chat type %s", ErrInvalidChat, req.Type)
+	}
+}
+
+func (s *service) createDirectChat(ctx context.Context, userGUID1, userGUID2 uuid.UUID) (*models.Chat, error) {
+	var ret *models.Chat
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		existing, err := s.repo.Chat.GetChatByUsersIDs(ctx, tx, chat.GetChatByUsersIDsParams{
 			UserID:   userGUID1,
 			UserID_2: userGUID2,
 		})
 		if err == nil {
-			ret = models.Chat{
-				ID:        chat.ID.String(),
-				CreatedAt: chat.CreatedAt,
-				UpdatedAt: chat.UpdatedAt,
-				Users:     userIDs,
-			}
-			return nil
+			ret, err = s.loadChat(ctx, tx, existing.ID)
+			return err
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
 		}
 
-		return err
-	})
-
-	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-		return nil, err
-	}
-
-	if err == nil {
-		return &ret, nil
-	}
+		if err := s.checkUsersExist(ctx, tx, []uuid.UUID{userGUID1, userGUID2}); err != nil {
+			return err
+		}
 
-	// Create chat
-	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
 		// Create chat
-		result, err := s.repo.Chat.CreateChat(ctx, tx)
+		result, err := s.repo.Chat.CreateChat(ctx, tx, chat.CreateChatParams{
+			Type: models.ChatTypeDirect,
+		})
 		if err != nil {
 			return err
 		}
 
 		// Add users to chat
-		for _, userID := range userIDs {
-			userGUID, err := uuid.Parse(userID)
-			if err != nil {
-				return err
-			}
+		for _, userGUID := range []uuid.UUID{userGUID1, userGUID2} {
 			err = s.repo.Chat.AddUserToChat(ctx, tx, chat.AddUserToChatParams{
 				ChatID: result.ID,
 				UserID: userGUID,
+				Role:   models.ChatRoleMember,
 			})
 			if err != nil {
 				return err
 			}
 		}
 
-		ret = models.Chat{
-			ID:        result.ID.String(),
-			CreatedAt: result.CreatedAt,
-			UpdatedAt: result.UpdatedAt,
-			Users:     userIDs,
-		}
-		return nil
+		ret, err = s.loadChat(ctx, tx, result.ID)
+		return err
 	})
 
-	return &ret, err
+	return ret, err
 }
 
 func (s *service) GetUserChats(ctx context.Context, userID string) ([]models.ChatWithLastMessage, error) {
@@ -139,13 +187,11 @@ func (s *service) GetUserChats(ctx context.Context, userID string) ([]models.Cha
 
 			var lastMessage *models.Message
 			if !errors.Is(err, pgx.ErrNoRows) {
-				lastMessage = &models.Message{
-					ID:        lastMessageFromDB.ID.String(),
-					ChatID:    lastMessageFromDB.ChatID.String(),
-					UserID:    lastMessageFromDB.UserID.String(),
-					Text:      lastMessageFromDB.Text,
-					CreatedAt: lastMessageFromDB.CreatedAt,
+				messages := []models.Message{mapMessage(lastMessageFromDB)}
+				if err := s.loadMessageDetails(ctx, tx, messages); err != nil {
+					return err
 				}
+				lastMessage = &messages[0]
 			}
 
 			// Get unread count
@@ -157,25 +203,37 @@ func (s *service) GetUserChats(ctx context.Context, userID string) ([]models.Cha
 				return err
 			}
 
-			users, ok := result.Users.(pgtype.UUIDArray)
-			if !ok {
-				return errors.New("failed to cast users to []string")
+			members, err := s.repo.Chat.GetChatMembers(ctx, tx, result.ID)
+			if err != nil {
+				return err
 			}
+			userChat := mapChat(chat.ChatChat{
+				ID:        result.ID,
+				CreatedAt: result.CreatedAt,
+				UpdatedAt: result.UpdatedAt,
+				Type:      result.Type,
+				Title:     result.Title,
+				JobID:     result.JobID,
+			}, members)
 
-			usersGUIDs, err := utils.UUIDArrayToStringArray(users)
+			receipts, err := s.getReadReceipts(ctx, tx, result.ID)
 			if err != nil {
 				return err
 			}
 
+			presence := make([]models.Presence, len(userChat.Users))
+			s.clientsMux.RLock()
+			for i, user := range userChat.Users {
+				presence[i] = s.presenceOf(user)
+			}
+			s.clientsMux.RUnlock()
+
 			chat := models.ChatWithLastMessage{
-				Chat: models.Chat{
-					ID:        result.ID.String(),
-					CreatedAt: result.CreatedAt,
-					UpdatedAt: result.UpdatedAt,
-					Users:     usersGUIDs,
-				},
-				LastMessage: lastMessage,
-				UnreadCount: int(unreadCount),
+				Chat:         userChat,
+				LastMessage:  lastMessage,
+				UnreadCount:  int(unreadCount),
+				ReadReceipts: receipts,
+				Presence:     presence,
 			}
 
 			chats = append(chats, chat)
@@ -186,14 +244,18 @@ func (s *service) GetUserChats(ctx context.Context, userID string) ([]models.Cha
 	return chats, err
 }
 
-func (s *service) GetChatMessages(ctx context.Context, chatID string, limit, offset int) ([]models.Message, error) {
-	chatGUID, err := uuid.Parse(chatID)
+func (s *service) GetChatMessages(ctx context.Context, chatID, userID string, limit, offset int) ([]models.Message, error) {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
 	if err != nil {
 		return nil, err
 	}
 
 	var messages []models.Message
 	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+			return err
+		}
+
 		results, err := s.repo.Chat.GetChatMessages(ctx, tx, chat.GetChatMessagesParams{
 			ChatID: chatGUID,
 			Limit:  int32(limit),
@@ -204,56 +266,87 @@ func (s *service) GetChatMessages(ctx context.Context, chatID string, limit, off
 		}
 
 		for _, result := range results {
-			messages = append(messages, models.Message{
-				ID:        result.ID.String(),
-				ChatID:    result.ChatID.String(),
-				UserID:    result.UserID.String(),
-				Text:      result.Text,
-				CreatedAt: result.CreatedAt,
-			})
+			messages = append(messages, mapMessage(result))
 		}
-		return nil
+		return s.loadMessageDetails(ctx, tx, messages)
 	})
 
 	return messages, err
 }
 
-func (s *service) SendMessage(ctx context.Context, chatID, userID, text string) (*models.Message, error) {
-	chatGUID, err := uuid.Parse(chatID)
+// SendMessage отправляет сообщение с текстом и/или вложениями, возможно,
+// в ответ на другое сообщение чата
+func (s *service) SendMessage(ctx context.Context, chatID, userID string, req *models.SendMessageRequest) (*models.Message, error) {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	userGUID, err := uuid.Parse(userID)
-	if err != nil {
-		return nil, err
+	var attachmentGUIDs []uuid.UUID
+	for _, id := range req.AttachmentIDs {
+		attachmentGUID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid attachment id %s", ErrInvalidMessage, id)
+		}
+		if !slices.Contains(attachmentGUIDs, attachmentGUID) {
+			attachmentGUIDs = append(attachmentGUIDs, attachmentGUID)
+		}
+	}
+	if len(attachmentGUIDs) > maxMessageAttachments {
+		return nil, fmt.Errorf("%w: more than %d attachments", ErrInvalidMessage, maxMessageAttachments)
+	}
+	if strings.TrimSpace(req.Text) == "" && len(attachmentGUIDs) == 0 && !req.ShareCV {
+		return nil, fmt.Errorf("%w: message is empty", ErrInvalidMessage)
 	}
 
 	var message models.Message
 	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+			return err
+		}
+
+		replyTo, err := s.getReplyTarget(ctx, tx, chatGUID, req.ReplyToID)
+		if err != nil {
+			return err
+		}
+
 		// Create message
 		result, err := s.repo.Chat.CreateMessage(ctx, tx, chat.CreateMessageParams{
-			ChatID: chatGUID,
-			UserID: userGUID,
-			Text:   text,
+			ChatID:    chatGUID,
+			UserID:    userGUID,
+			Text:      req.Text,
+			Type:      models.MessageTypeText,
+			ReplyToID: replyTo,
 		})
 		if err != nil {
 			return err
 		}
 
+		if err := s.attachFiles(ctx, tx, result, attachmentGUIDs, req.ShareCV); err != nil {
+			return err
+		}
+
 		// Update chat updated_at
 		err = s.repo.Chat.UpdateChatUpdatedAt(ctx, tx, chatGUID)
 		if err != nil {
 			return err
 		}
 
-		message = models.Message{
-			ID:        result.ID.String(),
-			ChatID:    result.ChatID.String(),
-			UserID:    result.UserID.String(),
-			Text:      result.Text,
-			CreatedAt: result.CreatedAt,
+		// Отправитель прочитал чат до своего сообщения включительно
+		_, err = s.repo.Chat.MarkChatRead(ctx, tx, chat.MarkChatReadParams{
+			ChatID: chatGUID,
+			UserID: userGUID,
+			ID:     result.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		messages := []models.Message{mapMessage(result)}
+		if err := s.loadMessageDetails(ctx, tx, messages); err != nil {
+			return err
 		}
+		message = messages[0]
 		return nil
 	})
 
@@ -261,62 +354,365 @@ func (s *service) SendMessage(ctx context.Context, chatID, userID, text string)
 		return nil, err
 	}
 
-	// Broadcast message to all clients in the chat
-	s.clientsMux.RLock()
-	if clients, ok := s.clients[chatID]; ok {
-		profile, err := s.profileService.GetProfile(ctx, message.UserID)
+	s.publish(ctx, message)
+
+	return &message, nil
+}
+
+// MarkRead отмечает сообщение и все предыдущие прочитанными участником чата.
+// Без messageID отмечается последнее сообщение. Отметка не сдвигается назад;
+// о новой отметке узнают остальные участники чата по WebSocket
+func (s *service) MarkRead(ctx context.Context, chatID, userID, messageID string) (*models.ReadReceipt, error) {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var messageGUID uuid.UUID
+	if messageID != "" {
+		messageGUID, err = uuid.Parse(messageID)
+		if err != nil {
+			return nil, ErrMessageNotFound
+		}
+	}
+
+	var receipt *models.ReadReceipt
+	var updated int64
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+			return err
+		}
+
+		var message chat.ChatMessage
+		var err error
+		if messageID == "" {
+			message, err = s.repo.Chat.GetLastMessage(ctx, tx, chatGUID)
+		} else {
+			message, err = s.repo.Chat.GetChatMessageByID(ctx, tx, chat.GetChatMessageByIDParams{
+				ID:     messageGUID,
+				ChatID: chatGUID,
+			})
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMessageNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.repo.Chat.MarkChatRead(ctx, tx, chat.MarkChatReadParams{
+			ChatID: chatGUID,
+			UserID: userGUID,
+			ID:     message.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		receipts, err := s.getReadReceipts(ctx, tx, chatGUID)
+		if err != nil {
+			return err
+		}
+		for i := range receipts {
+			if receipts[i].UserID == userGUID.String() {
+				receipt = &receipts[i]
+				return nil
+			}
+		}
+
+		// Участника исключили, пока шла транзакция
+		return ErrNotChatMember
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if updated > 0 {
+		event, err := json.Marshal(readEvent{Type: EventTypeRead, ReadReceipt: *receipt})
 		if err != nil {
 			return nil, err
 		}
+		s.send(chatID, event, userID)
+	}
+
+	return receipt, nil
+}
+
+// CheckChatMember проверяет, что пользователь - участник чата. Используется
+// перед подключением к WebSocket чата
+func (s *service) CheckChatMember(ctx context.Context, chatID, userID string) error {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.checkChatMember(ctx, tx, chatGUID, userGUID)
+	})
+}
 
-		messageAPI := models.MessageAPI{
-			ChatId:    message.ChatID,
-			CreatedAt: message.CreatedAt,
-			Id:        message.ID,
-			Text:      message.Text,
-			User: models.ChatUserAPI{
-				Avatar:      profile.Avatar,
-				Description: profile.Description,
-				Id:          profile.Guid,
-			},
+// checkChatMember возвращает ErrNotChatMember, если пользователь не участник
+// чата. Для несуществующего чата ответ тот же, чтобы не раскрывать, какие
+// чаты существуют
+func (s *service) checkChatMember(ctx context.Context, tx pgx.Tx, chatGUID, userGUID uuid.UUID) error {
+	_, err := s.repo.Chat.GetChatMember(ctx, tx, chat.GetChatMemberParams{
+		ChatID: chatGUID,
+		UserID: userGUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotChatMember
+	}
+	return err
+}
+
+// createSystemMessage добавляет в чат системное сообщение от имени actorGUID.
+// targetGUID - участник, которого касается изменение, или uuid.Nil
+func (s *service) createSystemMessage(ctx context.Context, tx pgx.Tx, chatGUID, actorGUID uuid.UUID, messageType string, targetGUID uuid.UUID, text string) (models.Message, error) {
+	result, err := s.repo.Chat.CreateMessage(ctx, tx, chat.CreateMessageParams{
+		ChatID:       chatGUID,
+		UserID:       actorGUID,
+		Text:         text,
+		Type:         messageType,
+		TargetUserID: uuid.NullUUID{UUID: targetGUID, Valid: targetGUID != uuid.Nil},
+	})
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	if err := s.repo.Chat.UpdateChatUpdatedAt(ctx, tx, chatGUID); err != nil {
+		return models.Message{}, err
+	}
+
+	return mapMessage(result), nil
+}
+
+// publish рассылает сообщения всем подключённым участникам чата, включая автора
+func (s *service) publish(ctx context.Context, messages ...models.Message) {
+	for _, message := range messages {
+		messageAPI, err := s.mapMessageAPI(ctx, message)
+		if err != nil {
+			log.Printf("Failed to get profile %s for message %s: %v", message.UserID, message.ID, err)
+			continue
 		}
+
 		messageJSON, err := json.Marshal(messageAPI)
 		if err != nil {
-			s.clientsMux.RUnlock()
-			return nil, err
+			log.Printf("Failed to marshal message %s: %v", message.ID, err)
+			continue
 		}
 
-		for client := range clients {
-			select {
-			case client.Send <- messageJSON:
-			default:
-				// Skip if client's buffer is full
-			}
+		s.send(message.ChatID, messageJSON, "")
+	}
+}
+
+// mapMessageAPI дополняет сообщение профилем автора для рассылки по WebSocket
+func (s *service) mapMessageAPI(ctx context.Context, message models.Message) (models.MessageAPI, error) {
+	profile, err := s.profileService.GetProfile(ctx, message.UserID)
+	if err != nil {
+		return models.MessageAPI{}, err
+	}
+
+	return models.MessageAPI{
+		Attachments:  message.Attachments,
+		ChatId:       message.ChatID,
+		CreatedAt:    message.CreatedAt,
+		DeletedAt:    message.DeletedAt,
+		EditedAt:     message.EditedAt,
+		Id:           message.ID,
+		Reactions:    message.Reactions,
+		ReplyTo:      message.ReplyTo,
+		TargetUserId: message.TargetUserID,
+		Text:         message.Text,
+		Type:         message.Type,
+		User: models.ChatUserAPI{
+			Avatar:      profile.Avatar,
+			Description: profile.Description,
+			Id:          profile.Guid,
+		},
+	}, nil
+}
+
+// loadChat возвращает чат с участниками
+func (s *service) loadChat(ctx context.Context, tx pgx.Tx, chatGUID uuid.UUID) (*models.Chat, error) {
+	result, err := s.repo.Chat.GetChatByID(ctx, tx, chatGUID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrChatNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.Chat.GetChatMembers(ctx, tx, chatGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := mapChat(chat.ChatChat{
+		ID:        result.ID,
+		CreatedAt: result.CreatedAt,
+		UpdatedAt: result.UpdatedAt,
+		Type:      result.Type,
+		Title:     result.Title,
+		JobID:     result.JobID,
+	}, members)
+	return &ret, nil
+}
+
+func mapChat(c chat.ChatChat, members []chat.GetChatMembersRow) models.Chat {
+	ret := models.Chat{
+		ID:        c.ID.String(),
+		Type:      c.Type,
+		Users:     make([]string, len(members)),
+		Members:   make([]models.ChatMember, len(members)),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	if c.Title.Valid {
+		ret.Title = &c.Title.String
+	}
+	if c.JobID.Valid {
+		jobID := c.JobID.UUID.String()
+		ret.JobID = &jobID
+	}
+	for i, member := range members {
+		ret.Users[i] = member.UserID.String()
+		ret.Members[i] = models.ChatMember{
+			UserID:   member.UserID.String(),
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
 		}
 	}
-	s.clientsMux.RUnlock()
+	return ret
+}
 
-	return &message, nil
+func mapMessage(m chat.ChatMessage) models.Message {
+	ret := models.Message{
+		ID:          m.ID.String(),
+		ChatID:      m.ChatID.String(),
+		UserID:      m.UserID.String(),
+		Type:        m.Type,
+		Text:        m.Text,
+		Attachments: []models.Attachment{},
+		Reactions:   []models.Reaction{},
+		CreatedAt:   m.CreatedAt,
+	}
+	if m.TargetUserID.Valid {
+		targetUserID := m.TargetUserID.UUID.String()
+		ret.TargetUserID = &targetUserID
+	}
+	// Остальные поля цитаты заполняет loadReplies
+	if m.ReplyToID.Valid {
+		ret.ReplyTo = &models.MessageReply{ID: m.ReplyToID.UUID.String()}
+	}
+	if m.EditedAt.Valid {
+		ret.EditedAt = &m.EditedAt.Time
+	}
+	if m.DeletedAt.Valid {
+		ret.DeletedAt = &m.DeletedAt.Time
+	}
+	return ret
 }
 
+func (s *service) getReadReceipts(ctx context.Context, tx pgx.Tx, chatID uuid.UUID) ([]models.ReadReceipt, error) {
+	results, err := s.repo.Chat.GetChatReadReceipts(ctx, tx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]models.ReadReceipt, 0, len(results))
+	for _, result := range results {
+		receipts = append(receipts, models.ReadReceipt{
+			ChatID:    chatID.String(),
+			UserID:    result.UserID.String(),
+			MessageID: result.LastReadMessageID.UUID.String(),
+			ReadAt:    result.LastReadAt.Time,
+		})
+	}
+	return receipts, nil
+}
+
+// Subscribe подписывает соединение на события чата. Первое соединение
+// пользователя делает его «в сети» для участников всех его чатов
 func (s *service) Subscribe(chatID string, conn *WebSocketConnection) {
 	s.clientsMux.Lock()
 	if _, ok := s.clients[chatID]; !ok {
 		s.clients[chatID] = make(map[*WebSocketConnection]bool)
 	}
 	s.clients[chatID][conn] = true
+
+	p, ok := s.presence[conn.UserID]
+	if !ok {
+		p = &userPresence{}
+		s.presence[conn.UserID] = p
+	}
+	p.connections++
+	cameOnline := p.connections == 1
+	presence := s.presenceOf(conn.UserID)
 	s.clientsMux.Unlock()
+
+	if cameOnline {
+		s.notifyPresence(presence)
+	}
 }
 
+// Unsubscribe отписывает соединение. С закрытием последнего соединения
+// пользователь уходит из сети и запоминается время, когда он был в сети
 func (s *service) Unsubscribe(chatID string, conn *WebSocketConnection) {
 	s.clientsMux.Lock()
-	if clients, ok := s.clients[chatID]; ok {
-		delete(clients, conn)
-		if len(clients) == 0 {
-			delete(s.clients, chatID)
+	presence, wentOffline := s.removeConnection(chatID, conn)
+	s.clientsMux.Unlock()
+
+	if wentOffline {
+		s.notifyPresence(presence)
+	}
+}
+
+// disconnectUser закрывает соединения пользователя с чатом - после
+// исключения из группы он не должен получать её события. Канал Send
+// закрывается после удаления из реестра, поэтому запись в него уже невозможна
+func (s *service) disconnectUser(chatID, userID string) {
+	var notify []models.Presence
+	s.clientsMux.Lock()
+	for conn := range s.clients[chatID] {
+		if conn.UserID != userID {
+			continue
+		}
+		presence, wentOffline := s.removeConnection(chatID, conn)
+		close(conn.Send)
+		if wentOffline {
+			notify = append(notify, presence)
 		}
 	}
 	s.clientsMux.Unlock()
+
+	for _, presence := range notify {
+		s.notifyPresence(presence)
+	}
+}
+
+// removeConnection удаляет соединение из реестра и возвращает статус
+// пользователя. Вызывается под clientsMux
+func (s *service) removeConnection(chatID string, conn *WebSocketConnection) (models.Presence, bool) {
+	clients := s.clients[chatID]
+	if !clients[conn] {
+		return models.Presence{}, false
+	}
+	delete(clients, conn)
+	if len(clients) == 0 {
+		delete(s.clients, chatID)
+	}
+
+	p, ok := s.presence[conn.UserID]
+	if !ok {
+		return models.Presence{}, false
+	}
+	p.connections--
+	if p.connections > 0 {
+		return s.presenceOf(conn.UserID), false
+	}
+	p.connections = 0
+	p.lastSeen = time.Now().UTC()
+	return s.presenceOf(conn.UserID), true
 }
 
 func (s *service) BroadcastMessage(chatID string, message []byte, excludeUserID string) {
@@ -356,10 +752,21 @@ func (s *service) BroadcastMessage(chatID string, message []byte, excludeUserID
 	}
 }
 
-func NewService(repo *repository.Repositories, profileService ProfileService) Service {
+func NewService(cfg *config.Config, repo *repository.Repositories, profileService ProfileService, mediaService MediaService, storageService storage.Service, scannerService scanner.Service) Service {
+	maxAttachmentSize := cfg.ChatAttachmentMaxFileSize
+	if maxAttachmentSize <= 0 {
+		maxAttachmentSize = defaultMaxAttachmentSize
+	}
+
 	return &service{
-		repo:           repo,
-		clients:        make(map[string]map[*WebSocketConnection]bool),
-		profileService: profileService,
+		repo:              repo,
+		clients:           make(map[string]map[*WebSocketConnection]bool),
+		presence:          make(map[string]*userPresence),
+		profileService:    profileService,
+		mediaService:      mediaService,
+		storageService:    storageService,
+		scannerService:    scannerService,
+		serverFullAddress: cfg.ServerFullAddress,
+		maxAttachmentSize: maxAttachmentSize,
 	}
 }
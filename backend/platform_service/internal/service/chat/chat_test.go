@@ -0,0 +1,242 @@
+package chat
+
+import (
+	"PlatformService/internal/models"
+	"PlatformService/internal/repository"
+	"PlatformService/internal/repository/chat"
+	"PlatformService/internal/repository/job"
+	"PlatformService/internal/repository/profile"
+	"context"
+	"errors"
+	"mime/multipart"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// fakeTxManager выполняет функцию без транзакции
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+// fakeQuerier знает только участников чатов. Вызов любого другого запроса
+// паникует на встроенном nil-интерфейсе: после отказа в доступе сервис не
+// должен обращаться к данным чата
+type fakeQuerier struct {
+	chat.Querier
+	members map[chat.GetChatMemberParams]chat.GetChatMemberRow
+	err     error
+}
+
+func (f *fakeQuerier) GetChatMember(_ context.Context, _ chat.DBTX, arg chat.GetChatMemberParams) (chat.GetChatMemberRow, error) {
+	if f.err != nil {
+		return chat.GetChatMemberRow{}, f.err
+	}
+	member, ok := f.members[arg]
+	if !ok {
+		return chat.GetChatMemberRow{}, pgx.ErrNoRows
+	}
+	return member, nil
+}
+
+func newTestService(querier chat.Querier) *service {
+	return &service{
+		repo: &repository.Repositories{
+			Chat:      querier,
+			TxManager: fakeTxManager{},
+		},
+		maxAttachmentSize: defaultMaxAttachmentSize,
+	}
+}
+
+func TestCheckChatMember(t *testing.T) {
+	chatGUID, memberGUID, strangerGUID := uuid.New(), uuid.New(), uuid.New()
+	errDB := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		repoErr error
+		wantErr error
+	}{
+		{"member", memberGUID, nil, nil},
+		{"not a member", strangerGUID, nil, ErrNotChatMember},
+		{"database error", memberGUID, errDB, errDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(&fakeQuerier{
+				members: map[chat.GetChatMemberParams]chat.GetChatMemberRow{
+					{ChatID: chatGUID, UserID: memberGUID}: {UserID: memberGUID, Role: models.ChatRoleMember},
+				},
+				err: tt.repoErr,
+			})
+
+			err := s.checkChatMember(context.Background(), nil, chatGUID, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkChatMember() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Каждая операция с чатом отказывает пользователю, который не состоит в нём
+func TestChatEndpointsRequireMembership(t *testing.T) {
+	chatID, userID, otherID, messageID := uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func(s *service) error
+	}{
+		{"CheckChatMember", func(s *service) error {
+			return s.CheckChatMember(ctx, chatID, userID)
+		}},
+		{"GetChatMessages", func(s *service) error {
+			_, err := s.GetChatMessages(ctx, chatID, userID, 50, 0)
+			return err
+		}},
+		{"SendMessage", func(s *service) error {
+			_, err := s.SendMessage(ctx, chatID, userID, &models.SendMessageRequest{Text: "hi"})
+			return err
+		}},
+		{"EditMessage", func(s *service) error {
+			_, err := s.EditMessage(ctx, chatID, userID, messageID, "edited")
+			return err
+		}},
+		{"DeleteMessage", func(s *service) error {
+			return s.DeleteMessage(ctx, chatID, userID, messageID)
+		}},
+		{"GetMessageEdits", func(s *service) error {
+			_, err := s.GetMessageEdits(ctx, chatID, userID, messageID)
+			return err
+		}},
+		{"AddReaction", func(s *service) error {
+			_, err := s.AddReaction(ctx, chatID, userID, messageID, "👍")
+			return err
+		}},
+		{"RemoveReaction", func(s *service) error {
+			_, err := s.RemoveReaction(ctx, chatID, userID, messageID, "👍")
+			return err
+		}},
+		{"SearchMessages", func(s *service) error {
+			_, err := s.SearchMessages(ctx, userID, "привет", chatID, 20, 0)
+			return err
+		}},
+		{"GetMessageContext", func(s *service) error {
+			_, err := s.GetMessageContext(ctx, chatID, userID, messageID, 20)
+			return err
+		}},
+		{"UploadAttachment", func(s *service) error {
+			_, err := s.UploadAttachment(ctx, chatID, userID, &multipart.FileHeader{Filename: "cv.pdf", Size: 1})
+			return err
+		}},
+		{"GetAttachment", func(s *service) error {
+			_, err := s.GetAttachment(ctx, chatID, userID, uuid.NewString())
+			return err
+		}},
+		{"DeleteAttachment", func(s *service) error {
+			return s.DeleteAttachment(ctx, chatID, userID, uuid.NewString())
+		}},
+		{"MarkRead", func(s *service) error {
+			_, err := s.MarkRead(ctx, chatID, userID, messageID)
+			return err
+		}},
+		{"UpdateChatTitle", func(s *service) error {
+			_, err := s.UpdateChatTitle(ctx, chatID, userID, "Команда")
+			return err
+		}},
+		{"AddChatMember", func(s *service) error {
+			_, err := s.AddChatMember(ctx, chatID, userID, otherID, models.ChatRoleMember)
+			return err
+		}},
+		{"UpdateChatMemberRole", func(s *service) error {
+			_, err := s.UpdateChatMemberRole(ctx, chatID, userID, otherID, models.ChatRoleAdmin)
+			return err
+		}},
+		{"RemoveChatMember", func(s *service) error {
+			return s.RemoveChatMember(ctx, chatID, userID, otherID)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(&fakeQuerier{})
+			if err := tt.call(s); !errors.Is(err, ErrNotChatMember) {
+				t.Errorf("%s() error = %v, want %v", tt.name, err, ErrNotChatMember)
+			}
+		})
+	}
+}
+
+// fakeGroupQuerier создаёт чат с ошибкой errCreateChat: тест проверяет только,
+// дошло ли создание группы до записи в базу
+type fakeGroupQuerier struct {
+	chat.Querier
+}
+
+var errCreateChat = errors.New("create chat called")
+
+func (fakeGroupQuerier) CreateChat(context.Context, chat.DBTX, chat.CreateChatParams) (chat.ChatChat, error) {
+	return chat.ChatChat{}, errCreateChat
+}
+
+// fakeProfileQuerier считает существующим любой профиль
+type fakeProfileQuerier struct {
+	profile.Querier
+}
+
+func (fakeProfileQuerier) GetProfileByGUID(_ context.Context, _ profile.DBTX, guid uuid.UUID) (profile.ProfileProfile, error) {
+	return profile.ProfileProfile{Guid: guid}, nil
+}
+
+// fakeJobQuerier знает одну вакансию
+type fakeJobQuerier struct {
+	job.Querier
+	job job.JobJob
+}
+
+func (f fakeJobQuerier) GetJobByID(_ context.Context, _ job.DBTX, id uuid.UUID) (job.JobJob, error) {
+	if id != f.job.ID {
+		return job.JobJob{}, pgx.ErrNoRows
+	}
+	return f.job, nil
+}
+
+// Привязать группу к вакансии может только автор вакансии
+func TestCreateGroupChatJobAuthor(t *testing.T) {
+	authorGUID, otherGUID := uuid.New(), uuid.New()
+	jobGUID := uuid.New()
+	title := "Команда найма"
+
+	tests := []struct {
+		name      string
+		creatorID uuid.UUID
+		jobID     string
+		wantErr   error
+	}{
+		{"job author", authorGUID, jobGUID.String(), errCreateChat},
+		{"not job author", otherGUID, jobGUID.String(), ErrNotJobAuthor},
+		{"job not found", authorGUID, uuid.NewString(), ErrInvalidChat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(fakeGroupQuerier{})
+			s.repo.Profile = fakeProfileQuerier{}
+			s.repo.Job = fakeJobQuerier{job: job.JobJob{ID: jobGUID, AuthorID: authorGUID}}
+
+			_, err := s.createGroupChat(context.Background(), tt.creatorID, []uuid.UUID{authorGUID, otherGUID}, &models.CreateChatRequest{
+				Title: &title,
+				JobID: &tt.jobID,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("createGroupChat() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
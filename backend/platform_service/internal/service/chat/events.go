@@ -0,0 +1,148 @@
+package chat
+
+import (
+	"PlatformService/internal/models"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// Типы событий WebSocket чата. Сообщения чата передаются как есть, без типа
+const (
+	EventTypeRead        = "read"
+	EventTypeTypingStart = "typing-start"
+	EventTypeTypingStop  = "typing-stop"
+	EventTypePresence    = "presence"
+
+	// Сообщение изменено: правка, удаление или реакции
+	EventTypeMessageUpdated = "message-updated"
+)
+
+// Сколько ждать список чатов пользователя при рассылке смены статуса
+const presenceTimeout = 5 * time.Second
+
+// IncomingEvent - событие, присланное клиентом по WebSocket. Поля, кроме
+// типа, зависят от события
+type IncomingEvent struct {
+	Type      string `json:"type"`
+	MessageID string `json:"message_id,omitempty"`
+}
+
+type readEvent struct {
+	Type string `json:"type"`
+	models.ReadReceipt
+}
+
+type typingEvent struct {
+	Type   string `json:"type"`
+	ChatID string `json:"chat_id"`
+	UserID string `json:"user_id"`
+}
+
+type messageUpdatedEvent struct {
+	Type    string            `json:"type"`
+	Message models.MessageAPI `json:"message"`
+}
+
+type presenceEvent struct {
+	Type string `json:"type"`
+	models.Presence
+}
+
+// userPresence - число открытых WebSocket соединений пользователя во всех
+// чатах и время закрытия последнего из них
+type userPresence struct {
+	connections int
+	lastSeen    time.Time
+}
+
+// SetTyping сообщает остальным участникам чата, что пользователь начал или
+// закончил набирать сообщение. Клиенту стоит считать набор законченным, если
+// typing-start не повторялся несколько секунд: соединение могло оборваться
+func (s *service) SetTyping(chatID, userID string, typing bool) {
+	eventType := EventTypeTypingStop
+	if typing {
+		eventType = EventTypeTypingStart
+	}
+
+	event, err := json.Marshal(typingEvent{
+		Type:   eventType,
+		ChatID: chatID,
+		UserID: userID,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal typing event: %v", err)
+		return
+	}
+
+	s.send(chatID, event, userID)
+}
+
+// presenceOf возвращает статус пользователя по реестру соединений.
+// Вызывается под clientsMux
+func (s *service) presenceOf(userID string) models.Presence {
+	result := models.Presence{UserID: userID}
+	if p, ok := s.presence[userID]; ok {
+		result.Online = p.connections > 0
+		if !result.Online && !p.lastSeen.IsZero() {
+			lastSeen := p.lastSeen
+			result.LastSeenAt = &lastSeen
+		}
+	}
+	return result
+}
+
+// notifyPresence рассылает смену статуса пользователя во все его чаты, где
+// сейчас кто-то подключён
+func (s *service) notifyPresence(presence models.Presence) {
+	userGUID, err := uuid.Parse(presence.UserID)
+	if err != nil {
+		log.Printf("Failed to notify presence: invalid user id %s", presence.UserID)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), presenceTimeout)
+	defer cancel()
+
+	var chatIDs []uuid.UUID
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		chatIDs, err = s.repo.Chat.GetUserChatIDs(ctx, tx, userGUID)
+		return err
+	})
+	if err != nil {
+		log.Printf("Failed to get chats of user %s: %v", presence.UserID, err)
+		return
+	}
+
+	event, err := json.Marshal(presenceEvent{Type: EventTypePresence, Presence: presence})
+	if err != nil {
+		log.Printf("Failed to marshal presence event: %v", err)
+		return
+	}
+
+	for _, chatID := range chatIDs {
+		s.send(chatID.String(), event, presence.UserID)
+	}
+}
+
+// send рассылает событие подключённым участникам чата, кроме excludeUserID.
+// Переполненные буферы клиентов пропускаются
+func (s *service) send(chatID string, event []byte, excludeUserID string) {
+	s.clientsMux.RLock()
+	defer s.clientsMux.RUnlock()
+
+	for client := range s.clients[chatID] {
+		if client.UserID == excludeUserID {
+			continue
+		}
+		select {
+		case client.Send <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,440 @@
+package chat
+
+import (
+	"PlatformService/internal/models"
+	"PlatformService/internal/repository/chat"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const maxChatTitleRunes = 100
+
+var (
+	ErrInvalidChat         = errors.New("invalid chat")
+	ErrNotChatAdmin        = errors.New("user is not a chat admin")
+	ErrChatMemberNotFound  = errors.New("chat member not found")
+	ErrChatMemberExists    = errors.New("user is already a chat member")
+	ErrLastChatAdmin       = errors.New("chat must have at least one admin")
+	ErrNotJobAuthor        = errors.New("access denied: not job author")
+	errDirectChatImmutable = fmt.Errorf("%w: members of a direct chat cannot change", ErrInvalidChat)
+)
+
+// UpdateChatTitle меняет название группы. Доступно администраторам
+func (s *service) UpdateChatTitle(ctx context.Context, chatID, userID, title string) (*models.Chat, error) {
+	title, err := normalizeChatTitle(title)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *models.Chat
+	var messages []models.Message
+	err = s.withGroupAdmin(ctx, chatID, userID, func(ctx context.Context, tx pgx.Tx, chatGUID, userGUID uuid.UUID) error {
+		_, err := s.repo.Chat.UpdateChatTitle(ctx, tx, chat.UpdateChatTitleParams{
+			ID:    chatGUID,
+			Title: sql.NullString{String: title, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		message, err := s.createSystemMessage(ctx, tx, chatGUID, userGUID, models.MessageTypeTitleChanged, uuid.Nil, title)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+
+		result, err = s.loadChat(ctx, tx, chatGUID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, messages...)
+	return result, nil
+}
+
+// AddChatMember добавляет пользователя в группу. Доступно администраторам
+func (s *service) AddChatMember(ctx context.Context, chatID, userID, memberID, role string) (*models.Chat, error) {
+	if role == "" {
+		role = models.ChatRoleMember
+	}
+	if err := validateChatRole(role); err != nil {
+		return nil, err
+	}
+
+	memberGUID, err := uuid.Parse(memberID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user id %s", ErrInvalidChat, memberID)
+	}
+
+	var result *models.Chat
+	var messages []models.Message
+	err = s.withGroupAdmin(ctx, chatID, userID, func(ctx context.Context, tx pgx.Tx, chatGUID, userGUID uuid.UUID) error {
+		_, err := s.repo.Chat.GetChatMember(ctx, tx, chat.GetChatMemberParams{
+			ChatID: chatGUID,
+			UserID: memberGUID,
+		})
+		if err == nil {
+			return ErrChatMemberExists
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		if err := s.checkUsersExist(ctx, tx, []uuid.UUID{memberGUID}); err != nil {
+			return err
+		}
+
+		err = s.repo.Chat.AddUserToChat(ctx, tx, chat.AddUserToChatParams{
+			ChatID: chatGUID,
+			UserID: memberGUID,
+			Role:   role,
+		})
+		if err != nil {
+			return err
+		}
+
+		message, err := s.createSystemMessage(ctx, tx, chatGUID, userGUID, models.MessageTypeMemberAdded, memberGUID, "")
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+
+		result, err = s.loadChat(ctx, tx, chatGUID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, messages...)
+	return result, nil
+}
+
+// UpdateChatMemberRole меняет роль участника группы. Доступно администраторам;
+// последнего администратора понизить нельзя
+func (s *service) UpdateChatMemberRole(ctx context.Context, chatID, userID, memberID, role string) (*models.Chat, error) {
+	if err := validateChatRole(role); err != nil {
+		return nil, err
+	}
+
+	memberGUID, err := uuid.Parse(memberID)
+	if err != nil {
+		return nil, ErrChatMemberNotFound
+	}
+
+	var result *models.Chat
+	var messages []models.Message
+	err = s.withGroupAdmin(ctx, chatID, userID, func(ctx context.Context, tx pgx.Tx, chatGUID, userGUID uuid.UUID) error {
+		members, err := s.repo.Chat.GetChatMembers(ctx, tx, chatGUID)
+		if err != nil {
+			return err
+		}
+
+		admins := 0
+		var member *chat.GetChatMembersRow
+		for i := range members {
+			if members[i].Role == models.ChatRoleAdmin {
+				admins++
+			}
+			if members[i].UserID == memberGUID {
+				member = &members[i]
+			}
+		}
+		if member == nil {
+			return ErrChatMemberNotFound
+		}
+
+		if member.Role != role {
+			if member.Role == models.ChatRoleAdmin && admins == 1 {
+				return ErrLastChatAdmin
+			}
+
+			_, err = s.repo.Chat.UpdateChatMemberRole(ctx, tx, chat.UpdateChatMemberRoleParams{
+				ChatID: chatGUID,
+				UserID: memberGUID,
+				Role:   role,
+			})
+			if err != nil {
+				return err
+			}
+
+			message, err := s.createSystemMessage(ctx, tx, chatGUID, userGUID, models.MessageTypeRoleChanged, memberGUID, role)
+			if err != nil {
+				return err
+			}
+			messages = append(messages, message)
+		}
+
+		result, err = s.loadChat(ctx, tx, chatGUID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, messages...)
+	return result, nil
+}
+
+// RemoveChatMember исключает участника из группы. Исключать других могут
+// администраторы, выйти из группы может любой участник. Если ушёл последний
+// администратор, администратором становится участник, вступивший раньше всех
+func (s *service) RemoveChatMember(ctx context.Context, chatID, userID, memberID string) error {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
+	if err != nil {
+		return err
+	}
+
+	memberGUID, err := uuid.Parse(memberID)
+	if err != nil {
+		return ErrChatMemberNotFound
+	}
+
+	var messages []models.Message
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		actor, err := s.getGroupMember(ctx, tx, chatGUID, userGUID)
+		if err != nil {
+			return err
+		}
+
+		messageType := models.MessageTypeMemberLeft
+		if memberGUID != userGUID {
+			if actor.Role != models.ChatRoleAdmin {
+				return ErrNotChatAdmin
+			}
+			messageType = models.MessageTypeMemberRemoved
+		}
+
+		removed, err := s.repo.Chat.RemoveUserFromChat(ctx, tx, chat.RemoveUserFromChatParams{
+			ChatID: chatGUID,
+			UserID: memberGUID,
+		})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return ErrChatMemberNotFound
+		}
+
+		message, err := s.createSystemMessage(ctx, tx, chatGUID, userGUID, messageType, memberGUID, "")
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+
+		members, err := s.repo.Chat.GetChatMembers(ctx, tx, chatGUID)
+		if err != nil || len(members) == 0 {
+			return err
+		}
+		for _, member := range members {
+			if member.Role == models.ChatRoleAdmin {
+				return nil
+			}
+		}
+
+		// Участники отсортированы по времени вступления
+		_, err = s.repo.Chat.UpdateChatMemberRole(ctx, tx, chat.UpdateChatMemberRoleParams{
+			ChatID: chatGUID,
+			UserID: members[0].UserID,
+			Role:   models.ChatRoleAdmin,
+		})
+		if err != nil {
+			return err
+		}
+
+		message, err = s.createSystemMessage(ctx, tx, chatGUID, userGUID, models.MessageTypeRoleChanged, members[0].UserID, models.ChatRoleAdmin)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publish(ctx, messages...)
+	s.disconnectUser(chatID, memberGUID.String())
+	return nil
+}
+
+// createGroupChat создаёт группу: создатель - администратор, остальные -
+// участники
+func (s *service) createGroupChat(ctx context.Context, creatorGUID uuid.UUID, userGUIDs []uuid.UUID, req *models.CreateChatRequest) (*models.Chat, error) {
+	if req.Title == nil {
+		return nil, fmt.Errorf("%w: group chat must have a title", ErrInvalidChat)
+	}
+	title, err := normalizeChatTitle(*req.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobGUID uuid.NullUUID
+	if req.JobID != nil {
+		id, err := uuid.Parse(*req.JobID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid job id %s", ErrInvalidChat, *req.JobID)
+		}
+		jobGUID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	var result *models.Chat
+	var messages []models.Message
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkUsersExist(ctx, tx, userGUIDs); err != nil {
+			return err
+		}
+
+		// Привязать группу к вакансии может только её автор
+		if jobGUID.Valid {
+			job, err := s.repo.Job.GetJobByID(ctx, tx, jobGUID.UUID)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: job %s not found", ErrInvalidChat, jobGUID.UUID)
+			}
+			if err != nil {
+				return err
+			}
+			if job.AuthorID != creatorGUID {
+				return ErrNotJobAuthor
+			}
+		}
+
+		created, err := s.repo.Chat.CreateChat(ctx, tx, chat.CreateChatParams{
+			Type:  models.ChatTypeGroup,
+			Title: sql.NullString{String: title, Valid: true},
+			JobID: jobGUID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, userGUID := range userGUIDs {
+			role := models.ChatRoleMember
+			if userGUID == creatorGUID {
+				role = models.ChatRoleAdmin
+			}
+			err = s.repo.Chat.AddUserToChat(ctx, tx, chat.AddUserToChatParams{
+				ChatID: created.ID,
+				UserID: userGUID,
+				Role:   role,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		message, err := s.createSystemMessage(ctx, tx, created.ID, creatorGUID, models.MessageTypeChatCreated, uuid.Nil, title)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+
+		result, err = s.loadChat(ctx, tx, created.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, messages...)
+	return result, nil
+}
+
+// withGroupAdmin выполняет fn в транзакции, если пользователь - администратор
+// группы
+func (s *service) withGroupAdmin(ctx context.Context, chatID, userID string, fn func(ctx context.Context, tx pgx.Tx, chatGUID, userGUID uuid.UUID) error) error {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		member, err := s.getGroupMember(ctx, tx, chatGUID, userGUID)
+		if err != nil {
+			return err
+		}
+		if member.Role != models.ChatRoleAdmin {
+			return ErrNotChatAdmin
+		}
+		return fn(ctx, tx, chatGUID, userGUID)
+	})
+}
+
+// getGroupMember возвращает участника группы. Для чужого чата возвращается
+// ErrNotChatMember, для личного - ошибка: его состав не меняется
+func (s *service) getGroupMember(ctx context.Context, tx pgx.Tx, chatGUID, userGUID uuid.UUID) (chat.GetChatMemberRow, error) {
+	member, err := s.repo.Chat.GetChatMember(ctx, tx, chat.GetChatMemberParams{
+		ChatID: chatGUID,
+		UserID: userGUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return chat.GetChatMemberRow{}, ErrNotChatMember
+	}
+	if err != nil {
+		return chat.GetChatMemberRow{}, err
+	}
+
+	result, err := s.repo.Chat.GetChatByID(ctx, tx, chatGUID)
+	if err != nil {
+		return chat.GetChatMemberRow{}, err
+	}
+	if result.Type != models.ChatTypeGroup {
+		return chat.GetChatMemberRow{}, errDirectChatImmutable
+	}
+
+	return member, nil
+}
+
+func (s *service) checkUsersExist(ctx context.Context, tx pgx.Tx, userGUIDs []uuid.UUID) error {
+	for _, userGUID := range userGUIDs {
+		_, err := s.repo.Profile.GetProfileByGUID(ctx, tx, userGUID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: user %s not found", ErrInvalidChat, userGUID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseChatAndUser(chatID, userID string) (uuid.UUID, uuid.UUID, error) {
+	chatGUID, err := uuid.Parse(chatID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, ErrChatNotFound
+	}
+
+	userGUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return chatGUID, userGUID, nil
+}
+
+func normalizeChatTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", fmt.Errorf("%w: title is empty", ErrInvalidChat)
+	}
+	if utf8.RuneCountInString(title) > maxChatTitleRunes {
+		return "", fmt.Errorf("%w: title is longer than %d characters", ErrInvalidChat, maxChatTitleRunes)
+	}
+	return title, nil
+}
+
+func validateChatRole(role string) error {
+	if role != models.ChatRoleAdmin && role != models.ChatRoleMember {
+		return fmt.Errorf("%w: unknown role %s", ErrInvalidChat, role)
+	}
+	return nil
+}
@@ -0,0 +1,440 @@
+package chat
+
+import (
+	"PlatformService/internal/models"
+	"PlatformService/internal/repository/chat"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// Эмодзи из нескольких символов (флаги, семьи, оттенки кожи) укладываются в лимит
+const maxReactionRunes = 10
+
+var ErrNotMessageAuthor = errors.New("user is not the message author")
+
+// EditMessage меняет текст своего сообщения. Прежний текст сохраняется в
+// истории правок, участники получают событие message-updated
+func (s *service) EditMessage(ctx context.Context, chatID, userID, messageID, text string) (*models.Message, error) {
+	chatGUID, userGUID, messageGUID, err := parseMessageIDs(chatID, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var message models.Message
+	var changed bool
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		current, err := s.getOwnMessage(ctx, tx, chatGUID, userGUID, messageGUID)
+		if err != nil {
+			return err
+		}
+
+		result := current
+		if current.Text != text {
+			if strings.TrimSpace(text) == "" {
+				attachments, err := s.repo.Chat.GetMessagesAttachments(ctx, tx, []uuid.UUID{messageGUID})
+				if err != nil {
+					return err
+				}
+				if len(attachments) == 0 {
+					return fmt.Errorf("%w: message is empty", ErrInvalidMessage)
+				}
+			}
+
+			err = s.repo.Chat.CreateMessageEdit(ctx, tx, chat.CreateMessageEditParams{
+				MessageID: messageGUID,
+				Text:      current.Text,
+			})
+			if err != nil {
+				return err
+			}
+
+			result, err = s.repo.Chat.UpdateMessageText(ctx, tx, chat.UpdateMessageTextParams{
+				ID:   messageGUID,
+				Text: text,
+			})
+			if err != nil {
+				return err
+			}
+			changed = true
+		}
+
+		messages := []models.Message{mapMessage(result)}
+		if err := s.loadMessageDetails(ctx, tx, messages); err != nil {
+			return err
+		}
+		message = messages[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		s.publishUpdate(ctx, message)
+	}
+	return &message, nil
+}
+
+// DeleteMessage удаляет своё сообщение: в чате остаётся заглушка без текста,
+// вложения, история правок и реакции удаляются
+func (s *service) DeleteMessage(ctx context.Context, chatID, userID, messageID string) error {
+	chatGUID, userGUID, messageGUID, err := parseMessageIDs(chatID, userID, messageID)
+	if err != nil {
+		return err
+	}
+
+	var message models.Message
+	var objects []string
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if _, err := s.getOwnMessage(ctx, tx, chatGUID, userGUID, messageGUID); err != nil {
+			return err
+		}
+
+		result, err := s.repo.Chat.DeleteMessage(ctx, tx, messageGUID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Chat.DeleteMessageEdits(ctx, tx, messageGUID); err != nil {
+			return err
+		}
+		if err := s.repo.Chat.DeleteMessageReactions(ctx, tx, messageGUID); err != nil {
+			return err
+		}
+
+		attachments, err := s.repo.Chat.DeleteMessageAttachments(ctx, tx, uuid.NullUUID{UUID: messageGUID, Valid: true})
+		if err != nil {
+			return err
+		}
+		// Файл CV принадлежит резюме и остаётся в хранилище
+		for _, attachment := range attachments {
+			if attachment.Kind == models.AttachmentKindCV {
+				continue
+			}
+			objects = append(objects, attachment.ObjectName)
+			if attachment.PreviewObjectName.Valid {
+				objects = append(objects, attachment.PreviewObjectName.String)
+			}
+		}
+
+		message = mapMessage(result)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteObjects(ctx, objects)
+	s.publishUpdate(ctx, message)
+	return nil
+}
+
+// GetMessageEdits возвращает прежние версии текста сообщения от старых к новым
+func (s *service) GetMessageEdits(ctx context.Context, chatID, userID, messageID string) ([]models.MessageEdit, error) {
+	chatGUID, userGUID, messageGUID, err := parseMessageIDs(chatID, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	edits := []models.MessageEdit{}
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+			return err
+		}
+
+		_, err := s.repo.Chat.GetChatMessageByID(ctx, tx, chat.GetChatMessageByIDParams{
+			ID:     messageGUID,
+			ChatID: chatGUID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMessageNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		results, err := s.repo.Chat.GetMessageEdits(ctx, tx, messageGUID)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			edits = append(edits, models.MessageEdit{
+				Text:       result.Text,
+				ReplacedAt: result.ReplacedAt,
+			})
+		}
+		return nil
+	})
+
+	return edits, err
+}
+
+// AddReaction ставит эмодзи под сообщением. Повторная реакция тем же эмодзи
+// ничего не меняет
+func (s *service) AddReaction(ctx context.Context, chatID, userID, messageID, emoji string) (*models.Message, error) {
+	return s.updateReaction(ctx, chatID, userID, messageID, emoji, true)
+}
+
+// RemoveReaction снимает реакцию пользователя
+func (s *service) RemoveReaction(ctx context.Context, chatID, userID, messageID, emoji string) (*models.Message, error) {
+	return s.updateReaction(ctx, chatID, userID, messageID, emoji, false)
+}
+
+func (s *service) updateReaction(ctx context.Context, chatID, userID, messageID, emoji string, add bool) (*models.Message, error) {
+	chatGUID, userGUID, messageGUID, err := parseMessageIDs(chatID, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEmoji(emoji); err != nil {
+		return nil, err
+	}
+
+	var message models.Message
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+			return err
+		}
+
+		result, err := s.repo.Chat.GetChatMessageByID(ctx, tx, chat.GetChatMessageByIDParams{
+			ID:     messageGUID,
+			ChatID: chatGUID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && result.DeletedAt.Valid) {
+			return ErrMessageNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if result.Type != models.MessageTypeText {
+			return fmt.Errorf("%w: system messages have no reactions", ErrInvalidMessage)
+		}
+
+		if add {
+			err = s.repo.Chat.AddMessageReaction(ctx, tx, chat.AddMessageReactionParams{
+				MessageID: messageGUID,
+				UserID:    userGUID,
+				Emoji:     emoji,
+			})
+		} else {
+			_, err = s.repo.Chat.RemoveMessageReaction(ctx, tx, chat.RemoveMessageReactionParams{
+				MessageID: messageGUID,
+				UserID:    userGUID,
+				Emoji:     emoji,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		messages := []models.Message{mapMessage(result)}
+		if err := s.loadMessageDetails(ctx, tx, messages); err != nil {
+			return err
+		}
+		message = messages[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishUpdate(ctx, message)
+	return &message, nil
+}
+
+// getOwnMessage блокирует сообщение пользователя для правки или удаления.
+// Удалённое сообщение считается ненайденным
+func (s *service) getOwnMessage(ctx context.Context, tx pgx.Tx, chatGUID, userGUID, messageGUID uuid.UUID) (chat.ChatMessage, error) {
+	if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+		return chat.ChatMessage{}, err
+	}
+
+	message, err := s.repo.Chat.GetChatMessageForUpdate(ctx, tx, chat.GetChatMessageForUpdateParams{
+		ID:     messageGUID,
+		ChatID: chatGUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && message.DeletedAt.Valid) {
+		return chat.ChatMessage{}, ErrMessageNotFound
+	}
+	if err != nil {
+		return chat.ChatMessage{}, err
+	}
+	if message.Type != models.MessageTypeText {
+		return chat.ChatMessage{}, fmt.Errorf("%w: system messages cannot be changed", ErrInvalidMessage)
+	}
+	if message.UserID != userGUID {
+		return chat.ChatMessage{}, ErrNotMessageAuthor
+	}
+	return message, nil
+}
+
+// getReplyTarget проверяет, что на сообщение можно ответить: оно из этого
+// чата, не системное и не удалено
+func (s *service) getReplyTarget(ctx context.Context, tx pgx.Tx, chatGUID uuid.UUID, replyToID string) (uuid.NullUUID, error) {
+	if replyToID == "" {
+		return uuid.NullUUID{}, nil
+	}
+
+	replyGUID, err := uuid.Parse(replyToID)
+	if err != nil {
+		return uuid.NullUUID{}, fmt.Errorf("%w: invalid reply message id %s", ErrInvalidMessage, replyToID)
+	}
+
+	message, err := s.repo.Chat.GetChatMessageByID(ctx, tx, chat.GetChatMessageByIDParams{
+		ID:     replyGUID,
+		ChatID: chatGUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && (message.DeletedAt.Valid || message.Type != models.MessageTypeText)) {
+		return uuid.NullUUID{}, fmt.Errorf("%w: reply message not found", ErrInvalidMessage)
+	}
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+
+	return uuid.NullUUID{UUID: replyGUID, Valid: true}, nil
+}
+
+// loadMessageDetails заполняет вложения, цитаты и реакции сообщений
+func (s *service) loadMessageDetails(ctx context.Context, tx pgx.Tx, messages []models.Message) error {
+	if err := s.loadAttachments(ctx, tx, messages); err != nil {
+		return err
+	}
+	if err := s.loadReplies(ctx, tx, messages); err != nil {
+		return err
+	}
+	return s.loadReactions(ctx, tx, messages)
+}
+
+// loadReplies заполняет цитаты сообщений, на которые отвечают, одним запросом
+func (s *service) loadReplies(ctx context.Context, tx pgx.Tx, messages []models.Message) error {
+	var ids []uuid.UUID
+	for _, message := range messages {
+		if message.ReplyTo == nil {
+			continue
+		}
+		id, err := uuid.Parse(message.ReplyTo.ID)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	results, err := s.repo.Chat.GetMessagesByIDs(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	replies := make(map[string]chat.ChatMessage, len(results))
+	for _, result := range results {
+		replies[result.ID.String()] = result
+	}
+	for i := range messages {
+		if messages[i].ReplyTo == nil {
+			continue
+		}
+		if reply, ok := replies[messages[i].ReplyTo.ID]; ok {
+			messages[i].ReplyTo.UserID = reply.UserID.String()
+			messages[i].ReplyTo.Text = reply.Text
+			messages[i].ReplyTo.Deleted = reply.DeletedAt.Valid
+		}
+	}
+	return nil
+}
+
+// loadReactions группирует реакции по эмодзи в порядке появления
+func (s *service) loadReactions(ctx context.Context, tx pgx.Tx, messages []models.Message) error {
+	ids := make([]uuid.UUID, 0, len(messages))
+	for _, message := range messages {
+		id, err := uuid.Parse(message.ID)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	results, err := s.repo.Chat.GetMessagesReactions(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	byMessage := make(map[string][]models.Reaction)
+	for _, result := range results {
+		messageID := result.MessageID.String()
+		reactions := byMessage[messageID]
+		i := 0
+		for i < len(reactions) && reactions[i].Emoji != result.Emoji {
+			i++
+		}
+		if i == len(reactions) {
+			reactions = append(reactions, models.Reaction{Emoji: result.Emoji})
+		}
+		reactions[i].Count++
+		reactions[i].UserIDs = append(reactions[i].UserIDs, result.UserID.String())
+		byMessage[messageID] = reactions
+	}
+	for i := range messages {
+		if reactions, ok := byMessage[messages[i].ID]; ok {
+			messages[i].Reactions = reactions
+		}
+	}
+	return nil
+}
+
+// publishUpdate рассылает участникам чата изменённое сообщение
+func (s *service) publishUpdate(ctx context.Context, message models.Message) {
+	messageAPI, err := s.mapMessageAPI(ctx, message)
+	if err != nil {
+		log.Printf("Failed to get profile %s for message %s: %v", message.UserID, message.ID, err)
+		return
+	}
+
+	event, err := json.Marshal(messageUpdatedEvent{Type: EventTypeMessageUpdated, Message: messageAPI})
+	if err != nil {
+		log.Printf("Failed to marshal message %s: %v", message.ID, err)
+		return
+	}
+
+	s.send(message.ChatID, event, "")
+}
+
+func parseMessageIDs(chatID, userID, messageID string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	chatGUID, userGUID, err := parseChatAndUser(chatID, userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	messageGUID, err := uuid.Parse(messageID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, ErrMessageNotFound
+	}
+
+	return chatGUID, userGUID, messageGUID, nil
+}
+
+// validateEmoji отсекает пустые реакции, текст и пробелы. Сам набор эмодзи не
+// проверяется: он растёт с каждой версией Unicode
+func validateEmoji(emoji string) error {
+	if emoji == "" || !utf8.ValidString(emoji) || utf8.RuneCountInString(emoji) > maxReactionRunes {
+		return fmt.Errorf("%w: invalid reaction", ErrInvalidMessage)
+	}
+	for _, r := range emoji {
+		if unicode.IsLetter(r) || unicode.IsSpace(r) || unicode.IsControl(r) {
+			return fmt.Errorf("%w: invalid reaction", ErrInvalidMessage)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package chat
+
+import (
+	"PlatformService/internal/models"
+	"PlatformService/internal/repository/chat"
+	"PlatformService/internal/service/textsearch"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// Сколько сообщений до и после найденного отдаётся для перехода к нему
+const maxContextMessages = 50
+
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+var snippetOptions = textsearch.HeadlineOptions("MaxWords=35, MinWords=15")
+
+// SearchMessages ищет по тексту сообщений в чатах пользователя (или в одном
+// чате chatID). Запрос разбирается русским и английским словарями, выдача
+// упорядочена по релевантности, затем от новых сообщений к старым. Удалённые
+// и системные сообщения не ищутся
+func (s *service) SearchMessages(ctx context.Context, userID, query, chatID string, limit, offset int) ([]models.MessageSearchResult, error) {
+	userGUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: query is empty", ErrInvalidSearchQuery)
+	}
+
+	params := chat.SearchMessagesParams{
+		Query:           query,
+		HeadlineOptions: snippetOptions,
+		UserID:          userGUID,
+		LimitCount:      int32(limit),
+		OffsetCount:     int32(offset),
+	}
+
+	results := []models.MessageSearchResult{}
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if chatID != "" {
+			chatGUID, err := uuid.Parse(chatID)
+			if err != nil {
+				return ErrChatNotFound
+			}
+			if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+				return err
+			}
+			params.ChatID = uuid.NullUUID{UUID: chatGUID, Valid: true}
+		}
+
+		rows, err := s.repo.Chat.SearchMessages(ctx, tx, params)
+		if err != nil {
+			return err
+		}
+
+		messages := make([]models.Message, len(rows))
+		for i, row := range rows {
+			messages[i] = mapMessage(chat.ChatMessage{
+				ID:           row.ID,
+				ChatID:       row.ChatID,
+				UserID:       row.UserID,
+				Text:         row.Text,
+				CreatedAt:    row.CreatedAt,
+				Type:         row.Type,
+				TargetUserID: row.TargetUserID,
+				EditedAt:     row.EditedAt,
+				DeletedAt:    row.DeletedAt,
+				ReplyToID:    row.ReplyToID,
+			})
+		}
+		if err := s.loadMessageDetails(ctx, tx, messages); err != nil {
+			return err
+		}
+
+		for i, row := range rows {
+			results = append(results, models.MessageSearchResult{
+				Message: messages[i],
+				Score:   row.Score,
+				Snippet: textsearch.ParseSnippet(row.Snippet),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetMessageContext возвращает сообщение и до limit сообщений до и после
+// него в хронологическом порядке - для перехода к результату поиска
+func (s *service) GetMessageContext(ctx context.Context, chatID, userID, messageID string, limit int) ([]models.Message, error) {
+	chatGUID, userGUID, messageGUID, err := parseMessageIDs(chatID, userID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	limit = min(max(limit, 0), maxContextMessages)
+
+	var messages []models.Message
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkChatMember(ctx, tx, chatGUID, userGUID); err != nil {
+			return err
+		}
+
+		hit, err := s.repo.Chat.GetChatMessageByID(ctx, tx, chat.GetChatMessageByIDParams{
+			ID:     messageGUID,
+			ChatID: chatGUID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMessageNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		before, err := s.repo.Chat.GetMessagesBefore(ctx, tx, chat.GetMessagesBeforeParams{
+			ChatID:     chatGUID,
+			CreatedAt:  hit.CreatedAt,
+			ID:         hit.ID,
+			LimitCount: int32(limit),
+		})
+		if err != nil {
+			return err
+		}
+		after, err := s.repo.Chat.GetMessagesAfter(ctx, tx, chat.GetMessagesAfterParams{
+			ChatID:     chatGUID,
+			CreatedAt:  hit.CreatedAt,
+			ID:         hit.ID,
+			LimitCount: int32(limit),
+		})
+		if err != nil {
+			return err
+		}
+
+		// Сообщения до найденного выбираются от новых к старым
+		slices.Reverse(before)
+		for _, result := range slices.Concat(before, []chat.ChatMessage{hit}, after) {
+			messages = append(messages, mapMessage(result))
+		}
+		return s.loadMessageDetails(ctx, tx, messages)
+	})
+
+	return messages, err
+}
@@ -4,9 +4,11 @@ import (
 	"PlatformService/internal/models"
 	"PlatformService/internal/repository"
 	repository_company "PlatformService/internal/repository/company"
+	"PlatformService/internal/service/media"
 	"PlatformService/internal/utils"
 	"context"
 	"database/sql"
+	"mime/multipart"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,13 +21,15 @@ type Service interface {
 	CreateCompany(ctx context.Context, userGUID string, company *models.Company) (*models.Company, error)
 	GetCompany(ctx context.Context, companyId string) (*models.Company, error)
 	UpdateCompany(ctx context.Context, userGUID string, companyId string, company *models.Company) (*models.Company, error)
+	UpdateLogo(ctx context.Context, userGUID string, companyId string, header *multipart.FileHeader) (*models.Company, error)
 	DeleteCompany(ctx context.Context, userGUID string, companyId string) error
 	SearchCompanies(ctx context.Context, name string) ([]models.ShortCompany, error)
 	DeleteExperience(ctx context.Context, userGUID string, experienceGUID string) error
 }
 
 type service struct {
-	repo *repository.Repositories
+	repo         *repository.Repositories
+	mediaService media.Service
 }
 
 func (s *service) GetExperience(ctx context.Context, userGUID string) ([]models.Experience, error) {
@@ -151,17 +155,7 @@ func (s *service) CreateCompany(ctx context.Context, userGUID string, company *m
 			return err
 		}
 
-		createdCompany = &models.Company{
-			Guid:          result.Guid.String(),
-			Name:          result.Name,
-			Description:   &result.Description.String,
-			Email:         &result.Email.String,
-			Phone:         &result.Phone.String,
-			Website:       &result.Website.String,
-			Address:       &result.Address.String,
-			Avatar:        &result.Avatar.String,
-			ShortLinkName: &result.ShortLinkName.String,
-		}
+		createdCompany = s.mapCompany(result)
 		return nil
 	})
 
@@ -196,17 +190,7 @@ func (s *service) GetCompany(ctx context.Context, companyId string) (*models.Com
 			return err
 		}
 
-		company = &models.Company{
-			Guid:          result.Guid.String(),
-			Name:          result.Name,
-			Description:   &result.Description.String,
-			Email:         &result.Email.String,
-			Phone:         &result.Phone.String,
-			Website:       &result.Website.String,
-			Address:       &result.Address.String,
-			Avatar:        &result.Avatar.String,
-			ShortLinkName: &result.ShortLinkName.String,
-		}
+		company = s.mapCompany(result)
 		return nil
 	})
 
@@ -236,23 +220,59 @@ func (s *service) UpdateCompany(ctx context.Context, userGUID string, companyId
 			return err
 		}
 
-		updatedCompany = &models.Company{
-			Guid:          result.Guid.String(),
-			Name:          result.Name,
-			Description:   &result.Description.String,
-			Email:         &result.Email.String,
-			Phone:         &result.Phone.String,
-			Website:       &result.Website.String,
-			Address:       &result.Address.String,
-			Avatar:        &result.Avatar.String,
-			ShortLinkName: &result.ShortLinkName.String,
-		}
+		updatedCompany = s.mapCompany(result)
 		return nil
 	})
 
 	return updatedCompany, err
 }
 
+// UpdateLogo сохраняет загруженное изображение логотипом компании.
+// Прежний логотип, загруженный на платформу, удаляется из хранилища.
+func (s *service) UpdateLogo(ctx context.Context, userGUID string, companyId string, header *multipart.FileHeader) (*models.Company, error) {
+	companyGUID, err := uuid.Parse(companyId)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails, err := s.mediaService.UploadImage(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous sql.NullString
+	var updatedCompany *models.Company
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		company, err := s.repo.Company.GetCompanyByGUID(ctx, tx, companyGUID)
+		if err != nil {
+			return err
+		}
+		previous = company.Avatar
+
+		result, err := s.repo.Company.UpdateCompanyAvatar(ctx, tx, repository_company.UpdateCompanyAvatarParams{
+			Avatar: sql.NullString{String: thumbnails.Large, Valid: true},
+			Guid:   companyGUID,
+		})
+		if err != nil {
+			return err
+		}
+
+		updatedCompany = s.mapCompany(result)
+		return nil
+	})
+	if err != nil {
+		_ = s.mediaService.DeleteImage(ctx, thumbnails.Large)
+		return nil, err
+	}
+
+	// Логотип уже заменён: не удалённые копии прежнего только занимают место
+	if previous.Valid {
+		_ = s.mediaService.DeleteImage(ctx, previous.String)
+	}
+
+	return updatedCompany, nil
+}
+
 func (s *service) DeleteCompany(ctx context.Context, userGUID string, companyId string) error {
 	companyGUID, err := uuid.Parse(companyId)
 	if err != nil {
@@ -305,6 +325,21 @@ func (s *service) DeleteExperience(ctx context.Context, userGUID string, experie
 	})
 }
 
-func NewService(repo *repository.Repositories) Service {
-	return &service{repo: repo}
+func (s *service) mapCompany(result repository_company.CompanyCompany) *models.Company {
+	return &models.Company{
+		Guid:             result.Guid.String(),
+		Name:             result.Name,
+		Description:      &result.Description.String,
+		Email:            &result.Email.String,
+		Phone:            &result.Phone.String,
+		Website:          &result.Website.String,
+		Address:          &result.Address.String,
+		Avatar:           &result.Avatar.String,
+		AvatarThumbnails: s.mediaService.Thumbnails(result.Avatar.String),
+		ShortLinkName:    &result.ShortLinkName.String,
+	}
+}
+
+func NewService(repo *repository.Repositories, mediaService media.Service) Service {
+	return &service{repo: repo, mediaService: mediaService}
 }
@@ -0,0 +1,272 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	repository_job "PlatformService/internal/repository/job"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Из резюме пользователя в запрос к модели попадает не больше этого числа
+// символов, чтобы пачка кандидатов помещалась в контекстное окно
+const applicantCVMaxRunes = 6000
+
+// MatchApplicants оценивает под вакансию пользователей платформы: всех
+// откликнувшихся и, если includeProfiles, открытых к предложениям. Оценка
+// строится по опыту работы из профиля и тексту загруженного резюме теми же
+// критериями, что и подбор из базы резюме, и так же кэшируется.
+func (s *service) MatchApplicants(ctx context.Context, userGUID, jobID string, limit int, includeProfiles, refresh bool) (*models.MatchApplicantsResponse, error) {
+	job, err := s.getAuthoredJob(ctx, userGUID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = min(limit, matchShortlistSize)
+
+	var shortlist []repository_cv.ShortlistApplicantsRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		shortlist, err = s.repo.CV.ShortlistApplicants(ctx, tx, repository_cv.ShortlistApplicantsParams{
+			Query:           job.Title + "\n" + job.Requirements,
+			JobID:           job.ID,
+			IncludeProfiles: includeProfiles,
+			AuthorID:        job.AuthorID,
+			LimitCount:      matchShortlistSize,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to shortlist applicants: %w", err)
+	}
+
+	if len(shortlist) == 0 {
+		return &models.MatchApplicantsResponse{Candidates: []models.MatchedApplicant{}, MatchedAt: time.Now()}, nil
+	}
+
+	shortlistHash := hashApplicantShortlist(shortlist)
+
+	if !refresh {
+		response, err := s.getCachedApplicantMatch(ctx, job, shortlistHash, limit)
+		if err != nil {
+			return nil, err
+		}
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	applicants := make([]models.ResumeRecord, len(shortlist))
+	for i, row := range shortlist {
+		applicants[i] = s.applicantRecord(ctx, row)
+	}
+
+	// Оцениваем кандидатов через DeepSeek. Идентификатором кандидата в запросе
+	// служит GUID профиля
+	scores, promptVersion, err := s.scoreCandidates(ctx, matchJobDescription(job), applicants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match applicants: %w", err)
+	}
+
+	var dbResults []repository_cv.GetJobApplicantMatchResultsRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.repo.CV.UpsertJobApplicantMatchRun(ctx, tx, repository_cv.UpsertJobApplicantMatchRunParams{
+			JobID:         job.ID,
+			JobUpdatedAt:  job.UpdatedAt,
+			ShortlistHash: shortlistHash,
+			ShortlistSize: int32(len(shortlist)),
+			PromptVersion: promptVersion,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.CV.DeleteJobApplicantMatchResults(ctx, tx, job.ID); err != nil {
+			return err
+		}
+
+		for _, score := range scores {
+			err := s.repo.CV.CreateJobApplicantMatchResult(ctx, tx, repository_cv.CreateJobApplicantMatchResultParams{
+				JobID:           job.ID,
+				ProfileID:       score.ResumeID,
+				MatchScore:      score.MatchScore,
+				SkillsScore:     score.SkillsScore,
+				ExperienceScore: score.ExperienceScore,
+				SeniorityScore:  score.SeniorityScore,
+				LocationScore:   score.LocationScore,
+				Reasoning:       score.Reasoning,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		dbResults, err = s.repo.CV.GetJobApplicantMatchResults(ctx, tx, repository_cv.GetJobApplicantMatchResultsParams{
+			JobID: job.ID,
+			Limit: int32(limit),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save match results: %w", err)
+	}
+
+	return &models.MatchApplicantsResponse{
+		Candidates:    s.mapMatchedApplicants(dbResults),
+		ShortlistSize: len(shortlist),
+		MatchedAt:     time.Now(),
+	}, nil
+}
+
+func (s *service) getCachedApplicantMatch(ctx context.Context, job repository_job.JobJob, shortlistHash string, limit int) (*models.MatchApplicantsResponse, error) {
+	var response *models.MatchApplicantsResponse
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		run, err := s.repo.CV.GetJobApplicantMatchRun(ctx, tx, job.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !run.JobUpdatedAt.Equal(job.UpdatedAt) || run.ShortlistHash != shortlistHash {
+			return nil
+		}
+
+		dbResults, err := s.repo.CV.GetJobApplicantMatchResults(ctx, tx, repository_cv.GetJobApplicantMatchResultsParams{
+			JobID: job.ID,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return err
+		}
+
+		response = &models.MatchApplicantsResponse{
+			Candidates:    s.mapMatchedApplicants(dbResults),
+			ShortlistSize: int(run.ShortlistSize),
+			Cached:        true,
+			MatchedAt:     run.MatchedAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached match: %w", err)
+	}
+
+	return response, nil
+}
+
+// applicantRecord собирает из профиля пользователя запись в формате резюме
+// для оценки моделью. Если пользователь загрузил резюме на платформу, его
+// текст добавляется к опыту из профиля; внешние ссылки не скачиваются.
+// Текст берётся сохранённый при загрузке, файл разбирается только для
+// резюме, загруженных до появления extracted_text.
+func (s *service) applicantRecord(ctx context.Context, row repository_cv.ShortlistApplicantsRow) models.ResumeRecord {
+	var analysis strings.Builder
+	if row.Experience != "" {
+		fmt.Fprintf(&analysis, "Опыт работы из профиля:\n%s\n", row.Experience)
+	}
+
+	if row.CvLink.Valid && strings.HasPrefix(row.CvLink.String, s.serverFullAddress+"/api/v1/cv/") {
+		objectName := path.Base(row.CvLink.String)
+		ext := strings.ToLower(path.Ext(objectName))
+		text := row.CvText.String
+		if !row.CvText.Valid && isValidResumeFile(ext) {
+			var err error
+			text, err = s.storeFileText(ctx, objectName, ext)
+			if err != nil {
+				s.log.WarnContext(ctx, "cv.applicantRecord failed to extract CV text", "profile_id", row.Guid, "error", err)
+			}
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			if runes := []rune(text); len(runes) > applicantCVMaxRunes {
+				text = string(runes[:applicantCVMaxRunes])
+			}
+			fmt.Fprintf(&analysis, "Резюме:\n%s\n", text)
+		}
+	}
+
+	if analysis.Len() == 0 {
+		analysis.WriteString("Пользователь не указал опыт работы и не загрузил резюме")
+	}
+
+	return models.ResumeRecord{
+		ID:            row.Guid.String(),
+		CandidateName: row.Description,
+		CandidateAge:  ageFromBirthday(row.Birthday, time.Now()),
+		Analysis:      analysis.String(),
+	}
+}
+
+// hashApplicantShortlist меняется, если изменился состав выборки, профиль,
+// опыт работы или резюме кого-то из отобранных пользователей.
+func hashApplicantShortlist(rows []repository_cv.ShortlistApplicantsRow) string {
+	hash := sha256.New()
+	for _, row := range rows {
+		fmt.Fprintf(hash, "%s:%d:%s:%s\n", row.Guid, row.UpdatedAt.Time.UnixNano(), row.CvLink.String, row.Experience)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ageFromBirthday вычисляет возраст по дате рождения из профиля (YYYY-MM-DD)
+func ageFromBirthday(birthday string, now time.Time) *int {
+	date, err := time.Parse("2006-01-02", birthday)
+	if err != nil {
+		return nil
+	}
+
+	age := now.Year() - date.Year()
+	if now.Month() < date.Month() || (now.Month() == date.Month() && now.Day() < date.Day()) {
+		age--
+	}
+	if age < 0 {
+		return nil
+	}
+	return &age
+}
+
+func (s *service) mapMatchedApplicants(dbResults []repository_cv.GetJobApplicantMatchResultsRow) []models.MatchedApplicant {
+	applicants := make([]models.MatchedApplicant, len(dbResults))
+	for i, result := range dbResults {
+		applicants[i] = models.MatchedApplicant{
+			ProfileID:  result.ProfileID.String(),
+			Name:       result.Description,
+			Email:      result.Email,
+			MatchScore: int(result.MatchScore),
+			Scores: models.MatchScoreBreakdown{
+				Skills:     int(result.SkillsScore),
+				Experience: int(result.ExperienceScore),
+				Seniority:  int(result.SeniorityScore),
+			},
+			Reasoning: result.Reasoning,
+		}
+		if result.Avatar.Valid {
+			applicants[i].Avatar = &result.Avatar.String
+		}
+		if result.CvLink.Valid {
+			// Кандидат откликнулся или открыт к предложениям: автор вакансии
+			// получает временную ссылку на его CV
+			cvLink := s.signedFileLink(result.CvLink.String)
+			applicants[i].CVLink = &cvLink
+		}
+		if result.ApplicationID.Valid {
+			applicationID := result.ApplicationID.UUID.String()
+			applicants[i].ApplicationID = &applicationID
+		}
+		if result.ApplicationStatus.Valid {
+			applicants[i].ApplicationStatus = &result.ApplicationStatus.String
+		}
+		if result.LocationScore.Valid {
+			location := int(result.LocationScore.Int32)
+			applicants[i].Scores.Location = &location
+		}
+	}
+	return applicants
+}
@@ -0,0 +1,84 @@
+package cv
+
+import (
+	repository_cv "PlatformService/internal/repository/cv"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeFileTextQuerier запоминает сохранённый текст файлов
+type fakeFileTextQuerier struct {
+	repository_cv.Querier
+	saved []repository_cv.SetStoredFileTextParams
+}
+
+func (f *fakeFileTextQuerier) SetStoredFileText(_ context.Context, _ repository_cv.DBTX, arg repository_cv.SetStoredFileTextParams) error {
+	f.saved = append(f.saved, arg)
+	return nil
+}
+
+// Сохранённый текст резюме используется без обращения к хранилищу, а резюме
+// без сохранённого текста разбирается один раз и текст сохраняется
+func TestApplicantRecordCVText(t *testing.T) {
+	const objectName = "cv.txt"
+
+	tests := []struct {
+		name        string
+		cvText      sql.NullString
+		unavailable bool
+		wantText    string
+		wantSaved   bool
+	}{
+		{
+			name:        "stored text",
+			cvText:      sql.NullString{String: "Сохранённый текст резюме", Valid: true},
+			unavailable: true,
+			wantText:    "Сохранённый текст резюме",
+		},
+		{
+			name:      "extracted and saved",
+			wantText:  strings.TrimSpace(testResumeText),
+			wantSaved: true,
+		},
+		{
+			name:        "storage unavailable",
+			unavailable: true,
+			wantText:    "не загрузил резюме",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier := &fakeFileTextQuerier{}
+			s, store := newIngestionTestService(t, querier)
+			s.serverFullAddress = "http://localhost"
+			if err := store.Put(context.Background(), objectName, strings.NewReader(testResumeText), int64(len(testResumeText)), "text/plain"); err != nil {
+				t.Fatalf("failed to store CV: %v", err)
+			}
+			store.unavailable = tt.unavailable
+
+			record := s.applicantRecord(context.Background(), repository_cv.ShortlistApplicantsRow{
+				Guid:   uuid.New(),
+				CvLink: sql.NullString{String: s.fileURL(objectName), Valid: true},
+				CvText: tt.cvText,
+			})
+
+			if !strings.Contains(record.Analysis, tt.wantText) {
+				t.Errorf("analysis = %q, want it to contain %q", record.Analysis, tt.wantText)
+			}
+			if !tt.wantSaved {
+				if len(querier.saved) != 0 {
+					t.Errorf("saved = %v, want nothing saved", querier.saved)
+				}
+				return
+			}
+			if len(querier.saved) != 1 || querier.saved[0].ObjectName != objectName || querier.saved[0].ExtractedText.String != tt.wantText {
+				t.Errorf("saved = %v, want text of %s", querier.saved, objectName)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	ErrCandidateNotFound = errors.New("candidate not found")
+	ErrInvalidMerge      = errors.New("invalid candidates merge")
+)
+
+// resolveCandidate находит кандидата, к которому относится резюме, или
+// создаёт нового. Кандидаты пользователя блокируются до конца транзакции,
+// чтобы параллельные воркеры не создали двух кандидатов для одного человека.
+func (s *service) resolveCandidate(ctx context.Context, tx pgx.Tx, userUUID uuid.UUID, identity candidateIdentity) (uuid.UUID, error) {
+	if err := s.repo.CV.LockUserCandidates(ctx, tx, userUUID.String()); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to lock candidates: %w", err)
+	}
+
+	candidates, err := s.repo.CV.GetCandidatesByUserID(ctx, tx, userUUID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get candidates: %w", err)
+	}
+
+	email := sql.NullString{String: identity.email, Valid: identity.email != ""}
+	phone := sql.NullString{String: identity.phone, Valid: identity.phone != ""}
+
+	if candidate := matchCandidate(candidates, identity); candidate != nil {
+		// Дополняем контакты кандидата найденными в новой версии резюме
+		err := s.repo.CV.UpdateCandidateContacts(ctx, tx, repository_cv.UpdateCandidateContactsParams{
+			ID:    candidate.ID,
+			Email: email,
+			Phone: phone,
+		})
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to update candidate: %w", err)
+		}
+		return candidate.ID, nil
+	}
+
+	candidate, err := s.repo.CV.CreateCandidate(ctx, tx, repository_cv.CreateCandidateParams{
+		UserID: userUUID,
+		Name:   identity.name,
+		Email:  email,
+		Phone:  phone,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create candidate: %w", err)
+	}
+
+	return candidate.ID, nil
+}
+
+// GetCandidates возвращает кандидатов из базы резюме пользователя вместе со
+// всеми версиями их резюме.
+func (s *service) GetCandidates(ctx context.Context, userGUID string, limit, offset int) ([]models.Candidate, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	var dbCandidates []repository_cv.CvCandidate
+	var dbResumes []repository_cv.CvResumeDatabase
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		dbCandidates, err = s.repo.CV.ListCandidates(ctx, tx, repository_cv.ListCandidatesParams{
+			UserID: userUUID,
+			Limit:  int32(limit),
+			Offset: int32(offset),
+		})
+		if err != nil || len(dbCandidates) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(dbCandidates))
+		for i, candidate := range dbCandidates {
+			ids[i] = candidate.ID
+		}
+		dbResumes, err = s.repo.CV.GetResumesByCandidateIDs(ctx, tx, ids)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candidates: %w", err)
+	}
+
+	resumes := make(map[uuid.UUID][]models.ResumeRecord)
+	for _, dbResume := range dbResumes {
+		resumes[dbResume.CandidateID.UUID] = append(resumes[dbResume.CandidateID.UUID], s.mapResumeFromDB(dbResume))
+	}
+
+	candidates := make([]models.Candidate, len(dbCandidates))
+	for i, dbCandidate := range dbCandidates {
+		candidates[i] = mapCandidateFromDB(dbCandidate, resumes[dbCandidate.ID])
+	}
+
+	return candidates, nil
+}
+
+// MergeCandidates переносит резюме кандидатов sourceIDs к кандидату targetID
+// и удаляет исходных кандидатов. Используется, когда автоматическое
+// сопоставление не распознало одного человека.
+func (s *service) MergeCandidates(ctx context.Context, userGUID, targetID string, sourceIDs []string) (*models.Candidate, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	targetUUID, err := uuid.Parse(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid target ID", ErrInvalidMerge)
+	}
+	if len(sourceIDs) == 0 {
+		return nil, fmt.Errorf("%w: no candidates to merge", ErrInvalidMerge)
+	}
+
+	sourceUUIDs := make([]uuid.UUID, 0, len(sourceIDs))
+	unique := map[uuid.UUID]bool{targetUUID: true}
+	for _, sourceID := range sourceIDs {
+		sourceUUID, err := uuid.Parse(sourceID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid source ID %q", ErrInvalidMerge, sourceID)
+		}
+		if unique[sourceUUID] {
+			return nil, fmt.Errorf("%w: duplicate candidate %s", ErrInvalidMerge, sourceID)
+		}
+		unique[sourceUUID] = true
+		sourceUUIDs = append(sourceUUIDs, sourceUUID)
+	}
+
+	var target repository_cv.CvCandidate
+	var dbResumes []repository_cv.CvResumeDatabase
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.repo.CV.LockUserCandidates(ctx, tx, userUUID.String()); err != nil {
+			return err
+		}
+
+		candidates, err := s.repo.CV.GetCandidatesByIDs(ctx, tx, repository_cv.GetCandidatesByIDsParams{
+			UserID: userUUID,
+			Ids:    append([]uuid.UUID{targetUUID}, sourceUUIDs...),
+		})
+		if err != nil {
+			return err
+		}
+		if len(candidates) != len(sourceUUIDs)+1 {
+			return ErrCandidateNotFound
+		}
+
+		// Контакты объединённых кандидатов дополняют контакты целевого
+		var email, phone sql.NullString
+		for _, candidate := range candidates {
+			if candidate.ID == targetUUID {
+				target = candidate
+				continue
+			}
+			if !email.Valid {
+				email = candidate.Email
+			}
+			if !phone.Valid {
+				phone = candidate.Phone
+			}
+		}
+
+		err = s.repo.CV.MoveCandidateResumes(ctx, tx, repository_cv.MoveCandidateResumesParams{
+			TargetID: uuid.NullUUID{UUID: targetUUID, Valid: true},
+			Ids:      sourceUUIDs,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.repo.CV.UpdateCandidateContacts(ctx, tx, repository_cv.UpdateCandidateContactsParams{
+			ID:    targetUUID,
+			Email: email,
+			Phone: phone,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.repo.CV.DeleteCandidates(ctx, tx, repository_cv.DeleteCandidatesParams{
+			UserID: userUUID,
+			Ids:    sourceUUIDs,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !target.Email.Valid {
+			target.Email = email
+		}
+		if !target.Phone.Valid {
+			target.Phone = phone
+		}
+
+		dbResumes, err = s.repo.CV.GetResumesByCandidateIDs(ctx, tx, []uuid.UUID{targetUUID})
+		return err
+	})
+	if errors.Is(err, ErrCandidateNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge candidates: %w", err)
+	}
+
+	resumes := make([]models.ResumeRecord, len(dbResumes))
+	for i, dbResume := range dbResumes {
+		resumes[i] = s.mapResumeFromDB(dbResume)
+	}
+	candidate := mapCandidateFromDB(target, resumes)
+
+	return &candidate, nil
+}
+
+func mapCandidateFromDB(candidate repository_cv.CvCandidate, resumes []models.ResumeRecord) models.Candidate {
+	if resumes == nil {
+		resumes = []models.ResumeRecord{}
+	}
+
+	result := models.Candidate{
+		ID:        candidate.ID.String(),
+		Name:      candidate.Name,
+		Resumes:   resumes,
+		CreatedAt: candidate.CreatedAt,
+		UpdatedAt: candidate.UpdatedAt,
+	}
+	if candidate.Email.Valid {
+		result.Email = &candidate.Email.String
+	}
+	if candidate.Phone.Valid {
+		result.Phone = &candidate.Phone.String
+	}
+
+	return result
+}
@@ -0,0 +1,382 @@
+package cv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Формат .doc (Word 97-2003) - это составной файл OLE2 (Compound File Binary),
+// внутри которого поток WordDocument содержит текст, а поток 0Table/1Table -
+// таблицу фрагментов (piece table), описывающую, где лежит каждый кусок текста.
+
+var cfbSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+const (
+	cfbFreeSect       = 0xFFFFFFFF
+	cfbEndOfChain     = 0xFFFFFFFE
+	cfbMaxRegularSect = 0xFFFFFFFA
+
+	cfbHeaderSize   = 512
+	cfbDirEntrySize = 128
+
+	wordIdent = 0xA5EC
+)
+
+type cfbFile struct {
+	data             []byte
+	sectorSize       int
+	miniSectorSize   int
+	miniStreamCutoff uint32
+	fat              []uint32
+	miniFAT          []uint32
+	miniStream       []byte
+	entries          map[string]cfbEntry
+}
+
+type cfbEntry struct {
+	start uint32
+	size  uint64
+}
+
+func openCFB(data []byte) (*cfbFile, error) {
+	if len(data) < cfbHeaderSize || !bytes.Equal(data[:8], cfbSignature) {
+		return nil, errors.New("not a compound file")
+	}
+
+	le := binary.LittleEndian
+	sectorShift := le.Uint16(data[0x1E:])
+	miniSectorShift := le.Uint16(data[0x20:])
+	if sectorShift != 9 && sectorShift != 12 || miniSectorShift != 6 {
+		return nil, errors.New("unsupported compound file sector size")
+	}
+
+	f := &cfbFile{
+		data:             data,
+		sectorSize:       1 << sectorShift,
+		miniSectorSize:   1 << miniSectorShift,
+		miniStreamCutoff: le.Uint32(data[0x38:]),
+		entries:          make(map[string]cfbEntry),
+	}
+
+	// Сектора FAT перечислены в DIFAT: первые 109 - в заголовке, остальные - цепочкой секторов
+	numFATSectors := le.Uint32(data[0x2C:])
+	var fatSectors []uint32
+	for i := 0; i < 109 && uint32(len(fatSectors)) < numFATSectors; i++ {
+		fatSectors = append(fatSectors, le.Uint32(data[0x4C+i*4:]))
+	}
+	difatSector := le.Uint32(data[0x44:])
+	perDIFAT := f.sectorSize/4 - 1
+	for visited := 0; difatSector <= cfbMaxRegularSect && uint32(len(fatSectors)) < numFATSectors; visited++ {
+		sector, err := f.sector(difatSector)
+		if err != nil || visited > len(data)/f.sectorSize {
+			return nil, errors.New("corrupted compound file DIFAT")
+		}
+		for i := 0; i < perDIFAT && uint32(len(fatSectors)) < numFATSectors; i++ {
+			fatSectors = append(fatSectors, le.Uint32(sector[i*4:]))
+		}
+		difatSector = le.Uint32(sector[perDIFAT*4:])
+	}
+
+	// Секторов FAT не может быть больше, чем секторов в файле
+	if len(fatSectors) > (len(data)-cfbHeaderSize)/f.sectorSize {
+		return nil, errors.New("corrupted compound file FAT")
+	}
+	for _, sectorID := range fatSectors {
+		sector, err := f.sector(sectorID)
+		if err != nil {
+			return nil, fmt.Errorf("corrupted compound file FAT: %w", err)
+		}
+		for i := 0; i < f.sectorSize; i += 4 {
+			f.fat = append(f.fat, le.Uint32(sector[i:]))
+		}
+	}
+
+	directory, err := f.chain(le.Uint32(data[0x30:]), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compound file directory: %w", err)
+	}
+
+	var root cfbEntry
+	for offset := 0; offset+cfbDirEntrySize <= len(directory); offset += cfbDirEntrySize {
+		entry := directory[offset : offset+cfbDirEntrySize]
+		nameLen := int(le.Uint16(entry[0x40:]))
+		entryType := entry[0x42]
+		if nameLen < 2 || nameLen > 64 || entryType == 0 {
+			continue
+		}
+		name := make([]uint16, nameLen/2-1)
+		for i := range name {
+			name[i] = le.Uint16(entry[i*2:])
+		}
+		e := cfbEntry{start: le.Uint32(entry[0x74:]), size: le.Uint64(entry[0x78:])}
+		if f.sectorSize == 512 {
+			// В версии 3 старшие 32 бита размера могут содержать мусор
+			e.size &= 0xFFFFFFFF
+		}
+		switch entryType {
+		case 5:
+			root = e
+		case 2:
+			f.entries[string(utf16.Decode(name))] = e
+		}
+	}
+
+	// Потоки меньше miniStreamCutoff хранятся в мини-потоке корневого элемента
+	if root.size > 0 {
+		if f.miniStream, err = f.chain(root.start, root.size); err != nil {
+			return nil, fmt.Errorf("failed to read compound file mini stream: %w", err)
+		}
+		miniFAT, err := f.chain(le.Uint32(data[0x3C:]), 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read compound file mini FAT: %w", err)
+		}
+		for i := 0; i+4 <= len(miniFAT); i += 4 {
+			f.miniFAT = append(f.miniFAT, le.Uint32(miniFAT[i:]))
+		}
+	}
+
+	return f, nil
+}
+
+func (f *cfbFile) sector(id uint32) ([]byte, error) {
+	start := cfbHeaderSize + int64(id)*int64(f.sectorSize)
+	end := start + int64(f.sectorSize)
+	if id > cfbMaxRegularSect || end > int64(len(f.data)) {
+		return nil, fmt.Errorf("sector %d out of range", id)
+	}
+	return f.data[start:end], nil
+}
+
+// chain читает цепочку секторов, начиная с start. Если size не 0, результат обрезается до size.
+// Цепочка не может быть длиннее числа секторов в файле: иначе в ней есть цикл
+func (f *cfbFile) chain(start uint32, size uint64) ([]byte, error) {
+	maxSectors := (len(f.data) - cfbHeaderSize) / f.sectorSize
+	var buf []byte
+	for id, steps := start, 0; id != cfbEndOfChain && id != cfbFreeSect; steps++ {
+		if steps >= maxSectors {
+			return nil, errors.New("sector chain loop")
+		}
+		sector, err := f.sector(id)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, sector...)
+		if size > 0 && uint64(len(buf)) >= size {
+			break
+		}
+		if int(id) >= len(f.fat) {
+			return nil, fmt.Errorf("sector %d not in FAT", id)
+		}
+		id = f.fat[id]
+	}
+	if size > 0 {
+		if uint64(len(buf)) < size {
+			return nil, errors.New("stream is truncated")
+		}
+		buf = buf[:size]
+	}
+	return buf, nil
+}
+
+func (f *cfbFile) miniChain(start uint32, size uint64) ([]byte, error) {
+	var buf []byte
+	maxSectors := len(f.miniStream) / f.miniSectorSize
+	for id, steps := start, 0; id != cfbEndOfChain && uint64(len(buf)) < size; steps++ {
+		if steps >= maxSectors || int(id) >= len(f.miniFAT) {
+			return nil, errors.New("corrupted mini sector chain")
+		}
+		start := int(id) * f.miniSectorSize
+		end := start + f.miniSectorSize
+		if end > len(f.miniStream) {
+			return nil, fmt.Errorf("mini sector %d out of range", id)
+		}
+		buf = append(buf, f.miniStream[start:end]...)
+		id = f.miniFAT[id]
+	}
+	if uint64(len(buf)) < size {
+		return nil, errors.New("stream is truncated")
+	}
+	return buf[:size], nil
+}
+
+func (f *cfbFile) stream(name string) ([]byte, error) {
+	entry, ok := f.entries[name]
+	if !ok {
+		return nil, fmt.Errorf("stream %s not found", name)
+	}
+	if entry.size > uint64(len(f.data)) {
+		return nil, fmt.Errorf("stream %s has invalid size", name)
+	}
+	if entry.size < uint64(f.miniStreamCutoff) {
+		return f.miniChain(entry.start, entry.size)
+	}
+	return f.chain(entry.start, entry.size)
+}
+
+// extractTextFromDOC извлекает основной текст документа Word 97-2003 по
+// таблице фрагментов. Сноски, колонтитулы и коды полей отбрасываются.
+func extractTextFromDOC(content []byte) (string, error) {
+	file, err := openCFB(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to open DOC file: %w", err)
+	}
+
+	wordDocument, err := file.stream("WordDocument")
+	if err != nil {
+		return "", fmt.Errorf("failed to read DOC file: %w", err)
+	}
+	if len(wordDocument) < 0x1AA {
+		return "", errors.New("DOC file header is truncated")
+	}
+
+	le := binary.LittleEndian
+	if le.Uint16(wordDocument) != wordIdent {
+		return "", errors.New("not a Word document")
+	}
+	flags := le.Uint16(wordDocument[0x0A:])
+	if flags&0x0100 != 0 {
+		return "", errors.New("DOC file is encrypted")
+	}
+
+	tableName := "0Table"
+	if flags&0x0200 != 0 {
+		tableName = "1Table"
+	}
+	table, err := file.stream(tableName)
+	if err != nil {
+		return "", fmt.Errorf("failed to read DOC file: %w", err)
+	}
+
+	// Длина основного текста документа (без сносок и колонтитулов) в символах
+	ccpText := le.Uint32(wordDocument[0x4C:])
+	fcClx := le.Uint32(wordDocument[0x1A2:])
+	lcbClx := le.Uint32(wordDocument[0x1A6:])
+	if uint64(fcClx)+uint64(lcbClx) > uint64(len(table)) {
+		return "", errors.New("DOC piece table is out of range")
+	}
+	clx := table[fcClx : fcClx+lcbClx]
+
+	// Clx: набор Prc (0x01) с форматированием, затем Pcdt (0x02) с таблицей фрагментов
+	for len(clx) > 0 && clx[0] == 0x01 {
+		if len(clx) < 3 {
+			return "", errors.New("DOC piece table is truncated")
+		}
+		skip := 3 + int(le.Uint16(clx[1:]))
+		if skip > len(clx) {
+			return "", errors.New("DOC piece table is truncated")
+		}
+		clx = clx[skip:]
+	}
+	if len(clx) < 5 || clx[0] != 0x02 {
+		return "", errors.New("DOC piece table not found")
+	}
+	plcPcd := clx[5:]
+	if lcb := le.Uint32(clx[1:]); uint64(lcb) <= uint64(len(plcPcd)) {
+		plcPcd = plcPcd[:lcb]
+	}
+
+	// PlcPcd: n+1 позиций символов (4 байта), затем n дескрипторов фрагментов (8 байт)
+	pieces := (len(plcPcd) - 4) / 12
+	if pieces <= 0 {
+		return "", errors.New("DOC piece table is empty")
+	}
+
+	decoder := charmap.Windows1252.NewDecoder()
+	var text strings.Builder
+	var written uint32
+	for i := 0; i < pieces && written < ccpText; i++ {
+		cpStart := le.Uint32(plcPcd[i*4:])
+		cpEnd := le.Uint32(plcPcd[(i+1)*4:])
+		if cpEnd <= cpStart {
+			continue
+		}
+		count := min(int(cpEnd-cpStart), int(ccpText-written))
+
+		pcd := plcPcd[(pieces+1)*4+i*8:]
+		fc := le.Uint32(pcd[2:])
+		compressed := fc&0x40000000 != 0
+		fc &= 0x3FFFFFFF
+
+		if compressed {
+			// 8-битный текст в кодировке Windows-1252
+			offset := int(fc / 2)
+			if offset+count > len(wordDocument) {
+				return "", errors.New("DOC text piece is out of range")
+			}
+			decoded, err := decoder.Bytes(wordDocument[offset : offset+count])
+			if err != nil {
+				return "", fmt.Errorf("failed to decode DOC text: %w", err)
+			}
+			text.Write(decoded)
+		} else {
+			offset := int(fc)
+			if offset+count*2 > len(wordDocument) {
+				return "", errors.New("DOC text piece is out of range")
+			}
+			units := make([]uint16, count)
+			for j := range units {
+				units[j] = le.Uint16(wordDocument[offset+j*2:])
+			}
+			text.WriteString(string(utf16.Decode(units)))
+		}
+		written += uint32(count)
+	}
+
+	return cleanWordText(text.String()), nil
+}
+
+// cleanWordText заменяет служебные символы Word на разметку текста и убирает
+// коды полей, оставляя их отображаемый результат.
+func cleanWordText(raw string) string {
+	var b textBuilder
+	// Уровни вложенности полей: true, пока идёт код поля (до разделителя 0x14)
+	var fields []bool
+	for _, r := range raw {
+		switch r {
+		case 0x13:
+			fields = append(fields, true)
+			continue
+		case 0x14:
+			if len(fields) > 0 {
+				fields[len(fields)-1] = false
+			}
+			continue
+		case 0x15:
+			if len(fields) > 0 {
+				fields = fields[:len(fields)-1]
+			}
+			continue
+		}
+		// Результат вложенного поля внутри кода внешнего тоже не отображается
+		if slices.Contains(fields, true) {
+			continue
+		}
+
+		switch {
+		case r == '\r' || r == 0x0B || r == 0x0C:
+			b.Newline()
+		case r == 0x07:
+			// Маркер конца ячейки или строки таблицы
+			b.CellEnd()
+		case r == '\t':
+			b.WriteString("\t")
+		case r == 0x1E:
+			// Неразрывный дефис
+			b.WriteString("-")
+		case r == 0xA0:
+			b.WriteString(" ")
+		case r < 0x20 || r == 0xFFFD:
+			// Прочие служебные символы (объекты, сноски, рисунки)
+		default:
+			b.WriteString(string(r))
+		}
+	}
+	return b.String()
+}
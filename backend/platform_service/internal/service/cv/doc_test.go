@@ -0,0 +1,81 @@
+package cv
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExtractTextFromDOC(t *testing.T) {
+	resume := readFixture(t, "resume.doc")
+	encrypted := bytes.Clone(resume)
+	// Флаг fEncrypted в FIB потока WordDocument (сектор 2)
+	encrypted[cfbHeaderSize+2*512+0x0B] |= 0x01
+	notWord := bytes.Clone(resume)
+	notWord[cfbHeaderSize+2*512] = 0
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "compressed and unicode pieces, tables and fields",
+			content: resume,
+			want:    "Ivan Petrov\nGo developer\nОпыт\tЯндекс\nexample.com",
+		},
+		{name: "directory chain loop", content: readFixture(t, "chain_loop.doc"), wantErr: true},
+		{name: "truncated", content: resume[:2000], wantErr: true},
+		{name: "header only", content: resume[:cfbHeaderSize], wantErr: true},
+		{name: "not a compound file", content: readFixture(t, "resume.rtf"), wantErr: true},
+		{name: "encrypted", content: encrypted, wantErr: true},
+		{name: "not a Word document", content: notWord, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractTextFromDOC(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractTextFromDOC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("extractTextFromDOC() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCFBChainLoop(t *testing.T) {
+	// Цепочка 0 -> 1 -> 0 в файле из двух секторов
+	f := &cfbFile{
+		data:       make([]byte, cfbHeaderSize+2*512),
+		sectorSize: 512,
+		fat:        append([]uint32{1, 0}, make([]uint32, 1<<16)...),
+	}
+	if _, err := f.chain(0, 0); err == nil {
+		t.Fatal("chain() error = nil, want loop error")
+	}
+}
+
+func TestCleanWordText(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"paragraphs", "first\rsecond\r", "first\nsecond"},
+		{"field result without code", "\x13 HYPERLINK \"x\" \x14link\x15 text", "link text"},
+		{"nested fields", "\x13 IF \x13 PAGE \x141\x15 \x14shown\x15", "shown"},
+		{"unbalanced field end", "text\x15\x15 more", "text more"},
+		{"table cells", "a\x07b\x07\x07\r", "a\tb"},
+		{"special characters", "non\x1ebreaking space\x01", "non-breaking space"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanWordText(tt.raw); got != tt.want {
+				t.Errorf("cleanWordText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
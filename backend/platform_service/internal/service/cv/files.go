@@ -0,0 +1,254 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	"PlatformService/internal/service/storage"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	fileKindCV     = "cv"
+	fileKindResume = "resume"
+
+	filesPath = "/api/v1/cv/"
+
+	defaultFileURLTTL = 5 * time.Minute
+)
+
+var (
+	ErrFileNotFound         = errors.New("file not found")
+	ErrFileAccessDenied     = errors.New("file access denied")
+	ErrInvalidFileSignature = errors.New("invalid or expired file signature")
+)
+
+// saveCV сохраняет загруженный пользователем файл резюме как новую версию
+// его CV
+func (s *service) saveCV(ctx context.Context, userGUID, objectName, filename, label string, primary bool) (*models.CVVersion, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	var dbVersion repository_cv.GetCVVersionRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.repo.CV.CreateStoredFile(ctx, tx, repository_cv.CreateStoredFileParams{
+			ObjectName:       objectName,
+			OwnerID:          userUUID,
+			Kind:             fileKindCV,
+			OriginalFilename: path.Base(filename),
+			ContentType:      storage.ContentType(filename),
+		})
+		if err != nil {
+			return err
+		}
+
+		dbVersion, err = s.createCVVersion(ctx, tx, userUUID, s.fileURL(objectName), label, primary)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save CV: %w", err)
+	}
+
+	version := mapCVVersion(dbVersion)
+	return &version, nil
+}
+
+// GetFile проверяет, что пользователь может скачать файл: владелец, автор
+// вакансии, на которую откликнулся владелец CV, или владелец базы резюме
+func (s *service) GetFile(ctx context.Context, userGUID, objectName string) (*models.StoredFile, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, ErrFileAccessDenied
+	}
+
+	var file repository_cv.CvFile
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		file, err = s.repo.CV.GetStoredFile(ctx, tx, objectName)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrFileNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if file.OwnerID == userUUID {
+			return nil
+		}
+		// Файлы базы резюме видит только её владелец
+		if file.Kind != fileKindCV {
+			return ErrFileAccessDenied
+		}
+
+		hasApplication, err := s.repo.CV.HasApplicationToAuthorJob(ctx, tx, repository_cv.HasApplicationToAuthorJobParams{
+			ApplicantID: file.OwnerID,
+			AuthorID:    userUUID,
+		})
+		if err != nil {
+			return err
+		}
+		if !hasApplication {
+			return ErrFileAccessDenied
+		}
+		return nil
+	})
+	if errors.Is(err, ErrFileNotFound) || errors.Is(err, ErrFileAccessDenied) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	return mapStoredFile(file), nil
+}
+
+// GetSignedFileURL выдаёт ссылку на файл, по которой его можно скачать без
+// авторизации до истечения срока действия
+func (s *service) GetSignedFileURL(ctx context.Context, userGUID, objectName string) (*models.SignedFileURL, error) {
+	if _, err := s.GetFile(ctx, userGUID, objectName); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.fileURLTTL).Truncate(time.Second)
+	return &models.SignedFileURL{
+		URL:       s.signFileURL(objectName, expiresAt),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// GetSignedFile проверяет подпись ссылки, выданной GetSignedFileURL
+func (s *service) GetSignedFile(ctx context.Context, objectName, expires, signature string) (*models.StoredFile, error) {
+	if !s.verifyFileSignature(objectName, expires, signature, time.Now()) {
+		return nil, ErrInvalidFileSignature
+	}
+
+	var file repository_cv.CvFile
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		file, err = s.repo.CV.GetStoredFile(ctx, tx, objectName)
+		return err
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	return mapStoredFile(file), nil
+}
+
+// uploadFile сохраняет файл в хранилище под уникальным именем и возвращает это имя
+func (s *service) uploadFile(ctx context.Context, content io.Reader, size int64, filename string) (string, error) {
+	objectName := storage.ObjectName(filename)
+	if err := s.storageService.Put(ctx, objectName, content, size, storage.ContentType(filename)); err != nil {
+		return "", err
+	}
+	return objectName, nil
+}
+
+// registerStoredFile сохраняет владельца и исходное имя файла, загруженного в хранилище
+func (s *service) registerStoredFile(ctx context.Context, userUUID uuid.UUID, kind, objectName, filename string) error {
+	return s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.repo.CV.CreateStoredFile(ctx, tx, repository_cv.CreateStoredFileParams{
+			ObjectName:       objectName,
+			OwnerID:          userUUID,
+			Kind:             kind,
+			OriginalFilename: path.Base(filename),
+			ContentType:      storage.ContentType(filename),
+		})
+	})
+}
+
+// storeFileText извлекает текст файла резюме и сохраняет его рядом с
+// метаданными файла, чтобы подбор кандидатов не разбирал файл заново.
+// Ошибка сохранения только логируется: текст всё равно возвращается
+func (s *service) storeFileText(ctx context.Context, objectName, ext string) (string, error) {
+	text, err := s.extractTextFromFile(ctx, objectName, ext)
+	if err != nil {
+		return "", err
+	}
+	text = strings.TrimSpace(text)
+
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.repo.CV.SetStoredFileText(ctx, tx, repository_cv.SetStoredFileTextParams{
+			ObjectName:    objectName,
+			ExtractedText: sql.NullString{String: text, Valid: true},
+		})
+	})
+	if err != nil {
+		s.log.WarnContext(ctx, "cv.storeFileText failed to save file text", "object_name", objectName, "error", err)
+	}
+	return text, nil
+}
+
+func (s *service) fileURL(objectName string) string {
+	return s.serverFullAddress + filesPath + objectName
+}
+
+// signedFileLink подписывает ссылку на файл платформы; внешние ссылки
+// возвращаются без изменений
+func (s *service) signedFileLink(link string) string {
+	if !strings.HasPrefix(link, s.serverFullAddress+filesPath) {
+		return link
+	}
+	return s.signFileURL(path.Base(link), time.Now().Add(s.fileURLTTL).Truncate(time.Second))
+}
+
+func (s *service) signFileURL(objectName string, expiresAt time.Time) string {
+	// Без секрета ссылка не подписывается: GetSignedFile её всё равно отклонит
+	if len(s.fileURLSecret) == 0 {
+		return s.fileURL(objectName)
+	}
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", s.fileSignature(objectName, expiresAt.Unix()))
+	return s.fileURL(objectName) + "?" + query.Encode()
+}
+
+// verifyFileSignature проверяет срок действия и подпись ссылки. Без секрета
+// подписи не принимаются: иначе их мог бы вычислить кто угодно
+func (s *service) verifyFileSignature(objectName, expires, signature string, now time.Time) bool {
+	if len(s.fileURLSecret) == 0 {
+		return false
+	}
+
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > expiresUnix {
+		return false
+	}
+
+	expected := s.fileSignature(objectName, expiresUnix)
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+func (s *service) fileSignature(objectName string, expires int64) string {
+	mac := hmac.New(sha256.New, s.fileURLSecret)
+	fmt.Fprintf(mac, "%s\n%d", objectName, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func mapStoredFile(file repository_cv.CvFile) *models.StoredFile {
+	return &models.StoredFile{
+		ObjectName:       file.ObjectName,
+		OriginalFilename: file.OriginalFilename,
+		ContentType:      file.ContentType,
+	}
+}
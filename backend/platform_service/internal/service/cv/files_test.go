@@ -0,0 +1,62 @@
+package cv
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerifyFileSignature(t *testing.T) {
+	s := &service{fileURLSecret: []byte("secret")}
+	now := time.Unix(1_700_000_000, 0)
+	expires := now.Add(time.Minute).Unix()
+	signature := s.fileSignature("cv.pdf", expires)
+	tampered := []byte(signature)
+	tampered[0] ^= 1
+
+	tests := []struct {
+		name       string
+		service    *service
+		objectName string
+		expires    string
+		signature  string
+		want       bool
+	}{
+		{"valid", s, "cv.pdf", strconv.FormatInt(expires, 10), signature, true},
+		{"expired", s, "cv.pdf", strconv.FormatInt(now.Add(-time.Second).Unix(), 10), s.fileSignature("cv.pdf", now.Add(-time.Second).Unix()), false},
+		{"other file", s, "other.pdf", strconv.FormatInt(expires, 10), signature, false},
+		{"extended expiry", s, "cv.pdf", strconv.FormatInt(expires+3600, 10), signature, false},
+		{"tampered signature", s, "cv.pdf", strconv.FormatInt(expires, 10), string(tampered), false},
+		{"invalid expires", s, "cv.pdf", "tomorrow", signature, false},
+		{"other secret", &service{fileURLSecret: []byte("other")}, "cv.pdf", strconv.FormatInt(expires, 10), signature, false},
+		{"empty secret", &service{}, "cv.pdf", strconv.FormatInt(expires, 10), (&service{}).fileSignature("cv.pdf", expires), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.service.verifyFileSignature(tt.objectName, tt.expires, tt.signature, now); got != tt.want {
+				t.Errorf("verifyFileSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignFileURL(t *testing.T) {
+	s := &service{serverFullAddress: "http://localhost:8080", fileURLSecret: []byte("secret")}
+	expiresAt := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	link, err := url.Parse(s.signFileURL("cv.pdf", expiresAt))
+	if err != nil {
+		t.Fatalf("signFileURL() returned invalid URL: %v", err)
+	}
+	query := link.Query()
+	if !s.verifyFileSignature("cv.pdf", query.Get("expires"), query.Get("signature"), time.Now()) {
+		t.Errorf("signFileURL() = %s, signature is not accepted", link)
+	}
+
+	unsigned := (&service{serverFullAddress: "http://localhost:8080"}).signFileURL("cv.pdf", expiresAt)
+	if unsigned != "http://localhost:8080"+filesPath+"cv.pdf" {
+		t.Errorf("signFileURL() without secret = %s, want unsigned link", unsigned)
+	}
+}
@@ -0,0 +1,281 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const maxFolderNameRunes = 100
+
+var (
+	ErrFolderNotFound    = errors.New("folder not found")
+	ErrFolderExists      = errors.New("folder with this name already exists")
+	ErrInvalidFolderName = errors.New("invalid folder name")
+)
+
+// GetResumeFolders возвращает папки (кадровые резервы) базы резюме
+// пользователя с количеством резюме в каждой.
+func (s *service) GetResumeFolders(ctx context.Context, userGUID string) ([]models.ResumeFolder, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	var dbFolders []repository_cv.ListResumeFoldersRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		dbFolders, err = s.repo.CV.ListResumeFolders(ctx, tx, userUUID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folders: %w", err)
+	}
+
+	folders := make([]models.ResumeFolder, len(dbFolders))
+	for i, folder := range dbFolders {
+		folders[i] = mapResumeFolderFromDB(repository_cv.GetResumeFolderRow(folder))
+	}
+
+	return folders, nil
+}
+
+func (s *service) CreateResumeFolder(ctx context.Context, userGUID, name string) (*models.ResumeFolder, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	name, err = normalizeFolderName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbFolder repository_cv.CvResumeFolder
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		dbFolder, err = s.repo.CV.CreateResumeFolder(ctx, tx, repository_cv.CreateResumeFolderParams{
+			UserID: userUUID,
+			Name:   name,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrFolderExists
+		}
+		return err
+	})
+	if errors.Is(err, ErrFolderExists) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create folder: %w", err)
+	}
+
+	folder := mapResumeFolderFromDB(repository_cv.GetResumeFolderRow{
+		ID:        dbFolder.ID,
+		UserID:    dbFolder.UserID,
+		Name:      dbFolder.Name,
+		CreatedAt: dbFolder.CreatedAt,
+		UpdatedAt: dbFolder.UpdatedAt,
+	})
+
+	return &folder, nil
+}
+
+func (s *service) RenameResumeFolder(ctx context.Context, userGUID, folderID, name string) (*models.ResumeFolder, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	folderUUID, err := uuid.Parse(folderID)
+	if err != nil {
+		return nil, ErrFolderNotFound
+	}
+
+	name, err = normalizeFolderName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbFolder repository_cv.GetResumeFolderRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		params := repository_cv.GetResumeFolderParams{ID: folderUUID, UserID: userUUID}
+		if _, err := s.repo.CV.GetResumeFolder(ctx, tx, params); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrFolderNotFound
+			}
+			return err
+		}
+
+		renamed, err := s.repo.CV.RenameResumeFolder(ctx, tx, repository_cv.RenameResumeFolderParams{
+			Name:   name,
+			ID:     folderUUID,
+			UserID: userUUID,
+		})
+		if err != nil {
+			return err
+		}
+		if renamed == 0 {
+			// Папка существует, значит имя занято другой папкой
+			return ErrFolderExists
+		}
+
+		dbFolder, err = s.repo.CV.GetResumeFolder(ctx, tx, params)
+		return err
+	})
+	if errors.Is(err, ErrFolderNotFound) || errors.Is(err, ErrFolderExists) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename folder: %w", err)
+	}
+
+	folder := mapResumeFolderFromDB(dbFolder)
+	return &folder, nil
+}
+
+// DeleteResumeFolder удаляет папку; резюме из неё остаются в базе
+func (s *service) DeleteResumeFolder(ctx context.Context, userGUID, folderID string) error {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	folderUUID, err := uuid.Parse(folderID)
+	if err != nil {
+		return ErrFolderNotFound
+	}
+
+	var deleted int64
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		deleted, err = s.repo.CV.DeleteResumeFolder(ctx, tx, repository_cv.DeleteResumeFolderParams{
+			ID:     folderUUID,
+			UserID: userUUID,
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete folder: %w", err)
+	}
+	if deleted == 0 {
+		return ErrFolderNotFound
+	}
+
+	return nil
+}
+
+func (s *service) AddResumeToFolder(ctx context.Context, userGUID, folderID, resumeID string) error {
+	userUUID, folderUUID, resumeUUID, err := parseFolderItem(userGUID, folderID, resumeID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkFolderOwner(ctx, tx, folderUUID, userUUID); err != nil {
+			return err
+		}
+
+		resume, err := s.repo.CV.GetResumeByID(ctx, tx, resumeUUID)
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && resume.UserID != userUUID) {
+			return ErrResumeNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.repo.CV.AddResumeToFolder(ctx, tx, repository_cv.AddResumeToFolderParams{
+			FolderID: folderUUID,
+			ResumeID: resumeUUID,
+		})
+	})
+	if errors.Is(err, ErrFolderNotFound) || errors.Is(err, ErrResumeNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add resume to folder: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) RemoveResumeFromFolder(ctx context.Context, userGUID, folderID, resumeID string) error {
+	userUUID, folderUUID, resumeUUID, err := parseFolderItem(userGUID, folderID, resumeID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.checkFolderOwner(ctx, tx, folderUUID, userUUID); err != nil {
+			return err
+		}
+
+		return s.repo.CV.RemoveResumeFromFolder(ctx, tx, repository_cv.RemoveResumeFromFolderParams{
+			FolderID: folderUUID,
+			ResumeID: resumeUUID,
+		})
+	})
+	if errors.Is(err, ErrFolderNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove resume from folder: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) checkFolderOwner(ctx context.Context, tx pgx.Tx, folderUUID, userUUID uuid.UUID) error {
+	_, err := s.repo.CV.GetResumeFolder(ctx, tx, repository_cv.GetResumeFolderParams{
+		ID:     folderUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrFolderNotFound
+	}
+	return err
+}
+
+func parseFolderItem(userGUID, folderID, resumeID string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+	folderUUID, err := uuid.Parse(folderID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, ErrFolderNotFound
+	}
+	resumeUUID, err := uuid.Parse(resumeID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, ErrResumeNotFound
+	}
+	return userUUID, folderUUID, resumeUUID, nil
+}
+
+func normalizeFolderName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is empty", ErrInvalidFolderName)
+	}
+	if utf8.RuneCountInString(name) > maxFolderNameRunes {
+		return "", fmt.Errorf("%w: name is longer than %d characters", ErrInvalidFolderName, maxFolderNameRunes)
+	}
+	return name, nil
+}
+
+func mapResumeFolderFromDB(folder repository_cv.GetResumeFolderRow) models.ResumeFolder {
+	return models.ResumeFolder{
+		ID:          folder.ID.String(),
+		Name:        folder.Name,
+		ResumeCount: int(folder.ResumeCount),
+		CreatedAt:   folder.CreatedAt,
+		UpdatedAt:   folder.UpdatedAt,
+	}
+}
@@ -0,0 +1,152 @@
+package cv
+
+import (
+	repository_cv "PlatformService/internal/repository/cv"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// Доля совпавших частей имени, при которой резюме без общих контактов
+	// считаются резюме одного кандидата
+	candidateNameThreshold = 0.9
+	// Минимальное сходство написания части имени (опечатки, транслитерация)
+	candidateTokenThreshold = 0.8
+)
+
+var (
+	emailPattern = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9\-]+(?:\.[a-z0-9\-]+)*\.[a-z]{2,}`)
+	// Российские номера: +7 (999) 123-45-67, 8 999 123 45 67, +79991234567
+	phonePattern = regexp.MustCompile(`(?:\+7|\b8|\b7)[\s\-]?\(?\d{3}\)?[\s\-]?\d{3}[\s\-]?\d{2}[\s\-]?\d{2}\b`)
+	// Международные номера записываются с +
+	intlPhonePattern = regexp.MustCompile(`\+\d[\d\s\-()]{8,16}\d`)
+)
+
+// candidateIdentity - данные, по которым резюме относятся к одному человеку.
+type candidateIdentity struct {
+	name  string
+	email string
+	phone string
+}
+
+// extractIdentity берёт имя из анализа резюме, а email и телефон - из текста.
+func extractIdentity(name, text string) candidateIdentity {
+	identity := candidateIdentity{name: strings.TrimSpace(name)}
+	if email := emailPattern.FindString(text); email != "" {
+		identity.email = strings.ToLower(email)
+	}
+	for _, pattern := range []*regexp.Regexp{phonePattern, intlPhonePattern} {
+		if phone := normalizePhone(pattern.FindString(text)); phone != "" {
+			identity.phone = phone
+			break
+		}
+	}
+	return identity
+}
+
+// normalizePhone оставляет только цифры в формате E.164 без +.
+func normalizePhone(raw string) string {
+	var digits strings.Builder
+	for _, r := range raw {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	phone := digits.String()
+	if len(phone) == 11 && phone[0] == '8' && !strings.HasPrefix(strings.TrimSpace(raw), "+") {
+		phone = "7" + phone[1:]
+	}
+	if len(phone) < 10 || len(phone) > 15 {
+		return ""
+	}
+	return phone
+}
+
+// matchCandidate ищет среди кандидатов пользователя того же человека.
+// Совпадение email или телефона надёжнее имени, поэтому проверяется первым.
+func matchCandidate(candidates []repository_cv.CvCandidate, identity candidateIdentity) *repository_cv.CvCandidate {
+	for i, candidate := range candidates {
+		if identity.email != "" && candidate.Email.String == identity.email ||
+			identity.phone != "" && candidate.Phone.String == identity.phone {
+			return &candidates[i]
+		}
+	}
+
+	for i, candidate := range candidates {
+		// Разные контакты указывают на разных людей с похожими именами
+		if identity.email != "" && candidate.Email.Valid ||
+			identity.phone != "" && candidate.Phone.Valid {
+			continue
+		}
+		if nameSimilarity(candidate.Name, identity.name) >= candidateNameThreshold {
+			return &candidates[i]
+		}
+	}
+
+	return nil
+}
+
+// nameSimilarity сравнивает имена без учёта порядка слов, регистра и ё/е.
+// Отчество может отсутствовать в одной из версий резюме, поэтому
+// сравниваются части более короткого имени.
+func nameSimilarity(a, b string) float64 {
+	aTokens, bTokens := nameTokens(a), nameTokens(b)
+	if len(aTokens) > len(bTokens) {
+		aTokens, bTokens = bTokens, aTokens
+	}
+	// Одного имени без фамилии недостаточно
+	if len(aTokens) < 2 {
+		return 0
+	}
+
+	matched := 0
+	used := make([]bool, len(bTokens))
+	for _, token := range aTokens {
+		for j, other := range bTokens {
+			if !used[j] && tokenSimilarity(token, other) >= candidateTokenThreshold {
+				used[j] = true
+				matched++
+				break
+			}
+		}
+	}
+
+	return float64(matched) / float64(len(aTokens))
+}
+
+func nameTokens(name string) []string {
+	name = strings.ReplaceAll(strings.ToLower(name), "ё", "е")
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+func tokenSimilarity(a, b string) float64 {
+	ar, br := []rune(a), []rune(b)
+	longest := max(len(ar), len(br))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ar, br))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
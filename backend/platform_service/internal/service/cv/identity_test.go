@@ -0,0 +1,180 @@
+package cv
+
+import (
+	repository_cv "PlatformService/internal/repository/cv"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"+7 (999) 123-45-67", "79991234567"},
+		{"8 999 123 45 67", "79991234567"},
+		{"8(999)123-45-67", "79991234567"},
+		{"+79991234567", "79991234567"},
+		{"7 999 123 45 67", "79991234567"},
+		{"+44 20 7946 0958", "442079460958"},
+		{"+8 999 123 45 67", "89991234567"},
+		{"123-45-67", ""},
+		{"+1234567890123456", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := normalizePhone(tt.raw); got != tt.want {
+				t.Errorf("normalizePhone(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullName string
+		text     string
+		want     candidateIdentity
+	}{
+		{
+			name:     "email and russian phone",
+			fullName: "  Иван Петров ",
+			text:     "Иван Петров\nEmail: Ivan.Petrov@Example.COM\nТел.: 8 (999) 123-45-67",
+			want:     candidateIdentity{name: "Иван Петров", email: "ivan.petrov@example.com", phone: "79991234567"},
+		},
+		{
+			name:     "international phone",
+			fullName: "John Smith",
+			text:     "John Smith, phone +44 20 7946 0958",
+			want:     candidateIdentity{name: "John Smith", phone: "442079460958"},
+		},
+		{
+			name:     "no contacts",
+			fullName: "Мария Сидорова",
+			text:     "Опыт работы 5 лет, стаж с 2019 года",
+			want:     candidateIdentity{name: "Мария Сидорова"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractIdentity(tt.fullName, tt.text); got != tt.want {
+				t.Errorf("extractIdentity() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"same name", "Иванов Иван Иванович", "Иванов Иван Иванович", 1},
+		{"different order and case", "иван ИВАНОВ", "Иванов Иван", 1},
+		{"yo and ye", "Фёдоров Пётр", "Федоров Петр", 1},
+		{"missing patronymic", "Иванов Иван", "Иванов Иван Иванович", 1},
+		{"typo", "Иваноф Иван", "Иванов Иван", 1},
+		{"different surname", "Петров Иван", "Иванов Иван", 0.5},
+		{"first name only", "Иван", "Иван Иванов", 0},
+		{"empty", "", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nameSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("nameSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchCandidate(t *testing.T) {
+	contact := func(value string) sql.NullString {
+		return sql.NullString{String: value, Valid: value != ""}
+	}
+	candidate := func(name, email, phone string) repository_cv.CvCandidate {
+		return repository_cv.CvCandidate{ID: uuid.New(), Name: name, Email: contact(email), Phone: contact(phone)}
+	}
+
+	ivan := candidate("Иванов Иван Иванович", "ivan@example.com", "79991234567")
+	ivanNoContacts := candidate("Иванов Иван", "", "")
+	petr := candidate("Петров Пётр", "", "79990000000")
+
+	tests := []struct {
+		name       string
+		candidates []repository_cv.CvCandidate
+		identity   candidateIdentity
+		want       *repository_cv.CvCandidate
+	}{
+		{
+			name:       "same email, different name",
+			candidates: []repository_cv.CvCandidate{petr, ivan},
+			identity:   candidateIdentity{name: "Ваня Иванов", email: "ivan@example.com"},
+			want:       &ivan,
+		},
+		{
+			name:       "same phone in different format",
+			candidates: []repository_cv.CvCandidate{petr, ivan},
+			identity:   extractIdentity("Иванов Иван", "тел. 8 (999) 123-45-67"),
+			want:       &ivan,
+		},
+		{
+			name:       "contacts win over name",
+			candidates: []repository_cv.CvCandidate{ivanNoContacts, petr},
+			identity:   candidateIdentity{name: "Иванов Иван", phone: "79990000000"},
+			want:       &petr,
+		},
+		{
+			name:       "same name, different contacts",
+			candidates: []repository_cv.CvCandidate{ivan},
+			identity:   candidateIdentity{name: "Иванов Иван Иванович", email: "other@example.com", phone: "79995555555"},
+		},
+		{
+			name:       "same name, different phone",
+			candidates: []repository_cv.CvCandidate{ivan},
+			identity:   candidateIdentity{name: "Иванов Иван", phone: "79995555555"},
+		},
+		{
+			name:       "same name, candidate without contacts",
+			candidates: []repository_cv.CvCandidate{petr, ivanNoContacts},
+			identity:   candidateIdentity{name: "Иван Иванович Иванов", email: "new@example.com"},
+			want:       &ivanNoContacts,
+		},
+		{
+			name:       "same name, no contacts in resume",
+			candidates: []repository_cv.CvCandidate{ivan},
+			identity:   candidateIdentity{name: "иванов иван"},
+			want:       &ivan,
+		},
+		{
+			name:       "similar names of different people",
+			candidates: []repository_cv.CvCandidate{ivanNoContacts},
+			identity:   candidateIdentity{name: "Иванова Мария"},
+		},
+		{
+			name:     "no candidates",
+			identity: candidateIdentity{name: "Иванов Иван", email: "ivan@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchCandidate(tt.candidates, tt.identity)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("matchCandidate() = %q, want no match", got.Name)
+			case tt.want != nil && got == nil:
+				t.Errorf("matchCandidate() = no match, want %q", tt.want.Name)
+			case tt.want != nil && got.ID != tt.want.ID:
+				t.Errorf("matchCandidate() = %q, want %q", got.Name, tt.want.Name)
+			}
+		})
+	}
+}
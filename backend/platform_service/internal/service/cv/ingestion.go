@@ -0,0 +1,737 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	ingestionFilePending = "pending"
+	ingestionFileFailed  = "failed"
+	ingestionFileSkipped = "skipped"
+
+	// Этапы обработки файла, на которых может произойти ошибка
+	ingestionStageArchive    = "archive"
+	ingestionStageValidation = "validation"
+	ingestionStageStorage    = "storage"
+	ingestionStageExtraction = "extraction"
+	ingestionStageAnalysis   = "analysis"
+	ingestionStageDatabase   = "database"
+
+	// Интервал опроса очереди на случай, если сигнал о новых файлах потерян
+	// или файлы добавлены другим экземпляром сервиса
+	ingestionPollInterval = 10 * time.Second
+	// Срок, на который файл закрепляется за воркером. Файл с истёкшим сроком
+	// (воркер упал или сервис перезапущен) забирает другой воркер
+	ingestionLeaseTimeout = 15 * time.Minute
+	// Обработка одного файла прерывается раньше, чем истекает закрепление,
+	// чтобы файл не обрабатывали два воркера одновременно
+	ingestionFileTimeout = 10 * time.Minute
+	// Сколько раз файл может потерять закрепление (воркер падает на нём),
+	// прежде чем он получит ошибку вместо новой попытки
+	ingestionMaxAttempts = 3
+)
+
+var ErrIngestionJobNotFound = errors.New("ingestion job not found")
+
+// errDuplicateResume - резюме с таким же содержимым уже есть в базе
+var errDuplicateResume = errors.New("resume already exists")
+
+// ingestionError - ошибка обработки файла с указанием этапа, на котором она
+// произошла. Этап сохраняется в отчёт вместе с текстом ошибки.
+type ingestionError struct {
+	stage string
+	err   error
+}
+
+func (e *ingestionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ingestionError) Unwrap() error {
+	return e.err
+}
+
+func stageError(stage string, err error) error {
+	return &ingestionError{stage: stage, err: err}
+}
+
+// errorStage возвращает этап ошибки; для ошибок без этапа - сохранение в базу,
+// так как остальные этапы размечают свои ошибки явно.
+func errorStage(err error) string {
+	var stageErr *ingestionError
+	if errors.As(err, &stageErr) {
+		return stageErr.stage
+	}
+	return ingestionStageDatabase
+}
+
+// UploadResumeDatabase сохраняет архив в хранилище и ставит его файлы в
+// очередь на обработку. В запросе проверяется только оглавление архива:
+// извлечение, проверка антивирусом и анализ файлов выполняются воркерами в фоне.
+func (s *service) UploadResumeDatabase(ctx context.Context, userGUID string, archive *multipart.FileHeader) (*models.IngestionJob, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	if archive.Size > s.uploadLimits.MaxArchiveSize {
+		return nil, ErrFileTooLarge
+	}
+
+	// Открываем архив без чтения в память: multipart.File реализует io.ReaderAt
+	file, err := archive.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	zipReader, err := zip.NewReader(file, archive.Size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read ZIP archive: %v", ErrInvalidFile, err)
+	}
+	if err := s.checkArchive(zipReader); err != nil {
+		return nil, err
+	}
+
+	archiveObject, err := s.uploadFile(ctx, file, archive.Size, archive.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	var job repository_cv.CvIngestionJob
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		job, err = s.repo.CV.CreateIngestionJob(ctx, tx, repository_cv.CreateIngestionJobParams{
+			UserID:        userUUID,
+			ArchiveName:   archive.Filename,
+			ArchiveObject: sql.NullString{String: archiveObject, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, zipFile := range zipReader.File {
+			if zipFile.FileInfo().IsDir() {
+				continue
+			}
+			fileName := filepath.Base(zipFile.Name)
+			if fileName == "" || strings.HasPrefix(fileName, ".") {
+				continue
+			}
+
+			params := repository_cv.CreateIngestionFileParams{
+				JobID:        job.ID,
+				FileName:     fileName,
+				Extension:    strings.ToLower(filepath.Ext(fileName)),
+				Status:       ingestionFilePending,
+				ArchiveEntry: sql.NullString{String: zipFile.Name, Valid: true},
+			}
+			if err := s.checkArchiveEntry(zipFile, params.Extension); err != nil {
+				params.Status = ingestionFileFailed
+				params.Error = sql.NullString{String: err.Error(), Valid: true}
+				params.Stage = sql.NullString{String: errorStage(err), Valid: true}
+			}
+
+			if _, err := s.repo.CV.CreateIngestionFile(ctx, tx, params); err != nil {
+				return fmt.Errorf("failed to register file %s: %w", fileName, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if err := s.storageService.Delete(ctx, archiveObject); err != nil {
+			s.log.ErrorContext(ctx, "cv.UploadResumeDatabase failed to delete archive", "object_name", archiveObject, "error", err)
+		}
+		return nil, fmt.Errorf("failed to create ingestion job: %w", err)
+	}
+
+	// Если в архиве не оказалось файлов для обработки, задача завершается сразу
+	if err := s.finishIngestionJob(ctx, job.ID); err != nil {
+		return nil, err
+	}
+	s.wakeIngestionWorkers()
+
+	return s.getIngestionJob(ctx, userUUID, job.ID)
+}
+
+// checkArchiveEntry проверяет файл по заголовку в оглавлении архива, не
+// распаковывая его
+func (s *service) checkArchiveEntry(zipFile *zip.File, ext string) error {
+	if !isValidResumeFile(ext) {
+		return stageError(ingestionStageValidation, fmt.Errorf("unsupported file extension %q", ext))
+	}
+	if err := s.checkArchiveFile(zipFile); err != nil {
+		return stageError(ingestionStageValidation, err)
+	}
+	return nil
+}
+
+// extractArchiveFile извлекает файл из сохранённого архива задачи в
+// хранилище и запоминает его объект и хеш, чтобы повторная обработка не
+// распаковывала архив заново. Для уже загруженного резюме возвращает его ID
+// и errDuplicateResume.
+func (s *service) extractArchiveFile(ctx context.Context, userUUID uuid.UUID, archiveObject sql.NullString, file *repository_cv.CvIngestionFile) (uuid.UUID, error) {
+	if !archiveObject.Valid || !file.ArchiveEntry.Valid {
+		return uuid.Nil, stageError(ingestionStageArchive, errors.New("archive is no longer available"))
+	}
+
+	reader, info, err := s.storageService.Get(ctx, archiveObject.String)
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageStorage, fmt.Errorf("failed to open archive: %w", err))
+	}
+	defer reader.Close()
+
+	// Хранилища отдают объекты с произвольным доступом, архив не читается целиком
+	readerAt, ok := reader.(io.ReaderAt)
+	if !ok {
+		return uuid.Nil, stageError(ingestionStageStorage, errors.New("storage does not support random access to archive"))
+	}
+	zipReader, err := zip.NewReader(readerAt, info.Size)
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageArchive, fmt.Errorf("failed to read ZIP archive: %w", err))
+	}
+
+	index := slices.IndexFunc(zipReader.File, func(f *zip.File) bool {
+		return f.Name == file.ArchiveEntry.String
+	})
+	if index < 0 {
+		return uuid.Nil, stageError(ingestionStageArchive, fmt.Errorf("file %s not found in archive", file.ArchiveEntry.String))
+	}
+
+	resumeID, storeErr := s.storeArchiveFile(ctx, userUUID, zipReader.File[index], file)
+	if storeErr != nil && !errors.Is(storeErr, errDuplicateResume) {
+		return uuid.Nil, storeErr
+	}
+
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		return s.repo.CV.SetIngestionFileObject(ctx, tx, repository_cv.SetIngestionFileObjectParams{
+			ID:          file.ID,
+			ObjectName:  file.ObjectName,
+			ContentHash: file.ContentHash,
+		})
+	})
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageDatabase, fmt.Errorf("failed to save extracted file: %w", err))
+	}
+
+	return resumeID, storeErr
+}
+
+// storeArchiveFile проверяет файл архива и сохраняет его в хранилище.
+// Для файла с тем же содержимым, что и уже обработанное резюме, возвращается
+// ID резюме и errDuplicateResume, а ранее сохранённый объект используется повторно.
+func (s *service) storeArchiveFile(ctx context.Context, userUUID uuid.UUID, zipFile *zip.File, file *repository_cv.CvIngestionFile) (uuid.UUID, error) {
+	hash, err := hashArchiveFile(zipFile)
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageArchive, fmt.Errorf("failed to read file in archive: %w", err))
+	}
+	file.ContentHash = sql.NullString{String: hash, Valid: true}
+
+	var resumeID uuid.UUID
+	var storedObject sql.NullString
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		resume, err := s.repo.CV.GetResumeByContentHash(ctx, tx, repository_cv.GetResumeByContentHashParams{
+			UserID:      userUUID,
+			ContentHash: file.ContentHash,
+		})
+		if err == nil {
+			resumeID = resume.ID
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		storedObject, err = s.repo.CV.GetStoredObjectByContentHash(ctx, tx, repository_cv.GetStoredObjectByContentHashParams{
+			UserID:      userUUID,
+			ContentHash: file.ContentHash,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageDatabase, fmt.Errorf("failed to check duplicates: %w", err))
+	}
+	if resumeID != uuid.Nil {
+		return resumeID, errDuplicateResume
+	}
+	if storedObject.Valid {
+		file.ObjectName = storedObject
+		return uuid.Nil, nil
+	}
+
+	if err := s.checkArchiveFileContent(ctx, zipFile, file.Extension); err != nil {
+		return uuid.Nil, err
+	}
+
+	fileReader, err := zipFile.Open()
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageArchive, fmt.Errorf("failed to open file in archive: %w", err))
+	}
+	defer fileReader.Close()
+
+	objectName, err := s.uploadFile(ctx, fileReader, int64(zipFile.UncompressedSize64), filepath.Base(zipFile.Name))
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageStorage, fmt.Errorf("failed to upload file: %w", err))
+	}
+	if err := s.registerStoredFile(ctx, userUUID, fileKindResume, objectName, zipFile.Name); err != nil {
+		if err := s.storageService.Delete(ctx, objectName); err != nil {
+			s.log.ErrorContext(ctx, "cv.storeArchiveFile failed to delete file", "object_name", objectName, "error", err)
+		}
+		return uuid.Nil, stageError(ingestionStageDatabase, fmt.Errorf("failed to save file metadata: %w", err))
+	}
+	file.ObjectName = sql.NullString{String: objectName, Valid: true}
+
+	return uuid.Nil, nil
+}
+
+// checkArchiveFileContent проверяет сигнатуру и антивирусом файл архива
+// перед сохранением в хранилище
+func (s *service) checkArchiveFileContent(ctx context.Context, zipFile *zip.File, ext string) error {
+	fileReader, err := zipFile.Open()
+	if err != nil {
+		return stageError(ingestionStageArchive, fmt.Errorf("failed to open file in archive: %w", err))
+	}
+	defer fileReader.Close()
+
+	if err := s.checkFileContent(ctx, fileReader, ext); err != nil {
+		return stageError(ingestionStageValidation, err)
+	}
+	return nil
+}
+
+// hashArchiveFile считает SHA-256 содержимого файла архива. Файл читается
+// повторно при загрузке, чтобы не держать его целиком в памяти.
+func hashArchiveFile(zipFile *zip.File) (string, error) {
+	fileReader, err := zipFile.Open()
+	if err != nil {
+		return "", err
+	}
+	defer fileReader.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, fileReader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (s *service) GetIngestionJob(ctx context.Context, userGUID, jobID string) (*models.IngestionJob, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, ErrIngestionJobNotFound
+	}
+
+	return s.getIngestionJob(ctx, userUUID, jobUUID)
+}
+
+// RetryIngestionJob возвращает в очередь файлы задачи, обработка которых
+// завершилась ошибкой. Успешно обработанные файлы повторно не анализируются.
+func (s *service) RetryIngestionJob(ctx context.Context, userGUID, jobID string) (*models.IngestionJob, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, ErrIngestionJobNotFound
+	}
+
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := s.repo.CV.GetIngestionJob(ctx, tx, repository_cv.GetIngestionJobParams{
+			ID:     jobUUID,
+			UserID: userUUID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrIngestionJobNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		retried, err := s.repo.CV.RetryFailedIngestionFiles(ctx, tx, jobUUID)
+		if err != nil {
+			return err
+		}
+		if retried == 0 {
+			return nil
+		}
+
+		return s.repo.CV.ReopenIngestionJob(ctx, tx, jobUUID)
+	})
+	if err != nil {
+		if errors.Is(err, ErrIngestionJobNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retry ingestion job: %w", err)
+	}
+	s.wakeIngestionWorkers()
+
+	return s.getIngestionJob(ctx, userUUID, jobUUID)
+}
+
+func (s *service) getIngestionJob(ctx context.Context, userUUID, jobUUID uuid.UUID) (*models.IngestionJob, error) {
+	var job repository_cv.GetIngestionJobRow
+	var files []repository_cv.CvIngestionFile
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		job, err = s.repo.CV.GetIngestionJob(ctx, tx, repository_cv.GetIngestionJobParams{
+			ID:     jobUUID,
+			UserID: userUUID,
+		})
+		if err != nil {
+			return err
+		}
+
+		files, err = s.repo.CV.GetIngestionFiles(ctx, tx, jobUUID)
+		return err
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrIngestionJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingestion job: %w", err)
+	}
+
+	result := &models.IngestionJob{
+		ID:              job.ID.String(),
+		ArchiveName:     job.ArchiveName,
+		Status:          job.Status,
+		TotalCount:      int(job.TotalFiles),
+		ProcessedCount:  int(job.SucceededFiles + job.FailedFiles + job.SkippedFiles),
+		SuccessfulCount: int(job.SucceededFiles),
+		FailedCount:     int(job.FailedFiles),
+		SkippedCount:    int(job.SkippedFiles),
+		Files:           make([]models.IngestionFile, len(files)),
+		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+	if job.FinishedAt.Valid {
+		result.FinishedAt = &job.FinishedAt.Time
+	}
+
+	for i, file := range files {
+		result.Files[i] = models.IngestionFile{
+			ID:        file.ID.String(),
+			FileName:  file.FileName,
+			Status:    file.Status,
+			Attempts:  int(file.Attempts),
+			UpdatedAt: file.UpdatedAt,
+		}
+		if file.Error.Valid {
+			result.Files[i].Error = &file.Error.String
+		}
+		if file.Stage.Valid {
+			result.Files[i].Stage = &file.Stage.String
+		}
+		result.Files[i].Retryable = isRetryableIngestionFile(file, job.ArchiveObject)
+		if file.ResumeID.Valid {
+			resumeID := file.ResumeID.UUID.String()
+			result.Files[i].ResumeID = &resumeID
+		}
+	}
+
+	return result, nil
+}
+
+// StartIngestion запускает пул воркеров, обрабатывающих файлы из очереди.
+// Воркеры работают до отмены ctx. Файлы, брошенные упавшими воркерами,
+// возвращаются в обработку по истечении закрепления.
+func (s *service) StartIngestion(ctx context.Context) error {
+	for range s.ingestionWorkers {
+		go s.runIngestionWorker(ctx)
+	}
+	if s.embeddingService.Model() != "" {
+		go s.runEmbeddingWorker(ctx)
+	}
+
+	return nil
+}
+
+func (s *service) wakeIngestionWorkers() {
+	for range s.ingestionWorkers {
+		select {
+		case s.ingestionWake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *service) runIngestionWorker(ctx context.Context) {
+	ticker := time.NewTicker(ingestionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.failAbandonedIngestionFiles(ctx)
+		// Обрабатываем файлы, пока очередь не опустеет
+		for ctx.Err() == nil && s.processNextIngestionFile(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.ingestionWake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// failAbandonedIngestionFiles завершает ошибкой файлы, которые исчерпали
+// попытки: обработка каждый раз обрывалась, не успев сохранить результат
+// (например, воркер падает на этом файле). Повторить их можно вручную
+func (s *service) failAbandonedIngestionFiles(ctx context.Context) {
+	var jobIDs []uuid.UUID
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		jobIDs, err = s.repo.CV.FailAbandonedIngestionFiles(ctx, tx, ingestionMaxAttempts)
+		return err
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "cv.failAbandonedIngestionFiles failed to fail files", "error", err)
+		return
+	}
+
+	slices.SortFunc(jobIDs, func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	for _, jobID := range slices.Compact(jobIDs) {
+		if err := s.finishIngestionJob(ctx, jobID); err != nil {
+			s.log.ErrorContext(ctx, "cv.failAbandonedIngestionFiles failed to finish job", "job_id", jobID, "error", err)
+		}
+	}
+}
+
+// processNextIngestionFile забирает из очереди один файл и обрабатывает его.
+// Возвращает false, если очередь пуста или недоступна.
+func (s *service) processNextIngestionFile(ctx context.Context) bool {
+	var file repository_cv.CvIngestionFile
+	var job repository_cv.GetIngestionJobOwnerRow
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		file, err = s.repo.CV.ClaimIngestionFile(ctx, tx, repository_cv.ClaimIngestionFileParams{
+			LeaseExpiresAt: sql.NullTime{Time: time.Now().Add(ingestionLeaseTimeout), Valid: true},
+			Attempts:       ingestionMaxAttempts,
+		})
+		if err != nil {
+			return err
+		}
+
+		job, err = s.repo.CV.GetIngestionJobOwner(ctx, tx, file.JobID)
+		return err
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false
+	}
+	if err != nil {
+		s.log.ErrorContext(ctx, "cv.processNextIngestionFile failed to claim file", "error", err)
+		return false
+	}
+
+	fileCtx, cancel := context.WithTimeout(ctx, ingestionFileTimeout)
+	resumeID, ingestErr := s.ingestFile(fileCtx, job.UserID, job.ArchiveObject, file)
+	cancel()
+	if ctx.Err() != nil {
+		// Сервис останавливается: файл заберёт другой воркер, когда истечёт закрепление
+		return false
+	}
+
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if errors.Is(ingestErr, errDuplicateResume) {
+			return s.repo.CV.SkipIngestionFile(ctx, tx, repository_cv.SkipIngestionFileParams{
+				ID:       file.ID,
+				ResumeID: uuid.NullUUID{UUID: resumeID, Valid: resumeID != uuid.Nil},
+			})
+		}
+		if ingestErr != nil {
+			return s.repo.CV.FailIngestionFile(ctx, tx, repository_cv.FailIngestionFileParams{
+				ID:    file.ID,
+				Error: sql.NullString{String: ingestErr.Error(), Valid: true},
+				Stage: sql.NullString{String: errorStage(ingestErr), Valid: true},
+			})
+		}
+		return s.repo.CV.CompleteIngestionFile(ctx, tx, repository_cv.CompleteIngestionFileParams{
+			ID:       file.ID,
+			ResumeID: uuid.NullUUID{UUID: resumeID, Valid: true},
+		})
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "cv.processNextIngestionFile failed to save file status", "file_id", file.ID, "error", err)
+		return true
+	}
+
+	if ingestErr == nil {
+		s.wakeEmbeddingWorker()
+	}
+
+	if err := s.finishIngestionJob(ctx, file.JobID); err != nil {
+		s.log.ErrorContext(ctx, "cv.processNextIngestionFile failed to finish job", "job_id", file.JobID, "error", err)
+	}
+
+	return true
+}
+
+// ingestFile извлекает файл из архива, если он ещё не сохранён, извлекает из
+// него текст, анализирует через DeepSeek и сохраняет запись в базу резюме,
+// привязывая её к кандидату. Для уже загруженного резюме возвращает его ID и
+// errDuplicateResume.
+func (s *service) ingestFile(ctx context.Context, userUUID uuid.UUID, archiveObject sql.NullString, file repository_cv.CvIngestionFile) (uuid.UUID, error) {
+	if !file.ObjectName.Valid {
+		if resumeID, err := s.extractArchiveFile(ctx, userUUID, archiveObject, &file); err != nil {
+			return resumeID, err
+		}
+	}
+
+	// Файл с тем же содержимым мог быть обработан другой задачей, пока этот ждал в очереди
+	if resumeID, err := s.findResumeByHash(ctx, userUUID, file.ContentHash); err != nil || resumeID != uuid.Nil {
+		if err != nil {
+			return uuid.Nil, stageError(ingestionStageDatabase, err)
+		}
+		return resumeID, errDuplicateResume
+	}
+
+	resumeText, err := s.extractTextFromFile(ctx, file.ObjectName.String, file.Extension)
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageExtraction, err)
+	}
+
+	analysis, err := s.deepSeekService.AnalyzeResume(ctx, resumeText)
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageAnalysis, err)
+	}
+
+	var candidateAge sql.NullInt32
+	if analysis.CandidateAge != nil {
+		candidateAge = sql.NullInt32{Int32: int32(*analysis.CandidateAge), Valid: true}
+	}
+	identity := extractIdentity(analysis.CandidateName, resumeText)
+
+	var resume repository_cv.CvResumeDatabase
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		candidateID, err := s.resolveCandidate(ctx, tx, userUUID, identity)
+		if err != nil {
+			return err
+		}
+
+		resume, err = s.repo.CV.CreateResumeRecord(ctx, tx, repository_cv.CreateResumeRecordParams{
+			UserID:          userUUID,
+			CandidateName:   analysis.CandidateName,
+			CandidateAge:    candidateAge,
+			ExperienceYears: analysis.ExperienceYears,
+			FileUrl:         fmt.Sprintf("%s/api/v1/cv/%s", s.serverFullAddress, file.ObjectName.String),
+			Analysis:        analysis.Analysis,
+			PromptVersion:   analysis.PromptVersion,
+			ContentHash:     file.ContentHash,
+			Email:           sql.NullString{String: identity.email, Valid: identity.email != ""},
+			Phone:           sql.NullString{String: identity.phone, Valid: identity.phone != ""},
+			CandidateID:     uuid.NullUUID{UUID: candidateID, Valid: true},
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Запись не вставлена из-за конфликта по хешу: тот же файл только
+			// что сохранён параллельно, созданный кандидат откатывается
+			return errDuplicateResume
+		}
+		return err
+	})
+	if errors.Is(err, errDuplicateResume) {
+		resumeID, err := s.findResumeByHash(ctx, userUUID, file.ContentHash)
+		if err != nil {
+			return uuid.Nil, stageError(ingestionStageDatabase, err)
+		}
+		return resumeID, errDuplicateResume
+	}
+	if err != nil {
+		return uuid.Nil, stageError(ingestionStageDatabase, fmt.Errorf("failed to save resume: %w", err))
+	}
+
+	return resume.ID, nil
+}
+
+// findResumeByHash возвращает ID резюме пользователя с тем же содержимым
+// или uuid.Nil, если такого резюме нет.
+func (s *service) findResumeByHash(ctx context.Context, userUUID uuid.UUID, contentHash sql.NullString) (uuid.UUID, error) {
+	if !contentHash.Valid {
+		return uuid.Nil, nil
+	}
+
+	var resumeID uuid.UUID
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		resume, err := s.repo.CV.GetResumeByContentHash(ctx, tx, repository_cv.GetResumeByContentHashParams{
+			UserID:      userUUID,
+			ContentHash: contentHash,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resumeID = resume.ID
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find resume by hash: %w", err)
+	}
+
+	return resumeID, nil
+}
+
+// finishIngestionJob завершает задачу, если все её файлы обработаны, и
+// удаляет архив. Архив остаётся, пока есть файлы, которые не удалось
+// извлечь из него: при повторе они извлекаются заново
+func (s *service) finishIngestionJob(ctx context.Context, jobID uuid.UUID) error {
+	var result repository_cv.FinishIngestionJobRow
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		result, err = s.repo.CV.FinishIngestionJob(ctx, tx, jobID)
+		return err
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to finish ingestion job: %w", err)
+	}
+
+	if result.ArchiveObject.Valid && !result.RetainedArchiveObject.Valid {
+		if err := s.storageService.Delete(ctx, result.ArchiveObject.String); err != nil {
+			s.log.ErrorContext(ctx, "cv.finishIngestionJob failed to delete archive", "object_name", result.ArchiveObject.String, "error", err)
+		}
+	}
+	return nil
+}
+
+// isRetryableIngestionFile повторяет условие RetryFailedIngestionFiles:
+// файл уже сохранён в хранилище или его можно заново извлечь из архива.
+// Файлы, отклонённые по оглавлению архива при загрузке, не обрабатывались
+// воркером (attempts = 0) и при повторе отклонились бы снова
+func isRetryableIngestionFile(file repository_cv.CvIngestionFile, archiveObject sql.NullString) bool {
+	if file.Status != ingestionFileFailed {
+		return false
+	}
+	return file.ObjectName.Valid || file.ArchiveEntry.Valid && file.Attempts > 0 && archiveObject.Valid
+}
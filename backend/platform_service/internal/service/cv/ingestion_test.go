@@ -0,0 +1,260 @@
+package cv
+
+import (
+	"PlatformService/internal/config"
+	"PlatformService/internal/repository"
+	repository_cv "PlatformService/internal/repository/cv"
+	"PlatformService/internal/service/scanner"
+	"PlatformService/internal/service/storage"
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const testResumeText = "Иванов Иван Иванович\nОпыт работы: 5 лет, Go разработчик\n"
+
+// fakeTxManager выполняет функцию без транзакции
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+// fakeIngestionQuerier - база без резюме и сохранённых файлов. Вызов
+// остальных запросов паникует на встроенном nil-интерфейсе
+type fakeIngestionQuerier struct {
+	repository_cv.Querier
+	savedObjects []repository_cv.SetIngestionFileObjectParams
+	finished     repository_cv.FinishIngestionJobRow
+	finishedJobs []uuid.UUID
+	abandoned    []uuid.UUID
+	maxAttempts  int32
+}
+
+func (f *fakeIngestionQuerier) GetResumeByContentHash(context.Context, repository_cv.DBTX, repository_cv.GetResumeByContentHashParams) (repository_cv.CvResumeDatabase, error) {
+	return repository_cv.CvResumeDatabase{}, pgx.ErrNoRows
+}
+
+func (f *fakeIngestionQuerier) GetStoredObjectByContentHash(context.Context, repository_cv.DBTX, repository_cv.GetStoredObjectByContentHashParams) (sql.NullString, error) {
+	return sql.NullString{}, pgx.ErrNoRows
+}
+
+func (f *fakeIngestionQuerier) CreateStoredFile(context.Context, repository_cv.DBTX, repository_cv.CreateStoredFileParams) error {
+	return nil
+}
+
+func (f *fakeIngestionQuerier) SetIngestionFileObject(_ context.Context, _ repository_cv.DBTX, arg repository_cv.SetIngestionFileObjectParams) error {
+	f.savedObjects = append(f.savedObjects, arg)
+	return nil
+}
+
+func (f *fakeIngestionQuerier) FinishIngestionJob(_ context.Context, _ repository_cv.DBTX, id uuid.UUID) (repository_cv.FinishIngestionJobRow, error) {
+	f.finishedJobs = append(f.finishedJobs, id)
+	return f.finished, nil
+}
+
+func (f *fakeIngestionQuerier) FailAbandonedIngestionFiles(_ context.Context, _ repository_cv.DBTX, attempts int32) ([]uuid.UUID, error) {
+	f.maxAttempts = attempts
+	return f.abandoned, nil
+}
+
+// flakyStorage - локальное хранилище, которое временно недоступно на чтение
+type flakyStorage struct {
+	storage.Service
+	unavailable bool
+}
+
+func (f *flakyStorage) Get(ctx context.Context, name string) (io.ReadSeekCloser, *storage.ObjectInfo, error) {
+	if f.unavailable {
+		return nil, nil, errors.New("connection refused")
+	}
+	return f.Service.Get(ctx, name)
+}
+
+func newIngestionTestService(t *testing.T, querier repository_cv.Querier) (*service, *flakyStorage) {
+	t.Helper()
+
+	local, err := storage.NewService(&config.Config{StorageProvider: storage.ProviderLocal, StorageLocalPath: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	scannerService, err := scanner.NewService(&config.Config{})
+	if err != nil {
+		t.Fatalf("failed to create scanner: %v", err)
+	}
+
+	store := &flakyStorage{Service: local}
+	return &service{
+		repo: &repository.Repositories{
+			CV:        querier,
+			TxManager: fakeTxManager{},
+		},
+		storageService: store,
+		scannerService: scannerService,
+		uploadLimits:   NewUploadLimits(&config.Config{}),
+		log:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}, store
+}
+
+// putTestArchive сохраняет в хранилище ZIP-архив с одним резюме
+func putTestArchive(t *testing.T, store storage.Service, entry string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	file, err := writer.Create(entry)
+	if err != nil {
+		t.Fatalf("failed to create archive entry: %v", err)
+	}
+	if _, err := file.Write([]byte(testResumeText)); err != nil {
+		t.Fatalf("failed to write archive entry: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close archive: %v", err)
+	}
+
+	if err := store.Put(context.Background(), "archive.zip", &buf, int64(buf.Len()), "application/zip"); err != nil {
+		t.Fatalf("failed to store archive: %v", err)
+	}
+	return "archive.zip"
+}
+
+// Файл, который не удалось извлечь из архива из-за недоступного хранилища,
+// при повторе извлекается из того же архива
+func TestExtractArchiveFileRetry(t *testing.T) {
+	querier := &fakeIngestionQuerier{}
+	s, store := newIngestionTestService(t, querier)
+	archiveObject := sql.NullString{String: putTestArchive(t, store, "resumes/ivanov.txt"), Valid: true}
+	file := repository_cv.CvIngestionFile{
+		ID:           uuid.New(),
+		FileName:     "ivanov.txt",
+		Extension:    ".txt",
+		Status:       "processing",
+		Attempts:     1,
+		ArchiveEntry: sql.NullString{String: "resumes/ivanov.txt", Valid: true},
+	}
+
+	store.unavailable = true
+	failed := file
+	_, err := s.extractArchiveFile(context.Background(), uuid.New(), archiveObject, &failed)
+	if stage := errorStage(err); err == nil || stage != ingestionStageStorage {
+		t.Fatalf("extractArchiveFile() error = %v (stage %s), want %s error", err, stage, ingestionStageStorage)
+	}
+	if len(querier.savedObjects) != 0 {
+		t.Fatalf("object saved after failed extraction: %+v", querier.savedObjects)
+	}
+
+	failed.Status = ingestionFileFailed
+	if !isRetryableIngestionFile(failed, archiveObject) {
+		t.Fatal("file that failed to extract is not retryable while archive is kept")
+	}
+
+	store.unavailable = false
+	retried := file
+	retried.Attempts = 2
+	if _, err := s.extractArchiveFile(context.Background(), uuid.New(), archiveObject, &retried); err != nil {
+		t.Fatalf("extractArchiveFile() on retry error = %v", err)
+	}
+	if !retried.ObjectName.Valid || !retried.ContentHash.Valid {
+		t.Fatalf("extracted file has no object or hash: %+v", retried)
+	}
+	if len(querier.savedObjects) != 1 || querier.savedObjects[0].ObjectName != retried.ObjectName {
+		t.Errorf("saved objects = %+v, want %s", querier.savedObjects, retried.ObjectName.String)
+	}
+
+	reader, _, err := store.Get(context.Background(), retried.ObjectName.String)
+	if err != nil {
+		t.Fatalf("extracted object is not stored: %v", err)
+	}
+	defer reader.Close()
+	content, _ := io.ReadAll(reader)
+	if string(content) != testResumeText {
+		t.Errorf("stored object = %q, want %q", content, testResumeText)
+	}
+}
+
+func TestFinishIngestionJobArchive(t *testing.T) {
+	tests := []struct {
+		name        string
+		retained    bool
+		wantDeleted bool
+	}{
+		{"all files extracted", false, true},
+		{"files left to extract", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier := &fakeIngestionQuerier{}
+			s, store := newIngestionTestService(t, querier)
+			archive := putTestArchive(t, store, "ivanov.txt")
+			querier.finished = repository_cv.FinishIngestionJobRow{
+				ArchiveObject:         sql.NullString{String: archive, Valid: true},
+				RetainedArchiveObject: sql.NullString{String: archive, Valid: tt.retained},
+			}
+
+			if err := s.finishIngestionJob(context.Background(), uuid.New()); err != nil {
+				t.Fatalf("finishIngestionJob() error = %v", err)
+			}
+
+			_, err := store.Stat(context.Background(), archive)
+			if deleted := errors.Is(err, storage.ErrObjectNotFound); deleted != tt.wantDeleted {
+				t.Errorf("archive deleted = %v, want %v (stat error %v)", deleted, tt.wantDeleted, err)
+			}
+		})
+	}
+}
+
+func TestIsRetryableIngestionFile(t *testing.T) {
+	archive := sql.NullString{String: "archive.zip", Valid: true}
+	object := sql.NullString{String: "file.pdf", Valid: true}
+	entry := sql.NullString{String: "resumes/file.pdf", Valid: true}
+
+	tests := []struct {
+		name    string
+		file    repository_cv.CvIngestionFile
+		archive sql.NullString
+		want    bool
+	}{
+		{"stored file", repository_cv.CvIngestionFile{Status: ingestionFileFailed, ObjectName: object, ArchiveEntry: entry, Attempts: 1}, sql.NullString{}, true},
+		{"not extracted, archive kept", repository_cv.CvIngestionFile{Status: ingestionFileFailed, ArchiveEntry: entry, Attempts: 1}, archive, true},
+		{"not extracted, archive deleted", repository_cv.CvIngestionFile{Status: ingestionFileFailed, ArchiveEntry: entry, Attempts: 1}, sql.NullString{}, false},
+		{"rejected by archive listing", repository_cv.CvIngestionFile{Status: ingestionFileFailed, ArchiveEntry: entry}, archive, false},
+		{"succeeded", repository_cv.CvIngestionFile{Status: "succeeded", ObjectName: object, Attempts: 1}, archive, false},
+		{"skipped", repository_cv.CvIngestionFile{Status: ingestionFileSkipped, ArchiveEntry: entry, Attempts: 1}, archive, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableIngestionFile(tt.file, tt.archive); got != tt.want {
+				t.Errorf("isRetryableIngestionFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Файлы, исчерпавшие попытки, завершаются ошибкой, а их задачи - проверкой
+// на завершение, по одному разу на задачу
+func TestFailAbandonedIngestionFiles(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	querier := &fakeIngestionQuerier{abandoned: []uuid.UUID{first, second, first}}
+	s, _ := newIngestionTestService(t, querier)
+
+	s.failAbandonedIngestionFiles(context.Background())
+
+	if querier.maxAttempts != ingestionMaxAttempts {
+		t.Errorf("attempts limit = %d, want %d", querier.maxAttempts, ingestionMaxAttempts)
+	}
+	if len(querier.finishedJobs) != 2 || !slices.Contains(querier.finishedJobs, first) || !slices.Contains(querier.finishedJobs, second) {
+		t.Errorf("finished jobs = %v, want %v and %v once each", querier.finishedJobs, first, second)
+	}
+}
@@ -0,0 +1,353 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	repository_job "PlatformService/internal/repository/job"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// Столько резюме, отобранных поиском, оцениваются моделью
+	matchShortlistSize = 40
+	// Размер пачки резюме в одном запросе к модели
+	matchBatchSize = 10
+	// Количество одновременных запросов к модели при подборе
+	matchConcurrency = 4
+)
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrNotJobAuthor = errors.New("access denied: not job author")
+)
+
+// MatchCandidatesFromDatabase подбирает кандидатов под вакансию в два этапа.
+// Сначала поиск по тексту и эмбеддингам отбирает matchShortlistSize резюме,
+// затем модель оценивает их пачками по критериям. Оценки сохраняются и
+// переиспользуются, пока не изменились вакансия и отобранные резюме; refresh
+// принудительно запускает подбор заново.
+func (s *service) MatchCandidatesFromDatabase(ctx context.Context, userGUID, jobID string, limit int, refresh bool) (*models.MatchCandidatesResponse, error) {
+	job, err := s.getAuthoredJob(ctx, userGUID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = min(limit, matchShortlistSize)
+
+	shortlist, err := s.shortlistResumes(ctx, job.AuthorID, job)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shortlist) == 0 {
+		return &models.MatchCandidatesResponse{Candidates: []models.MatchedCandidate{}, MatchedAt: time.Now()}, nil
+	}
+
+	shortlistHash := hashShortlist(shortlist)
+
+	if !refresh {
+		response, err := s.getCachedMatch(ctx, job, shortlistHash, limit)
+		if err != nil {
+			return nil, err
+		}
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	// Оцениваем кандидатов через DeepSeek
+	scores, promptVersion, err := s.scoreCandidates(ctx, matchJobDescription(job), shortlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match candidates: %w", err)
+	}
+
+	var dbResults []repository_cv.GetJobMatchResultsRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.repo.CV.UpsertJobMatchRun(ctx, tx, repository_cv.UpsertJobMatchRunParams{
+			JobID:         job.ID,
+			JobUpdatedAt:  job.UpdatedAt,
+			ShortlistHash: shortlistHash,
+			ShortlistSize: int32(len(shortlist)),
+			PromptVersion: promptVersion,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.CV.DeleteJobMatchResults(ctx, tx, job.ID); err != nil {
+			return err
+		}
+
+		for _, score := range scores {
+			score.JobID = job.ID
+			if err := s.repo.CV.CreateJobMatchResult(ctx, tx, score); err != nil {
+				return err
+			}
+		}
+
+		dbResults, err = s.repo.CV.GetJobMatchResults(ctx, tx, repository_cv.GetJobMatchResultsParams{
+			JobID: job.ID,
+			Limit: int32(limit),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save match results: %w", err)
+	}
+
+	return &models.MatchCandidatesResponse{
+		Candidates:    mapMatchedCandidates(dbResults),
+		ShortlistSize: len(shortlist),
+		MatchedAt:     time.Now(),
+	}, nil
+}
+
+// getAuthoredJob возвращает вакансию, если пользователь является её автором.
+func (s *service) getAuthoredJob(ctx context.Context, userGUID, jobID string) (repository_job.JobJob, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return repository_job.JobJob{}, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		return repository_job.JobJob{}, fmt.Errorf("invalid job ID: %w", err)
+	}
+
+	var job repository_job.JobJob
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		job, err = s.repo.Job.GetJobByID(ctx, tx, jobUUID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrJobNotFound
+		}
+		return err
+	})
+	if errors.Is(err, ErrJobNotFound) {
+		return repository_job.JobJob{}, err
+	}
+	if err != nil {
+		return repository_job.JobJob{}, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if job.AuthorID != userUUID {
+		return repository_job.JobJob{}, ErrNotJobAuthor
+	}
+
+	return job, nil
+}
+
+// shortlistResumes отбирает резюме для оценки моделью. Полнотекстовый поиск
+// ищет любые слова из названия и требований вакансии, эмбеддинги - близость
+// ко всему описанию; выдачи объединяются так же, как в SearchResumeDatabase.
+func (s *service) shortlistResumes(ctx context.Context, userUUID uuid.UUID, job repository_job.JobJob) ([]models.ResumeRecord, error) {
+	params := repository_cv.ShortlistResumesParams{
+		Query:      job.Title + "\n" + job.Requirements,
+		Model:      s.embeddingService.Model(),
+		UserID:     userUUID,
+		LimitCount: matchShortlistSize,
+	}
+
+	if params.Model != "" {
+		vectors, err := s.embeddingService.Embed(ctx, []string{matchJobDescription(job)})
+		if err != nil {
+			s.log.WarnContext(ctx, "cv.shortlistResumes failed to embed job, using full-text search only", "error", err)
+		} else {
+			params.Embedding = vectors[0]
+		}
+	}
+
+	var rows []repository_cv.ShortlistResumesRow
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		rows, err = s.repo.CV.ShortlistResumes(ctx, tx, params)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to shortlist resumes: %w", err)
+	}
+
+	resumes := make([]models.ResumeRecord, len(rows))
+	for i, row := range rows {
+		resumes[i] = s.mapResumeFromDB(repository_cv.CvResumeDatabase{
+			ID:              row.ID,
+			UserID:          row.UserID,
+			CandidateName:   row.CandidateName,
+			CandidateAge:    row.CandidateAge,
+			ExperienceYears: row.ExperienceYears,
+			FileUrl:         row.FileUrl,
+			Analysis:        row.Analysis,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+			PromptVersion:   row.PromptVersion,
+			ContentHash:     row.ContentHash,
+			Email:           row.Email,
+			Phone:           row.Phone,
+			CandidateID:     row.CandidateID,
+		})
+	}
+
+	return resumes, nil
+}
+
+// getCachedMatch возвращает сохранённый подбор, если он сделан для текущей
+// редакции вакансии и тех же версий резюме, иначе nil.
+func (s *service) getCachedMatch(ctx context.Context, job repository_job.JobJob, shortlistHash string, limit int) (*models.MatchCandidatesResponse, error) {
+	var response *models.MatchCandidatesResponse
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		run, err := s.repo.CV.GetJobMatchRun(ctx, tx, job.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !run.JobUpdatedAt.Equal(job.UpdatedAt) || run.ShortlistHash != shortlistHash {
+			return nil
+		}
+
+		dbResults, err := s.repo.CV.GetJobMatchResults(ctx, tx, repository_cv.GetJobMatchResultsParams{
+			JobID: job.ID,
+			Limit: int32(limit),
+		})
+		if err != nil {
+			return err
+		}
+
+		response = &models.MatchCandidatesResponse{
+			Candidates:    mapMatchedCandidates(dbResults),
+			ShortlistSize: int(run.ShortlistSize),
+			Cached:        true,
+			MatchedAt:     run.MatchedAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached match: %w", err)
+	}
+
+	return response, nil
+}
+
+// scoreCandidates оценивает резюме пачками по matchBatchSize, чтобы запрос
+// к модели не выходил за контекстное окно при любом размере выборки.
+func (s *service) scoreCandidates(ctx context.Context, jobDescription string, resumes []models.ResumeRecord) ([]repository_cv.CreateJobMatchResultParams, string, error) {
+	responses, err := inBatches(resumes, func(batch []models.ResumeRecord) (*models.DeepSeekMatchResponse, error) {
+		return s.deepSeekService.MatchCandidates(ctx, jobDescription, batch)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var scores []repository_cv.CreateJobMatchResultParams
+	seen := make(map[string]bool)
+	for _, response := range responses {
+		for _, candidate := range response.Candidates {
+			// Схема не запрещает повторы внутри пачки, учитываем первую оценку
+			if seen[candidate.ResumeID] {
+				continue
+			}
+			seen[candidate.ResumeID] = true
+
+			resumeUUID, err := uuid.Parse(candidate.ResumeID)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid resume ID in match response: %w", err)
+			}
+
+			score := repository_cv.CreateJobMatchResultParams{
+				ResumeID:        resumeUUID,
+				MatchScore:      int32(candidate.MatchScore),
+				SkillsScore:     int32(candidate.Scores.Skills),
+				ExperienceScore: int32(candidate.Scores.Experience),
+				SeniorityScore:  int32(candidate.Scores.Seniority),
+				Reasoning:       candidate.Reasoning,
+			}
+			if candidate.Scores.Location != nil {
+				score.LocationScore = sql.NullInt32{Int32: int32(*candidate.Scores.Location), Valid: true}
+			}
+			scores = append(scores, score)
+		}
+	}
+
+	return scores, responses[0].PromptVersion, nil
+}
+
+// inBatches обрабатывает items пачками по matchBatchSize, выполняя не больше
+// matchConcurrency пачек одновременно. Результаты возвращаются в порядке пачек.
+func inBatches[T, R any](items []T, process func(batch []T) (R, error)) ([]R, error) {
+	var batches [][]T
+	for start := 0; start < len(items); start += matchBatchSize {
+		batches = append(batches, items[start:min(start+matchBatchSize, len(items))])
+	}
+
+	results := make([]R, len(batches))
+	errs := make([]error, len(batches))
+	semaphore := make(chan struct{}, matchConcurrency)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			results[i], errs[i] = process(batch)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func matchJobDescription(job repository_job.JobJob) string {
+	return fmt.Sprintf("Название: %s\nЛокация: %s\nТип занятости: %s\nОписание: %s\nТребования: %s",
+		job.Title, job.Location, job.EmploymentType, job.Description, job.Requirements)
+}
+
+// hashShortlist - отпечаток выборки: меняется, если в неё попало другое
+// резюме или отобранное резюме было обновлено.
+func hashShortlist(resumes []models.ResumeRecord) string {
+	hash := sha256.New()
+	for _, resume := range resumes {
+		fmt.Fprintf(hash, "%s:%d\n", resume.ID, resume.UpdatedAt.UnixNano())
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func mapMatchedCandidates(dbResults []repository_cv.GetJobMatchResultsRow) []models.MatchedCandidate {
+	candidates := make([]models.MatchedCandidate, len(dbResults))
+	for i, result := range dbResults {
+		candidates[i] = models.MatchedCandidate{
+			ResumeID:      result.ResumeID.String(),
+			CandidateName: result.CandidateName,
+			FileURL:       result.FileUrl,
+			MatchScore:    int(result.MatchScore),
+			Scores: models.MatchScoreBreakdown{
+				Skills:     int(result.SkillsScore),
+				Experience: int(result.ExperienceScore),
+				Seniority:  int(result.SeniorityScore),
+			},
+			Reasoning: result.Reasoning,
+		}
+		if result.LocationScore.Valid {
+			location := int(result.LocationScore.Int32)
+			candidates[i].Scores.Location = &location
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,479 @@
+package cv
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	// Документы с текстом больше этого размера считаются повреждёнными или
+	// вредоносными (распаковка XML из ZIP может многократно увеличить объём)
+	maxDocumentXMLSize = 64 << 20
+	// Извлечённый текст обрезается до этого размера: разметка вроде
+	// <text:s text:c="N"/> позволяет получить из короткого XML длинный текст
+	maxDocumentTextSize = 16 << 20
+	// Сколько пробелов подряд может дать один элемент <text:s>
+	maxODTSpaces = 256
+)
+
+// extractTextFromDOCX открывает контейнер DOCX и разбирает word/document.xml:
+// абзацы, переносы строк, табуляции, таблицы (ячейки через табуляцию) и списки.
+func extractTextFromDOCX(content []byte) (string, error) {
+	document, err := readZipEntry(content, "word/document.xml")
+	if err != nil {
+		return "", fmt.Errorf("failed to open DOCX document: %w", err)
+	}
+	defer document.Close()
+
+	decoder := xml.NewDecoder(document)
+	var b textBuilder
+	inText := false
+	// Абзацы внутри ячейки таблицы остаются в одной строке
+	cellDepth := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse DOCX document: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				b.WriteString("\t")
+			case "br", "cr":
+				b.Newline()
+			case "numPr":
+				// Элемент нумерации в свойствах абзаца - это пункт списка
+				b.WriteString("- ")
+			case "tc":
+				cellDepth++
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				b.ParagraphEnd(cellDepth > 0)
+			case "tc":
+				cellDepth--
+				b.CellEnd()
+			case "tr":
+				b.Newline()
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+
+	return b.String(), nil
+}
+
+// extractTextFromODT разбирает content.xml документа OpenDocument.
+func extractTextFromODT(content []byte) (string, error) {
+	document, err := readZipEntry(content, "content.xml")
+	if err != nil {
+		return "", fmt.Errorf("failed to open ODT document: %w", err)
+	}
+	defer document.Close()
+
+	decoder := xml.NewDecoder(document)
+	var b textBuilder
+	// Текст встречается только внутри абзацев и заголовков
+	depth := 0
+	cellDepth := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse ODT document: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p", "h":
+				depth++
+			case "table-cell":
+				cellDepth++
+			case "list-item":
+				b.WriteString("- ")
+			case "s":
+				// <text:s text:c="N"/> - N пробелов подряд
+				count := 1
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "c" {
+						if n, err := strconv.Atoi(attr.Value); err == nil && n > 0 {
+							count = min(n, maxODTSpaces)
+						}
+					}
+				}
+				b.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				b.WriteString("\t")
+			case "line-break":
+				b.Newline()
+			case "note", "annotation":
+				// Сноски и комментарии не относятся к основному тексту
+				if err := decoder.Skip(); err != nil {
+					return "", fmt.Errorf("failed to parse ODT document: %w", err)
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "p", "h":
+				depth--
+				b.ParagraphEnd(cellDepth > 0)
+			case "table-cell":
+				cellDepth--
+				b.CellEnd()
+			case "table-row":
+				b.Newline()
+			}
+		case xml.CharData:
+			if depth > 0 {
+				b.Write(t)
+			}
+		}
+	}
+
+	return b.String(), nil
+}
+
+// RTF-группы, содержимое которых не является текстом документа
+var rtfSkipDestinations = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "info": true,
+	"pict": true, "object": true, "header": true, "headerl": true,
+	"headerr": true, "headerf": true, "footer": true, "footerl": true,
+	"footerr": true, "footerf": true, "footnote": true, "annotation": true,
+	"listtable": true, "listoverridetable": true, "rsidtbl": true,
+	"generator": true, "xmlnstbl": true, "themedata": true,
+	"colorschememapping": true, "latentstyles": true, "datastore": true,
+	"fldinst": true, "bkmkstart": true, "bkmkend": true,
+}
+
+type rtfState struct {
+	skip     bool
+	ucSkip   int
+	codepage *charmap.Charmap
+}
+
+// extractTextFromRTF разбирает управляющие слова RTF: абзацы, таблицы,
+// символы в кодировке документа (\'hh) и Unicode (\uN).
+func extractTextFromRTF(content []byte) (string, error) {
+	if !bytes.HasPrefix(content, []byte(`{\rtf`)) {
+		return "", errors.New("not an RTF document")
+	}
+
+	var b textBuilder
+	state := rtfState{ucSkip: 1, codepage: charmap.Windows1252}
+	var stack []rtfState
+	// Байты \'hh накапливаются, чтобы корректно декодировать многобайтовые последовательности
+	var pending []byte
+	flush := func() {
+		if len(pending) > 0 {
+			decoded, _ := state.codepage.NewDecoder().Bytes(pending)
+			b.Write(decoded)
+			pending = pending[:0]
+		}
+	}
+	// После \uN пропускается ucSkip символов-заменителей
+	skipChars := 0
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch c {
+		case '{':
+			flush()
+			stack = append(stack, state)
+		case '}':
+			flush()
+			if len(stack) == 0 {
+				return b.String(), nil
+			}
+			state = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			// Текст после корневой группы не относится к документу
+			if len(stack) == 0 {
+				return b.String(), nil
+			}
+		case '\\':
+			if i+1 >= len(content) {
+				break
+			}
+			next := content[i+1]
+			switch {
+			case next == '\'':
+				// \'hh - символ в кодировке документа
+				if i+3 < len(content) {
+					if v, err := strconv.ParseUint(string(content[i+2:i+4]), 16, 8); err == nil {
+						if skipChars > 0 {
+							skipChars--
+						} else if !state.skip {
+							pending = append(pending, byte(v))
+						}
+					}
+				}
+				i += 3
+			case next == '*':
+				// \* - необязательная группа, которую можно пропустить
+				state.skip = true
+				i++
+			case next == '\\' || next == '{' || next == '}':
+				flush()
+				if !state.skip {
+					b.WriteString(string(next))
+				}
+				i++
+			case next == '~':
+				flush()
+				if !state.skip {
+					b.WriteString(" ")
+				}
+				i++
+			case next == '\n' || next == '\r':
+				flush()
+				if !state.skip {
+					b.Newline()
+				}
+				i++
+			case isASCIILetter(next):
+				flush()
+				j := i + 1
+				for j < len(content) && isASCIILetter(content[j]) {
+					j++
+				}
+				word := string(content[i+1 : j])
+				k := j
+				if k < len(content) && (content[k] == '-' || isASCIIDigit(content[k])) {
+					k++
+					for k < len(content) && isASCIIDigit(content[k]) {
+						k++
+					}
+				}
+				param, hasParam := 0, k > j
+				if hasParam {
+					param, _ = strconv.Atoi(string(content[j:k]))
+				}
+				// Пробел после управляющего слова - часть слова
+				if k < len(content) && content[k] == ' ' {
+					k++
+				}
+				i = k - 1
+
+				if rtfSkipDestinations[word] {
+					state.skip = true
+					continue
+				}
+				switch word {
+				case "ansicpg":
+					if cm := codepageCharmap(param); cm != nil {
+						state.codepage = cm
+					}
+				case "uc":
+					if hasParam {
+						state.ucSkip = param
+					}
+				case "u":
+					if !state.skip {
+						if param < 0 {
+							param += 65536
+						}
+						b.WriteString(string(rune(param)))
+					}
+					skipChars = state.ucSkip
+				case "par", "line", "sect", "page":
+					if !state.skip {
+						b.Newline()
+					}
+				case "tab":
+					if !state.skip {
+						b.WriteString("\t")
+					}
+				case "cell":
+					if !state.skip {
+						b.CellEnd()
+					}
+				case "row":
+					if !state.skip {
+						b.Newline()
+					}
+				case "bullet":
+					if !state.skip {
+						b.WriteString("- ")
+					}
+				case "emdash", "endash":
+					if !state.skip {
+						b.WriteString("-")
+					}
+				case "lquote", "rquote":
+					if !state.skip {
+						b.WriteString("'")
+					}
+				case "ldblquote", "rdblquote":
+					if !state.skip {
+						b.WriteString("\"")
+					}
+				}
+			default:
+				i++
+			}
+		case '\r', '\n':
+			// Переводы строк в исходнике RTF не значимы
+		default:
+			flush()
+			if skipChars > 0 {
+				skipChars--
+				continue
+			}
+			if !state.skip {
+				b.WriteString(string(c))
+			}
+		}
+	}
+	flush()
+
+	return b.String(), nil
+}
+
+func codepageCharmap(codepage int) *charmap.Charmap {
+	switch codepage {
+	case 866:
+		return charmap.CodePage866
+	case 1250:
+		return charmap.Windows1250
+	case 1251:
+		return charmap.Windows1251
+	case 1252:
+		return charmap.Windows1252
+	case 10007:
+		return charmap.MacintoshCyrillic
+	case 20866:
+		return charmap.KOI8R
+	default:
+		return nil
+	}
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func readZipEntry(content []byte, name string) (io.ReadCloser, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range reader.File {
+		if file.Name != name {
+			continue
+		}
+		if file.UncompressedSize64 > maxDocumentXMLSize {
+			return nil, fmt.Errorf("%s is too large", name)
+		}
+		entry, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(entry, maxDocumentXMLSize), entry}, nil
+	}
+
+	return nil, fmt.Errorf("%s not found", name)
+}
+
+// textBuilder собирает текст документа, схлопывая пустые строки и
+// лишние разделители ячеек таблиц. Текст сверх maxDocumentTextSize отбрасывается.
+type textBuilder struct {
+	sb strings.Builder
+	// Пробел между абзацами ячейки ставится, только если за ним следует текст
+	pendingSpace bool
+}
+
+func (b *textBuilder) Write(p []byte) {
+	if !utf8.Valid(p) {
+		p = []byte(strings.ToValidUTF8(string(p), ""))
+	}
+	b.WriteString(string(p))
+}
+
+func (b *textBuilder) WriteString(s string) {
+	if s == "" || b.full() {
+		return
+	}
+	if b.pendingSpace {
+		b.sb.WriteString(" ")
+		b.pendingSpace = false
+	}
+	b.sb.WriteString(s)
+}
+
+func (b *textBuilder) Newline() {
+	b.pendingSpace = false
+	if !b.full() {
+		b.sb.WriteString("\n")
+	}
+}
+
+func (b *textBuilder) ParagraphEnd(inCell bool) {
+	if inCell {
+		b.pendingSpace = true
+		return
+	}
+	b.Newline()
+}
+
+func (b *textBuilder) CellEnd() {
+	b.pendingSpace = false
+	if !b.full() {
+		b.sb.WriteString("\t")
+	}
+}
+
+func (b *textBuilder) full() bool {
+	return b.sb.Len() >= maxDocumentTextSize
+}
+
+func (b *textBuilder) String() string {
+	lines := strings.Split(b.sb.String(), "\n")
+	result := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			if !blank && len(result) > 0 {
+				result = append(result, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		result = append(result, line)
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
@@ -0,0 +1,137 @@
+package cv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return content
+}
+
+func TestExtractTextFromDOCX(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "paragraphs, lists and tables",
+			content: readFixture(t, "resume.docx"),
+			want:    "Иван Петров\nEmail:\tivan@example.com\nМосква\n\n- Go\n- PostgreSQL\n2020\tЯндекс Разработчик",
+		},
+		{name: "broken XML", content: readFixture(t, "broken_xml.docx"), wantErr: true},
+		{name: "no document.xml", content: readFixture(t, "no_document.docx"), wantErr: true},
+		{name: "not a ZIP", content: []byte("PK\x03\x04garbage"), wantErr: true},
+		{name: "truncated ZIP", content: readFixture(t, "resume.docx")[:200], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractTextFromDOCX(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractTextFromDOCX() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("extractTextFromDOCX() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTextFromODT(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "headings, spaces, notes, lists and tables",
+			content: readFixture(t, "resume.odt"),
+			want:    "Иван Петров\nТелефон:   +7 900 000-00-00\nНавыки:\tGo\nSQL\n- Kubernetes\n2021\tТинькофф Тимлид",
+		},
+		{name: "no content.xml", content: readFixture(t, "no_document.docx"), wantErr: true},
+		{name: "not a ZIP", content: []byte("not an archive"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractTextFromODT(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractTextFromODT() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("extractTextFromODT() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTextFromODTClampsSpaces(t *testing.T) {
+	// 1000 элементов <text:s text:c="2000000000"/>
+	got, err := extractTextFromODT(readFixture(t, "spaces_bomb.odt"))
+	if err != nil {
+		t.Fatalf("extractTextFromODT() error = %v", err)
+	}
+	if want := 2 + 1000*maxODTSpaces; len(got) != want {
+		t.Errorf("extractTextFromODT() returned %d bytes, want %d", len(got), want)
+	}
+	if !strings.HasPrefix(got, "A ") || !strings.HasSuffix(got, " B") {
+		t.Errorf("extractTextFromODT() lost text around spaces: %q...", got[:10])
+	}
+}
+
+func TestExtractTextFromRTF(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "codepage, unicode, fields and tables",
+			content: readFixture(t, "resume.rtf"),
+			want:    "Иван Петров\nОпыт - 5 лет\nexample.com\n2019\tAvito\n-  Go\tPostgreSQL\nC{++}",
+		},
+		{name: "not RTF", content: []byte("plain text"), wantErr: true},
+		{name: "unbalanced closing brace", content: []byte(`{\rtf1 first}second}`), want: "first"},
+		{name: "unclosed group", content: []byte(`{\rtf1 {\b bold`), want: "bold"},
+		{name: "truncated hex escape", content: []byte(`{\rtf1 text\'c`), want: "text"},
+		{name: "trailing backslash", content: []byte(`{\rtf1 text\`), want: "text"},
+		{name: "negative unicode", content: []byte(`{\rtf1 \u-3913?}`), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractTextFromRTF(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractTextFromRTF() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("extractTextFromRTF() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextBuilderLimit(t *testing.T) {
+	var b textBuilder
+	chunk := strings.Repeat("x", 1<<20)
+	for range 2 * maxDocumentTextSize / len(chunk) {
+		b.WriteString(chunk)
+		b.Newline()
+		b.CellEnd()
+	}
+	if got := len(b.String()); got > maxDocumentTextSize+len(chunk) {
+		t.Errorf("textBuilder kept %d bytes, limit is %d", got, maxDocumentTextSize)
+	}
+}
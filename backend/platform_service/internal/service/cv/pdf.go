@@ -0,0 +1,388 @@
+package cv
+
+import (
+	"PlatformService/internal/service/ocr"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ledongthuc/pdf"
+)
+
+const (
+	// Если в текстовом слое меньше значимых символов, PDF считается сканом
+	minPDFTextLength = 32
+	// Ширина, начиная с которой промежуток внутри строки разделяет колонки
+	// или ячейки таблицы (в размерах шрифта)
+	pdfSegmentGap = 2.0
+	// Промежуток между символами, начиная с которого ставится пробел
+	pdfWordGap = 0.2
+	// Минимальная ширина промежутка между колонками, в пунктах
+	pdfMinGutterWidth = 8.0
+	// Колонки внутри колонок ищутся не глубже этого уровня
+	pdfMaxColumnDepth = 2
+)
+
+var errPDFNoText = errors.New("PDF has no text layer")
+
+// extractTextFromPDF извлекает текстовый слой PDF с учётом расположения
+// текста на странице, а для сканов без текстового слоя обращается к OCR.
+func (s *service) extractTextFromPDF(ctx context.Context, content []byte) (string, error) {
+	text, err := extractPDFTextLayer(content)
+	if err != nil {
+		return "", err
+	}
+	if countSignificantRunes(text) >= minPDFTextLength {
+		return text, nil
+	}
+
+	recognized, err := s.ocrService.RecognizePDF(ctx, content)
+	if errors.Is(err, ocr.ErrDisabled) {
+		if strings.TrimSpace(text) == "" {
+			return "", errPDFNoText
+		}
+		return text, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to recognize PDF: %w", err)
+	}
+	if countSignificantRunes(recognized) < countSignificantRunes(text) {
+		return text, nil
+	}
+
+	return recognized, nil
+}
+
+func extractPDFTextLayer(content []byte) (string, error) {
+	reader, err := openPDF(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to open PDF: %w", err)
+	}
+
+	numPages, err := pdfNumPage(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PDF page tree: %w", err)
+	}
+
+	pages := make([]string, 0, numPages)
+	var pageErr error
+	for i := 1; i <= numPages; i++ {
+		text, err := pdfPageText(reader, i)
+		if err != nil {
+			// Повреждённая страница не должна лишать нас текста остальных
+			pageErr = errors.Join(pageErr, fmt.Errorf("page %d: %w", i, err))
+			continue
+		}
+		if text != "" {
+			pages = append(pages, text)
+		}
+	}
+	if len(pages) == 0 && pageErr != nil {
+		return "", fmt.Errorf("failed to extract PDF text: %w", pageErr)
+	}
+
+	return strings.Join(pages, "\n\n"), nil
+}
+
+// Библиотека ledongthuc/pdf сообщает о повреждённых файлах через panic,
+// поэтому каждое обращение к ней оборачивается в recover.
+
+func openPDF(content []byte) (reader *pdf.Reader, err error) {
+	defer recoverPDFPanic(&err)
+	return pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+}
+
+func pdfNumPage(reader *pdf.Reader) (n int, err error) {
+	defer recoverPDFPanic(&err)
+	return reader.NumPage(), nil
+}
+
+func pdfPageText(reader *pdf.Reader, num int) (text string, err error) {
+	defer recoverPDFPanic(&err)
+
+	page := reader.Page(num)
+	if page.V.IsNull() {
+		return "", nil
+	}
+	return layoutPDFPage(page.Content().Text), nil
+}
+
+func recoverPDFPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("malformed PDF: %v", r)
+	}
+}
+
+// pdfSegment - непрерывный фрагмент строки. Строка разбивается на фрагменты
+// по широким промежуткам, чтобы колонки и ячейки таблиц не склеивались.
+type pdfSegment struct {
+	x0, x1   float64
+	y        float64
+	fontSize float64
+	text     string
+}
+
+// layoutPDFPage восстанавливает порядок чтения страницы: собирает символы
+// в строки по базовой линии, делит строки на фрагменты и, если на странице
+// есть колонки, выводит их по очереди.
+func layoutPDFPage(glyphs []pdf.Text) string {
+	segments := pdfSegments(pdfLines(glyphs))
+	if len(segments) == 0 {
+		return ""
+	}
+
+	var b textBuilder
+	var prev *pdfSegment
+	for _, segment := range orderPDFSegments(segments, 0) {
+		if prev != nil {
+			tolerance := 0.5 * max(prev.fontSize, segment.fontSize)
+			switch {
+			case math.Abs(prev.y-segment.y) <= tolerance && segment.x0 > prev.x1:
+				// Фрагменты одной строки (например, должность и даты)
+				b.WriteString("\t")
+			case prev.y-segment.y > 2*max(prev.fontSize, segment.fontSize):
+				// Большой вертикальный отступ - новый абзац
+				b.Newline()
+				b.Newline()
+			default:
+				b.Newline()
+			}
+		}
+		b.WriteString(segment.text)
+		prev = &segment
+	}
+
+	return b.String()
+}
+
+// pdfLines группирует символы в строки сверху вниз; символы внутри строки
+// упорядочены слева направо.
+func pdfLines(glyphs []pdf.Text) [][]pdf.Text {
+	glyphs = append([]pdf.Text(nil), glyphs...)
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		return glyphs[i].Y > glyphs[j].Y
+	})
+
+	var lines [][]pdf.Text
+	var line []pdf.Text
+	lineY, lineSize := 0.0, 0.0
+	for _, glyph := range glyphs {
+		if glyph.S == "" {
+			continue
+		}
+		size := pdfFontSize(glyph)
+		if len(line) > 0 && lineY-glyph.Y > 0.4*max(lineSize, size) {
+			lines = append(lines, line)
+			line = nil
+		}
+		if len(line) == 0 {
+			lineY, lineSize = glyph.Y, size
+		}
+		lineSize = max(lineSize, size)
+		line = append(line, glyph)
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+
+	for _, line := range lines {
+		// Устойчивая сортировка сохраняет порядок из потока содержимого для
+		// символов с одинаковой координатой (шрифты без таблицы ширин)
+		sort.SliceStable(line, func(i, j int) bool {
+			return line[i].X < line[j].X
+		})
+	}
+
+	return lines
+}
+
+func pdfSegments(lines [][]pdf.Text) []pdfSegment {
+	var segments []pdfSegment
+	for _, line := range lines {
+		var current *pdfSegment
+		var sb strings.Builder
+		lastSpace := false
+		flush := func() {
+			if current == nil {
+				return
+			}
+			current.text = strings.TrimSpace(sb.String())
+			if current.text != "" {
+				segments = append(segments, *current)
+			}
+			current = nil
+			sb.Reset()
+		}
+
+		for _, glyph := range line {
+			size := pdfFontSize(glyph)
+			space := strings.TrimSpace(glyph.S) == ""
+			if current != nil {
+				gap := glyph.X - current.x1
+				switch {
+				case gap > pdfSegmentGap*max(size, current.fontSize):
+					flush()
+				case gap > pdfWordGap*size && !lastSpace && !space:
+					sb.WriteString(" ")
+				}
+			}
+			if current == nil {
+				if space {
+					continue
+				}
+				current = &pdfSegment{x0: glyph.X, x1: glyph.X, y: glyph.Y, fontSize: size}
+			}
+
+			if space {
+				if !lastSpace {
+					sb.WriteString(" ")
+				}
+			} else {
+				sb.WriteString(glyph.S)
+			}
+			lastSpace = space
+			current.x1 = max(current.x1, glyph.X+pdfGlyphWidth(glyph))
+			current.fontSize = max(current.fontSize, size)
+		}
+		flush()
+	}
+
+	return segments
+}
+
+// orderPDFSegments упорядочивает фрагменты для чтения. Если на странице
+// найден промежуток между колонками, фрагменты между двумя строками во всю
+// ширину выводятся сначала из левой колонки, затем из правой. Фрагменты
+// на входе уже упорядочены сверху вниз и слева направо.
+func orderPDFSegments(segments []pdfSegment, depth int) []pdfSegment {
+	gutter, ok := findPDFGutter(segments)
+	if !ok || depth >= pdfMaxColumnDepth {
+		return segments
+	}
+
+	result := make([]pdfSegment, 0, len(segments))
+	var left, right []pdfSegment
+	flush := func() {
+		result = append(result, orderPDFSegments(left, depth+1)...)
+		result = append(result, orderPDFSegments(right, depth+1)...)
+		left, right = nil, nil
+	}
+	for _, segment := range segments {
+		switch {
+		case segment.x1 <= gutter:
+			left = append(left, segment)
+		case segment.x0 >= gutter:
+			right = append(right, segment)
+		default:
+			// Заголовок во всю ширину страницы разделяет блоки колонок
+			flush()
+			result = append(result, segment)
+		}
+	}
+	flush()
+
+	return result
+}
+
+// findPDFGutter ищет вертикальную полосу, которую почти не пересекают
+// фрагменты текста и по обе стороны от которой есть текст. Полосы между
+// строками таблиц (должность слева, даты справа) колонками не считаются.
+func findPDFGutter(segments []pdfSegment) (float64, bool) {
+	if len(segments) < 6 {
+		return 0, false
+	}
+
+	minX, maxX := segments[0].x0, segments[0].x1
+	for _, segment := range segments {
+		minX = math.Min(minX, segment.x0)
+		maxX = max(maxX, segment.x1)
+	}
+	width := maxX - minX
+	if width < 100 {
+		return 0, false
+	}
+
+	coverage := make([]int, int(width)+1)
+	for _, segment := range segments {
+		from := int(segment.x0 - minX)
+		to := min(int(segment.x1-minX), len(coverage)-1)
+		for x := from; x <= to; x++ {
+			coverage[x]++
+		}
+	}
+
+	// Допускаем пересечение полосы заголовками во всю ширину
+	allowed := len(segments) / 5
+	bestFrom, bestWidth := 0, 0
+	runFrom := -1
+	lo, hi := int(0.15*width), int(0.85*width)
+	for x := lo; x <= hi+1; x++ {
+		if x <= hi && coverage[x] <= allowed {
+			if runFrom < 0 {
+				runFrom = x
+			}
+			continue
+		}
+		if runFrom >= 0 && x-runFrom > bestWidth {
+			bestFrom, bestWidth = runFrom, x-runFrom
+		}
+		runFrom = -1
+	}
+	if float64(bestWidth) < pdfMinGutterWidth {
+		return 0, false
+	}
+	gutter := minX + float64(bestFrom) + float64(bestWidth)/2
+
+	var leftCount, rightCount, paired int
+	for i, segment := range segments {
+		switch {
+		case segment.x1 <= gutter:
+			leftCount++
+		case segment.x0 >= gutter:
+			rightCount++
+			for j, other := range segments {
+				if j != i && other.x1 <= gutter && math.Abs(other.y-segment.y) <= 0.5*segment.fontSize {
+					paired++
+					break
+				}
+			}
+		}
+	}
+	if leftCount < 3 || rightCount < 3 {
+		return 0, false
+	}
+	// Почти у каждого правого фрагмента есть левый на той же строке - это таблица
+	if float64(paired) >= 0.7*float64(rightCount) {
+		return 0, false
+	}
+
+	return gutter, true
+}
+
+func pdfFontSize(glyph pdf.Text) float64 {
+	return max(math.Abs(glyph.FontSize), 1)
+}
+
+// pdfGlyphWidth возвращает ширину символа. Для составных шрифтов библиотека
+// не знает ширин, поэтому используется средняя ширина символа.
+func pdfGlyphWidth(glyph pdf.Text) float64 {
+	if glyph.W > 0 {
+		return glyph.W
+	}
+	return 0.5 * pdfFontSize(glyph)
+}
+
+func countSignificantRunes(text string) int {
+	count := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			count++
+		}
+	}
+	return count
+}
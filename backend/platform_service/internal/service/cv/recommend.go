@@ -0,0 +1,303 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	repository_job "PlatformService/internal/repository/job"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// Столько вакансий, отобранных поиском по опыту из профиля, оцениваются моделью
+const recommendShortlistSize = 20
+
+var ErrProfileNotFound = errors.New("profile not found")
+
+// RecommendJobs подбирает кандидату активные вакансии, на которые он ещё не
+// откликался. Поиск по опыту работы из профиля отбирает recommendShortlistSize
+// вакансий, модель оценивает их по профилю, опыту и загруженному резюме и
+// объясняет каждую рекомендацию. Подборка кэшируется, пока не изменились
+// профиль и отобранные вакансии.
+func (s *service) RecommendJobs(ctx context.Context, userGUID string, limit int, refresh bool) (*models.RecommendedJobsResponse, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	limit = min(limit, recommendShortlistSize)
+
+	var profile repository_cv.GetApplicantProfileRow
+	var shortlist []repository_cv.ShortlistRecommendedJobsRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		profile, err = s.repo.CV.GetApplicantProfile(ctx, tx, userUUID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrProfileNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		shortlist, err = s.repo.CV.ShortlistRecommendedJobs(ctx, tx, repository_cv.ShortlistRecommendedJobsParams{
+			Query:      profile.Experience,
+			ProfileID:  userUUID,
+			LimitCount: recommendShortlistSize,
+		})
+		return err
+	})
+	if errors.Is(err, ErrProfileNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to shortlist jobs: %w", err)
+	}
+
+	if len(shortlist) == 0 {
+		return &models.RecommendedJobsResponse{Jobs: []models.RecommendedJob{}, MatchedAt: time.Now()}, nil
+	}
+
+	applicant := repository_cv.ShortlistApplicantsRow{
+		Guid:        profile.Guid,
+		Description: profile.Description,
+		Birthday:    profile.Birthday,
+		UpdatedAt:   profile.UpdatedAt,
+		Experience:  profile.Experience,
+		CvLink:      profile.CvLink,
+		CvText:      profile.CvText,
+	}
+	shortlistHash := hashRecommendationShortlist(applicant, shortlist)
+
+	if !refresh {
+		response, err := s.getCachedRecommendations(ctx, userUUID, shortlistHash, limit)
+		if err != nil {
+			return nil, err
+		}
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	jobs := make([]models.Job, len(shortlist))
+	for i, row := range shortlist {
+		jobs[i] = mapRecommendedJobFromDB(repository_job.JobJob{
+			ID:             row.ID,
+			Title:          row.Title,
+			CompanyName:    row.CompanyName,
+			Location:       row.Location,
+			EmploymentType: row.EmploymentType,
+			SalaryFrom:     row.SalaryFrom,
+			SalaryTo:       row.SalaryTo,
+			Description:    row.Description,
+			Requirements:   row.Requirements,
+			AuthorID:       row.AuthorID,
+			CreatedAt:      row.CreatedAt,
+			UpdatedAt:      row.UpdatedAt,
+			Status:         row.Status,
+		})
+	}
+
+	// Оцениваем вакансии через DeepSeek
+	scores, promptVersion, err := s.scoreJobs(ctx, s.applicantRecord(ctx, applicant), jobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recommend jobs: %w", err)
+	}
+
+	var dbResults []repository_cv.GetJobRecommendationsRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		err := s.repo.CV.UpsertJobRecommendationRun(ctx, tx, repository_cv.UpsertJobRecommendationRunParams{
+			ProfileID:     userUUID,
+			ShortlistHash: shortlistHash,
+			ShortlistSize: int32(len(shortlist)),
+			PromptVersion: promptVersion,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.CV.DeleteJobRecommendationResults(ctx, tx, userUUID); err != nil {
+			return err
+		}
+
+		for _, score := range scores {
+			score.ProfileID = userUUID
+			if err := s.repo.CV.CreateJobRecommendationResult(ctx, tx, score); err != nil {
+				return err
+			}
+		}
+
+		dbResults, err = s.repo.CV.GetJobRecommendations(ctx, tx, repository_cv.GetJobRecommendationsParams{
+			ProfileID: userUUID,
+			Limit:     int32(limit),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save recommendations: %w", err)
+	}
+
+	return &models.RecommendedJobsResponse{
+		Jobs:          mapRecommendedJobs(dbResults),
+		ShortlistSize: len(shortlist),
+		MatchedAt:     time.Now(),
+	}, nil
+}
+
+func (s *service) getCachedRecommendations(ctx context.Context, userUUID uuid.UUID, shortlistHash string, limit int) (*models.RecommendedJobsResponse, error) {
+	var response *models.RecommendedJobsResponse
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		run, err := s.repo.CV.GetJobRecommendationRun(ctx, tx, userUUID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if run.ShortlistHash != shortlistHash {
+			return nil
+		}
+
+		dbResults, err := s.repo.CV.GetJobRecommendations(ctx, tx, repository_cv.GetJobRecommendationsParams{
+			ProfileID: userUUID,
+			Limit:     int32(limit),
+		})
+		if err != nil {
+			return err
+		}
+
+		response = &models.RecommendedJobsResponse{
+			Jobs:          mapRecommendedJobs(dbResults),
+			ShortlistSize: int(run.ShortlistSize),
+			Cached:        true,
+			MatchedAt:     run.MatchedAt,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached recommendations: %w", err)
+	}
+
+	return response, nil
+}
+
+// scoreJobs оценивает вакансии для кандидата пачками, как scoreCandidates.
+func (s *service) scoreJobs(ctx context.Context, candidate models.ResumeRecord, jobs []models.Job) ([]repository_cv.CreateJobRecommendationResultParams, string, error) {
+	responses, err := inBatches(jobs, func(batch []models.Job) (*models.DeepSeekRecommendResponse, error) {
+		return s.deepSeekService.RecommendJobs(ctx, candidate, batch)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var scores []repository_cv.CreateJobRecommendationResultParams
+	seen := make(map[string]bool)
+	for _, response := range responses {
+		for _, job := range response.Jobs {
+			if seen[job.JobID] {
+				continue
+			}
+			seen[job.JobID] = true
+
+			jobUUID, err := uuid.Parse(job.JobID)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid job ID in recommendation response: %w", err)
+			}
+
+			score := repository_cv.CreateJobRecommendationResultParams{
+				JobID:           jobUUID,
+				MatchScore:      int32(job.MatchScore),
+				SkillsScore:     int32(job.Scores.Skills),
+				ExperienceScore: int32(job.Scores.Experience),
+				SeniorityScore:  int32(job.Scores.Seniority),
+				Reasoning:       job.Reasoning,
+			}
+			if job.Scores.Location != nil {
+				score.LocationScore = sql.NullInt32{Int32: int32(*job.Scores.Location), Valid: true}
+			}
+			scores = append(scores, score)
+		}
+	}
+
+	return scores, responses[0].PromptVersion, nil
+}
+
+// hashRecommendationShortlist меняется, если изменился профиль кандидата
+// или состав и редакции отобранных вакансий.
+func hashRecommendationShortlist(applicant repository_cv.ShortlistApplicantsRow, jobs []repository_cv.ShortlistRecommendedJobsRow) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n", hashApplicantShortlist([]repository_cv.ShortlistApplicantsRow{applicant}))
+	for _, job := range jobs {
+		fmt.Fprintf(hash, "%s:%d\n", job.ID, job.UpdatedAt.UnixNano())
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func mapRecommendedJobs(dbResults []repository_cv.GetJobRecommendationsRow) []models.RecommendedJob {
+	jobs := make([]models.RecommendedJob, len(dbResults))
+	for i, result := range dbResults {
+		jobs[i] = models.RecommendedJob{
+			Job: mapRecommendedJobFromDB(repository_job.JobJob{
+				ID:             result.ID,
+				Title:          result.Title,
+				CompanyName:    result.CompanyName,
+				Location:       result.Location,
+				EmploymentType: result.EmploymentType,
+				SalaryFrom:     result.SalaryFrom,
+				SalaryTo:       result.SalaryTo,
+				Description:    result.Description,
+				Requirements:   result.Requirements,
+				AuthorID:       result.AuthorID,
+				CreatedAt:      result.CreatedAt,
+				UpdatedAt:      result.UpdatedAt,
+				Status:         result.Status,
+			}),
+			MatchScore: int(result.MatchScore),
+			Scores: models.MatchScoreBreakdown{
+				Skills:     int(result.SkillsScore),
+				Experience: int(result.ExperienceScore),
+				Seniority:  int(result.SeniorityScore),
+			},
+			Reasoning: result.Reasoning,
+		}
+		if result.LocationScore.Valid {
+			location := int(result.LocationScore.Int32)
+			jobs[i].Scores.Location = &location
+		}
+	}
+	return jobs
+}
+
+func mapRecommendedJobFromDB(job repository_job.JobJob) models.Job {
+	var salaryFrom, salaryTo *int
+	if job.SalaryFrom.Valid {
+		val := int(job.SalaryFrom.Int32)
+		salaryFrom = &val
+	}
+	if job.SalaryTo.Valid {
+		val := int(job.SalaryTo.Int32)
+		salaryTo = &val
+	}
+
+	return models.Job{
+		ID:             job.ID.String(),
+		Title:          job.Title,
+		CompanyName:    job.CompanyName,
+		Location:       job.Location,
+		EmploymentType: job.EmploymentType,
+		SalaryFrom:     salaryFrom,
+		SalaryTo:       salaryTo,
+		Description:    job.Description,
+		Requirements:   job.Requirements,
+		AuthorID:       job.AuthorID.String(),
+		CreatedAt:      job.CreatedAt,
+		UpdatedAt:      job.UpdatedAt,
+		Status:         job.Status,
+	}
+}
@@ -0,0 +1,196 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	maxResumeTags       = 20
+	maxResumeTagRunes   = 50
+	maxResumeNotesRunes = 10000
+)
+
+var (
+	ErrResumeNotFound      = errors.New("resume not found")
+	ErrInvalidResumeUpdate = errors.New("invalid resume update")
+)
+
+// UpdateResume меняет теги и заметки рекрутера. Содержимое резюме не
+// меняется, поэтому updated_at не обновляется и сохранённые результаты
+// подбора остаются действительными.
+func (s *service) UpdateResume(ctx context.Context, userGUID, resumeID string, update models.ResumeUpdate) (*models.ResumeRecord, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	resumeUUID, err := uuid.Parse(resumeID)
+	if err != nil {
+		return nil, ErrResumeNotFound
+	}
+
+	params := repository_cv.UpdateResumeAnnotationsParams{
+		ID:     resumeUUID,
+		UserID: userUUID,
+	}
+	if update.Tags != nil {
+		params.Tags, err = normalizeTags(*update.Tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if update.Notes != nil {
+		if utf8.RuneCountInString(*update.Notes) > maxResumeNotesRunes {
+			return nil, fmt.Errorf("%w: notes are longer than %d characters", ErrInvalidResumeUpdate, maxResumeNotesRunes)
+		}
+		params.Notes = sql.NullString{String: *update.Notes, Valid: true}
+	}
+
+	var dbResume repository_cv.CvResumeDatabase
+	var folderItems []repository_cv.CvResumeFolderItem
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		dbResume, err = s.repo.CV.UpdateResumeAnnotations(ctx, tx, params)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrResumeNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		folderItems, err = s.repo.CV.GetResumeFolderItems(ctx, tx, []uuid.UUID{resumeUUID})
+		return err
+	})
+	if errors.Is(err, ErrResumeNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update resume: %w", err)
+	}
+
+	resumes := []models.ResumeRecord{s.mapResumeFromDB(dbResume)}
+	attachFolderIDs(resumes, folderItems)
+
+	return &resumes[0], nil
+}
+
+// DeleteResume удаляет резюме вместе с оценками подбора и папками, а также
+// кандидата, у которого не осталось резюме. Объект в хранилище мог быть
+// переиспользован при повторной загрузке того же файла, поэтому он
+// удаляется, только если на него больше ничего не ссылается.
+func (s *service) DeleteResume(ctx context.Context, userGUID, resumeID string) error {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	resumeUUID, err := uuid.Parse(resumeID)
+	if err != nil {
+		return ErrResumeNotFound
+	}
+
+	var objectName string
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		resume, err := s.repo.CV.DeleteResumeRecord(ctx, tx, repository_cv.DeleteResumeRecordParams{
+			ID:     resumeUUID,
+			UserID: userUUID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrResumeNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if resume.CandidateID.Valid {
+			if err := s.repo.CV.DeleteEmptyCandidate(ctx, tx, resume.CandidateID.UUID); err != nil {
+				return err
+			}
+		}
+
+		if !strings.Contains(resume.FileUrl, "/api/v1/cv/") {
+			return nil
+		}
+		name := path.Base(resume.FileUrl)
+
+		inUse, err := s.repo.CV.IsStoredObjectInUse(ctx, tx, name)
+		if err != nil || inUse {
+			return err
+		}
+
+		// Загруженные ранее файлы архивов больше не указывают на объект, и
+		// повторная загрузка того же резюме сохранит файл заново
+		if err := s.repo.CV.ReleaseStoredObject(ctx, tx, sql.NullString{String: name, Valid: true}); err != nil {
+			return err
+		}
+		if err := s.repo.CV.DeleteStoredFile(ctx, tx, name); err != nil {
+			return err
+		}
+		objectName = name
+		return nil
+	})
+	if errors.Is(err, ErrResumeNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete resume: %w", err)
+	}
+
+	if objectName != "" {
+		if err := s.storageService.Delete(ctx, objectName); err != nil {
+			// Запись уже удалена, в хранилище остаётся объект без ссылок
+			s.log.ErrorContext(ctx, "cv.DeleteResume failed to delete file", "object_name", objectName, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// normalizeTags приводит теги к нижнему регистру и убирает пустые и
+// повторяющиеся, сохраняя порядок.
+func normalizeTags(tags []string) ([]string, error) {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxResumeTagRunes {
+			return nil, fmt.Errorf("%w: tag %q is longer than %d characters", ErrInvalidResumeUpdate, tag, maxResumeTagRunes)
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	if len(result) > maxResumeTags {
+		return nil, fmt.Errorf("%w: more than %d tags", ErrInvalidResumeUpdate, maxResumeTags)
+	}
+	return result, nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// attachFolderIDs заполняет папки, в которых лежат резюме
+func attachFolderIDs(resumes []models.ResumeRecord, items []repository_cv.CvResumeFolderItem) {
+	folders := make(map[string][]string)
+	for _, item := range items {
+		resumeID := item.ResumeID.String()
+		folders[resumeID] = append(folders[resumeID], item.FolderID.String())
+	}
+	for i := range resumes {
+		resumes[i].FolderIDs = folders[resumes[i].ID]
+	}
+}
@@ -0,0 +1,203 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	"PlatformService/internal/service/textsearch"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// Резюме с меньшим косинусным сходством с запросом не считаются найденными
+	// по смыслу (но могут найтись по словам)
+	searchMinSimilarity = 0.3
+
+	// Эмбеддинги считаются пачками, чтобы не делать запрос на каждое резюме
+	embeddingBatchSize = 16
+	// Интервал, через который повторяется расчёт эмбеддингов после ошибки API
+	embeddingPollInterval = time.Minute
+)
+
+var ErrInvalidSearchFilter = errors.New("invalid search filter")
+
+var snippetOptions = textsearch.HeadlineOptions("MaxFragments=3, MaxWords=30, MinWords=10")
+
+// SearchResumeDatabase ищет по базе резюме пользователя. Полнотекстовая
+// выдача и выдача по близости эмбеддингов объединяются методом Reciprocal
+// Rank Fusion: резюме, высоко стоящее в обеих выдачах, оказывается выше
+// резюме, найденного только одним способом. Если эмбеддинги отключены или
+// API недоступно, поиск выполняется только по тексту.
+func (s *service) SearchResumeDatabase(ctx context.Context, userGUID string, filter models.ResumeSearchFilter, limit, offset int) ([]models.ResumeSearchResult, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	if filter.MinAge != nil && filter.MaxAge != nil && *filter.MinAge > *filter.MaxAge {
+		return nil, fmt.Errorf("%w: min_age is greater than max_age", ErrInvalidSearchFilter)
+	}
+	if filter.MinExperience != nil && filter.MaxExperience != nil && *filter.MinExperience > *filter.MaxExperience {
+		return nil, fmt.Errorf("%w: min_experience is greater than max_experience", ErrInvalidSearchFilter)
+	}
+
+	params := repository_cv.SearchResumesParams{
+		Query:           strings.TrimSpace(filter.Query),
+		Model:           s.embeddingService.Model(),
+		UserID:          userUUID,
+		MinSimilarity:   searchMinSimilarity,
+		HeadlineOptions: snippetOptions,
+		LimitCount:      int32(limit),
+		OffsetCount:     int32(offset),
+	}
+	if filter.MinAge != nil {
+		params.MinAge = sql.NullInt32{Int32: int32(*filter.MinAge), Valid: true}
+	}
+	if filter.MaxAge != nil {
+		params.MaxAge = sql.NullInt32{Int32: int32(*filter.MaxAge), Valid: true}
+	}
+	if filter.MinExperience != nil {
+		params.MinExperience = sql.NullFloat64{Float64: *filter.MinExperience, Valid: true}
+	}
+	if filter.MaxExperience != nil {
+		params.MaxExperience = sql.NullFloat64{Float64: *filter.MaxExperience, Valid: true}
+	}
+
+	if params.Query != "" && params.Model != "" {
+		vectors, err := s.embeddingService.Embed(ctx, []string{params.Query})
+		if err != nil {
+			s.log.WarnContext(ctx, "cv.SearchResumeDatabase failed to embed query, using full-text search only", "error", err)
+		} else {
+			params.Embedding = vectors[0]
+		}
+	}
+
+	var rows []repository_cv.SearchResumesRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		rows, err = s.repo.CV.SearchResumes(ctx, tx, params)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search resumes: %w", err)
+	}
+
+	results := make([]models.ResumeSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = models.ResumeSearchResult{
+			Resume: s.mapResumeFromDB(repository_cv.CvResumeDatabase{
+				ID:              row.ID,
+				UserID:          row.UserID,
+				CandidateName:   row.CandidateName,
+				CandidateAge:    row.CandidateAge,
+				ExperienceYears: row.ExperienceYears,
+				FileUrl:         row.FileUrl,
+				Analysis:        row.Analysis,
+				CreatedAt:       row.CreatedAt,
+				UpdatedAt:       row.UpdatedAt,
+				PromptVersion:   row.PromptVersion,
+				ContentHash:     row.ContentHash,
+				Email:           row.Email,
+				Phone:           row.Phone,
+				CandidateID:     row.CandidateID,
+				Tags:            row.Tags,
+				Notes:           row.Notes,
+			}),
+			Score:   row.Score,
+			Snippet: textsearch.ParseSnippet(row.Snippet),
+		}
+	}
+
+	return results, nil
+}
+
+func (s *service) wakeEmbeddingWorker() {
+	select {
+	case s.embeddingWake <- struct{}{}:
+	default:
+	}
+}
+
+// runEmbeddingWorker рассчитывает эмбеддинги резюме, у которых их нет для
+// текущей модели: новых резюме, резюме, загруженных до включения
+// эмбеддингов, и всех резюме после смены модели.
+func (s *service) runEmbeddingWorker(ctx context.Context) {
+	ticker := time.NewTicker(embeddingPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil && s.embedNextResumes(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.embeddingWake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// embedNextResumes рассчитывает эмбеддинги одной пачки резюме. Возвращает
+// false, если резюме без эмбеддингов не осталось или API недоступно.
+func (s *service) embedNextResumes(ctx context.Context) bool {
+	model := s.embeddingService.Model()
+
+	var resumes []repository_cv.CvResumeDatabase
+	err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		resumes, err = s.repo.CV.GetResumesWithoutEmbedding(ctx, tx, repository_cv.GetResumesWithoutEmbeddingParams{
+			Model: model,
+			Limit: embeddingBatchSize,
+		})
+		return err
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "cv.embedNextResumes failed to get resumes", "error", err)
+		return false
+	}
+	if len(resumes) == 0 {
+		return false
+	}
+
+	texts := make([]string, len(resumes))
+	for i, resume := range resumes {
+		texts[i] = resumeEmbeddingText(resume)
+	}
+
+	vectors, err := s.embeddingService.Embed(ctx, texts)
+	if err != nil {
+		s.log.ErrorContext(ctx, "cv.embedNextResumes failed to embed resumes", "error", err)
+		return false
+	}
+
+	for i, resume := range resumes {
+		err := s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			return s.repo.CV.UpsertResumeEmbedding(ctx, tx, repository_cv.UpsertResumeEmbeddingParams{
+				ResumeID:  resume.ID,
+				Model:     model,
+				Embedding: vectors[i],
+			})
+		})
+		if err != nil {
+			// Резюме могло быть удалено, пока считался эмбеддинг
+			s.log.ErrorContext(ctx, "cv.embedNextResumes failed to save embedding", "resume_id", resume.ID, "error", err)
+			return false
+		}
+	}
+
+	return len(resumes) == embeddingBatchSize
+}
+
+// resumeEmbeddingText - текст, по которому ищется резюме. Имя кандидата не
+// несёт смысла для поиска по содержанию и не включается.
+func resumeEmbeddingText(resume repository_cv.CvResumeDatabase) string {
+	return fmt.Sprintf("Опыт работы: %s\n%s", resume.ExperienceYears, resume.Analysis)
+}
@@ -0,0 +1,191 @@
+package cv
+
+import (
+	"PlatformService/internal/config"
+	"PlatformService/internal/models"
+	"PlatformService/internal/service/upload"
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultMaxFileSize            = 10 << 20
+	defaultMaxArchiveSize         = 200 << 20
+	defaultMaxArchiveEntries      = 1000
+	defaultMaxArchiveUncompressed = 1 << 30
+	defaultMaxCompressionRatio    = 100
+)
+
+var (
+	ErrFileTooLarge     = errors.New("file is too large")
+	ErrInvalidFile      = errors.New("invalid file")
+	ErrFileInfected     = upload.ErrInfected
+	ErrArchiveRejected  = errors.New("archive exceeds limits")
+	errUnsupportedMagic = errors.New("file content does not match its extension")
+)
+
+// UploadLimits - ограничения на загружаемые резюме и архивы. Значения из
+// конфигурации, не заданные явно, заменяются значениями по умолчанию.
+type UploadLimits struct {
+	MaxFileSize            int64
+	MaxArchiveSize         int64
+	MaxArchiveEntries      int
+	MaxArchiveUncompressed int64
+	MaxCompressionRatio    int
+}
+
+func NewUploadLimits(cfg *config.Config) UploadLimits {
+	limits := UploadLimits{
+		MaxFileSize:            cfg.UploadMaxFileSize,
+		MaxArchiveSize:         cfg.UploadMaxArchiveSize,
+		MaxArchiveEntries:      cfg.UploadMaxArchiveEntries,
+		MaxArchiveUncompressed: cfg.UploadMaxArchiveUncompressed,
+		MaxCompressionRatio:    cfg.UploadMaxCompressionRatio,
+	}
+	if limits.MaxFileSize <= 0 {
+		limits.MaxFileSize = defaultMaxFileSize
+	}
+	if limits.MaxArchiveSize <= 0 {
+		limits.MaxArchiveSize = defaultMaxArchiveSize
+	}
+	if limits.MaxArchiveEntries <= 0 {
+		limits.MaxArchiveEntries = defaultMaxArchiveEntries
+	}
+	if limits.MaxArchiveUncompressed <= 0 {
+		limits.MaxArchiveUncompressed = defaultMaxArchiveUncompressed
+	}
+	if limits.MaxCompressionRatio <= 0 {
+		limits.MaxCompressionRatio = defaultMaxCompressionRatio
+	}
+	return limits
+}
+
+// UploadCV проверяет файл резюме пользователя, сохраняет его в хранилище и
+// добавляет новую версию CV пользователя
+func (s *service) UploadCV(ctx context.Context, userGUID string, header *multipart.FileHeader, label string, primary bool) (*models.CVVersion, error) {
+	label, err := normalizeCVLabel(label)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Size > s.uploadLimits.MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	if !isValidResumeFile(ext) {
+		return nil, fmt.Errorf("%w: unsupported file extension %q", ErrInvalidFile, ext)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	if err := s.checkFileContent(ctx, file, ext); err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind file: %w", err)
+	}
+
+	objectName, err := s.uploadFile(ctx, file, header.Size, header.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	version, err := s.saveCV(ctx, userGUID, objectName, header.Filename, label, primary)
+	if err != nil {
+		if err := s.storageService.Delete(ctx, objectName); err != nil {
+			s.log.ErrorContext(ctx, "cv.UploadCV failed to delete file", "object_name", objectName, "error", err)
+		}
+		return nil, err
+	}
+
+	// Текст нужен подбору кандидатов; если извлечь его не удалось, подбор
+	// попробует ещё раз при первом обращении
+	if _, err := s.storeFileText(ctx, objectName, ext); err != nil {
+		s.log.WarnContext(ctx, "cv.UploadCV failed to extract CV text", "object_name", objectName, "error", err)
+	}
+
+	return version, nil
+}
+
+// checkArchive отклоняет архив целиком, если он похож на ZIP-бомбу: слишком
+// много файлов или слишком большой суммарный размер после распаковки.
+// Размеры из заголовков можно подделать, но archive/zip не даёт прочитать
+// больше заявленного размера.
+func (s *service) checkArchive(zipReader *zip.Reader) error {
+	var entries int
+	var total uint64
+	for _, zipFile := range zipReader.File {
+		if zipFile.FileInfo().IsDir() {
+			continue
+		}
+		entries++
+		total += zipFile.UncompressedSize64
+	}
+
+	if entries > s.uploadLimits.MaxArchiveEntries {
+		return fmt.Errorf("%w: more than %d files", ErrArchiveRejected, s.uploadLimits.MaxArchiveEntries)
+	}
+	if total > uint64(s.uploadLimits.MaxArchiveUncompressed) {
+		return fmt.Errorf("%w: more than %d bytes uncompressed", ErrArchiveRejected, s.uploadLimits.MaxArchiveUncompressed)
+	}
+	return nil
+}
+
+// checkArchiveFile проверяет размер и степень сжатия отдельного файла архива
+func (s *service) checkArchiveFile(zipFile *zip.File) error {
+	if zipFile.UncompressedSize64 > uint64(s.uploadLimits.MaxFileSize) {
+		return ErrFileTooLarge
+	}
+	if zipFile.CompressedSize64 > 0 && zipFile.UncompressedSize64/zipFile.CompressedSize64 > uint64(s.uploadLimits.MaxCompressionRatio) {
+		return fmt.Errorf("%w: suspicious compression ratio", ErrInvalidFile)
+	}
+	return nil
+}
+
+// checkFileContent сверяет сигнатуру файла с расширением и проверяет файл
+// антивирусом. Файл читается один раз.
+func (s *service) checkFileContent(ctx context.Context, content io.Reader, ext string) error {
+	_, err := upload.CheckContent(ctx, s.scannerService, content, func(head []byte) error {
+		if !matchesFileSignature(head, ext) {
+			return fmt.Errorf("%w: %w", ErrInvalidFile, errUnsupportedMagic)
+		}
+		return nil
+	})
+	return err
+}
+
+// matchesFileSignature проверяет магические байты формата. Для .doc
+// допускаются переименованные DOCX и RTF, которые умеет разбирать извлечение текста.
+func matchesFileSignature(head []byte, ext string) bool {
+	isZIP := bytes.HasPrefix(head, []byte("PK\x03\x04"))
+	isRTF := bytes.HasPrefix(head, []byte(`{\rtf`))
+
+	switch ext {
+	case ".pdf":
+		// Перед заголовком PDF допускается мусор, но только в начале файла
+		return bytes.Contains(head, []byte("%PDF-"))
+	case ".doc":
+		return bytes.HasPrefix(head, cfbSignature) || isZIP || isRTF
+	case ".docx", ".odt":
+		return isZIP
+	case ".rtf":
+		return isRTF
+	case ".txt":
+		return len(head) == 0 || strings.HasPrefix(http.DetectContentType(head), "text/plain")
+	default:
+		return false
+	}
+}
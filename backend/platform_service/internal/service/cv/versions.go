@@ -0,0 +1,260 @@
+package cv
+
+import (
+	"PlatformService/internal/models"
+	repository_cv "PlatformService/internal/repository/cv"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const maxCVLabelRunes = 100
+
+var (
+	ErrCVVersionNotFound = errors.New("cv version not found")
+	ErrCVVersionInUse    = errors.New("cv version is attached to an active application")
+	ErrInvalidCVVersion  = errors.New("invalid cv version")
+)
+
+// ListCVVersions возвращает версии CV пользователя: сначала основная, затем
+// остальные от новых к старым
+func (s *service) ListCVVersions(ctx context.Context, userGUID string) ([]models.CVVersion, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	var dbVersions []repository_cv.ListCVVersionsRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		dbVersions, err = s.repo.CV.ListCVVersions(ctx, tx, userUUID.String())
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cv versions: %w", err)
+	}
+
+	versions := make([]models.CVVersion, len(dbVersions))
+	for i, version := range dbVersions {
+		versions[i] = mapCVVersion(repository_cv.GetCVVersionRow(version))
+	}
+
+	return versions, nil
+}
+
+// UpdateCVVersion меняет подпись версии и/или делает её основной. Снять
+// отметку основной нельзя - только выбрать другую версию
+func (s *service) UpdateCVVersion(ctx context.Context, userGUID, versionID string, update models.CVVersionUpdate) (*models.CVVersion, error) {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	versionUUID, err := uuid.Parse(versionID)
+	if err != nil {
+		return nil, ErrCVVersionNotFound
+	}
+
+	if update.IsPrimary != nil && !*update.IsPrimary {
+		return nil, fmt.Errorf("%w: is_primary can only be set to true", ErrInvalidCVVersion)
+	}
+
+	var label string
+	if update.Label != nil {
+		label, err = normalizeCVLabel(*update.Label)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var dbVersion repository_cv.GetCVVersionRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		params := repository_cv.GetCVVersionParams{
+			Guid:     versionUUID,
+			UserGuid: userUUID.String(),
+		}
+
+		if update.Label != nil {
+			updated, err := s.repo.CV.UpdateCVLabel(ctx, tx, repository_cv.UpdateCVLabelParams{
+				Label:    label,
+				Guid:     versionUUID,
+				UserGuid: userUUID.String(),
+			})
+			if err != nil {
+				return err
+			}
+			if updated == 0 {
+				return ErrCVVersionNotFound
+			}
+		}
+
+		if update.IsPrimary != nil {
+			// Сначала снимаем отметку с текущей основной версии, иначе
+			// сработает уникальный индекс
+			if err := s.repo.CV.UnsetPrimaryCV(ctx, tx, userUUID.String()); err != nil {
+				return err
+			}
+			updated, err := s.repo.CV.SetPrimaryCV(ctx, tx, repository_cv.SetPrimaryCVParams(params))
+			if err != nil {
+				return err
+			}
+			if updated == 0 {
+				return ErrCVVersionNotFound
+			}
+		}
+
+		var err error
+		dbVersion, err = s.repo.CV.GetCVVersion(ctx, tx, params)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCVVersionNotFound
+		}
+		return err
+	})
+	if errors.Is(err, ErrCVVersionNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cv version: %w", err)
+	}
+
+	version := mapCVVersion(dbVersion)
+	return &version, nil
+}
+
+// DeleteCVVersion удаляет версию CV. Версию, приложенную к отклику на
+// рассмотрении, удалить нельзя. Если удалена основная версия, основной
+// становится самая новая из оставшихся. Файл удаляется из хранилища, когда на
+// него больше ничего не ссылается
+func (s *service) DeleteCVVersion(ctx context.Context, userGUID, versionID string) error {
+	userUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return fmt.Errorf("invalid user GUID: %w", err)
+	}
+
+	versionUUID, err := uuid.Parse(versionID)
+	if err != nil {
+		return ErrCVVersionNotFound
+	}
+
+	var objectName string
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		version, err := s.repo.CV.GetCVVersion(ctx, tx, repository_cv.GetCVVersionParams{
+			Guid:     versionUUID,
+			UserGuid: userUUID.String(),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCVVersionNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		inUse, err := s.repo.CV.IsCVInActiveApplication(ctx, tx, uuid.NullUUID{UUID: versionUUID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return ErrCVVersionInUse
+		}
+
+		if err := s.repo.CV.DeleteCV(ctx, tx, versionUUID); err != nil {
+			return err
+		}
+		if version.IsPrimary {
+			if err := s.repo.CV.PromoteLatestCV(ctx, tx, userUUID.String()); err != nil {
+				return err
+			}
+		}
+
+		if !strings.HasPrefix(version.Link, s.serverFullAddress+filesPath) {
+			return nil
+		}
+		name := path.Base(version.Link)
+
+		inUse, err = s.repo.CV.IsStoredObjectInUse(ctx, tx, name)
+		if err != nil || inUse {
+			return err
+		}
+		if err := s.repo.CV.DeleteStoredFile(ctx, tx, name); err != nil {
+			return err
+		}
+		objectName = name
+		return nil
+	})
+	if errors.Is(err, ErrCVVersionNotFound) || errors.Is(err, ErrCVVersionInUse) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete cv version: %w", err)
+	}
+
+	if objectName != "" {
+		if err := s.storageService.Delete(ctx, objectName); err != nil {
+			// Запись уже удалена, в хранилище остаётся объект без ссылок
+			s.log.ErrorContext(ctx, "cv.DeleteCVVersion failed to delete file", "object_name", objectName, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// createCVVersion добавляет версию CV. Первая версия пользователя всегда
+// становится основной
+func (s *service) createCVVersion(ctx context.Context, tx pgx.Tx, userUUID uuid.UUID, link, label string, primary bool) (repository_cv.GetCVVersionRow, error) {
+	versions, err := s.repo.CV.ListCVVersions(ctx, tx, userUUID.String())
+	if err != nil {
+		return repository_cv.GetCVVersionRow{}, err
+	}
+	if len(versions) == 0 {
+		primary = true
+	} else if primary {
+		if err := s.repo.CV.UnsetPrimaryCV(ctx, tx, userUUID.String()); err != nil {
+			return repository_cv.GetCVVersionRow{}, err
+		}
+	}
+
+	cv, err := s.repo.CV.CreateCV(ctx, tx, repository_cv.CreateCVParams{
+		Guid:      uuid.New(),
+		UserGuid:  userUUID.String(),
+		Link:      link,
+		Label:     label,
+		IsPrimary: primary,
+	})
+	if err != nil {
+		return repository_cv.GetCVVersionRow{}, err
+	}
+
+	return s.repo.CV.GetCVVersion(ctx, tx, repository_cv.GetCVVersionParams{
+		Guid:     cv.Guid,
+		UserGuid: cv.UserGuid,
+	})
+}
+
+func normalizeCVLabel(label string) (string, error) {
+	label = strings.TrimSpace(label)
+	if utf8.RuneCountInString(label) > maxCVLabelRunes {
+		return "", fmt.Errorf("%w: label is longer than %d characters", ErrInvalidCVVersion, maxCVLabelRunes)
+	}
+	return label, nil
+}
+
+func mapCVVersion(version repository_cv.GetCVVersionRow) models.CVVersion {
+	result := models.CVVersion{
+		ID:        version.Guid.String(),
+		Label:     version.Label,
+		Link:      version.Link,
+		IsPrimary: version.IsPrimary,
+		CreatedAt: version.CreatedAt.Time,
+		UpdatedAt: version.UpdatedAt.Time,
+	}
+	if version.OriginalFilename.Valid {
+		result.OriginalFilename = &version.OriginalFilename.String
+	}
+	return result
+}
@@ -6,153 +6,382 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 	"time"
 )
 
+const (
+	OutputModeTools      = "tools"
+	OutputModeJSONObject = "json_object"
+)
+
 type Service interface {
 	AnalyzeResume(ctx context.Context, resumeContent string) (*models.DeepSeekAnalysisResponse, error)
 	MatchCandidates(ctx context.Context, jobDescription string, resumes []models.ResumeRecord) (*models.DeepSeekMatchResponse, error)
+	RecommendJobs(ctx context.Context, candidate models.ResumeRecord, jobs []models.Job) (*models.DeepSeekRecommendResponse, error)
 }
 
 type service struct {
-	apiKey  string
-	baseURL string
-	model   string
-	client  *http.Client
+	apiKey         string
+	baseURL        string
+	model          string
+	outputMode     string
+	repairAttempts int
+	prompts        *promptSet
+	client         *http.Client
 }
 
 type deepSeekRequest struct {
-	Model    string            `json:"model"`
-	Messages []deepSeekMessage `json:"messages"`
-	Stream   bool              `json:"stream"`
+	Model          string            `json:"model"`
+	Messages       []deepSeekMessage `json:"messages"`
+	Stream         bool              `json:"stream"`
+	ResponseFormat *responseFormat   `json:"response_format,omitempty"`
+	Tools          []tool            `json:"tools,omitempty"`
+	ToolChoice     *toolChoice       `json:"tool_choice,omitempty"`
 }
 
 type deepSeekMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []toolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+}
+
+type responseFormat struct {
+	Type string `json:"type"`
+}
+
+type tool struct {
+	Type     string       `json:"type"`
+	Function toolFunction `json:"function"`
+}
+
+type toolFunction struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Parameters  *Schema `json:"parameters,omitempty"`
+}
+
+type toolChoice struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name string `json:"name"`
+	} `json:"function"`
+}
+
+type toolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
 }
 
 type deepSeekResponse struct {
 	Choices []struct {
-		Message struct {
-			Content string `json:"content"`
-		} `json:"message"`
+		Message deepSeekMessage `json:"message"`
 	} `json:"choices"`
 }
 
-func NewService(cfg *config.Config) Service {
+// structuredCall описывает запрос, ответ на который должен соответствовать схеме.
+type structuredCall struct {
+	prompt   string
+	language string
+	function string
+	purpose  string
+	schema   *Schema
+}
+
+func NewService(cfg *config.Config) (Service, error) {
+	prompts, err := loadPrompts(cfg.DeepSeekPromptsDir, cfg.DeepSeekPromptLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	outputMode := cfg.DeepSeekOutputMode
+	if outputMode != OutputModeJSONObject {
+		outputMode = OutputModeTools
+	}
+
 	return &service{
-		apiKey:  cfg.DeepSeekAPIKey,
-		baseURL: cfg.DeepSeekAPIURL,
-		model:   cfg.DeepSeekModel,
+		apiKey:         cfg.DeepSeekAPIKey,
+		baseURL:        cfg.DeepSeekAPIURL,
+		model:          cfg.DeepSeekModel,
+		outputMode:     outputMode,
+		repairAttempts: max(0, cfg.DeepSeekRepairAttempts),
+		prompts:        prompts,
 		client: &http.Client{
 			Timeout: 60 * time.Second,
 		},
-	}
+	}, nil
 }
 
 func (s *service) AnalyzeResume(ctx context.Context, resumeContent string) (*models.DeepSeekAnalysisResponse, error) {
-	prompt := fmt.Sprintf(`Проанализируй следующее резюме и верни результат строго в JSON формате без дополнительного текста. 
-
-Резюме:
-%s
-
-Верни JSON с полями:
-- candidate_name: ФИО кандидата (строка)
-- candidate_age: возраст кандидата (число или null если не указан)
-- experience_years: общий опыт работы (строка, например "3 года" или "5 лет")
-- analysis: детальный анализ резюме включающий soft и hard skills, оценку опыта, рекомендации (строка)
-
-Ответ должен содержать только JSON без дополнительного текста.`, resumeContent)
-
-	response, err := s.makeRequest(ctx, prompt)
+	language := detectLanguage(resumeContent, s.prompts.defaultLanguage)
+	prompt, version, err := s.prompts.render(promptAnalyzeResume, language, struct {
+		Resume string
+	}{
+		Resume: resumeContent,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to analyze resume: %w", err)
+		return nil, err
 	}
-	response = strings.Replace(response, "```json", "", -1)
-	response = strings.Replace(response, "```", "", -1)
 
 	var result models.DeepSeekAnalysisResponse
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse DeepSeek response: %w", err)
+	err = s.completeStructured(ctx, structuredCall{
+		prompt:   prompt,
+		language: language,
+		function: "save_resume_analysis",
+		purpose:  "Сохранить результат анализа резюме",
+		schema:   analysisSchema(),
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze resume: %w", err)
 	}
+	result.PromptVersion = version
 
 	return &result, nil
 }
 
+// MatchCandidates оценивает каждого кандидата из переданной пачки по
+// критериям вакансии. Отбор лучших кандидатов выполняет вызывающий код.
 func (s *service) MatchCandidates(ctx context.Context, jobDescription string, resumes []models.ResumeRecord) (*models.DeepSeekMatchResponse, error) {
-	candidatesInfo := make([]string, len(resumes))
-	for i, resume := range resumes {
-		candidatesInfo[i] = fmt.Sprintf(`{
-			"resume_id": "%s",
-			"candidate_name": "%s",
-			"file_url": "%s",
-			"analysis": "%s"
-		}`, resume.ID, resume.CandidateName, resume.FileURL, strings.ReplaceAll(resume.Analysis, `"`, `\"`))
+	type candidate struct {
+		ResumeID        string `json:"resume_id"`
+		CandidateAge    *int   `json:"candidate_age"`
+		ExperienceYears string `json:"experience_years"`
+		Analysis        string `json:"analysis"`
 	}
 
-	prompt := fmt.Sprintf(`Подбери топ-5 кандидатов из базы резюме для данной вакансии и верни результат строго в JSON формате без дополнительного текста.
+	candidates := make([]candidate, len(resumes))
+	resumeIDs := make([]string, len(resumes))
+	for i, resume := range resumes {
+		candidates[i] = candidate{
+			ResumeID:        resume.ID,
+			CandidateAge:    resume.CandidateAge,
+			ExperienceYears: resume.ExperienceYears,
+			Analysis:        resume.Analysis,
+		}
+		resumeIDs[i] = resume.ID
+	}
 
-Описание вакансии:
-%s
+	language := detectLanguage(jobDescription, s.prompts.defaultLanguage)
+	prompt, version, err := s.prompts.render(promptMatchCandidates, language, struct {
+		JobDescription string
+		Candidates     []candidate
+	}{
+		JobDescription: jobDescription,
+		Candidates:     candidates,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-Кандидаты:
-[%s]
+	var result models.DeepSeekMatchResponse
+	err = s.completeStructured(ctx, structuredCall{
+		prompt:   prompt,
+		language: language,
+		function: "save_candidate_scores",
+		purpose:  "Сохранить оценки кандидатов",
+		schema:   matchSchema(resumeIDs),
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match candidates: %w", err)
+	}
+	result.PromptVersion = version
 
-Верни JSON с полем candidates содержащим массив из максимум 5 лучших кандидатов, отсортированных по убыванию соответствия:
-{
-  "candidates": [
-    {
-      "resume_id": "id кандидата",
-      "candidate_name": "ФИО кандидата", 
-      "file_url": "ссылка на резюме",
-      "match_score": число от 1 до 100,
-      "reasoning": "объяснение почему кандидат подходит"
-    }
-  ]
+	return &result, nil
 }
 
-Ответ должен содержать только JSON без дополнительного текста.`, jobDescription, strings.Join(candidatesInfo, ","))
+// RecommendJobs оценивает для кандидата каждую вакансию из переданной пачки
+// по тем же критериям, что и MatchCandidates, но объяснение адресовано
+// кандидату.
+func (s *service) RecommendJobs(ctx context.Context, candidate models.ResumeRecord, jobs []models.Job) (*models.DeepSeekRecommendResponse, error) {
+	type vacancy struct {
+		JobID          string `json:"job_id"`
+		Title          string `json:"title"`
+		CompanyName    string `json:"company_name"`
+		Location       string `json:"location"`
+		EmploymentType string `json:"employment_type"`
+		Description    string `json:"description"`
+		Requirements   string `json:"requirements"`
+	}
+
+	vacancies := make([]vacancy, len(jobs))
+	jobIDs := make([]string, len(jobs))
+	for i, job := range jobs {
+		vacancies[i] = vacancy{
+			JobID:          job.ID,
+			Title:          job.Title,
+			CompanyName:    job.CompanyName,
+			Location:       job.Location,
+			EmploymentType: job.EmploymentType,
+			Description:    job.Description,
+			Requirements:   job.Requirements,
+		}
+		jobIDs[i] = job.ID
+	}
 
-	response, err := s.makeRequest(ctx, prompt)
+	language := detectLanguage(candidate.Analysis, s.prompts.defaultLanguage)
+	prompt, version, err := s.prompts.render(promptRecommendJobs, language, struct {
+		CandidateAge *int
+		Candidate    string
+		Jobs         []vacancy
+	}{
+		CandidateAge: candidate.CandidateAge,
+		Candidate:    candidate.Analysis,
+		Jobs:         vacancies,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to match candidates: %w", err)
+		return nil, err
 	}
 
-	var result models.DeepSeekMatchResponse
-	response = strings.Replace(response, "```json", "", -1)
-	response = strings.Replace(response, "```", "", -1)
-	if err := json.Unmarshal([]byte(response), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse DeepSeek match response: %w", err)
+	var result models.DeepSeekRecommendResponse
+	err = s.completeStructured(ctx, structuredCall{
+		prompt:   prompt,
+		language: language,
+		function: "save_job_scores",
+		purpose:  "Сохранить оценки вакансий",
+		schema:   recommendSchema(jobIDs),
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recommend jobs: %w", err)
 	}
+	result.PromptVersion = version
 
 	return &result, nil
 }
 
-func (s *service) makeRequest(ctx context.Context, prompt string) (string, error) {
+// completeStructured отправляет запрос с ограничением формата ответа схемой,
+// проверяет ответ и при нарушениях просит модель исправить его.
+// Ошибки доступа к API возвращаются как *TransportError, исчерпание попыток
+// исправления - как *FormatError.
+func (s *service) completeStructured(ctx context.Context, call structuredCall, dest any) error {
 	req := deepSeekRequest{
 		Model: s.model,
 		Messages: []deepSeekMessage{
 			{
 				Role:    "user",
-				Content: prompt,
+				Content: call.prompt,
 			},
 		},
 		Stream: false,
 	}
 
+	switch s.outputMode {
+	case OutputModeJSONObject:
+		// response_format не принимает схему, поэтому она передаётся модели
+		// в системном сообщении
+		system, _, err := s.prompts.render(promptJSONSchema, call.language, struct {
+			Schema *Schema
+		}{
+			Schema: call.schema,
+		})
+		if err != nil {
+			return err
+		}
+		req.Messages = append([]deepSeekMessage{{Role: "system", Content: system}}, req.Messages...)
+		req.ResponseFormat = &responseFormat{Type: "json_object"}
+	default:
+		req.Tools = []tool{{
+			Type: "function",
+			Function: toolFunction{
+				Name:        call.function,
+				Description: call.purpose,
+				Parameters:  call.schema,
+			},
+		}}
+		req.ToolChoice = &toolChoice{Type: "function"}
+		req.ToolChoice.Function.Name = call.function
+	}
+
+	var violations []string
+	var raw string
+	for attempt := 0; attempt <= s.repairAttempts; attempt++ {
+		message, err := s.makeRequest(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		var toolCallID string
+		raw, toolCallID = s.extractOutput(message, call.function)
+		violations = s.decode(raw, call.schema, dest)
+		if len(violations) == 0 {
+			return nil
+		}
+
+		repair, _, err := s.prompts.render(promptRepair, call.language, struct {
+			Violations []string
+		}{
+			Violations: violations,
+		})
+		if err != nil {
+			return err
+		}
+
+		req.Messages = append(req.Messages, message)
+		if toolCallID != "" {
+			req.Messages = append(req.Messages, deepSeekMessage{
+				Role:       "tool",
+				Content:    repair,
+				ToolCallID: toolCallID,
+			})
+		} else {
+			req.Messages = append(req.Messages, deepSeekMessage{
+				Role:    "user",
+				Content: repair,
+			})
+		}
+	}
+
+	return &FormatError{
+		Attempts:   s.repairAttempts + 1,
+		Violations: violations,
+		Raw:        raw,
+	}
+}
+
+// extractOutput возвращает JSON из вызова функции, а если модель ответила
+// текстом - из содержимого сообщения.
+func (s *service) extractOutput(message deepSeekMessage, function string) (string, string) {
+	for _, call := range message.ToolCalls {
+		if call.Function.Name == function {
+			return call.Function.Arguments, call.ID
+		}
+	}
+	return extractJSON(message.Content), ""
+}
+
+func (s *service) decode(raw string, schema *Schema, dest any) []string {
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return []string{fmt.Sprintf("ответ не является корректным JSON: %v", err)}
+	}
+
+	if violations := schema.Validate(value); len(violations) > 0 {
+		return violations
+	}
+
+	if err := json.Unmarshal([]byte(raw), dest); err != nil {
+		return []string{fmt.Sprintf("ответ не соответствует ожидаемой структуре: %v", err)}
+	}
+
+	return nil
+}
+
+func (s *service) makeRequest(ctx context.Context, req deepSeekRequest) (deepSeekMessage, error) {
 	reqBody, err := json.Marshal(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return deepSeekMessage{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.baseURL, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+		return deepSeekMessage{}, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -160,28 +389,31 @@ func (s *service) makeRequest(ctx context.Context, prompt string) (string, error
 
 	resp, err := s.client.Do(httpReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to make HTTP request: %w", err)
+		return deepSeekMessage{}, &TransportError{Err: fmt.Errorf("failed to make HTTP request: %w", err)}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("DeepSeek API error: status %d, body: %s", resp.StatusCode, string(body))
+		return deepSeekMessage{}, &TransportError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("DeepSeek API error: %s", string(body)),
+		}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return deepSeekMessage{}, &TransportError{Err: fmt.Errorf("failed to read response body: %w", err)}
 	}
 
 	var deepSeekResp deepSeekResponse
 	if err := json.Unmarshal(body, &deepSeekResp); err != nil {
-		return "", fmt.Errorf("failed to unmarshal DeepSeek response: %w", err)
+		return deepSeekMessage{}, &TransportError{Err: fmt.Errorf("failed to unmarshal DeepSeek response: %w", err)}
 	}
 
 	if len(deepSeekResp.Choices) == 0 {
-		return "", fmt.Errorf("no choices in DeepSeek response")
+		return deepSeekMessage{}, &TransportError{Err: errors.New("no choices in DeepSeek response")}
 	}
 
-	return deepSeekResp.Choices[0].Message.Content, nil
+	return deepSeekResp.Choices[0].Message, nil
 }
@@ -0,0 +1,269 @@
+package deepseek
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const validAnalysis = `{"candidate_name": "Иван", "candidate_age": 30, "experience_years": "5 лет", "analysis": "..."}`
+
+// recordedRequest - запрос к API в том виде, в каком его получил сервер.
+// Schema не умеет разбираться из JSON, поэтому параметры функции остаются сырыми.
+type recordedRequest struct {
+	Messages       []deepSeekMessage `json:"messages"`
+	ResponseFormat *responseFormat   `json:"response_format"`
+	Tools          []struct {
+		Function struct {
+			Name       string          `json:"name"`
+			Parameters json.RawMessage `json:"parameters"`
+		} `json:"function"`
+	} `json:"tools"`
+}
+
+// fakeAPI отвечает заранее заданными сообщениями по порядку и запоминает запросы
+type fakeAPI struct {
+	mu       sync.Mutex
+	replies  []deepSeekMessage
+	requests []recordedRequest
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req recordedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.requests = append(f.requests, req)
+
+	if len(f.replies) == 0 {
+		http.Error(w, "no more replies", http.StatusInternalServerError)
+		return
+	}
+	reply := f.replies[0]
+	f.replies = f.replies[1:]
+
+	var resp deepSeekResponse
+	resp.Choices = append(resp.Choices, struct {
+		Message deepSeekMessage `json:"message"`
+	}{Message: reply})
+	json.NewEncoder(w).Encode(resp)
+}
+
+func textReply(content string) deepSeekMessage {
+	return deepSeekMessage{Role: "assistant", Content: content}
+}
+
+func toolReply(id, function, arguments string) deepSeekMessage {
+	call := toolCall{ID: id, Type: "function"}
+	call.Function.Name = function
+	call.Function.Arguments = arguments
+	return deepSeekMessage{Role: "assistant", ToolCalls: []toolCall{call}}
+}
+
+func newTestService(t *testing.T, outputMode string, repairAttempts int, api http.Handler) *service {
+	t.Helper()
+
+	prompts, err := loadPrompts("", defaultPromptLanguage)
+	if err != nil {
+		t.Fatalf("loadPrompts() error = %v", err)
+	}
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return &service{
+		baseURL:        server.URL,
+		model:          "test",
+		outputMode:     outputMode,
+		repairAttempts: repairAttempts,
+		prompts:        prompts,
+		client:         server.Client(),
+	}
+}
+
+func analysisCall() structuredCall {
+	return structuredCall{
+		prompt:   "резюме",
+		language: "ru",
+		function: "submit_resume_analysis",
+		purpose:  "анализ резюме",
+		schema:   analysisSchema(),
+	}
+}
+
+func TestCompleteStructured(t *testing.T) {
+	tests := []struct {
+		name           string
+		outputMode     string
+		repairAttempts int
+		replies        []deepSeekMessage
+		wantRequests   int
+		wantErr        bool
+		wantViolation  string
+	}{
+		{
+			name:         "valid tool call",
+			outputMode:   OutputModeTools,
+			replies:      []deepSeekMessage{toolReply("call_1", "submit_resume_analysis", validAnalysis)},
+			wantRequests: 1,
+		},
+		{
+			name:         "json in markdown",
+			outputMode:   OutputModeJSONObject,
+			replies:      []deepSeekMessage{textReply("```json\n" + validAnalysis + "\n```")},
+			wantRequests: 1,
+		},
+		{
+			name:           "repaired after invalid json",
+			outputMode:     OutputModeJSONObject,
+			repairAttempts: 1,
+			replies:        []deepSeekMessage{textReply("{не json"), textReply(validAnalysis)},
+			wantRequests:   2,
+		},
+		{
+			name:           "repaired after schema violation",
+			outputMode:     OutputModeTools,
+			repairAttempts: 2,
+			replies: []deepSeekMessage{
+				toolReply("call_1", "submit_resume_analysis", `{"candidate_name": "Иван"}`),
+				toolReply("call_2", "submit_resume_analysis", validAnalysis),
+			},
+			wantRequests: 2,
+		},
+		{
+			name:           "repair attempts exhausted",
+			outputMode:     OutputModeJSONObject,
+			repairAttempts: 1,
+			replies: []deepSeekMessage{
+				textReply(`{"candidate_name": "Иван"}`),
+				textReply(`{"candidate_name": "Иван", "extra": true}`),
+			},
+			wantRequests:  2,
+			wantErr:       true,
+			wantViolation: `$: недопустимое поле "extra"`,
+		},
+		{
+			name:          "no repair attempts",
+			outputMode:    OutputModeTools,
+			replies:       []deepSeekMessage{textReply("не знаю")},
+			wantRequests:  1,
+			wantErr:       true,
+			wantViolation: "ответ не является корректным JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{replies: tt.replies}
+			s := newTestService(t, tt.outputMode, tt.repairAttempts, api)
+
+			var result struct {
+				CandidateName string `json:"candidate_name"`
+			}
+			err := s.completeStructured(context.Background(), analysisCall(), &result)
+
+			if len(api.requests) != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", len(api.requests), tt.wantRequests)
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("completeStructured() error = %v", err)
+				}
+				if result.CandidateName != "Иван" {
+					t.Errorf("candidate_name = %q, want %q", result.CandidateName, "Иван")
+				}
+				return
+			}
+
+			var formatErr *FormatError
+			if !errors.As(err, &formatErr) {
+				t.Fatalf("completeStructured() error = %v, want *FormatError", err)
+			}
+			if formatErr.Attempts != tt.repairAttempts+1 {
+				t.Errorf("Attempts = %d, want %d", formatErr.Attempts, tt.repairAttempts+1)
+			}
+			if !strings.Contains(strings.Join(formatErr.Violations, "\n"), tt.wantViolation) {
+				t.Errorf("Violations = %q, want %q", formatErr.Violations, tt.wantViolation)
+			}
+		})
+	}
+}
+
+func TestCompleteStructuredRepairMessages(t *testing.T) {
+	t.Run("tools", func(t *testing.T) {
+		api := &fakeAPI{replies: []deepSeekMessage{
+			toolReply("call_1", "submit_resume_analysis", `{}`),
+			toolReply("call_2", "submit_resume_analysis", validAnalysis),
+		}}
+		s := newTestService(t, OutputModeTools, 1, api)
+
+		var result map[string]any
+		if err := s.completeStructured(context.Background(), analysisCall(), &result); err != nil {
+			t.Fatalf("completeStructured() error = %v", err)
+		}
+
+		first := api.requests[0]
+		if len(first.Tools) != 1 || first.Tools[0].Function.Name != "submit_resume_analysis" || first.ResponseFormat != nil {
+			t.Errorf("first request has no forced tool call: %+v", first)
+		}
+		repair := api.requests[1].Messages
+		last := repair[len(repair)-1]
+		if last.Role != "tool" || last.ToolCallID != "call_1" || !strings.Contains(last.Content, "отсутствует обязательное поле") {
+			t.Errorf("repair message = %+v, want tool reply with violations", last)
+		}
+	})
+
+	t.Run("json_object", func(t *testing.T) {
+		api := &fakeAPI{replies: []deepSeekMessage{
+			textReply(`{}`),
+			textReply(validAnalysis),
+		}}
+		s := newTestService(t, OutputModeJSONObject, 1, api)
+
+		var result map[string]any
+		if err := s.completeStructured(context.Background(), analysisCall(), &result); err != nil {
+			t.Fatalf("completeStructured() error = %v", err)
+		}
+
+		first := api.requests[0]
+		if first.ResponseFormat == nil || first.ResponseFormat.Type != "json_object" || len(first.Tools) != 0 {
+			t.Errorf("first request has no json_object format: %+v", first)
+		}
+		system := first.Messages[0]
+		if system.Role != "system" || !strings.Contains(system.Content, `"additionalProperties":false`) || !strings.Contains(system.Content, `"candidate_name"`) {
+			t.Errorf("system message = %+v, want schema", system)
+		}
+		repair := api.requests[1].Messages
+		last := repair[len(repair)-1]
+		if last.Role != "user" || !strings.Contains(last.Content, "отсутствует обязательное поле") {
+			t.Errorf("repair message = %+v, want user message with violations", last)
+		}
+	})
+}
+
+func TestCompleteStructuredTransportError(t *testing.T) {
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	})
+	s := newTestService(t, OutputModeTools, 2, api)
+
+	var result map[string]any
+	err := s.completeStructured(context.Background(), analysisCall(), &result)
+
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) {
+		t.Fatalf("completeStructured() error = %v, want *TransportError", err)
+	}
+	if transportErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want %d", transportErr.StatusCode, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,36 @@
+package deepseek
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TransportError возвращается, когда запрос к DeepSeek API не дошёл до модели:
+// сетевая ошибка, таймаут или ответ с кодом, отличным от 200.
+type TransportError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *TransportError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("deepseek transport error: status %d: %v", e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("deepseek transport error: %v", e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// FormatError возвращается, когда модель так и не вернула ответ,
+// удовлетворяющий JSON-схеме, после всех попыток исправления.
+type FormatError struct {
+	Attempts   int
+	Violations []string
+	Raw        string
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("deepseek returned invalid output after %d attempt(s): %s", e.Attempts, strings.Join(e.Violations, "; "))
+}
@@ -0,0 +1,170 @@
+package deepseek
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const (
+	promptAnalyzeResume   = "analyze_resume"
+	promptMatchCandidates = "match_candidates"
+	promptRecommendJobs   = "recommend_jobs"
+	promptRepair          = "repair"
+	promptJSONSchema      = "json_schema"
+
+	defaultPromptLanguage = "ru"
+)
+
+// Шаблоны промптов встроены в бинарник. Файлы с теми же именами в каталоге
+// DEEPSEEK_PROMPTS_DIR заменяют встроенные, новые файлы добавляют языки.
+//
+//go:embed prompts/*.tmpl
+var embeddedPrompts embed.FS
+
+// Каждый шаблон начинается с комментария {{/* version: N */}}. Версия
+// сохраняется вместе с результатом, чтобы было видно, каким промптом он получен.
+var promptVersionPattern = regexp.MustCompile(`^\{\{/\*\s*version:\s*([^\s*]+)\s*\*/`)
+
+type prompt struct {
+	tmpl    *template.Template
+	version string
+}
+
+type promptSet struct {
+	prompts         map[string]*prompt
+	defaultLanguage string
+}
+
+// loadPrompts читает встроенные шаблоны <name>.<lang>.tmpl и поверх них -
+// шаблоны из overrideDir, если он задан.
+func loadPrompts(overrideDir, defaultLanguage string) (*promptSet, error) {
+	if defaultLanguage == "" {
+		defaultLanguage = defaultPromptLanguage
+	}
+
+	set := &promptSet{
+		prompts:         make(map[string]*prompt),
+		defaultLanguage: defaultLanguage,
+	}
+
+	embedded, err := fs.Sub(embeddedPrompts, "prompts")
+	if err != nil {
+		return nil, err
+	}
+	if err := set.load(embedded); err != nil {
+		return nil, fmt.Errorf("failed to load embedded prompts: %w", err)
+	}
+
+	if overrideDir != "" {
+		if err := set.load(os.DirFS(overrideDir)); err != nil {
+			return nil, fmt.Errorf("failed to load prompts from %s: %w", overrideDir, err)
+		}
+	}
+
+	for _, name := range []string{promptAnalyzeResume, promptMatchCandidates, promptRecommendJobs, promptRepair, promptJSONSchema} {
+		if _, ok := set.prompts[name+"."+defaultLanguage]; !ok {
+			return nil, fmt.Errorf("prompt %s has no variant for default language %q", name, defaultLanguage)
+		}
+	}
+
+	return set, nil
+}
+
+func (p *promptSet) load(fsys fs.FS) error {
+	files, err := fs.Glob(fsys, "*.tmpl")
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		match := promptVersionPattern.FindSubmatch(content)
+		if match == nil {
+			return fmt.Errorf("prompt %s has no version header", file)
+		}
+
+		tmpl, err := template.New(file).
+			Funcs(template.FuncMap{"json": toJSON}).
+			Option("missingkey=error").
+			Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse prompt %s: %w", file, err)
+		}
+
+		key := strings.TrimSuffix(path.Base(file), ".tmpl")
+		p.prompts[key] = &prompt{
+			tmpl:    tmpl,
+			version: fmt.Sprintf("%s@%s", key, match[1]),
+		}
+	}
+
+	return nil
+}
+
+// render выполняет шаблон для указанного языка, а если такого варианта нет -
+// для языка по умолчанию. Возвращает текст промпта и его версию.
+func (p *promptSet) render(name, language string, data any) (string, string, error) {
+	pr, ok := p.prompts[name+"."+language]
+	if !ok {
+		pr, ok = p.prompts[name+"."+p.defaultLanguage]
+	}
+	if !ok {
+		return "", "", fmt.Errorf("prompt %s not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := pr.tmpl.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render prompt %s: %w", name, err)
+	}
+
+	return buf.String(), pr.version, nil
+}
+
+// toJSON безопасно встраивает данные в промпт: кавычки, переводы строк и
+// обратные слэши экранируются encoding/json.
+func toJSON(v any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// detectLanguage определяет язык текста по преобладающему алфавиту.
+func detectLanguage(text, fallback string) string {
+	var cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	switch {
+	case cyrillic == 0 && latin == 0:
+		return fallback
+	case latin > cyrillic*2:
+		return "en"
+	case cyrillic > 0:
+		return "ru"
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,288 @@
+package deepseek
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Schema описывает подмножество JSON Schema, достаточное для ответов модели.
+// Одна и та же схема отправляется в API (tool call / response_format) и
+// используется для проверки разобранного ответа.
+type Schema struct {
+	Type        string
+	Nullable    bool
+	Description string
+	Properties  map[string]*Schema
+	Required    []string
+	Items       *Schema
+	Enum        []string
+	Minimum     *float64
+	Maximum     *float64
+	MinItems    *int
+	MaxItems    *int
+	// UniqueBy - поле элементов массива, значения которого не должны
+	// повторяться. В API не отправляется и проверяется только в Validate.
+	UniqueBy string
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	out := map[string]any{}
+	if s.Nullable {
+		out["type"] = []string{s.Type, "null"}
+	} else {
+		out["type"] = s.Type
+	}
+	if s.Description != "" {
+		out["description"] = s.Description
+	}
+	if len(s.Properties) > 0 {
+		out["properties"] = s.Properties
+		out["additionalProperties"] = false
+	}
+	if len(s.Required) > 0 {
+		out["required"] = s.Required
+	}
+	if s.Items != nil {
+		out["items"] = s.Items
+	}
+	if len(s.Enum) > 0 {
+		out["enum"] = s.Enum
+	}
+	if s.Minimum != nil {
+		out["minimum"] = *s.Minimum
+	}
+	if s.Maximum != nil {
+		out["maximum"] = *s.Maximum
+	}
+	if s.MinItems != nil {
+		out["minItems"] = *s.MinItems
+	}
+	if s.MaxItems != nil {
+		out["maxItems"] = *s.MaxItems
+	}
+	return json.Marshal(out)
+}
+
+// Validate проверяет значение, полученное через json.Unmarshal в any, и
+// возвращает список нарушений. Пустой список означает, что значение валидно.
+func (s *Schema) Validate(value any) []string {
+	var violations []string
+	s.validate(value, "$", &violations)
+	return violations
+}
+
+func (s *Schema) validate(value any, path string, violations *[]string) {
+	if value == nil {
+		if !s.Nullable {
+			*violations = append(*violations, fmt.Sprintf("%s: значение не может быть null", path))
+		}
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: ожидался объект", path))
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				*violations = append(*violations, fmt.Sprintf("%s: отсутствует обязательное поле %q", path, name))
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			if !ok {
+				// В схему уходит additionalProperties: false, если заданы свойства
+				if len(s.Properties) > 0 {
+					*violations = append(*violations, fmt.Sprintf("%s: недопустимое поле %q", path, name))
+				}
+				continue
+			}
+			prop.validate(obj[name], path+"."+name, violations)
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: ожидался массив", path))
+			return
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			*violations = append(*violations, fmt.Sprintf("%s: не менее %d элементов, получено %d", path, *s.MinItems, len(arr)))
+		}
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			*violations = append(*violations, fmt.Sprintf("%s: не более %d элементов, получено %d", path, *s.MaxItems, len(arr)))
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+		if s.UniqueBy != "" {
+			seen := make(map[string]bool, len(arr))
+			for i, item := range arr {
+				obj, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+				key, ok := obj[s.UniqueBy].(string)
+				if !ok {
+					continue
+				}
+				if seen[key] {
+					*violations = append(*violations, fmt.Sprintf("%s[%d].%s: значение %q повторяется", path, i, s.UniqueBy, key))
+				}
+				seen[key] = true
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: ожидалась строка", path))
+			return
+		}
+		if len(s.Enum) > 0 && !contains(s.Enum, str) {
+			*violations = append(*violations, fmt.Sprintf("%s: недопустимое значение %q", path, str))
+		}
+	case "integer", "number":
+		num, ok := value.(float64)
+		if !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: ожидалось число", path))
+			return
+		}
+		if s.Type == "integer" && num != math.Trunc(num) {
+			*violations = append(*violations, fmt.Sprintf("%s: ожидалось целое число", path))
+		}
+		if s.Minimum != nil && num < *s.Minimum {
+			*violations = append(*violations, fmt.Sprintf("%s: значение меньше %v", path, *s.Minimum))
+		}
+		if s.Maximum != nil && num > *s.Maximum {
+			*violations = append(*violations, fmt.Sprintf("%s: значение больше %v", path, *s.Maximum))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: ожидалось логическое значение", path))
+		}
+	}
+}
+
+// extractJSON вырезает JSON-объект из ответа модели, если она обернула его
+// в markdown или добавила пояснения вокруг.
+func extractJSON(content string) string {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start == -1 || end < start {
+		return strings.TrimSpace(content)
+	}
+	return content[start : end+1]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func analysisSchema() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"candidate_name":   {Type: "string", Description: "ФИО кандидата"},
+			"candidate_age":    {Type: "integer", Nullable: true, Description: "Возраст кандидата или null, если не указан", Minimum: float(14), Maximum: float(100)},
+			"experience_years": {Type: "string", Description: "Общий опыт работы, например \"3 года\" или \"5 лет\""},
+			"analysis":         {Type: "string", Description: "Детальный анализ резюме: soft и hard skills, оценка опыта, рекомендации"},
+		},
+		Required: []string{"candidate_name", "candidate_age", "experience_years", "analysis"},
+	}
+}
+
+// matchSchema требует оценить каждого кандидата из пачки ровно один раз
+func matchSchema(resumeIDs []string) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"candidates": {
+				Type:     "array",
+				MinItems: intPtr(len(resumeIDs)),
+				MaxItems: intPtr(len(resumeIDs)),
+				UniqueBy: "resume_id",
+				Items: &Schema{
+					Type: "object",
+					Properties: map[string]*Schema{
+						"resume_id":   {Type: "string", Description: "id кандидата", Enum: resumeIDs},
+						"match_score": scoreSchema("Итоговая оценка соответствия от 1 до 100"),
+						"scores":      scoreBreakdownSchema(),
+						"reasoning":   {Type: "string", Description: "Краткое объяснение оценки"},
+					},
+					Required: []string{"resume_id", "match_score", "scores", "reasoning"},
+				},
+			},
+		},
+		Required: []string{"candidates"},
+	}
+}
+
+func recommendSchema(jobIDs []string) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"jobs": {
+				Type:     "array",
+				MinItems: intPtr(len(jobIDs)),
+				MaxItems: intPtr(len(jobIDs)),
+				UniqueBy: "job_id",
+				Items: &Schema{
+					Type: "object",
+					Properties: map[string]*Schema{
+						"job_id":      {Type: "string", Description: "id вакансии", Enum: jobIDs},
+						"match_score": scoreSchema("Итоговая оценка соответствия от 1 до 100"),
+						"scores":      scoreBreakdownSchema(),
+						"reasoning":   {Type: "string", Description: "Краткое объяснение для кандидата, чем вакансия ему подходит или не подходит"},
+					},
+					Required: []string{"job_id", "match_score", "scores", "reasoning"},
+				},
+			},
+		},
+		Required: []string{"jobs"},
+	}
+}
+
+func scoreSchema(description string) *Schema {
+	return &Schema{Type: "integer", Description: description, Minimum: float(1), Maximum: float(100)}
+}
+
+func scoreBreakdownSchema() *Schema {
+	location := scoreSchema("Соответствие местоположения и формата работы от 1 до 100 или null, если в резюме нет данных")
+	location.Nullable = true
+
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"skills":     scoreSchema("Соответствие навыков требованиям от 1 до 100"),
+			"experience": scoreSchema("Соответствие опыта работы от 1 до 100"),
+			"seniority":  scoreSchema("Соответствие уровня (junior/middle/senior/lead) от 1 до 100"),
+			"location":   location,
+		},
+		Required: []string{"skills", "experience", "seniority", "location"},
+	}
+}
@@ -0,0 +1,127 @@
+package deepseek
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	scores := `"scores": {"skills": 80, "experience": 70, "seniority": 60, "location": null}`
+	candidate := func(id string, score string) string {
+		return `{"resume_id": "` + id + `", "match_score": ` + score + `, ` + scores + `, "reasoning": "ok"}`
+	}
+
+	tests := []struct {
+		name   string
+		schema *Schema
+		value  string
+		want   []string
+	}{
+		{
+			name:   "valid analysis",
+			schema: analysisSchema(),
+			value:  `{"candidate_name": "Иван", "candidate_age": null, "experience_years": "3 года", "analysis": "..."}`,
+		},
+		{
+			name:   "missing required field",
+			schema: analysisSchema(),
+			value:  `{"candidate_name": "Иван", "candidate_age": 30, "experience_years": "3 года"}`,
+			want:   []string{`$: отсутствует обязательное поле "analysis"`},
+		},
+		{
+			name:   "additional property",
+			schema: analysisSchema(),
+			value:  `{"candidate_name": "Иван", "candidate_age": 30, "experience_years": "3 года", "analysis": "...", "salary": 100}`,
+			want:   []string{`$: недопустимое поле "salary"`},
+		},
+		{
+			name:   "wrong types and bounds",
+			schema: analysisSchema(),
+			value:  `{"candidate_name": 1, "candidate_age": 30.5, "experience_years": "3 года", "analysis": null}`,
+			want: []string{
+				"$.analysis: значение не может быть null",
+				"$.candidate_age: ожидалось целое число",
+				"$.candidate_name: ожидалась строка",
+			},
+		},
+		{
+			name:   "age out of range",
+			schema: analysisSchema(),
+			value:  `{"candidate_name": "Иван", "candidate_age": 7, "experience_years": "1 год", "analysis": "..."}`,
+			want:   []string{"$.candidate_age: значение меньше 14"},
+		},
+		{
+			name:   "not an object",
+			schema: analysisSchema(),
+			value:  `[]`,
+			want:   []string{"$: ожидался объект"},
+		},
+		{
+			name:   "valid match",
+			schema: matchSchema([]string{"a", "b"}),
+			value:  `{"candidates": [` + candidate("a", "90") + `, ` + candidate("b", "10") + `]}`,
+		},
+		{
+			name:   "duplicate candidate",
+			schema: matchSchema([]string{"a", "b"}),
+			value:  `{"candidates": [` + candidate("a", "90") + `, ` + candidate("a", "10") + `]}`,
+			want:   []string{`$.candidates[1].resume_id: значение "a" повторяется`},
+		},
+		{
+			name:   "unknown candidate and wrong count",
+			schema: matchSchema([]string{"a", "b"}),
+			value:  `{"candidates": [` + candidate("c", "101") + `]}`,
+			want: []string{
+				"$.candidates: не менее 2 элементов, получено 1",
+				`$.candidates[0].match_score: значение больше 100`,
+				`$.candidates[0].resume_id: недопустимое значение "c"`,
+			},
+		},
+		{
+			name:   "additional property in nested object",
+			schema: matchSchema([]string{"a"}),
+			value:  `{"candidates": [{"resume_id": "a", "match_score": 50, "scores": {"skills": 80, "experience": 70, "seniority": 60, "location": 1, "salary": 5}, "reasoning": "ok"}]}`,
+			want:   []string{`$.candidates[0].scores: недопустимое поле "salary"`},
+		},
+		{
+			name:   "duplicate job",
+			schema: recommendSchema([]string{"j1", "j2"}),
+			value:  `{"jobs": [{"job_id": "j2", "match_score": 50, ` + scores + `, "reasoning": "ok"}, {"job_id": "j2", "match_score": 40, ` + scores + `, "reasoning": "ok"}]}`,
+			want:   []string{`$.jobs[1].job_id: значение "j2" повторяется`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatalf("invalid test value: %v", err)
+			}
+			if got := tt.schema.Validate(value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaMarshalOmitsUniqueBy(t *testing.T) {
+	data, err := json.Marshal(matchSchema([]string{"a"}))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	candidates := schema["properties"].(map[string]any)["candidates"].(map[string]any)
+	for _, key := range []string{"UniqueBy", "uniqueBy"} {
+		if _, ok := candidates[key]; ok {
+			t.Errorf("schema contains %q: %s", key, data)
+		}
+	}
+	if schema["additionalProperties"] != false {
+		t.Errorf("schema has no additionalProperties: false: %s", data)
+	}
+}
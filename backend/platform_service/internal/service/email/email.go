@@ -0,0 +1,50 @@
+package email
+
+import (
+	"PlatformService/internal/config"
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	ProviderNone = "none"
+	ProviderSMTP = "smtp"
+)
+
+// ErrDisabled возвращается, когда отправка писем не настроена
+var ErrDisabled = errors.New("email is disabled")
+
+type Message struct {
+	To      string
+	Subject string
+	// Body - текст письма без разметки
+	Body string
+}
+
+type Service interface {
+	// Enabled сообщает, настроена ли отправка писем
+	Enabled() bool
+	Send(ctx context.Context, msg Message) error
+}
+
+func NewService(cfg *config.Config) (Service, error) {
+	switch cfg.EmailProvider {
+	case "", ProviderNone:
+		return noopService{}, nil
+	case ProviderSMTP:
+		return newSMTPService(cfg)
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", cfg.EmailProvider)
+	}
+}
+
+type noopService struct{}
+
+func (noopService) Enabled() bool {
+	return false
+}
+
+func (noopService) Send(context.Context, Message) error {
+	return ErrDisabled
+}
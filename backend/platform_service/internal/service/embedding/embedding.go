@@ -0,0 +1,45 @@
+package embedding
+
+import (
+	"PlatformService/internal/config"
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	ProviderNone   = "none"
+	ProviderOpenAI = "openai"
+)
+
+// ErrDisabled возвращается, когда эмбеддинги не настроены
+var ErrDisabled = errors.New("embeddings are disabled")
+
+type Service interface {
+	// Model возвращает имя модели. Векторы разных моделей несравнимы, поэтому
+	// сохранённые эмбеддинги помечаются моделью. Пустая строка - эмбеддинги отключены.
+	Model() string
+	// Embed возвращает векторы текстов в том же порядке
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+func NewService(cfg *config.Config) (Service, error) {
+	switch cfg.EmbeddingProvider {
+	case "", ProviderNone:
+		return noopService{}, nil
+	case ProviderOpenAI:
+		return newOpenAIService(cfg)
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", cfg.EmbeddingProvider)
+	}
+}
+
+type noopService struct{}
+
+func (noopService) Model() string {
+	return ""
+}
+
+func (noopService) Embed(context.Context, []string) ([][]float32, error) {
+	return nil, ErrDisabled
+}
@@ -0,0 +1,132 @@
+package embedding
+
+import (
+	"PlatformService/internal/config"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultAPIURL = "https://api.openai.com/v1/embeddings"
+	defaultModel  = "text-embedding-3-small"
+	// Длинные тексты обрезаются, чтобы не превысить лимит токенов модели
+	maxInputRunes = 8000
+)
+
+// openAIService обращается к API, совместимому с OpenAI embeddings
+// (OpenAI, Ollama, vLLM, LocalAI и т.п.).
+type openAIService struct {
+	apiURL string
+	apiKey string
+	model  string
+	client *http.Client
+}
+
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func newOpenAIService(cfg *config.Config) (Service, error) {
+	s := &openAIService{
+		apiURL: cfg.EmbeddingAPIURL,
+		apiKey: cfg.EmbeddingAPIKey,
+		model:  cfg.EmbeddingModel,
+		client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+	if s.apiURL == "" {
+		s.apiURL = defaultAPIURL
+	}
+	if s.model == "" {
+		s.model = defaultModel
+	}
+
+	return s, nil
+}
+
+func (s *openAIService) Model() string {
+	return s.model
+}
+
+func (s *openAIService) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	input := make([]string, len(texts))
+	for i, text := range texts {
+		input[i] = truncateRunes(text, maxInputRunes)
+	}
+
+	reqBody, err := json.Marshal(embeddingRequest{Model: s.model, Input: input})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.apiURL, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embedding API error: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var embeddingResp embeddingResponse
+	if err := json.Unmarshal(body, &embeddingResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal embedding response: %w", err)
+	}
+
+	// Порядок в ответе не гарантирован, сопоставляем по index
+	vectors := make([][]float32, len(texts))
+	for _, item := range embeddingResp.Data {
+		if item.Index < 0 || item.Index >= len(vectors) {
+			return nil, fmt.Errorf("embedding response has unexpected index %d", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	for i, vector := range vectors {
+		if len(vector) == 0 {
+			return nil, fmt.Errorf("embedding response has no vector for input %d", i)
+		}
+	}
+
+	return vectors, nil
+}
+
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return string([]rune(text)[:limit])
+}
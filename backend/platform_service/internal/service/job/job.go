@@ -1,12 +1,16 @@
 package job
 
 import (
+	"PlatformService/internal/config"
 	"PlatformService/internal/models"
 	"PlatformService/internal/repository"
 	repository_job "PlatformService/internal/repository/job"
+	"PlatformService/internal/service/email"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
@@ -19,14 +23,19 @@ type Service interface {
 	CreateJob(ctx context.Context, userID string, req *models.CreateJobRequest) (*models.Job, error)
 	UpdateJob(ctx context.Context, jobID, userID string, req *models.UpdateJobRequest) (*models.Job, error)
 	DeleteJob(ctx context.Context, jobID, userID string) error
-	ApplyToJob(ctx context.Context, jobID, userID string) error
+	ApplyToJob(ctx context.Context, jobID, userID string, cvID *string) error
 	GetApplicationStatus(ctx context.Context, jobID, userID string) (*models.ApplicationStatus, error)
 	GetJobApplications(ctx context.Context, jobID, userID string, limit, offset int) ([]models.JobApplication, error)
 	UpdateJobApplicationStatus(ctx context.Context, jobID, applicantID, authorID, status string) (*models.JobApplication, error)
+	SourceCandidate(ctx context.Context, jobID, userID string, req *models.SourceCandidateRequest) (*models.SourcedApplication, error)
+	ClaimSourcedApplication(ctx context.Context, userID, token string) (*models.Job, error)
 }
 
 type service struct {
-	repo *repository.Repositories
+	cfg          *config.Config
+	repo         *repository.Repositories
+	emailService email.Service
+	log          *slog.Logger
 }
 
 func (s *service) GetAllJobs(ctx context.Context, search *string, limit, offset int) ([]models.Job, error) {
@@ -86,7 +95,7 @@ func (s *service) GetJobByID(ctx context.Context, jobID, userID string) (*models
 		// Проверяем, подавал ли пользователь заявку
 		_, err = s.repo.Job.GetJobApplication(ctx, tx, repository_job.GetJobApplicationParams{
 			JobID:       jobUUID,
-			ApplicantID: userUUID,
+			ApplicantID: uuid.NullUUID{UUID: userUUID, Valid: true},
 		})
 		hasApplied = err == nil
 
@@ -282,7 +291,7 @@ func (s *service) DeleteJob(ctx context.Context, jobID, userID string) error {
 	})
 }
 
-func (s *service) ApplyToJob(ctx context.Context, jobID, userID string) error {
+func (s *service) ApplyToJob(ctx context.Context, jobID, userID string, cvID *string) error {
 	jobUUID, err := uuid.Parse(jobID)
 	if err != nil {
 		return fmt.Errorf("invalid job ID: %w", err)
@@ -293,6 +302,15 @@ func (s *service) ApplyToJob(ctx context.Context, jobID, userID string) error {
 		return fmt.Errorf("invalid user ID: %w", err)
 	}
 
+	var requestedCV uuid.NullUUID
+	if cvID != nil {
+		cvUUID, err := uuid.Parse(*cvID)
+		if err != nil {
+			return fmt.Errorf("cv not found")
+		}
+		requestedCV = uuid.NullUUID{UUID: cvUUID, Valid: true}
+	}
+
 	return s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
 		// Проверяем, что вакансия существует и активна
 		job, err := s.repo.Job.GetJobByID(ctx, tx, jobUUID)
@@ -314,16 +332,35 @@ func (s *service) ApplyToJob(ctx context.Context, jobID, userID string) error {
 		// Проверяем, не подавал ли уже заявку
 		_, err = s.repo.Job.GetJobApplication(ctx, tx, repository_job.GetJobApplicationParams{
 			JobID:       jobUUID,
-			ApplicantID: userUUID,
+			ApplicantID: uuid.NullUUID{UUID: userUUID, Valid: true},
 		})
 		if err == nil {
 			return fmt.Errorf("already applied to this job")
 		}
 
+		// Версия CV фиксируется на момент отклика: выбранная кандидатом или
+		// основная; отклик без CV тоже допустим
+		var applicationCV uuid.NullUUID
+		cvGUID, err := s.repo.Job.GetApplicantCVID(ctx, tx, repository_job.GetApplicantCVIDParams{
+			UserGuid: userUUID.String(),
+			CvID:     requestedCV,
+		})
+		switch {
+		case err == nil:
+			applicationCV = uuid.NullUUID{UUID: cvGUID, Valid: true}
+		case errors.Is(err, pgx.ErrNoRows):
+			if requestedCV.Valid {
+				return fmt.Errorf("cv not found")
+			}
+		default:
+			return fmt.Errorf("failed to get cv: %w", err)
+		}
+
 		// Создаем заявку
 		_, err = s.repo.Job.CreateJobApplication(ctx, tx, repository_job.CreateJobApplicationParams{
 			JobID:       jobUUID,
-			ApplicantID: userUUID,
+			ApplicantID: uuid.NullUUID{UUID: userUUID, Valid: true},
+			CvID:        applicationCV,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create job application: %w", err)
@@ -351,7 +388,7 @@ func (s *service) GetApplicationStatus(ctx context.Context, jobID, userID string
 		var err error
 		application, err = s.repo.Job.GetJobApplication(ctx, tx, repository_job.GetJobApplicationParams{
 			JobID:       jobUUID,
-			ApplicantID: userUUID,
+			ApplicantID: uuid.NullUUID{UUID: userUUID, Valid: true},
 		})
 		if err != nil {
 			if err == sql.ErrNoRows {
@@ -462,24 +499,59 @@ func (s *service) mapJobFromDB(job repository_job.JobJob) models.Job {
 }
 
 func (s *service) mapJobApplicationFromDB(app repository_job.GetJobApplicationsRow) models.JobApplication {
-	var avatar *string
-	if app.ApplicantAvatar.Valid {
-		avatar = &app.ApplicantAvatar.String
-	}
-
-	return models.JobApplication{
-		ID:          app.ID.String(),
-		JobID:       app.JobID.String(),
-		ApplicantID: app.ApplicantID.String(),
-		ApplicantProfile: models.ApplicantProfile{
-			ID:          app.ApplicantID.String(),
-			Description: app.ApplicantDescription,
-			Email:       app.ApplicantEmail,
-			Avatar:      avatar,
-		},
+	result := models.JobApplication{
+		ID:        app.ID.String(),
+		JobID:     app.JobID.String(),
 		AppliedAt: app.AppliedAt,
 		Status:    app.Status,
+		Source:    app.Source,
+	}
+
+	if app.ApplicantID.Valid {
+		applicantID := app.ApplicantID.UUID.String()
+		result.ApplicantID = &applicantID
+
+		var avatar *string
+		if app.ApplicantAvatar.Valid {
+			avatar = &app.ApplicantAvatar.String
+		}
+		result.ApplicantProfile = &models.ApplicantProfile{
+			ID:          applicantID,
+			Description: app.ApplicantDescription.String,
+			Email:       app.ApplicantEmail.String,
+			Avatar:      avatar,
+		}
 	}
+
+	if app.CvID.Valid && app.CvLink.Valid {
+		result.CV = &models.ApplicationCV{
+			ID:    app.CvID.UUID.String(),
+			Label: app.CvLabel.String,
+			Link:  app.CvLink.String,
+		}
+	}
+
+	if app.Source == sourceDatabase {
+		candidate := &models.SourcedCandidate{
+			Name: app.CandidateName.String,
+		}
+		if app.ResumeID.Valid {
+			resumeID := app.ResumeID.UUID.String()
+			candidate.ResumeID = &resumeID
+		}
+		if app.CandidateEmail.Valid {
+			candidate.Email = &app.CandidateEmail.String
+		}
+		if app.InvitedAt.Valid {
+			candidate.InvitedAt = &app.InvitedAt.Time
+		}
+		if app.ClaimedAt.Valid {
+			candidate.ClaimedAt = &app.ClaimedAt.Time
+		}
+		result.SourcedCandidate = candidate
+	}
+
+	return result
 }
 
 func (s *service) UpdateJobApplicationStatus(ctx context.Context, jobID, applicantID, authorID, status string) (*models.JobApplication, error) {
@@ -498,8 +570,7 @@ func (s *service) UpdateJobApplicationStatus(ctx context.Context, jobID, applica
 		return nil, fmt.Errorf("invalid author ID: %w", err)
 	}
 
-	var updatedApplication repository_job.JobJobApplication
-	var applicantProfile repository_job.GetJobApplicationsRow
+	var application repository_job.GetJobApplicationByIDRow
 
 	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
 		// Проверяем, что пользователь является автором вакансии
@@ -515,65 +586,41 @@ func (s *service) UpdateJobApplicationStatus(ctx context.Context, jobID, applica
 			return fmt.Errorf("access denied: not job author")
 		}
 
-		// Обновляем статус заявки
-		updatedApplication, err = s.repo.Job.UpdateJobApplicationStatus(ctx, tx, repository_job.UpdateJobApplicationStatusParams{
+		// Обновляем статус заявки. Заявку кандидата из базы резюме, ещё не
+		// привязанную к пользователю, ищем по ID заявки
+		updatedApplication, err := s.repo.Job.UpdateJobApplicationStatus(ctx, tx, repository_job.UpdateJobApplicationStatusParams{
+			Status:      status,
 			JobID:       jobUUID,
 			ApplicantID: applicantUUID,
-			Status:      status,
 		})
 		if err != nil {
-			if err == sql.ErrNoRows {
+			if errors.Is(err, pgx.ErrNoRows) {
 				return fmt.Errorf("application not found")
 			}
 			return fmt.Errorf("failed to update application status: %w", err)
 		}
 
 		// Получаем данные профиля соискателя
-		applications, err := s.repo.Job.GetJobApplications(ctx, tx, repository_job.GetJobApplicationsParams{
-			JobID:  jobUUID,
-			Limit:  1,
-			Offset: 0,
-		})
+		application, err = s.repo.Job.GetJobApplicationByID(ctx, tx, updatedApplication.ID)
 		if err != nil {
 			return fmt.Errorf("failed to get application details: %w", err)
 		}
 
-		// Ищем нужную заявку
-		for _, app := range applications {
-			if app.ApplicantID == applicantUUID {
-				applicantProfile = app
-				break
-			}
-		}
-
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var avatar *string
-	if applicantProfile.ApplicantAvatar.Valid {
-		avatar = &applicantProfile.ApplicantAvatar.String
-	}
-
-	return &models.JobApplication{
-		ID:          updatedApplication.ID.String(),
-		JobID:       updatedApplication.JobID.String(),
-		ApplicantID: updatedApplication.ApplicantID.String(),
-		ApplicantProfile: models.ApplicantProfile{
-			ID:          updatedApplication.ApplicantID.String(),
-			Description: applicantProfile.ApplicantDescription,
-			Email:       applicantProfile.ApplicantEmail,
-			Avatar:      avatar,
-		},
-		AppliedAt: updatedApplication.AppliedAt,
-		Status:    updatedApplication.Status,
-	}, nil
+	result := s.mapJobApplicationFromDB(repository_job.GetJobApplicationsRow(application))
+	return &result, nil
 }
 
-func NewService(repo *repository.Repositories) Service {
+func NewService(cfg *config.Config, repo *repository.Repositories, emailService email.Service, log *slog.Logger) Service {
 	return &service{
-		repo: repo,
+		cfg:          cfg,
+		repo:         repo,
+		emailService: emailService,
+		log:          log,
 	}
 }
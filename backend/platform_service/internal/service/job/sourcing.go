@@ -0,0 +1,248 @@
+package job
+
+import (
+	"PlatformService/internal/models"
+	repository_job "PlatformService/internal/repository/job"
+	"PlatformService/internal/service/email"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	sourceDatabase = "database"
+
+	// Столько действует ссылка из приглашения
+	sourcedInviteTTL      = 30 * 24 * time.Hour
+	maxInviteMessageRunes = 2000
+)
+
+// SourceCandidate добавляет кандидата из базы резюме в воронку вакансии.
+// Заявка не привязана к пользователю, пока кандидат не примет приглашение по
+// ссылке; имя и email сохраняются в заявке на случай удаления резюме из базы.
+// Письмо отправляется после сохранения заявки, и ошибка отправки не отменяет
+// её: ссылку можно передать кандидату вручную.
+func (s *service) SourceCandidate(ctx context.Context, jobID, userID string, req *models.SourceCandidateRequest) (*models.SourcedApplication, error) {
+	jobUUID, err := uuid.Parse(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job ID: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	resumeUUID, err := uuid.Parse(req.ResumeID)
+	if err != nil {
+		return nil, fmt.Errorf("resume not found")
+	}
+
+	var message string
+	if req.Message != nil {
+		message = strings.TrimSpace(*req.Message)
+		if utf8.RuneCountInString(message) > maxInviteMessageRunes {
+			return nil, fmt.Errorf("message is too long")
+		}
+	}
+
+	if req.SendEmail && !s.emailService.Enabled() {
+		return nil, fmt.Errorf("email is not configured")
+	}
+
+	token, tokenHash, err := newInviteToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var job repository_job.JobJob
+	var application repository_job.GetJobApplicationByIDRow
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		job, err = s.repo.Job.GetJobByID(ctx, tx, jobUUID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("job not found")
+			}
+			return fmt.Errorf("failed to get job: %w", err)
+		}
+
+		if job.AuthorID != userUUID {
+			return fmt.Errorf("access denied: not job author")
+		}
+
+		if job.Status != "active" {
+			return fmt.Errorf("job is not active")
+		}
+
+		resume, err := s.repo.CV.GetResumeByID(ctx, tx, resumeUUID)
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && resume.UserID != userUUID) {
+			return fmt.Errorf("resume not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get resume: %w", err)
+		}
+
+		if req.SendEmail && !resume.Email.Valid {
+			return fmt.Errorf("candidate has no email")
+		}
+
+		params := repository_job.CreateSourcedApplicationParams{
+			JobID:           jobUUID,
+			ResumeID:        uuid.NullUUID{UUID: resumeUUID, Valid: true},
+			CandidateName:   sql.NullString{String: resume.CandidateName, Valid: true},
+			CandidateEmail:  resume.Email,
+			InviteTokenHash: sql.NullString{String: tokenHash, Valid: true},
+		}
+		if req.SendEmail {
+			params.InvitedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+
+		created, err := s.repo.Job.CreateSourcedApplication(ctx, tx, params)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("candidate already added to this job")
+			}
+			return fmt.Errorf("failed to create application: %w", err)
+		}
+
+		application, err = s.repo.Job.GetJobApplicationByID(ctx, tx, created.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get application details: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.SourcedApplication{
+		Application: s.mapJobApplicationFromDB(repository_job.GetJobApplicationsRow(application)),
+		ClaimURL:    s.claimURL(token),
+	}
+
+	if req.SendEmail {
+		err := s.emailService.Send(ctx, inviteEmail(job, application.CandidateName.String, application.CandidateEmail.String, message, result.ClaimURL))
+		if err != nil {
+			s.log.ErrorContext(ctx, "job.SourceCandidate failed to send invite", "application_id", application.ID, "error", err)
+		} else {
+			result.EmailSent = true
+		}
+	}
+
+	return result, nil
+}
+
+// ClaimSourcedApplication привязывает заявку кандидата из базы резюме к
+// пользователю, перешедшему по ссылке из приглашения. Ссылка одноразовая.
+func (s *service) ClaimSourcedApplication(ctx context.Context, userID, token string) (*models.Job, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if token == "" {
+		return nil, fmt.Errorf("invitation not found")
+	}
+
+	var job repository_job.JobJob
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		application, err := s.repo.Job.GetSourcedApplicationByToken(ctx, tx, sql.NullString{String: hashInviteToken(token), Valid: true})
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && time.Since(application.AppliedAt) > sourcedInviteTTL) {
+			return fmt.Errorf("invitation not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get invitation: %w", err)
+		}
+
+		job, err = s.repo.Job.GetJobByID(ctx, tx, application.JobID)
+		if err != nil {
+			return fmt.Errorf("failed to get job: %w", err)
+		}
+
+		if job.AuthorID == userUUID {
+			return fmt.Errorf("cannot apply to your own job")
+		}
+
+		// Пользователь мог откликнуться на вакансию сам до приглашения
+		_, err = s.repo.Job.GetJobApplication(ctx, tx, repository_job.GetJobApplicationParams{
+			JobID:       application.JobID,
+			ApplicantID: uuid.NullUUID{UUID: userUUID, Valid: true},
+		})
+		if err == nil {
+			return fmt.Errorf("already applied to this job")
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to get application: %w", err)
+		}
+
+		_, err = s.repo.Job.ClaimSourcedApplication(ctx, tx, repository_job.ClaimSourcedApplicationParams{
+			ID:          application.ID,
+			ApplicantID: uuid.NullUUID{UUID: userUUID, Valid: true},
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("invitation not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim application: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.mapJobFromDB(job)
+	return &result, nil
+}
+
+func (s *service) claimURL(token string) string {
+	return strings.TrimRight(s.cfg.FrontendURL, "/") + "/jobs/claim/" + url.PathEscape(token)
+}
+
+func inviteEmail(job repository_job.JobJob, name, to, message, claimURL string) email.Message {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Здравствуйте, %s!\n\n", name)
+	fmt.Fprintf(&body, "Компания %s приглашает вас рассмотреть вакансию «%s» (%s).\n\n", job.CompanyName, job.Title, job.Location)
+	if message != "" {
+		fmt.Fprintf(&body, "%s\n\n", message)
+	}
+	fmt.Fprintf(&body, "Чтобы откликнуться, перейдите по ссылке и войдите или зарегистрируйтесь на платформе:\n%s\n\n", claimURL)
+	fmt.Fprintf(&body, "Ссылка действительна %d дней.\n", int(sourcedInviteTTL.Hours()/24))
+
+	return email.Message{
+		To:      to,
+		Subject: fmt.Sprintf("Приглашение на вакансию «%s»", job.Title),
+		Body:    body.String(),
+	}
+}
+
+// newInviteToken возвращает токен для ссылки и его хеш: в базе хранится
+// только хеш, поэтому ссылку нельзя восстановить из базы.
+func newInviteToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate invite token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashInviteToken(token), nil
+}
+
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
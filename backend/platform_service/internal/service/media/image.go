@@ -0,0 +1,207 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	// Поддерживаемые форматы загружаемых изображений
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	sizeSmall  = "small"
+	sizeMedium = "medium"
+	sizeLarge  = "large"
+
+	// Изображение в памяти занимает 4 байта на пиксель: ограничение защищает
+	// от маленьких файлов с огромным разрешением
+	maxImagePixels = 25_000_000
+	jpegQuality    = 85
+
+	// EXIF записан в начале JPEG-файла
+	exifSearchLen = 64 << 10
+)
+
+// Размеры по убыванию: каждая следующая копия масштабируется из предыдущей
+var thumbnailSizes = []struct {
+	name string
+	side int
+}{
+	{name: sizeLarge, side: 512},
+	{name: sizeMedium, side: 256},
+	{name: sizeSmall, side: 64},
+}
+
+type thumbnail struct {
+	size    string
+	format  string
+	content []byte
+}
+
+// makeThumbnails декодирует изображение и кодирует заново уменьшенные копии.
+// Повторное кодирование отбрасывает EXIF и другие метаданные, поэтому
+// ориентация из EXIF применяется к пикселям заранее.
+func makeThumbnails(file io.ReadSeeker) ([]thumbnail, error) {
+	img, orientation, outputFormat, err := decodeImage(file)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails := make([]thumbnail, 0, len(thumbnailSizes))
+	for _, size := range thumbnailSizes {
+		img = fitImage(img, size.side)
+
+		content, err := encodeImage(orientImage(img, orientation), outputFormat)
+		if err != nil {
+			return nil, err
+		}
+		thumbnails = append(thumbnails, thumbnail{
+			size:    size.name,
+			format:  outputFormat,
+			content: content,
+		})
+	}
+
+	return thumbnails, nil
+}
+
+// makePreview - одна уменьшенная копия изображения без метаданных
+func makePreview(file io.ReadSeeker, side int) (thumbnail, error) {
+	img, orientation, outputFormat, err := decodeImage(file)
+	if err != nil {
+		return thumbnail{}, err
+	}
+
+	content, err := encodeImage(orientImage(fitImage(img, side), orientation), outputFormat)
+	if err != nil {
+		return thumbnail{}, err
+	}
+	return thumbnail{format: outputFormat, content: content}, nil
+}
+
+// decodeImage проверяет размеры и декодирует изображение. Возвращает
+// ориентацию из EXIF и формат, в котором сохранять уменьшенные копии
+func decodeImage(file io.ReadSeeker) (image.Image, int, string, error) {
+	head := make([]byte, exifSearchLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, 0, "", fmt.Errorf("failed to read image: %w", err)
+	}
+	head = head[:n]
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, "", fmt.Errorf("failed to rewind image: %w", err)
+	}
+	config, format, err := image.DecodeConfig(file)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, 0, "", fmt.Errorf("%w: empty image", ErrInvalidImage)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, 0, "", fmt.Errorf("%w: %dx%d pixels", ErrImageTooLarge, config.Width, config.Height)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, "", fmt.Errorf("failed to rewind image: %w", err)
+	}
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	// Фотографии сохраняются в JPEG, остальное - в PNG, чтобы не потерять прозрачность
+	orientation := 1
+	outputFormat := "png"
+	if format == "jpeg" {
+		orientation = jpegOrientation(head)
+		outputFormat = "jpg"
+	}
+
+	return img, orientation, outputFormat, nil
+}
+
+// fitImage уменьшает изображение до квадрата side с сохранением пропорций;
+// маленькие изображения не увеличиваются
+func fitImage(src image.Image, side int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= side && height <= side {
+		return src
+	}
+
+	if width >= height {
+		height = max(1, height*side/width)
+		width = side
+	} else {
+		width = max(1, width*side/height)
+		height = side
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
+
+// orientImage поворачивает и отражает изображение согласно тегу EXIF Orientation
+func orientImage(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // отражение по горизонтали
+				dx, dy = width-1-x, y
+			case 3: // поворот на 180°
+				dx, dy = width-1-x, height-1-y
+			case 4: // отражение по вертикали
+				dx, dy = x, height-1-y
+			case 5: // транспонирование
+				dx, dy = y, x
+			case 6: // поворот на 90° по часовой стрелке
+				dx, dy = height-1-y, x
+			case 7: // транспонирование относительно побочной диагонали
+				dx, dy = height-1-y, width-1-x
+			case 8: // поворот на 90° против часовой стрелки
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+func encodeImage(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if format == "jpg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,184 @@
+package media
+
+import (
+	"PlatformService/internal/config"
+	"PlatformService/internal/models"
+	"PlatformService/internal/service/storage"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMaxImageSize = 5 << 20
+
+	// Объекты изображений хранятся отдельно от файлов резюме: публичный
+	// эндпоинт отдаёт только этот префикс
+	imagesPrefix = "images/"
+	imagesPath   = "/api/v1/media/images/"
+
+	// Сторона превью изображений, которые хранят другие модули
+	previewSide = 512
+)
+
+var (
+	ErrImageTooLarge = errors.New("image is too large")
+	ErrInvalidImage  = errors.New("invalid image")
+	ErrImageNotFound = errors.New("image not found")
+)
+
+// Имя объекта: <uuid>_<размер>.<формат>
+var imageNamePattern = regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})_(small|medium|large)\.(jpg|png)$`)
+
+type Service interface {
+	// UploadImage проверяет изображение, убирает метаданные и сохраняет
+	// уменьшенные копии всех размеров
+	UploadImage(ctx context.Context, header *multipart.FileHeader) (*models.ImageThumbnails, error)
+	GetImage(ctx context.Context, filename string) (io.ReadSeekCloser, *storage.ObjectInfo, error)
+	// DeleteImage удаляет все размеры изображения по ссылке на любой из них;
+	// ссылки не на изображения платформы игнорируются
+	DeleteImage(ctx context.Context, link string) error
+	// Thumbnails возвращает ссылки на все размеры изображения платформы или
+	// nil для внешних ссылок
+	Thumbnails(link string) *models.ImageThumbnails
+	// MakePreview уменьшает изображение без сохранения и возвращает содержимое
+	// превью и его расширение: jpg или png. Размер файла проверяет вызывающий
+	MakePreview(file io.ReadSeeker) ([]byte, string, error)
+	MaxFileSize() int64
+}
+
+type service struct {
+	storageService    storage.Service
+	serverFullAddress string
+	maxFileSize       int64
+}
+
+func NewService(cfg *config.Config, storageService storage.Service) Service {
+	maxFileSize := cfg.ImageMaxFileSize
+	if maxFileSize <= 0 {
+		maxFileSize = defaultMaxImageSize
+	}
+
+	return &service{
+		storageService:    storageService,
+		serverFullAddress: cfg.ServerFullAddress,
+		maxFileSize:       maxFileSize,
+	}
+}
+
+func (s *service) UploadImage(ctx context.Context, header *multipart.FileHeader) (*models.ImageThumbnails, error) {
+	if header.Size > s.maxFileSize {
+		return nil, ErrImageTooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer file.Close()
+
+	thumbnails, err := makeThumbnails(file)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	var names []string
+	for _, thumbnail := range thumbnails {
+		name := fmt.Sprintf("%s%s_%s.%s", imagesPrefix, id, thumbnail.size, thumbnail.format)
+		err := s.storageService.Put(ctx, name, bytes.NewReader(thumbnail.content), int64(len(thumbnail.content)), storage.ContentType(name))
+		if err != nil {
+			s.deleteObjects(ctx, names)
+			return nil, fmt.Errorf("failed to save image: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	return s.Thumbnails(s.imageURL(strings.TrimPrefix(names[0], imagesPrefix))), nil
+}
+
+func (s *service) GetImage(ctx context.Context, filename string) (io.ReadSeekCloser, *storage.ObjectInfo, error) {
+	if !imageNamePattern.MatchString(filename) {
+		return nil, nil, ErrImageNotFound
+	}
+
+	object, info, err := s.storageService.Get(ctx, imagesPrefix+filename)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	return object, info, nil
+}
+
+func (s *service) DeleteImage(ctx context.Context, link string) error {
+	id, format, ok := s.parseImageURL(link)
+	if !ok {
+		return nil
+	}
+
+	for _, size := range thumbnailSizes {
+		name := fmt.Sprintf("%s%s_%s.%s", imagesPrefix, id, size.name, format)
+		if err := s.storageService.Delete(ctx, name); err != nil {
+			return fmt.Errorf("failed to delete image: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *service) Thumbnails(link string) *models.ImageThumbnails {
+	id, format, ok := s.parseImageURL(link)
+	if !ok {
+		return nil
+	}
+
+	return &models.ImageThumbnails{
+		Small:  s.imageURL(fmt.Sprintf("%s_%s.%s", id, sizeSmall, format)),
+		Medium: s.imageURL(fmt.Sprintf("%s_%s.%s", id, sizeMedium, format)),
+		Large:  s.imageURL(fmt.Sprintf("%s_%s.%s", id, sizeLarge, format)),
+	}
+}
+
+func (s *service) MakePreview(file io.ReadSeeker) ([]byte, string, error) {
+	preview, err := makePreview(file, previewSide)
+	if err != nil {
+		return nil, "", err
+	}
+	return preview.content, preview.format, nil
+}
+
+func (s *service) MaxFileSize() int64 {
+	return s.maxFileSize
+}
+
+func (s *service) imageURL(filename string) string {
+	return s.serverFullAddress + imagesPath + filename
+}
+
+// parseImageURL достаёт идентификатор и формат изображения из ссылки платформы
+func (s *service) parseImageURL(link string) (id, format string, ok bool) {
+	filename, found := strings.CutPrefix(link, s.serverFullAddress+imagesPath)
+	if !found {
+		return "", "", false
+	}
+
+	match := imageNamePattern.FindStringSubmatch(filename)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[3], true
+}
+
+func (s *service) deleteObjects(ctx context.Context, names []string) {
+	for _, name := range names {
+		_ = s.storageService.Delete(ctx, name)
+	}
+}
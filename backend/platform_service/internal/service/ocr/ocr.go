@@ -0,0 +1,38 @@
+package ocr
+
+import (
+	"PlatformService/internal/config"
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	ProviderNone      = "none"
+	ProviderTesseract = "tesseract"
+)
+
+// ErrDisabled возвращается, когда распознавание текста не настроено
+var ErrDisabled = errors.New("OCR is disabled")
+
+type Service interface {
+	// RecognizePDF распознаёт текст страниц PDF-документа без текстового слоя
+	RecognizePDF(ctx context.Context, content []byte) (string, error)
+}
+
+func NewService(cfg *config.Config) (Service, error) {
+	switch cfg.OCRProvider {
+	case "", ProviderNone:
+		return noopService{}, nil
+	case ProviderTesseract:
+		return newTesseractService(cfg)
+	default:
+		return nil, fmt.Errorf("unknown OCR provider %q", cfg.OCRProvider)
+	}
+}
+
+type noopService struct{}
+
+func (noopService) RecognizePDF(context.Context, []byte) (string, error) {
+	return "", ErrDisabled
+}
@@ -0,0 +1,131 @@
+package ocr
+
+import (
+	"PlatformService/internal/config"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTesseractPath = "tesseract"
+	defaultPdftoppmPath  = "pdftoppm"
+	defaultLanguages     = "rus+eng"
+	defaultMaxPages      = 10
+	defaultTimeout       = 120 * time.Second
+	// Разрешение, на котором Tesseract распознаёт текст лучше всего
+	renderDPI = 300
+)
+
+// tesseractService растеризует страницы PDF через pdftoppm (poppler-utils)
+// и распознаёт их локально установленным Tesseract.
+type tesseractService struct {
+	tesseractPath string
+	pdftoppmPath  string
+	languages     string
+	maxPages      int
+	timeout       time.Duration
+}
+
+func newTesseractService(cfg *config.Config) (Service, error) {
+	s := &tesseractService{
+		tesseractPath: cfg.OCRTesseractPath,
+		pdftoppmPath:  cfg.OCRPdftoppmPath,
+		languages:     cfg.OCRLanguages,
+		maxPages:      cfg.OCRMaxPages,
+		timeout:       time.Duration(cfg.OCRTimeout) * time.Second,
+	}
+	if s.tesseractPath == "" {
+		s.tesseractPath = defaultTesseractPath
+	}
+	if s.pdftoppmPath == "" {
+		s.pdftoppmPath = defaultPdftoppmPath
+	}
+	if s.languages == "" {
+		s.languages = defaultLanguages
+	}
+	if s.maxPages <= 0 {
+		s.maxPages = defaultMaxPages
+	}
+	if s.timeout <= 0 {
+		s.timeout = defaultTimeout
+	}
+
+	var err error
+	if s.tesseractPath, err = exec.LookPath(s.tesseractPath); err != nil {
+		return nil, fmt.Errorf("tesseract not found: %w", err)
+	}
+	if s.pdftoppmPath, err = exec.LookPath(s.pdftoppmPath); err != nil {
+		return nil, fmt.Errorf("pdftoppm not found: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *tesseractService) RecognizePDF(ctx context.Context, content []byte) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "ocr-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err := os.WriteFile(input, content, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write PDF: %w", err)
+	}
+
+	_, err = run(ctx, s.pdftoppmPath,
+		"-r", strconv.Itoa(renderDPI),
+		"-l", strconv.Itoa(s.maxPages),
+		"-gray", "-png",
+		input, filepath.Join(dir, "page"),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to render PDF pages: %w", err)
+	}
+
+	// pdftoppm дополняет номера страниц нулями, поэтому сортировка по имени
+	// совпадает с порядком страниц
+	images, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(images)
+
+	pages := make([]string, 0, len(images))
+	for _, image := range images {
+		text, err := run(ctx, s.tesseractPath, image, "stdout", "-l", s.languages)
+		if err != nil {
+			return "", fmt.Errorf("failed to recognize %s: %w", filepath.Base(image), err)
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			pages = append(pages, text)
+		}
+	}
+
+	return strings.Join(pages, "\n\n"), nil
+}
+
+func run(ctx context.Context, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
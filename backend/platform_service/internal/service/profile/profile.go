@@ -5,9 +5,11 @@ import (
 	"PlatformService/internal/models"
 	"PlatformService/internal/repository"
 	repository_profile "PlatformService/internal/repository/profile"
+	"PlatformService/internal/service/media"
 	"context"
 	"database/sql"
 	"errors"
+	"mime/multipart"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +21,7 @@ type Service interface {
 	GetProfile(ctx context.Context, userGUID string) (*models.Profile, error)
 	CreateProfile(ctx context.Context, userGUID string, profile *models.Profile) error
 	UpdateProfile(ctx context.Context, userGUID string, profile *models.Profile) error
+	UpdateAvatar(ctx context.Context, userGUID string, header *multipart.FileHeader) (*models.Profile, error)
 	DeleteProfile(ctx context.Context, userGUID string) error
 	SearchProfiles(ctx context.Context, description string) ([]models.ShortProfile, error)
 	Authenticate(ctx context.Context, email string, password string) (string, error)
@@ -27,8 +30,9 @@ type Service interface {
 }
 
 type service struct {
-	cfg  *config.Config
-	repo *repository.Repositories
+	cfg          *config.Config
+	repo         *repository.Repositories
+	mediaService media.Service
 }
 
 func (s *service) GetProfile(ctx context.Context, userGUID string) (*models.Profile, error) {
@@ -58,12 +62,14 @@ func (s *service) GetProfile(ctx context.Context, userGUID string) (*models.Prof
 				return nil
 			}
 		}(),
-		Email:     profile.Email,
-		Birthdate: profile.Birthday,
-		Gender:    profile.Gender,
-		Avatar:    &profile.Avatar.String,
-		CreatedAt: profile.CreatedAt.Time,
-		UpdatedAt: profile.UpdatedAt.Time,
+		Email:            profile.Email,
+		Birthdate:        profile.Birthday,
+		Gender:           profile.Gender,
+		Avatar:           &profile.Avatar.String,
+		AvatarThumbnails: s.mediaService.Thumbnails(profile.Avatar.String),
+		CreatedAt:        profile.CreatedAt.Time,
+		UpdatedAt:        profile.UpdatedAt.Time,
+		OpenToOffers:     &profile.OpenToOffers,
 	}, nil
 }
 
@@ -132,6 +138,9 @@ func (s *service) UpdateProfile(ctx context.Context, userGUID string, profile *m
 	profileData.Gender = profile.Gender
 	profileData.Birthday = profile.Birthdate
 	profileData.Avatar = sql.NullString{String: *profile.Avatar, Valid: profile.Avatar != nil}
+	if profile.OpenToOffers != nil {
+		profileData.OpenToOffers = *profile.OpenToOffers
+	}
 	profileData.UpdatedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
 	profileData.CreatedAt = sql.NullTime{Time: profileData.CreatedAt.Time, Valid: true}
 
@@ -155,9 +164,51 @@ func (s *service) UpdateProfile(ctx context.Context, userGUID string, profile *m
 			PasswordHash:      profileData.PasswordHash,
 			IsActive:          profileData.IsActive,
 			VerificationToken: profileData.VerificationToken,
+			OpenToOffers:      profileData.OpenToOffers,
+		})
+		return err
+	})
+}
+
+// UpdateAvatar сохраняет загруженное изображение аватаром пользователя.
+// Прежний аватар, загруженный на платформу, удаляется из хранилища.
+func (s *service) UpdateAvatar(ctx context.Context, userGUID string, header *multipart.FileHeader) (*models.Profile, error) {
+	userGUIDUUID, err := uuid.Parse(userGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails, err := s.mediaService.UploadImage(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous sql.NullString
+	err = s.repo.TxManager.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		profile, err := s.repo.Profile.GetProfileByGUID(ctx, tx, userGUIDUUID)
+		if err != nil {
+			return err
+		}
+		previous = profile.Avatar
+
+		_, err = s.repo.Profile.UpdateProfileAvatar(ctx, tx, repository_profile.UpdateProfileAvatarParams{
+			Avatar:    sql.NullString{String: thumbnails.Large, Valid: true},
+			UpdatedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			Guid:      userGUIDUUID,
 		})
 		return err
 	})
+	if err != nil {
+		_ = s.mediaService.DeleteImage(ctx, thumbnails.Large)
+		return nil, err
+	}
+
+	// Аватар уже заменён: не удалённые копии прежнего только занимают место
+	if previous.Valid {
+		_ = s.mediaService.DeleteImage(ctx, previous.String)
+	}
+
+	return s.GetProfile(ctx, userGUID)
 }
 
 func (s *service) DeleteProfile(ctx context.Context, userGUID string) error {
@@ -263,6 +314,6 @@ func (s *service) Restore(ctx context.Context, email string) error {
 	return nil
 }
 
-func NewService(repo *repository.Repositories) Service {
-	return &service{repo: repo}
+func NewService(repo *repository.Repositories, mediaService media.Service) Service {
+	return &service{repo: repo, mediaService: mediaService}
 }
@@ -0,0 +1,133 @@
+package scanner
+
+import (
+	"PlatformService/internal/config"
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultClamAVAddress = "tcp://localhost:3310"
+	defaultClamAVTimeout = 60 * time.Second
+	// Размер фрагмента команды INSTREAM
+	clamAVChunkSize = 64 << 10
+)
+
+// clamAVService проверяет файлы демоном clamd по протоколу INSTREAM
+type clamAVService struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func newClamAVService(cfg *config.Config) (Service, error) {
+	address := cfg.ClamAVAddress
+	if address == "" {
+		address = defaultClamAVAddress
+	}
+
+	// Адрес в виде tcp://host:port или unix:///path/to/clamd.sock
+	network, addr, ok := strings.Cut(address, "://")
+	if !ok {
+		network, addr = "tcp", address
+	}
+	if network != "tcp" && network != "unix" {
+		return nil, fmt.Errorf("unsupported clamd address %q", address)
+	}
+
+	timeout := time.Duration(cfg.ClamAVTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultClamAVTimeout
+	}
+
+	return &clamAVService{
+		network: network,
+		address: addr,
+		timeout: timeout,
+	}, nil
+}
+
+func (s *clamAVService) Scan(ctx context.Context, content io.Reader) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("failed to set clamd deadline: %w", err)
+		}
+	}
+
+	if err := s.stream(conn, content); err != nil {
+		return err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamAVReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// stream отправляет файл фрагментами с длиной в 4 байта (big-endian);
+// фрагмент нулевой длины завершает поток
+func (s *clamAVService) stream(conn net.Conn, content io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return fmt.Errorf("failed to send clamd command: %w", err)
+	}
+
+	buf := make([]byte, clamAVChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return fmt.Errorf("failed to send file to clamd: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return fmt.Errorf("failed to send file to clamd: %w", err)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("failed to finish clamd stream: %w", err)
+	}
+	return nil
+}
+
+// parseClamAVReply разбирает ответ вида "stream: OK",
+// "stream: <сигнатура> FOUND" или "<описание> ERROR"
+func parseClamAVReply(reply string) error {
+	switch {
+	case reply == "stream: OK":
+		return nil
+	case strings.HasSuffix(reply, " FOUND"):
+		signature := strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND")
+		return &InfectedError{Signature: signature}
+	case strings.Contains(reply, "size limit exceeded"):
+		return fmt.Errorf("file exceeds clamd StreamMaxLength: %s", reply)
+	default:
+		return fmt.Errorf("unexpected clamd reply %q", reply)
+	}
+}
@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"PlatformService/internal/config"
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	ProviderNone   = "none"
+	ProviderClamAV = "clamav"
+)
+
+// InfectedError возвращается, когда антивирус нашёл в файле угрозу
+type InfectedError struct {
+	Signature string
+}
+
+func (e *InfectedError) Error() string {
+	return fmt.Sprintf("malware detected: %s", e.Signature)
+}
+
+type Service interface {
+	// Scan проверяет содержимое файла. Файл с угрозой - *InfectedError,
+	// остальные ошибки означают, что проверить файл не удалось.
+	Scan(ctx context.Context, content io.Reader) error
+}
+
+func NewService(cfg *config.Config) (Service, error) {
+	switch cfg.ScannerProvider {
+	case "", ProviderNone:
+		return noopService{}, nil
+	case ProviderClamAV:
+		return newClamAVService(cfg)
+	default:
+		return nil, fmt.Errorf("unknown scanner provider %q", cfg.ScannerProvider)
+	}
+}
+
+// noopService пропускает все файлы: проверка отключена
+type noopService struct{}
+
+func (noopService) Scan(context.Context, io.Reader) error {
+	return nil
+}
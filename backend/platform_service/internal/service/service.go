@@ -10,14 +10,19 @@ import (
 	"PlatformService/internal/service/company"
 	"PlatformService/internal/service/cv"
 	"PlatformService/internal/service/deepseek"
+	"PlatformService/internal/service/email"
+	"PlatformService/internal/service/embedding"
 	"PlatformService/internal/service/job"
+	"PlatformService/internal/service/media"
+	"PlatformService/internal/service/ocr"
 	"PlatformService/internal/service/profile"
+	"PlatformService/internal/service/scanner"
 	"PlatformService/internal/service/storage"
 	"context"
-	"github.com/minio/minio-go/v7"
 	"io"
 	"log/slog"
 	"mime/multipart"
+	"time"
 )
 
 type AuthService interface {
@@ -30,6 +35,7 @@ type ProfileService interface {
 	GetProfile(ctx context.Context, userGUID string) (*models.Profile, error)
 	CreateProfile(ctx context.Context, userGUID string, profile *models.Profile) error
 	UpdateProfile(ctx context.Context, userGUID string, profile *models.Profile) error
+	UpdateAvatar(ctx context.Context, userGUID string, header *multipart.FileHeader) (*models.Profile, error)
 	DeleteProfile(ctx context.Context, userGUID string) error
 	SearchProfiles(ctx context.Context, description string) ([]models.ShortProfile, error)
 	Authenticate(ctx context.Context, email string, password string) (string, error)
@@ -43,6 +49,7 @@ type CompanyService interface {
 	CreateCompany(ctx context.Context, userGUID string, company *models.Company) (*models.Company, error)
 	GetCompany(ctx context.Context, companyId string) (*models.Company, error)
 	UpdateCompany(ctx context.Context, userGUID string, companyId string, company *models.Company) (*models.Company, error)
+	UpdateLogo(ctx context.Context, userGUID string, companyId string, header *multipart.FileHeader) (*models.Company, error)
 	DeleteCompany(ctx context.Context, userGUID string, companyId string) error
 	SearchCompanies(ctx context.Context, name string) ([]models.ShortCompany, error)
 	DeleteExperience(ctx context.Context, userGUID string, experienceGUID string) error
@@ -51,22 +58,75 @@ type CompanyService interface {
 type CVService interface {
 	SaveCVLink(ctx context.Context, userGUID string, link string) error
 	GetCVLink(ctx context.Context, userGUID string) (string, error)
-	UploadResumeDatabase(ctx context.Context, userGUID string, archive *multipart.FileHeader) (*models.UploadDatabaseResponse, error)
-	GetResumeDatabase(ctx context.Context, userGUID string, limit, offset int) ([]models.ResumeRecord, error)
-	MatchCandidatesFromDatabase(ctx context.Context, userGUID, jobID string) (*models.MatchCandidatesResponse, error)
+	UploadCV(ctx context.Context, userGUID string, header *multipart.FileHeader, label string, primary bool) (*models.CVVersion, error)
+	ListCVVersions(ctx context.Context, userGUID string) ([]models.CVVersion, error)
+	UpdateCVVersion(ctx context.Context, userGUID, versionID string, update models.CVVersionUpdate) (*models.CVVersion, error)
+	DeleteCVVersion(ctx context.Context, userGUID, versionID string) error
+	GetFile(ctx context.Context, userGUID, objectName string) (*models.StoredFile, error)
+	GetSignedFileURL(ctx context.Context, userGUID, objectName string) (*models.SignedFileURL, error)
+	GetSignedFile(ctx context.Context, objectName, expires, signature string) (*models.StoredFile, error)
+	UploadResumeDatabase(ctx context.Context, userGUID string, archive *multipart.FileHeader) (*models.IngestionJob, error)
+	GetIngestionJob(ctx context.Context, userGUID, jobID string) (*models.IngestionJob, error)
+	RetryIngestionJob(ctx context.Context, userGUID, jobID string) (*models.IngestionJob, error)
+	GetResumeDatabase(ctx context.Context, userGUID string, filter models.ResumeListFilter, limit, offset int) ([]models.ResumeRecord, error)
+	UpdateResume(ctx context.Context, userGUID, resumeID string, update models.ResumeUpdate) (*models.ResumeRecord, error)
+	DeleteResume(ctx context.Context, userGUID, resumeID string) error
+	GetResumeFolders(ctx context.Context, userGUID string) ([]models.ResumeFolder, error)
+	CreateResumeFolder(ctx context.Context, userGUID, name string) (*models.ResumeFolder, error)
+	RenameResumeFolder(ctx context.Context, userGUID, folderID, name string) (*models.ResumeFolder, error)
+	DeleteResumeFolder(ctx context.Context, userGUID, folderID string) error
+	AddResumeToFolder(ctx context.Context, userGUID, folderID, resumeID string) error
+	RemoveResumeFromFolder(ctx context.Context, userGUID, folderID, resumeID string) error
+	SearchResumeDatabase(ctx context.Context, userGUID string, filter models.ResumeSearchFilter, limit, offset int) ([]models.ResumeSearchResult, error)
+	GetCandidates(ctx context.Context, userGUID string, limit, offset int) ([]models.Candidate, error)
+	MergeCandidates(ctx context.Context, userGUID, targetID string, sourceIDs []string) (*models.Candidate, error)
+	MatchCandidatesFromDatabase(ctx context.Context, userGUID, jobID string, limit int, refresh bool) (*models.MatchCandidatesResponse, error)
+	MatchApplicants(ctx context.Context, userGUID, jobID string, limit int, includeProfiles, refresh bool) (*models.MatchApplicantsResponse, error)
+	RecommendJobs(ctx context.Context, userGUID string, limit int, refresh bool) (*models.RecommendedJobsResponse, error)
+	StartIngestion(ctx context.Context) error
 }
 
 type StorageService interface {
-	UploadFile(ctx context.Context, file io.Reader, filename string) (string, error)
-	GetFile(ctx context.Context, filename string) (io.ReadCloser, error)
-	GetFileObject(ctx context.Context, filename string) (*minio.Object, error)
+	Put(ctx context.Context, name string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, name string) (io.ReadSeekCloser, *storage.ObjectInfo, error)
+	Stat(ctx context.Context, name string) (*storage.ObjectInfo, error)
+	Delete(ctx context.Context, name string) error
+	List(ctx context.Context, prefix string) ([]storage.ObjectInfo, error)
+	PresignGet(ctx context.Context, name string, expires time.Duration) (string, error)
+}
+
+type MediaService interface {
+	UploadImage(ctx context.Context, header *multipart.FileHeader) (*models.ImageThumbnails, error)
+	GetImage(ctx context.Context, filename string) (io.ReadSeekCloser, *storage.ObjectInfo, error)
+	DeleteImage(ctx context.Context, link string) error
+	Thumbnails(link string) *models.ImageThumbnails
+	MakePreview(file io.ReadSeeker) ([]byte, string, error)
+	MaxFileSize() int64
 }
 
 type ChatService interface {
-	CreateChat(ctx context.Context, userIDs []string) (*models.Chat, error)
+	CreateChat(ctx context.Context, userID string, req *models.CreateChatRequest) (*models.Chat, error)
+	UpdateChatTitle(ctx context.Context, chatID, userID, title string) (*models.Chat, error)
+	AddChatMember(ctx context.Context, chatID, userID, memberID, role string) (*models.Chat, error)
+	UpdateChatMemberRole(ctx context.Context, chatID, userID, memberID, role string) (*models.Chat, error)
+	RemoveChatMember(ctx context.Context, chatID, userID, memberID string) error
 	GetUserChats(ctx context.Context, userID string) ([]models.ChatWithLastMessage, error)
-	GetChatMessages(ctx context.Context, chatID string, limit, offset int) ([]models.Message, error)
-	SendMessage(ctx context.Context, chatID, userID, text string) (*models.Message, error)
+	GetChatMessages(ctx context.Context, chatID, userID string, limit, offset int) ([]models.Message, error)
+	SendMessage(ctx context.Context, chatID, userID string, req *models.SendMessageRequest) (*models.Message, error)
+	EditMessage(ctx context.Context, chatID, userID, messageID, text string) (*models.Message, error)
+	DeleteMessage(ctx context.Context, chatID, userID, messageID string) error
+	GetMessageEdits(ctx context.Context, chatID, userID, messageID string) ([]models.MessageEdit, error)
+	AddReaction(ctx context.Context, chatID, userID, messageID, emoji string) (*models.Message, error)
+	RemoveReaction(ctx context.Context, chatID, userID, messageID, emoji string) (*models.Message, error)
+	SearchMessages(ctx context.Context, userID, query, chatID string, limit, offset int) ([]models.MessageSearchResult, error)
+	GetMessageContext(ctx context.Context, chatID, userID, messageID string, limit int) ([]models.Message, error)
+	UploadAttachment(ctx context.Context, chatID, userID string, header *multipart.FileHeader) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, chatID, userID, attachmentID string) (*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, chatID, userID, attachmentID string) error
+	MaxAttachmentSize() int64
+	MarkRead(ctx context.Context, chatID, userID, messageID string) (*models.ReadReceipt, error)
+	CheckChatMember(ctx context.Context, chatID, userID string) error
+	SetTyping(chatID, userID string, typing bool)
 	Subscribe(chatID string, conn *chat.WebSocketConnection)
 	Unsubscribe(chatID string, conn *chat.WebSocketConnection)
 }
@@ -82,22 +142,29 @@ type JobService interface {
 	CreateJob(ctx context.Context, userID string, req *models.CreateJobRequest) (*models.Job, error)
 	UpdateJob(ctx context.Context, jobID, userID string, req *models.UpdateJobRequest) (*models.Job, error)
 	DeleteJob(ctx context.Context, jobID, userID string) error
-	ApplyToJob(ctx context.Context, jobID, userID string) error
+	ApplyToJob(ctx context.Context, jobID, userID string, cvID *string) error
 	GetApplicationStatus(ctx context.Context, jobID, userID string) (*models.ApplicationStatus, error)
 	GetJobApplications(ctx context.Context, jobID, userID string, limit, offset int) ([]models.JobApplication, error)
 	UpdateJobApplicationStatus(ctx context.Context, jobID, applicantID, authorID, status string) (*models.JobApplication, error)
+	SourceCandidate(ctx context.Context, jobID, userID string, req *models.SourceCandidateRequest) (*models.SourcedApplication, error)
+	ClaimSourcedApplication(ctx context.Context, userID, token string) (*models.Job, error)
 }
 
 type Services struct {
-	Auth     auth.Service
-	Profile  profile.Service
-	Company  company.Service
-	CV       cv.Service
-	Chat     chat.Service
-	Call     call.Service
-	Job      job.Service
-	Storage  storage.Service
-	DeepSeek deepseek.Service
+	Auth      auth.Service
+	Profile   profile.Service
+	Company   company.Service
+	CV        cv.Service
+	Chat      chat.Service
+	Call      call.Service
+	Job       job.Service
+	Storage   storage.Service
+	Media     media.Service
+	DeepSeek  deepseek.Service
+	OCR       ocr.Service
+	Embedding embedding.Service
+	Email     email.Service
+	Scanner   scanner.Service
 }
 
 func NewServices(cfg *config.Config, repo *repository.Repositories, log *slog.Logger) (*Services, error) {
@@ -106,19 +173,49 @@ func NewServices(cfg *config.Config, repo *repository.Repositories, log *slog.Lo
 		return nil, err
 	}
 
-	deepSeekService := deepseek.NewService(cfg)
+	mediaService := media.NewService(cfg, storageService)
+
+	deepSeekService, err := deepseek.NewService(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ocrService, err := ocr.NewService(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddingService, err := embedding.NewService(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	emailService, err := email.NewService(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	scannerService, err := scanner.NewService(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	profileService := profile.NewService(repo)
+	profileService := profile.NewService(repo, mediaService)
 
 	return &Services{
-		Auth:     auth.NewService(cfg, repo),
-		Profile:  profileService,
-		Company:  company.NewService(repo),
-		CV:       cv.NewService(repo, storageService, deepSeekService, cfg.ServerFullAddress),
-		Chat:     chat.NewService(repo, profileService),
-		Call:     call.NewService(repo, log),
-		Job:      job.NewService(repo),
-		Storage:  storageService,
-		DeepSeek: deepSeekService,
+		Auth:      auth.NewService(cfg, repo),
+		Profile:   profileService,
+		Company:   company.NewService(repo, mediaService),
+		CV:        cv.NewService(cfg, repo, storageService, deepSeekService, ocrService, embeddingService, scannerService, log),
+		Chat:      chat.NewService(cfg, repo, profileService, mediaService, storageService, scannerService),
+		Call:      call.NewService(repo, log),
+		Job:       job.NewService(cfg, repo, emailService, log),
+		Storage:   storageService,
+		Media:     mediaService,
+		DeepSeek:  deepSeekService,
+		OCR:       ocrService,
+		Embedding: embeddingService,
+		Email:     emailService,
+		Scanner:   scannerService,
 	}, nil
 }
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"PlatformService/internal/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultLocalPath = "data/storage"
+
+// localService хранит объекты файлами в каталоге на диске: для разработки и
+// тестов без объектного хранилища. Тип содержимого определяется по расширению.
+type localService struct {
+	root string
+}
+
+func newLocalService(cfg *config.Config) (Service, error) {
+	root := cfg.StorageLocalPath
+	if root == "" {
+		root = defaultLocalPath
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &localService{root: root}, nil
+}
+
+func (s *localService) Put(ctx context.Context, name string, content io.Reader, size int64, _ string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы читатели не увидели
+	// недописанный объект
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if size >= 0 {
+		content = io.LimitReader(content, size)
+	}
+	written, err := io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("failed to write file: %w", io.ErrUnexpectedEOF)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	return nil
+}
+
+func (s *localService) Get(_ context.Context, name string) (io.ReadSeekCloser, *ObjectInfo, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, nil, ErrObjectNotFound
+	}
+
+	return file, mapFileInfo(name, info), nil
+}
+
+func (s *localService) Stat(_ context.Context, name string) (*ObjectInfo, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	return mapFileInfo(name, info), nil
+}
+
+// Delete, как и в S3, не считает ошибкой отсутствие объекта
+func (s *localService) Delete(_ context.Context, name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+func (s *localService) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Временные файлы незавершённой записи
+		if strings.HasPrefix(entry.Name(), ".") && path != s.root {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, *mapFileInfo(name, info))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	return objects, nil
+}
+
+func (s *localService) PresignGet(context.Context, string, time.Duration) (string, error) {
+	return "", ErrPresignNotSupported
+}
+
+// path переводит имя объекта в путь внутри каталога хранилища, не давая
+// выйти за его пределы
+func (s *localService) path(name string) (string, error) {
+	if name == "" || strings.Contains(name, `\`) || !filepath.IsLocal(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidObjectName, name)
+	}
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return "", fmt.Errorf("%w: %q", ErrInvalidObjectName, name)
+		}
+	}
+	return filepath.Join(s.root, filepath.FromSlash(name)), nil
+}
+
+func mapFileInfo(name string, info fs.FileInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Name:         name,
+		Size:         info.Size(),
+		ContentType:  ContentType(name),
+		LastModified: info.ModTime(),
+	}
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"PlatformService/internal/config"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestLocalService(t *testing.T) (Service, string) {
+	t.Helper()
+
+	// Корень хранилища - подкаталог, чтобы выход за его пределы был виден
+	root := filepath.Join(t.TempDir(), "storage")
+	s, err := NewService(&config.Config{StorageProvider: ProviderLocal, StorageLocalPath: root})
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+	return s, root
+}
+
+func TestLocalRoundTrip(t *testing.T) {
+	s, _ := newTestLocalService(t)
+	ctx := context.Background()
+	content := "резюме кандидата"
+	name := "chat/123/resume.pdf"
+
+	if err := s.Put(ctx, name, strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	reader, info, err := s.Get(ctx, name)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		t.Fatalf("failed to read object: %v", err)
+	}
+	if string(got) != content {
+		t.Errorf("Get() content = %q, want %q", got, content)
+	}
+	if info.Name != name || info.Size != int64(len(content)) || info.ContentType != "application/pdf" {
+		t.Errorf("Get() info = %+v", info)
+	}
+
+	if _, err := s.Stat(ctx, name); err != nil {
+		t.Errorf("Stat() error = %v", err)
+	}
+
+	objects, err := s.List(ctx, "chat/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 1 || objects[0].Name != name {
+		t.Errorf("List() = %+v, want only %s", objects, name)
+	}
+
+	if err := s.Delete(ctx, name); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, err := s.Get(ctx, name); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, ErrObjectNotFound)
+	}
+	// Как и в S3, удаление отсутствующего объекта не ошибка
+	if err := s.Delete(ctx, name); err != nil {
+		t.Errorf("second Delete() error = %v", err)
+	}
+}
+
+func TestLocalPutShortContent(t *testing.T) {
+	s, _ := newTestLocalService(t)
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "short.txt", strings.NewReader("abc"), 10, ""); err == nil {
+		t.Fatal("Put() with short content error = nil")
+	}
+	if _, err := s.Stat(ctx, "short.txt"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Stat() after failed Put() error = %v, want %v", err, ErrObjectNotFound)
+	}
+	objects, err := s.List(ctx, "")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 0 {
+		t.Errorf("List() = %+v, want no objects and no temporary files", objects)
+	}
+}
+
+func TestLocalMissingObject(t *testing.T) {
+	s, _ := newTestLocalService(t)
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "dir/file.txt", strings.NewReader("x"), 1, ""); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	for _, name := range []string{"missing.pdf", "dir/missing.pdf", "dir"} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := s.Get(ctx, name); !errors.Is(err, ErrObjectNotFound) {
+				t.Errorf("Get() error = %v, want %v", err, ErrObjectNotFound)
+			}
+			if _, err := s.Stat(ctx, name); !errors.Is(err, ErrObjectNotFound) {
+				t.Errorf("Stat() error = %v, want %v", err, ErrObjectNotFound)
+			}
+		})
+	}
+}
+
+func TestLocalInvalidObjectName(t *testing.T) {
+	s, root := newTestLocalService(t)
+	ctx := context.Background()
+
+	secret := filepath.Join(filepath.Dir(root), "secret.txt")
+	if err := os.WriteFile(secret, []byte("secret"), 0o600); err != nil {
+		t.Fatalf("failed to write file outside storage: %v", err)
+	}
+
+	names := []string{
+		"",
+		"../secret.txt",
+		"../escaped.txt",
+		"dir/../../secret.txt",
+		"dir/../file.txt",
+		"/etc/passwd",
+		`..\secret.txt`,
+		".hidden",
+		"dir/.upload-123",
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			if err := s.Put(ctx, name, strings.NewReader("x"), 1, ""); !errors.Is(err, ErrInvalidObjectName) {
+				t.Errorf("Put() error = %v, want %v", err, ErrInvalidObjectName)
+			}
+			if _, _, err := s.Get(ctx, name); !errors.Is(err, ErrInvalidObjectName) {
+				t.Errorf("Get() error = %v, want %v", err, ErrInvalidObjectName)
+			}
+			if _, err := s.Stat(ctx, name); !errors.Is(err, ErrInvalidObjectName) {
+				t.Errorf("Stat() error = %v, want %v", err, ErrInvalidObjectName)
+			}
+			if err := s.Delete(ctx, name); !errors.Is(err, ErrInvalidObjectName) {
+				t.Errorf("Delete() error = %v, want %v", err, ErrInvalidObjectName)
+			}
+		})
+	}
+
+	if _, err := os.Stat(secret); err != nil {
+		t.Errorf("file outside storage was removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(root), "escaped.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file was written outside storage: %v", err)
+	}
+}
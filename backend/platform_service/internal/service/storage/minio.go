@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"PlatformService/internal/config"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// minioService хранит объекты в MinIO или другом S3-совместимом хранилище
+type minioService struct {
+	client *minio.Client
+	bucket string
+}
+
+func newMinIOService(cfg *config.Config) (Service, error) {
+	client, err := minio.New(cfg.MinioEndpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.MinioAccessKey, cfg.MinioSecretKey, ""),
+		Secure: cfg.MinioUseSSL,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: cfg.MinioInsecure,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create minio client: %w", err)
+	}
+
+	// Ensure bucket exists
+	exists, err := client.BucketExists(context.Background(), cfg.MinioBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket existence: %w", err)
+	}
+
+	if !exists {
+		err = client.MakeBucket(context.Background(), cfg.MinioBucket, minio.MakeBucketOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bucket: %w", err)
+		}
+	}
+
+	return &minioService{
+		client: client,
+		bucket: cfg.MinioBucket,
+	}, nil
+}
+
+func (s *minioService) Put(ctx context.Context, name string, content io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, name, content, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
+
+func (s *minioService) Get(ctx context.Context, name string) (io.ReadSeekCloser, *ObjectInfo, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	// GetObject не обращается к хранилищу, отсутствие объекта видно только по Stat
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, nil, mapMinIOError(err)
+	}
+
+	return object, mapObjectInfo(info), nil
+}
+
+func (s *minioService) Stat(ctx context.Context, name string) (*ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, name, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, mapMinIOError(err)
+	}
+	return mapObjectInfo(info), nil
+}
+
+func (s *minioService) Delete(ctx context.Context, name string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, name, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *minioService) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for info := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", info.Err)
+		}
+		objects = append(objects, *mapObjectInfo(info))
+	}
+	return objects, nil
+}
+
+func (s *minioService) PresignGet(ctx context.Context, name string, expires time.Duration) (string, error) {
+	presignedURL, err := s.client.PresignedGetObject(ctx, s.bucket, name, expires, url.Values{})
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object: %w", err)
+	}
+	return presignedURL.String(), nil
+}
+
+func mapMinIOError(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrObjectNotFound
+	}
+	return fmt.Errorf("failed to stat object: %w", err)
+}
+
+func mapObjectInfo(info minio.ObjectInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Name:         info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestMapMinIOError(t *testing.T) {
+	errTimeout := errors.New("i/o timeout")
+
+	tests := []struct {
+		name     string
+		err      error
+		want     error
+		notFound bool
+	}{
+		{"no such key", minio.ErrorResponse{Code: "NoSuchKey", StatusCode: 404}, ErrObjectNotFound, true},
+		{"access denied", minio.ErrorResponse{Code: "AccessDenied", StatusCode: 403}, nil, false},
+		{"network error", errTimeout, errTimeout, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mapMinIOError(tt.err)
+			if got := errors.Is(err, ErrObjectNotFound); got != tt.notFound {
+				t.Errorf("mapMinIOError() = %v, not found = %v, want %v", err, got, tt.notFound)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("mapMinIOError() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -3,82 +3,83 @@ package storage
 import (
 	"PlatformService/internal/config"
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"mime"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
-type Service interface {
-	UploadFile(ctx context.Context, file io.Reader, filename string) (string, error)
-	GetFile(ctx context.Context, filename string) (io.ReadCloser, error)
-	GetFileObject(ctx context.Context, filename string) (*minio.Object, error)
+const (
+	ProviderMinIO = "minio"
+	ProviderLocal = "local"
+)
+
+var (
+	ErrObjectNotFound      = errors.New("object not found")
+	ErrInvalidObjectName   = errors.New("invalid object name")
+	ErrPresignNotSupported = errors.New("presigned URLs are not supported by storage provider")
+)
+
+// ObjectInfo - метаданные объекта, не зависящие от хранилища
+type ObjectInfo struct {
+	Name         string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
 }
 
-type service struct {
-	client *minio.Client
-	bucket string
+type Service interface {
+	// Put сохраняет объект известного размера: поток длиннее size не будет
+	// сохранён целиком
+	Put(ctx context.Context, name string, content io.Reader, size int64, contentType string) error
+	// Get открывает объект на чтение; отсутствующий объект - ErrObjectNotFound
+	Get(ctx context.Context, name string) (io.ReadSeekCloser, *ObjectInfo, error)
+	Stat(ctx context.Context, name string) (*ObjectInfo, error)
+	Delete(ctx context.Context, name string) error
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// PresignGet выдаёт прямую ссылку на объект, если хранилище это умеет
+	PresignGet(ctx context.Context, name string, expires time.Duration) (string, error)
 }
 
 func NewService(cfg *config.Config) (Service, error) {
-	client, err := minio.New(cfg.MinioEndpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(cfg.MinioAccessKey, cfg.MinioSecretKey, ""),
-		Secure: cfg.MinioUseSSL,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: cfg.MinioInsecure,
-			},
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create minio client: %w", err)
-	}
-
-	// Ensure bucket exists
-	exists, err := client.BucketExists(context.Background(), cfg.MinioBucket)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check bucket existence: %w", err)
-	}
-
-	if !exists {
-		err = client.MakeBucket(context.Background(), cfg.MinioBucket, minio.MakeBucketOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create bucket: %w", err)
-		}
+	switch cfg.StorageProvider {
+	case "", ProviderMinIO:
+		return newMinIOService(cfg)
+	case ProviderLocal:
+		return newLocalService(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage provider %q", cfg.StorageProvider)
 	}
-
-	return &service{
-		client: client,
-		bucket: cfg.MinioBucket,
-	}, nil
 }
 
-func (s *service) GetFileObject(ctx context.Context, filename string) (*minio.Object, error) {
-	return s.client.GetObject(ctx, s.bucket, filename, minio.GetObjectOptions{})
+// ObjectName генерирует уникальное имя объекта с расширением исходного файла
+func ObjectName(filename string) string {
+	return uuid.New().String() + filepath.Ext(filename)
 }
 
-func (s *service) GetFile(ctx context.Context, filename string) (io.ReadCloser, error) {
-	return s.client.GetObject(ctx, s.bucket, filename, minio.GetObjectOptions{})
+// Типы форматов резюме не зависят от mime.types в образе
+var contentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".txt":  "text/plain; charset=utf-8",
+	".rtf":  "application/rtf",
+	".odt":  "application/vnd.oasis.opendocument.text",
 }
 
-func (s *service) UploadFile(ctx context.Context, file io.Reader, filename string) (string, error) {
-	// Generate unique filename
-	ext := filepath.Ext(filename)
-	objectName := fmt.Sprintf("%s%s", uuid.New().String(), ext)
-
-	// Upload file
-	_, err := s.client.PutObject(ctx, s.bucket, objectName, file, -1, minio.PutObjectOptions{
-		ContentType: "application/octet-stream",
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to upload file: %w", err)
+// ContentType возвращает MIME-тип файла по расширению
+func ContentType(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if contentType, ok := contentTypes[ext]; ok {
+		return contentType
 	}
-
-	// Return public URL
-	return objectName, nil
+	if contentType := mime.TypeByExtension(ext); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
 }
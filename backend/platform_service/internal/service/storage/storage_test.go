@@ -0,0 +1,29 @@
+package storage
+
+import "testing"
+
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"cv.pdf", "application/pdf"},
+		{"CV.PDF", "application/pdf"},
+		{"cv.doc", "application/msword"},
+		{"cv.docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{"cv.rtf", "application/rtf"},
+		{"cv.odt", "application/vnd.oasis.opendocument.text"},
+		{"cv.txt", "text/plain; charset=utf-8"},
+		{"photo.png", "image/png"},
+		{"archive", "application/octet-stream"},
+		{"data.unknown-ext", "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			if got := ContentType(tt.filename); got != tt.want {
+				t.Errorf("ContentType(%q) = %q, want %q", tt.filename, got, tt.want)
+			}
+		})
+	}
+}
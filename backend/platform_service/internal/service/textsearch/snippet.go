@@ -0,0 +1,42 @@
+package textsearch
+
+import (
+	"PlatformService/internal/models"
+	"fmt"
+	"strings"
+)
+
+// Маркеры совпадений в тексте ts_headline. Управляющие символы не
+// встречаются в анализе резюме и тексте сообщений, поэтому фрагменты
+// разбираются однозначно
+const (
+	snippetStart = "\x02"
+	snippetStop  = "\x03"
+)
+
+// HeadlineOptions дополняет параметры ts_headline маркерами совпадений,
+// которые понимает ParseSnippet
+func HeadlineOptions(options string) string {
+	return fmt.Sprintf("StartSel=%s, StopSel=%s, %s", snippetStart, snippetStop, options)
+}
+
+// ParseSnippet разбивает фрагмент ts_headline на части с совпадениями и без,
+// чтобы клиент мог выделить совпадения, не интерпретируя текст как HTML
+func ParseSnippet(snippet string) []models.SnippetFragment {
+	fragments := []models.SnippetFragment{}
+	appendFragment := func(text string, highlight bool) {
+		if text != "" {
+			fragments = append(fragments, models.SnippetFragment{Text: text, Highlight: highlight})
+		}
+	}
+
+	parts := strings.Split(snippet, snippetStart)
+	appendFragment(parts[0], false)
+	for _, part := range parts[1:] {
+		match, rest, _ := strings.Cut(part, snippetStop)
+		appendFragment(match, true)
+		appendFragment(rest, false)
+	}
+
+	return fragments
+}
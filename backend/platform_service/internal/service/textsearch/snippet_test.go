@@ -0,0 +1,60 @@
+package textsearch
+
+import (
+	"PlatformService/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestParseSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    []models.SnippetFragment
+	}{
+		{"empty", "", []models.SnippetFragment{}},
+		{"no matches", "опыт работы", []models.SnippetFragment{{Text: "опыт работы"}}},
+		{
+			name:    "matches inside text",
+			snippet: "опыт \x02Go\x03 и \x02PostgreSQL\x03 разработки",
+			want: []models.SnippetFragment{
+				{Text: "опыт "},
+				{Text: "Go", Highlight: true},
+				{Text: " и "},
+				{Text: "PostgreSQL", Highlight: true},
+				{Text: " разработки"},
+			},
+		},
+		{
+			name:    "match at edges",
+			snippet: "\x02Go\x03",
+			want:    []models.SnippetFragment{{Text: "Go", Highlight: true}},
+		},
+		{
+			name:    "unterminated match",
+			snippet: "знание \x02Go",
+			want:    []models.SnippetFragment{{Text: "знание "}, {Text: "Go", Highlight: true}},
+		},
+		{
+			name:    "html is kept as text",
+			snippet: "<b>\x02Go\x03</b>",
+			want:    []models.SnippetFragment{{Text: "<b>"}, {Text: "Go", Highlight: true}, {Text: "</b>"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSnippet(tt.snippet); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSnippet(%q) = %+v, want %+v", tt.snippet, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadlineOptions(t *testing.T) {
+	got := HeadlineOptions("MaxWords=35, MinWords=15")
+	want := "StartSel=\x02, StopSel=\x03, MaxWords=35, MinWords=15"
+	if got != want {
+		t.Errorf("HeadlineOptions() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package upload
+
+import (
+	"PlatformService/internal/service/scanner"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Сколько байт с начала файла передаётся проверке формата. Этого хватает
+// и http.DetectContentType, и поиску заголовка PDF
+const SniffLen = 1024
+
+var ErrInfected = errors.New("file rejected by malware scanner")
+
+// CheckContent читает начало файла для проверки формата и проверяет весь файл
+// антивирусом. Файл читается один раз. checkHead может быть nil; его ошибка
+// возвращается без изменений, и тогда файл не сканируется. Возвращает первые
+// SniffLen байт файла.
+func CheckContent(ctx context.Context, scannerService scanner.Service, content io.Reader, checkHead func(head []byte) error) ([]byte, error) {
+	reader := bufio.NewReaderSize(content, SniffLen)
+	head, err := reader.Peek(SniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if checkHead != nil {
+		if err := checkHead(head); err != nil {
+			return nil, err
+		}
+	}
+	// Peek возвращает срез буфера, который перезапишет чтение при сканировании
+	head = append([]byte(nil), head...)
+
+	err = scannerService.Scan(ctx, reader)
+	var infected *scanner.InfectedError
+	if errors.As(err, &infected) {
+		return nil, fmt.Errorf("%w: %s", ErrInfected, infected.Signature)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan file: %w", err)
+	}
+	return head, nil
+}
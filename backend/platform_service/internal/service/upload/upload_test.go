@@ -0,0 +1,113 @@
+package upload
+
+import (
+	"PlatformService/internal/service/scanner"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeScanner запоминает просканированное содержимое и возвращает заданную ошибку
+type fakeScanner struct {
+	err     error
+	scanned []byte
+	called  bool
+}
+
+func (f *fakeScanner) Scan(_ context.Context, content io.Reader) error {
+	f.called = true
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	f.scanned = data
+	return f.err
+}
+
+func TestCheckContent(t *testing.T) {
+	errBadHead := errors.New("bad head")
+	large := strings.Repeat("a", SniffLen*3)
+
+	tests := []struct {
+		name       string
+		content    string
+		scanErr    error
+		checkHead  func(head []byte) error
+		wantHead   string
+		wantErr    error
+		wantErrMsg string
+		wantScan   bool
+	}{
+		{
+			name:     "clean small file",
+			content:  "hello",
+			wantHead: "hello",
+			wantScan: true,
+		},
+		{
+			name:     "clean large file",
+			content:  large,
+			wantHead: large[:SniffLen],
+			wantScan: true,
+		},
+		{
+			name:     "empty file",
+			content:  "",
+			wantHead: "",
+			wantScan: true,
+		},
+		{
+			name:      "rejected by head check",
+			content:   "MZ",
+			checkHead: func([]byte) error { return errBadHead },
+			wantErr:   errBadHead,
+		},
+		{
+			name:       "infected",
+			content:    "X5O!P%@AP",
+			scanErr:    &scanner.InfectedError{Signature: "Eicar-Test-Signature"},
+			wantErr:    ErrInfected,
+			wantErrMsg: "Eicar-Test-Signature",
+			wantScan:   true,
+		},
+		{
+			name:       "scanner unavailable",
+			content:    "hello",
+			scanErr:    errors.New("connection refused"),
+			wantErrMsg: "failed to scan file",
+			wantScan:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scannerService := &fakeScanner{err: tt.scanErr}
+			head, err := CheckContent(context.Background(), scannerService, strings.NewReader(tt.content), tt.checkHead)
+
+			if scannerService.called != tt.wantScan {
+				t.Errorf("scanner called = %v, want %v", scannerService.called, tt.wantScan)
+			}
+			if tt.wantScan && !bytes.Equal(scannerService.scanned, []byte(tt.content)) {
+				t.Errorf("scanned %d bytes, want the whole file of %d bytes", len(scannerService.scanned), len(tt.content))
+			}
+			if tt.wantErr != nil || tt.wantErrMsg != "" {
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("CheckContent() error = %v, want %v", err, tt.wantErr)
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Errorf("CheckContent() error = %v, want message containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckContent() error = %v", err)
+			}
+			if string(head) != tt.wantHead {
+				t.Errorf("CheckContent() head = %d bytes, want %d", len(head), len(tt.wantHead))
+			}
+		})
+	}
+}
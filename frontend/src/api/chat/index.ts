@@ -7,11 +7,29 @@ export { CancelablePromise, CancelError } from './core/CancelablePromise';
 export { OpenAPI } from './core/OpenAPI';
 export type { OpenAPIConfig } from './core/OpenAPI';
 
+export type { AddChatMemberRequest } from './models/AddChatMemberRequest';
+export type { Attachment } from './models/Attachment';
+export { AttachmentKind } from './models/AttachmentKind';
 export type { Chat } from './models/Chat';
+export { ChatRole } from './models/ChatRole';
+export { ChatType } from './models/ChatType';
 export type { ChatUser } from './models/ChatUser';
 export type { ChatWithLastMessage } from './models/ChatWithLastMessage';
 export type { CreateChatRequest } from './models/CreateChatRequest';
+export type { EditMessageRequest } from './models/EditMessageRequest';
+export type { MarkChatReadRequest } from './models/MarkChatReadRequest';
 export type { Message } from './models/Message';
+export type { MessageEdit } from './models/MessageEdit';
+export type { MessageReaction } from './models/MessageReaction';
+export type { MessageReactionRequest } from './models/MessageReactionRequest';
+export type { MessageReply } from './models/MessageReply';
+export type { MessageSearchResult } from './models/MessageSearchResult';
+export { MessageType } from './models/MessageType';
+export type { ReadReceipt } from './models/ReadReceipt';
 export type { SendMessageRequest } from './models/SendMessageRequest';
+export type { SnippetFragment } from './models/SnippetFragment';
+export type { UpdateChatMemberRequest } from './models/UpdateChatMemberRequest';
+export type { UpdateChatRequest } from './models/UpdateChatRequest';
+export type { UserPresence } from './models/UserPresence';
 
 export { ChatService } from './services/ChatService';
@@ -0,0 +1,25 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { AttachmentKind } from './AttachmentKind';
+/**
+ * File attached to a message; url and preview_url are available to chat members only
+ */
+export type Attachment = {
+    id: string;
+    kind: AttachmentKind;
+    filename: string;
+    content_type: string;
+    /**
+     * File size in bytes
+     */
+    size: number;
+    url: string;
+    /**
+     * Reduced copy of an image; null for other attachments
+     */
+    preview_url: string | null;
+    created_at: string;
+};
+
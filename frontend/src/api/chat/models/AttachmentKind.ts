@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Uploaded file, image with a preview or CV of the sender
+ */
+export enum AttachmentKind {
+    FILE = 'file',
+    IMAGE = 'image',
+    CV = 'cv',
+}
@@ -2,9 +2,19 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { ChatType } from './ChatType';
 import type { ChatUser } from './ChatUser';
 export type Chat = {
     id: string;
+    type: ChatType;
+    /**
+     * Title of a group chat; null for direct chats
+     */
+    title: string | null;
+    /**
+     * Job of a hiring-team room
+     */
+    job_id: string | null;
     users: Array<ChatUser>;
     created_at: string;
     updated_at: string;
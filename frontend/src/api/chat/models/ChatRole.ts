@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Role of a group chat member
+ */
+export enum ChatRole {
+    ADMIN = 'admin',
+    MEMBER = 'member',
+}
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Direct chat of two users or a group chat
+ */
+export enum ChatType {
+    DIRECT = 'direct',
+    GROUP = 'group',
+}
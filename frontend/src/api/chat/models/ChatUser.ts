@@ -2,9 +2,11 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { ChatRole } from './ChatRole';
 export type ChatUser = {
     id: string;
     description: string;
     avatar?: string | null;
+    role?: ChatRole;
 };
 
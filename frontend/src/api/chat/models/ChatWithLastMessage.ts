@@ -4,9 +4,22 @@
 /* eslint-disable */
 import type { Chat } from './Chat';
 import type { Message } from './Message';
+import type { ReadReceipt } from './ReadReceipt';
+import type { UserPresence } from './UserPresence';
 export type ChatWithLastMessage = {
     chat: Chat;
     last_message?: Message;
+    /**
+     * Messages from other participants after the last message read by the current user
+     */
     unread_count: number;
+    /**
+     * Last read message of each participant who has read the chat
+     */
+    read_receipts: Array<ReadReceipt>;
+    /**
+     * Online status of the chat participants
+     */
+    presence: Array<UserPresence>;
 };
 
@@ -2,7 +2,17 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { ChatType } from './ChatType';
 export type CreateChatRequest = {
+    type?: ChatType;
+    /**
+     * Required for group chats
+     */
+    title?: string;
+    /**
+     * Job of a hiring-team room; group chats only
+     */
+    job_id?: string;
     users: Array<string>;
 };
 
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type EditMessageRequest = {
+    /**
+     * May be empty if the message has attachments
+     */
+    text: string;
+};
+
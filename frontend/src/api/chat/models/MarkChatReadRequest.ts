@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type MarkChatReadRequest = {
+    /**
+     * Last read message; the latest message of the chat is used if omitted
+     */
+    message_id?: string;
+};
+
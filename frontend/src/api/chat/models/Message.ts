@@ -2,12 +2,35 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { Attachment } from './Attachment';
 import type { ChatUser } from './ChatUser';
+import type { MessageReaction } from './MessageReaction';
+import type { MessageReply } from './MessageReply';
+import type { MessageType } from './MessageType';
 export type Message = {
     id: string;
     chat_id: string;
     user: ChatUser;
+    type: MessageType;
+    /**
+     * Member affected by a system message
+     */
+    target_user_id?: string;
+    /**
+     * Message text; new title for title_changed, new role for role_changed
+     */
     text: string;
+    attachments: Array<Attachment>;
+    reply_to?: MessageReply;
+    reactions: Array<MessageReaction>;
+    /**
+     * Time of the last edit
+     */
+    edited_at?: string;
+    /**
+     * Set for a deleted message; its text and attachments are empty
+     */
+    deleted_at?: string;
     created_at: string;
 };
 
@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Previous text of a message and the time it was replaced
+ */
+export type MessageEdit = {
+    text: string;
+    replaced_at: string;
+};
+
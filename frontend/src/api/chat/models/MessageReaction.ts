@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Emoji reaction and the members who left it
+ */
+export type MessageReaction = {
+    emoji: string;
+    count: number;
+    user_ids: Array<string>;
+};
+
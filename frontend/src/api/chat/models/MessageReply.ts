@@ -0,0 +1,17 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Quote of the message being replied to
+ */
+export type MessageReply = {
+    id: string;
+    user_id: string;
+    text: string;
+    /**
+     * The quoted message was deleted; its text is empty
+     */
+    deleted: boolean;
+};
+
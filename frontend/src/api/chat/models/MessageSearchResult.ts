@@ -0,0 +1,18 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { Message } from './Message';
+import type { SnippetFragment } from './SnippetFragment';
+/**
+ * Message found by the search; snippet is its text split into matching and non-matching parts
+ */
+export type MessageSearchResult = {
+    message: Message;
+    /**
+     * Relevance of the message to the query
+     */
+    score: number;
+    snippet: Array<SnippetFragment>;
+};
+
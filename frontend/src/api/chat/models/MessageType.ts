@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Regular message or a system message about a group change
+ */
+export enum MessageType {
+    TEXT = 'text',
+    CHAT_CREATED = 'chat_created',
+    MEMBER_ADDED = 'member_added',
+    MEMBER_REMOVED = 'member_removed',
+    MEMBER_LEFT = 'member_left',
+    ROLE_CHANGED = 'role_changed',
+    TITLE_CHANGED = 'title_changed',
+}
@@ -0,0 +1,14 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Last message read by a chat participant; every earlier message is read as well
+ */
+export type ReadReceipt = {
+    chat_id: string;
+    user_id: string;
+    message_id: string;
+    read_at: string;
+};
+
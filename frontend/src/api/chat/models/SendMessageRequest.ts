@@ -3,6 +3,21 @@
 /* tslint:disable */
 /* eslint-disable */
 export type SendMessageRequest = {
+    /**
+     * May be empty if the message has attachments
+     */
     text: string;
+    /**
+     * Files uploaded by the sender to this chat and not sent yet
+     */
+    attachment_ids?: Array<string>;
+    /**
+     * Attach the primary CV of the sender
+     */
+    share_cv?: boolean;
+    /**
+     * Message of this chat being replied to
+     */
+    reply_to_id?: string;
 };
 
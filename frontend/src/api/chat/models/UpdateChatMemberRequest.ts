@@ -0,0 +1,9 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { ChatRole } from './ChatRole';
+export type UpdateChatMemberRequest = {
+    role: ChatRole;
+};
+
@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Online status of a chat participant
+ */
+export type UserPresence = {
+    user_id: string;
+    online: boolean;
+    /**
+     * When the last connection of the user was closed; null while the user is online or has not connected since the service started
+     */
+    last_seen_at: string | null;
+};
+
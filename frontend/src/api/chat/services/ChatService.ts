@@ -2,11 +2,21 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { AddChatMemberRequest } from '../models/AddChatMemberRequest';
+import type { Attachment } from '../models/Attachment';
 import type { Chat } from '../models/Chat';
 import type { ChatWithLastMessage } from '../models/ChatWithLastMessage';
 import type { CreateChatRequest } from '../models/CreateChatRequest';
+import type { EditMessageRequest } from '../models/EditMessageRequest';
+import type { MarkChatReadRequest } from '../models/MarkChatReadRequest';
 import type { Message } from '../models/Message';
+import type { MessageEdit } from '../models/MessageEdit';
+import type { MessageReactionRequest } from '../models/MessageReactionRequest';
+import type { MessageSearchResult } from '../models/MessageSearchResult';
+import type { ReadReceipt } from '../models/ReadReceipt';
 import type { SendMessageRequest } from '../models/SendMessageRequest';
+import type { UpdateChatMemberRequest } from '../models/UpdateChatMemberRequest';
+import type { UpdateChatRequest } from '../models/UpdateChatRequest';
 import type { CancelablePromise } from '../core/CancelablePromise';
 import { OpenAPI } from '../core/OpenAPI';
 import { request as __request } from '../core/request';
@@ -28,6 +38,10 @@ export class ChatService {
     }
     /**
      * Create new chat
+     * The current user is always added to the chat. A direct chat has exactly
+     * two users; an existing direct chat of the same users is returned
+     * instead of a new one. The creator of a group chat becomes its admin.
+     *
      * @param requestBody
      * @returns Chat Chat created
      * @throws ApiError
@@ -41,11 +55,273 @@ export class ChatService {
             body: requestBody,
             mediaType: 'application/json',
             errors: {
+                400: `Invalid chat type, title, job or users`,
                 401: `Unauthorized`,
                 500: `Internal Server Error`,
             },
         });
     }
+    /**
+     * Search messages in chats of the user
+     * Full-text search over messages of the chats the user belongs to. The
+     * query is matched with Russian and English word forms; results are
+     * ordered by relevance, then newest first. Deleted and system messages
+     * are not searched. Use `/messages/{message_id}/context` to jump to a
+     * result.
+     *
+     * @param q Search query
+     * @param chatId Search only in this chat
+     * @param limit
+     * @param offset
+     * @returns MessageSearchResult Found messages
+     * @throws ApiError
+     */
+    public static searchChatMessages(
+        q: string,
+        chatId?: string,
+        limit: number = 20,
+        offset?: number,
+    ): CancelablePromise<Array<MessageSearchResult>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/chat/search',
+            query: {
+                'q': q,
+                'chat_id': chatId,
+                'limit': limit,
+                'offset': offset,
+            },
+            errors: {
+                400: `Empty search query`,
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Chat not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Rename group chat
+     * Available to group admins. Members receive a `title_changed` system message.
+     * @param chatId
+     * @param requestBody
+     * @returns Chat Updated chat
+     * @throws ApiError
+     */
+    public static updateChat(
+        chatId: string,
+        requestBody: UpdateChatRequest,
+    ): CancelablePromise<Chat> {
+        return __request(OpenAPI, {
+            method: 'PATCH',
+            url: '/api/v1/chat/{chat_id}',
+            path: {
+                'chat_id': chatId,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid title or not a group chat`,
+                401: `Unauthorized`,
+                403: `User is not a chat member or not a group admin`,
+                404: `Chat not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Upload a file to attach to the next message
+     * The file is checked by the malware scanner and stored until it is sent
+     * with a message (`attachment_ids` of sendMessage) or deleted. Images
+     * (JPEG, PNG, GIF, WebP by content) get a preview. Until the message is
+     * sent, the attachment is visible to the uploader only.
+     *
+     * @param chatId
+     * @param formData
+     * @returns Attachment Uploaded attachment
+     * @throws ApiError
+     */
+    public static uploadChatAttachment(
+        chatId: string,
+        formData: {
+            file: Blob;
+        },
+    ): CancelablePromise<Attachment> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/chat/{chat_id}/attachments',
+            path: {
+                'chat_id': chatId,
+            },
+            formData: formData,
+            mediaType: 'multipart/form-data',
+            errors: {
+                400: `File is missing`,
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Chat not found`,
+                413: `File exceeds CHAT_ATTACHMENT_MAX_FILE_SIZE`,
+                422: `File rejected by malware scanner`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Delete an attachment that was not sent
+     * @param chatId
+     * @param attachmentId
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteChatAttachment(
+        chatId: string,
+        attachmentId: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/chat/{chat_id}/attachments/{attachment_id}',
+            path: {
+                'chat_id': chatId,
+                'attachment_id': attachmentId,
+            },
+            errors: {
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Attachment not found, already sent or uploaded by another user`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Download a message attachment
+     * Available to chat members; an attachment that was not sent yet is
+     * available to the uploader only. Images are served inline, other files
+     * as downloads with the original filename in Content-Disposition.
+     *
+     * @param chatId
+     * @param attachmentId
+     * @param preview Return the reduced copy of an image instead of the file
+     * @returns binary File content
+     * @throws ApiError
+     */
+    public static getChatAttachment(
+        chatId: string,
+        attachmentId: string,
+        preview?: boolean,
+    ): CancelablePromise<Blob> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/chat/{chat_id}/attachments/{attachment_id}',
+            path: {
+                'chat_id': chatId,
+                'attachment_id': attachmentId,
+            },
+            query: {
+                'preview': preview,
+            },
+            errors: {
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Chat or attachment not found, or the attachment has no preview`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Add group chat member
+     * Available to group admins. Members receive a `member_added` system message.
+     * @param chatId
+     * @param requestBody
+     * @returns Chat Updated chat
+     * @throws ApiError
+     */
+    public static addChatMember(
+        chatId: string,
+        requestBody: AddChatMemberRequest,
+    ): CancelablePromise<Chat> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/chat/{chat_id}/members',
+            path: {
+                'chat_id': chatId,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid user or role, or not a group chat`,
+                401: `Unauthorized`,
+                403: `User is not a chat member or not a group admin`,
+                404: `Chat not found`,
+                409: `User is already a member`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Remove group chat member or leave the group
+     * Admins can remove any member; any member can remove themselves to leave
+     * the group. If the last admin leaves, the longest-standing member
+     * becomes admin. Members receive a `member_removed` or `member_left`
+     * system message.
+     *
+     * @param chatId
+     * @param userId
+     * @returns void
+     * @throws ApiError
+     */
+    public static removeChatMember(
+        chatId: string,
+        userId: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/chat/{chat_id}/members/{user_id}',
+            path: {
+                'chat_id': chatId,
+                'user_id': userId,
+            },
+            errors: {
+                400: `Not a group chat`,
+                401: `Unauthorized`,
+                403: `User is not a chat member or not a group admin`,
+                404: `Chat or member not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Change role of a group chat member
+     * Available to group admins. The last admin cannot be demoted. Members receive a `role_changed` system message.
+     * @param chatId
+     * @param userId
+     * @param requestBody
+     * @returns Chat Updated chat
+     * @throws ApiError
+     */
+    public static updateChatMember(
+        chatId: string,
+        userId: string,
+        requestBody: UpdateChatMemberRequest,
+    ): CancelablePromise<Chat> {
+        return __request(OpenAPI, {
+            method: 'PATCH',
+            url: '/api/v1/chat/{chat_id}/members/{user_id}',
+            path: {
+                'chat_id': chatId,
+                'user_id': userId,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid role or not a group chat`,
+                401: `Unauthorized`,
+                403: `User is not a chat member or not a group admin`,
+                404: `Chat or member not found`,
+                409: `The member is the last admin`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
     /**
      * Get chat messages
      * @param chatId
@@ -71,6 +347,7 @@ export class ChatService {
             },
             errors: {
                 401: `Unauthorized`,
+                403: `User is not a chat member`,
                 404: `Chat not found`,
                 500: `Internal Server Error`,
             },
@@ -78,6 +355,11 @@ export class ChatService {
     }
     /**
      * Send message
+     * A message needs text or at least one attachment. `attachment_ids`
+     * are files uploaded by the sender to this chat and not sent yet, up to
+     * 10 per message. `share_cv` attaches the primary CV of the sender.
+     * `reply_to_id` quotes a message of this chat that is not deleted.
+     *
      * @param chatId
      * @param requestBody
      * @returns Message Message sent
@@ -96,12 +378,238 @@ export class ChatService {
             body: requestBody,
             mediaType: 'application/json',
             errors: {
+                400: `Empty message, unknown attachment or reply message, or no uploaded CV to share`,
                 401: `Unauthorized`,
+                403: `User is not a chat member`,
                 404: `Chat not found`,
                 500: `Internal Server Error`,
             },
         });
     }
+    /**
+     * Delete own message
+     * The message stays in the chat as a placeholder with `deleted_at` set
+     * and empty text. Its attachments, edit history and reactions are
+     * removed. Participants receive `{"type": "message-updated", "message": Message}`.
+     *
+     * @param chatId
+     * @param messageId
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteMessage(
+        chatId: string,
+        messageId: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/chat/{chat_id}/messages/{message_id}',
+            path: {
+                'chat_id': chatId,
+                'message_id': messageId,
+            },
+            errors: {
+                400: `System messages cannot be deleted`,
+                401: `Unauthorized`,
+                403: `User is not a chat member or not the message author`,
+                404: `Chat or message not found, or the message is already deleted`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Edit own message
+     * The previous text is kept in the edit history. Participants receive
+     * `{"type": "message-updated", "message": Message}`.
+     *
+     * @param chatId
+     * @param messageId
+     * @param requestBody
+     * @returns Message Edited message
+     * @throws ApiError
+     */
+    public static editMessage(
+        chatId: string,
+        messageId: string,
+        requestBody: EditMessageRequest,
+    ): CancelablePromise<Message> {
+        return __request(OpenAPI, {
+            method: 'PATCH',
+            url: '/api/v1/chat/{chat_id}/messages/{message_id}',
+            path: {
+                'chat_id': chatId,
+                'message_id': messageId,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Empty message or a system message`,
+                401: `Unauthorized`,
+                403: `User is not a chat member or not the message author`,
+                404: `Chat or message not found, or the message is deleted`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Get a message with the messages around it
+     * The message and up to `limit` messages before and after it, oldest
+     * first. Used to jump to a search result.
+     *
+     * @param chatId
+     * @param messageId
+     * @param limit Number of messages to return before and after the message
+     * @returns Message Messages around the message, including it
+     * @throws ApiError
+     */
+    public static getMessageContext(
+        chatId: string,
+        messageId: string,
+        limit: number = 10,
+    ): CancelablePromise<Array<Message>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/chat/{chat_id}/messages/{message_id}/context',
+            path: {
+                'chat_id': chatId,
+                'message_id': messageId,
+            },
+            query: {
+                'limit': limit,
+            },
+            errors: {
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Chat or message not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Get edit history of a message
+     * Previous versions of the message text, oldest first
+     * @param chatId
+     * @param messageId
+     * @returns MessageEdit Previous versions of the text
+     * @throws ApiError
+     */
+    public static getMessageEdits(
+        chatId: string,
+        messageId: string,
+    ): CancelablePromise<Array<MessageEdit>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/chat/{chat_id}/messages/{message_id}/edits',
+            path: {
+                'chat_id': chatId,
+                'message_id': messageId,
+            },
+            errors: {
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Chat or message not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Remove own reaction from a message
+     * @param chatId
+     * @param messageId
+     * @param emoji
+     * @returns Message Message with updated reactions
+     * @throws ApiError
+     */
+    public static removeMessageReaction(
+        chatId: string,
+        messageId: string,
+        emoji: string,
+    ): CancelablePromise<Message> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/chat/{chat_id}/messages/{message_id}/reactions',
+            path: {
+                'chat_id': chatId,
+                'message_id': messageId,
+            },
+            query: {
+                'emoji': emoji,
+            },
+            errors: {
+                400: `Invalid emoji or a system message`,
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Chat or message not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * React to a message with an emoji
+     * A member leaves each emoji once; repeating a reaction changes nothing.
+     * Participants receive `{"type": "message-updated", "message": Message}`.
+     *
+     * @param chatId
+     * @param messageId
+     * @param requestBody
+     * @returns Message Message with updated reactions
+     * @throws ApiError
+     */
+    public static addMessageReaction(
+        chatId: string,
+        messageId: string,
+        requestBody: MessageReactionRequest,
+    ): CancelablePromise<Message> {
+        return __request(OpenAPI, {
+            method: 'PUT',
+            url: '/api/v1/chat/{chat_id}/messages/{message_id}/reactions',
+            path: {
+                'chat_id': chatId,
+                'message_id': messageId,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid emoji or a system message`,
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Chat or message not found, or the message is deleted`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Mark chat messages as read
+     * Marks the message and every earlier message as read by the current user.
+     * The mark never moves back. Other participants connected to the chat
+     * WebSocket receive an event `{"type": "read", ...ReadReceipt}`. The same
+     * can be sent over the WebSocket as `{"type": "read", "message_id": "..."}`.
+     *
+     * @param chatId
+     * @param requestBody
+     * @returns ReadReceipt Current read receipt of the user
+     * @throws ApiError
+     */
+    public static markChatRead(
+        chatId: string,
+        requestBody?: MarkChatReadRequest,
+    ): CancelablePromise<ReadReceipt> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/chat/{chat_id}/read',
+            path: {
+                'chat_id': chatId,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                401: `Unauthorized`,
+                403: `User is not a chat member`,
+                404: `Chat or message not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
     /**
      * WebSocket connection for real-time chat
      * @param chatId
@@ -138,6 +646,7 @@ export class ChatService {
             },
             errors: {
                 401: `Unauthorized`,
+                403: `User is not a chat member`,
                 404: `Chat not found`,
             },
         });
@@ -11,6 +11,7 @@ export type { ApiCreateCompany } from './models/ApiCreateCompany';
 export type { ApiGetCompany } from './models/ApiGetCompany';
 export type { ApiSearchCompanyResp } from './models/ApiSearchCompanyResp';
 export type { ApiUpdateCompany } from './models/ApiUpdateCompany';
+export type { ImageThumbnails } from './models/ImageThumbnails';
 export type { ShortCompany } from './models/ShortCompany';
 
 export { CompanyService } from './services/CompanyService';
@@ -2,6 +2,7 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { ImageThumbnails } from './ImageThumbnails';
 export type ApiGetCompany = {
     /**
      * GUID компании
@@ -35,6 +36,7 @@ export type ApiGetCompany = {
      * Логотип компании
      */
     avatar?: string;
+    avatar_thumbnails?: ImageThumbnails;
     /**
      * Уникальное название компании для короткой ссылки
      */
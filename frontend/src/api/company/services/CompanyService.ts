@@ -103,6 +103,42 @@ export class CompanyService {
             },
         });
     }
+    /**
+     * Загрузить логотип компании
+     * Изображение проверяется, метаданные (EXIF) удаляются, сохраняются копии
+     * нескольких размеров. Ссылка на большую копию становится логотипом компании.
+     *
+     * @param companyId GUID компании
+     * @param formData
+     * @returns ApiGetCompany successful operation
+     * @throws ApiError
+     */
+    public static uploadCompanyLogo(
+        companyId: string,
+        formData: {
+            /**
+             * Изображение JPEG, PNG, GIF или WebP
+             */
+            file?: Blob;
+        },
+    ): CancelablePromise<ApiGetCompany> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/company/{company_id}/logo',
+            path: {
+                'company_id': companyId,
+            },
+            formData: formData,
+            mediaType: 'multipart/form-data',
+            errors: {
+                400: `Файл не является изображением поддерживаемого формата`,
+                401: `Unauthorized`,
+                404: `Not found`,
+                413: `Изображение превышает допустимый размер или разрешение`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
     /**
      * Получить данные чужой компании по названию
      * @param name Часть названия компании
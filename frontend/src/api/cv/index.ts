@@ -8,9 +8,23 @@ export { OpenAPI } from './core/OpenAPI';
 export type { OpenAPIConfig } from './core/OpenAPI';
 
 export type { ApiUploadCVResp } from './models/ApiUploadCVResp';
+export type { Candidate } from './models/Candidate';
+export type { CVVersion } from './models/CVVersion';
+export { IngestionFile } from './models/IngestionFile';
+export { IngestionJob } from './models/IngestionJob';
+export type { MatchApplicantsResponse } from './models/MatchApplicantsResponse';
 export type { MatchCandidatesResponse } from './models/MatchCandidatesResponse';
+export type { MatchedApplicant } from './models/MatchedApplicant';
 export type { MatchedCandidate } from './models/MatchedCandidate';
+export type { MatchScoreBreakdown } from './models/MatchScoreBreakdown';
+export type { MergeCandidatesRequest } from './models/MergeCandidatesRequest';
+export type { ResumeFolder } from './models/ResumeFolder';
+export type { ResumeFolderRequest } from './models/ResumeFolderRequest';
 export type { ResumeRecord } from './models/ResumeRecord';
-export type { UploadDatabaseResponse } from './models/UploadDatabaseResponse';
+export type { ResumeSearchResult } from './models/ResumeSearchResult';
+export type { SignedFileURL } from './models/SignedFileURL';
+export type { SnippetFragment } from './models/SnippetFragment';
+export type { UpdateCVVersionRequest } from './models/UpdateCVVersionRequest';
+export type { UpdateResumeRequest } from './models/UpdateResumeRequest';
 
 export { CvService } from './services/CvService';
@@ -2,10 +2,12 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { CVVersion } from './CVVersion';
 export type ApiUploadCVResp = {
     /**
      * Ссылка на загруженное резюме
      */
     link: string;
+    version: CVVersion;
 };
 
@@ -0,0 +1,29 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Версия CV пользователя
+ */
+export type CVVersion = {
+    id: string;
+    /**
+     * Подпись версии, может быть пустой
+     */
+    label: string;
+    /**
+     * Ссылка на файл резюме
+     */
+    link: string;
+    /**
+     * Исходное имя загруженного файла; null для внешних ссылок
+     */
+    original_filename: string | null;
+    /**
+     * Основная версия показывается в профиле и прикладывается к откликам по умолчанию
+     */
+    is_primary: boolean;
+    created_at: string;
+    updated_at: string;
+};
+
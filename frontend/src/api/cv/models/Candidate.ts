@@ -0,0 +1,18 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { ResumeRecord } from './ResumeRecord';
+export type Candidate = {
+    id: string;
+    name: string;
+    email?: string | null;
+    phone?: string | null;
+    /**
+     * Версии резюме кандидата, новые первыми
+     */
+    resumes: Array<ResumeRecord>;
+    created_at: string;
+    updated_at: string;
+};
+
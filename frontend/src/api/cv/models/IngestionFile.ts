@@ -0,0 +1,51 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type IngestionFile = {
+    id: string;
+    file_name: string;
+    status: IngestionFile.status;
+    /**
+     * Этап, на котором обработка файла завершилась ошибкой
+     */
+    stage?: IngestionFile.stage | null;
+    /**
+     * Причина ошибки обработки файла
+     */
+    error?: string | null;
+    /**
+     * Файл можно поставить на повторную обработку
+     */
+    retryable: boolean;
+    /**
+     * Количество попыток обработки
+     */
+    attempts: number;
+    /**
+     * Идентификатор созданной записи в базе резюме (для пропущенного файла - уже существующей)
+     */
+    resume_id?: string | null;
+    updated_at: string;
+};
+export namespace IngestionFile {
+    export enum status {
+        PENDING = 'pending',
+        PROCESSING = 'processing',
+        SUCCEEDED = 'succeeded',
+        FAILED = 'failed',
+        SKIPPED = 'skipped',
+    }
+    /**
+     * Этап, на котором обработка файла завершилась ошибкой
+     */
+    export enum stage {
+        ARCHIVE = 'archive',
+        VALIDATION = 'validation',
+        STORAGE = 'storage',
+        EXTRACTION = 'extraction',
+        ANALYSIS = 'analysis',
+        DATABASE = 'database',
+    }
+}
+
@@ -0,0 +1,29 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { IngestionFile } from './IngestionFile';
+export type IngestionJob = {
+    id: string;
+    archive_name: string;
+    status: IngestionJob.status;
+    total_count: number;
+    processed_count: number;
+    successful_count: number;
+    failed_count: number;
+    /**
+     * Количество файлов, совпавших с уже загруженными резюме
+     */
+    skipped_count: number;
+    files: Array<IngestionFile>;
+    created_at: string;
+    updated_at: string;
+    finished_at?: string | null;
+};
+export namespace IngestionJob {
+    export enum status {
+        PROCESSING = 'processing',
+        COMPLETED = 'completed',
+    }
+}
+
@@ -0,0 +1,21 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { MatchedApplicant } from './MatchedApplicant';
+export type MatchApplicantsResponse = {
+    candidates: Array<MatchedApplicant>;
+    /**
+     * Количество пользователей, оценённых моделью
+     */
+    shortlist_size: number;
+    /**
+     * Результат взят из сохранённого подбора: вакансия и профили отобранных пользователей не менялись
+     */
+    cached: boolean;
+    /**
+     * Время, когда кандидаты были оценены
+     */
+    matched_at: string;
+};
+
@@ -5,5 +5,17 @@
 import type { MatchedCandidate } from './MatchedCandidate';
 export type MatchCandidatesResponse = {
     candidates: Array<MatchedCandidate>;
+    /**
+     * Количество резюме, отобранных поиском и оценённых моделью
+     */
+    shortlist_size: number;
+    /**
+     * Результат взят из сохранённого подбора: вакансия и отобранные резюме не менялись
+     */
+    cached: boolean;
+    /**
+     * Время, когда кандидаты были оценены
+     */
+    matched_at: string;
 };
 
@@ -0,0 +1,17 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Оценки кандидата по критериям от 1 до 100
+ */
+export type MatchScoreBreakdown = {
+    skills: number;
+    experience: number;
+    seniority: number;
+    /**
+     * Соответствие местоположения; null, если в резюме нет данных
+     */
+    location: number | null;
+};
+
@@ -0,0 +1,36 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { MatchScoreBreakdown } from './MatchScoreBreakdown';
+export type MatchedApplicant = {
+    profile_id: string;
+    /**
+     * ФИО пользователя
+     */
+    name: string;
+    email: string;
+    avatar: string | null;
+    /**
+     * Ссылка на резюме из профиля
+     */
+    cv_link: string | null;
+    /**
+     * Идентификатор отклика; null, если пользователь не откликался на вакансию
+     */
+    application_id: string | null;
+    /**
+     * Статус отклика
+     */
+    application_status: string | null;
+    /**
+     * Оценка соответствия от 1 до 100
+     */
+    match_score: number;
+    scores: MatchScoreBreakdown;
+    /**
+     * Объяснение почему кандидат подходит для вакансии
+     */
+    reasoning: string;
+};
+
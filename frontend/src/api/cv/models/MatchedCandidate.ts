@@ -2,6 +2,7 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { MatchScoreBreakdown } from './MatchScoreBreakdown';
 export type MatchedCandidate = {
     resume_id: string;
     candidate_name: string;
@@ -10,6 +11,7 @@ export type MatchedCandidate = {
      * Оценка соответствия от 1 до 100
      */
     match_score: number;
+    scores: MatchScoreBreakdown;
     /**
      * Объяснение почему кандидат подходит для вакансии
      */
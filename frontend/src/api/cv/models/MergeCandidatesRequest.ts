@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type MergeCandidatesRequest = {
+    /**
+     * Кандидат, который остаётся после объединения
+     */
+    target_id: string;
+    /**
+     * Кандидаты, резюме которых переносятся к целевому
+     */
+    source_ids: Array<string>;
+};
+
@@ -0,0 +1,18 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Папка (кадровый резерв) в базе резюме
+ */
+export type ResumeFolder = {
+    id: string;
+    name: string;
+    /**
+     * Количество резюме в папке
+     */
+    resume_count: number;
+    created_at: string;
+    updated_at: string;
+};
+
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ResumeFolderRequest = {
+    /**
+     * Название папки, уникальное в базе резюме пользователя
+     */
+    name: string;
+};
+
@@ -10,6 +10,28 @@ export type ResumeRecord = {
     experience_years: string;
     file_url: string;
     analysis: string;
+    /**
+     * Версия промпта, которым получен анализ
+     */
+    prompt_version?: string;
+    /**
+     * Кандидат, к которому относится резюме
+     */
+    candidate_id?: string | null;
+    email?: string | null;
+    phone?: string | null;
+    /**
+     * Теги рекрутера в нижнем регистре
+     */
+    tags: Array<string>;
+    /**
+     * Заметки рекрутера
+     */
+    notes: string;
+    /**
+     * Папки, в которых лежит резюме (только в списке базы резюме)
+     */
+    folder_ids?: Array<string>;
     created_at: string;
     updated_at: string;
 };
@@ -0,0 +1,18 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { ResumeRecord } from './ResumeRecord';
+import type { SnippetFragment } from './SnippetFragment';
+export type ResumeSearchResult = {
+    resume: ResumeRecord;
+    /**
+     * Релевантность резюме запросу; чем больше, тем выше в выдаче
+     */
+    score: number;
+    /**
+     * Фрагменты анализа с выделенными совпадениями
+     */
+    snippet: Array<SnippetFragment>;
+};
+
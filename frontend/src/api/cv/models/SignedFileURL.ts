@@ -0,0 +1,18 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Временная ссылка на файл резюме
+ */
+export type SignedFileURL = {
+    /**
+     * Ссылка на скачивание, не требующая авторизации
+     */
+    url: string;
+    /**
+     * Время, после которого ссылка перестаёт действовать
+     */
+    expires_at: string;
+};
+
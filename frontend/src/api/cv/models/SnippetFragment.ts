@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type SnippetFragment = {
+    text: string;
+    /**
+     * Фрагмент совпадает с поисковым запросом
+     */
+    highlight: boolean;
+};
+
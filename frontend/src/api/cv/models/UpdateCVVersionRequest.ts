@@ -0,0 +1,18 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Отсутствующие поля не меняются
+ */
+export type UpdateCVVersionRequest = {
+    /**
+     * Подпись версии, до 100 символов
+     */
+    label?: string;
+    /**
+     * Только true - сделать версию основной
+     */
+    is_primary?: boolean;
+};
+
@@ -0,0 +1,18 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Отсутствующие поля не меняются
+ */
+export type UpdateResumeRequest = {
+    /**
+     * Теги резюме, заменяют текущие; не больше 20 тегов до 50 символов
+     */
+    tags?: Array<string>;
+    /**
+     * Заметки рекрутера, до 10000 символов
+     */
+    notes?: string;
+};
+
@@ -3,15 +3,26 @@
 /* tslint:disable */
 /* eslint-disable */
 import type { ApiUploadCVResp } from '../models/ApiUploadCVResp';
+import type { Candidate } from '../models/Candidate';
+import type { CVVersion } from '../models/CVVersion';
+import type { IngestionJob } from '../models/IngestionJob';
+import type { MatchApplicantsResponse } from '../models/MatchApplicantsResponse';
 import type { MatchCandidatesResponse } from '../models/MatchCandidatesResponse';
+import type { MergeCandidatesRequest } from '../models/MergeCandidatesRequest';
+import type { ResumeFolder } from '../models/ResumeFolder';
+import type { ResumeFolderRequest } from '../models/ResumeFolderRequest';
 import type { ResumeRecord } from '../models/ResumeRecord';
-import type { UploadDatabaseResponse } from '../models/UploadDatabaseResponse';
+import type { ResumeSearchResult } from '../models/ResumeSearchResult';
+import type { SignedFileURL } from '../models/SignedFileURL';
+import type { UpdateCVVersionRequest } from '../models/UpdateCVVersionRequest';
+import type { UpdateResumeRequest } from '../models/UpdateResumeRequest';
 import type { CancelablePromise } from '../core/CancelablePromise';
 import { OpenAPI } from '../core/OpenAPI';
 import { request as __request } from '../core/request';
 export class CvService {
     /**
      * Загрузить резюме
+     * Загруженный файл сохраняется как новая версия CV пользователя
      * @param formData
      * @returns ApiUploadCVResp successful operation
      * @throws ApiError
@@ -19,6 +30,14 @@ export class CvService {
     public static uploadCv(
         formData?: {
             file?: Blob;
+            /**
+             * Подпись версии, например "Backend CV", до 100 символов
+             */
+            label?: string;
+            /**
+             * Сделать версию основной; первая версия пользователя всегда основная
+             */
+            primary?: boolean;
         },
     ): CancelablePromise<ApiUploadCVResp> {
         return __request(OpenAPI, {
@@ -26,9 +45,80 @@ export class CvService {
             url: '/api/v1/cv/upload',
             formData: formData,
             mediaType: 'multipart/form-data',
+            errors: {
+                400: `Недопустимый формат файла или содержимое не соответствует расширению`,
+                401: `Unauthorized`,
+                413: `Файл превышает допустимый размер`,
+                422: `Файл отклонён антивирусом`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Список версий CV
+     * Основная версия идёт первой, остальные - от новых к старым
+     * @returns CVVersion successful operation
+     * @throws ApiError
+     */
+    public static listCvVersions(): CancelablePromise<Array<CVVersion>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cv/versions',
+            errors: {
+                401: `Unauthorized`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Изменить подпись версии CV или сделать её основной
+     * @param id
+     * @param requestBody
+     * @returns CVVersion successful operation
+     * @throws ApiError
+     */
+    public static updateCvVersion(
+        id: string,
+        requestBody: UpdateCVVersionRequest,
+    ): CancelablePromise<CVVersion> {
+        return __request(OpenAPI, {
+            method: 'PATCH',
+            url: '/api/v1/cv/versions/{id}',
+            path: {
+                'id': id,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
             errors: {
                 400: `Bad request`,
                 401: `Unauthorized`,
+                404: `CV version not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Удалить версию CV
+     * Файл удаляется из хранилища, если больше нигде не используется. Если
+     * удалена основная версия, основной становится последняя загруженная.
+     *
+     * @param id
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteCvVersion(
+        id: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/cv/versions/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `CV version not found`,
+                409: `Версия приложена к отклику, который ещё рассматривается`,
                 500: `Internal Server Error`,
             },
         });
@@ -36,43 +126,211 @@ export class CvService {
     /**
      * Загрузить архив с базой резюме
      * @param formData
-     * @returns UploadDatabaseResponse Архив обработан успешно
+     * @returns IngestionJob Архив принят, файлы поставлены в очередь на обработку
      * @throws ApiError
      */
     public static uploadResumeDatabase(
         formData?: {
             /**
-             * ZIP архив содержащий PDF, TXT, DOC, DOCX файлы резюме
+             * ZIP архив содержащий PDF, TXT, DOC, DOCX, RTF, ODT файлы резюме
              */
             archive?: Blob;
         },
-    ): CancelablePromise<UploadDatabaseResponse> {
+    ): CancelablePromise<IngestionJob> {
         return __request(OpenAPI, {
             method: 'POST',
             url: '/api/v1/cv/database/upload',
             formData: formData,
             mediaType: 'multipart/form-data',
             errors: {
-                400: `Bad request`,
+                400: `Повреждённый архив`,
+                401: `Unauthorized`,
+                413: `Архив превышает допустимый размер, число файлов или объём после распаковки`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Получить статус обработки архива с резюме
+     * @param id
+     * @returns IngestionJob successful operation
+     * @throws ApiError
+     */
+    public static getIngestionJob(
+        id: string,
+    ): CancelablePromise<IngestionJob> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cv/database/jobs/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
                 401: `Unauthorized`,
+                404: `Ingestion job not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Повторить обработку файлов, завершившихся ошибкой
+     * @param id
+     * @returns IngestionJob Файлы с ошибками поставлены в очередь повторно
+     * @throws ApiError
+     */
+    public static retryIngestionJob(
+        id: string,
+    ): CancelablePromise<IngestionJob> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cv/database/jobs/{id}/retry',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Ingestion job not found`,
                 500: `Internal Server Error`,
             },
         });
     }
     /**
      * Получить базу резюме пользователя
+     * @param tag Только резюме с этим тегом
+     * @param folderId Только резюме из этой папки
      * @param limit
      * @param offset
      * @returns ResumeRecord successful operation
      * @throws ApiError
      */
     public static getResumeDatabase(
+        tag?: string,
+        folderId?: string,
         limit: number = 20,
         offset?: number,
     ): CancelablePromise<Array<ResumeRecord>> {
         return __request(OpenAPI, {
             method: 'GET',
             url: '/api/v1/cv/database',
+            query: {
+                'tag': tag,
+                'folder_id': folderId,
+                'limit': limit,
+                'offset': offset,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Folder not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Изменить теги и заметки резюме
+     * @param id
+     * @param requestBody
+     * @returns ResumeRecord successful operation
+     * @throws ApiError
+     */
+    public static updateResume(
+        id: string,
+        requestBody: UpdateResumeRequest,
+    ): CancelablePromise<ResumeRecord> {
+        return __request(OpenAPI, {
+            method: 'PATCH',
+            url: '/api/v1/cv/database/{id}',
+            path: {
+                'id': id,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Bad request`,
+                401: `Unauthorized`,
+                404: `Resume not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Удалить резюме из базы
+     * Удаляет резюме вместе с результатами подбора и файлом в хранилище, если файл больше нигде не используется
+     * @param id
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteResume(
+        id: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/cv/database/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Resume not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Поиск по базе резюме
+     * Гибридный поиск - полнотекстовое ранжирование объединяется с близостью эмбеддингов (если они включены)
+     * @param q Поисковый запрос - навыки, должность, опыт
+     * @param minAge Минимальный возраст кандидата
+     * @param maxAge Максимальный возраст кандидата
+     * @param minExperience Минимальный стаж в годах
+     * @param maxExperience Максимальный стаж в годах
+     * @param limit
+     * @param offset
+     * @returns ResumeSearchResult successful operation
+     * @throws ApiError
+     */
+    public static searchResumeDatabase(
+        q?: string,
+        minAge?: number,
+        maxAge?: number,
+        minExperience?: number,
+        maxExperience?: number,
+        limit: number = 20,
+        offset?: number,
+    ): CancelablePromise<Array<ResumeSearchResult>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cv/database/search',
+            query: {
+                'q': q,
+                'min_age': minAge,
+                'max_age': maxAge,
+                'min_experience': minExperience,
+                'max_experience': maxExperience,
+                'limit': limit,
+                'offset': offset,
+            },
+            errors: {
+                400: `Bad request`,
+                401: `Unauthorized`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Получить кандидатов из базы резюме
+     * Версии резюме одного человека (совпадение email, телефона или ФИО) объединяются в одного кандидата
+     * @param limit
+     * @param offset
+     * @returns Candidate successful operation
+     * @throws ApiError
+     */
+    public static getCandidates(
+        limit: number = 20,
+        offset?: number,
+    ): CancelablePromise<Array<Candidate>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cv/database/candidates',
             query: {
                 'limit': limit,
                 'offset': offset,
@@ -83,14 +341,180 @@ export class CvService {
             },
         });
     }
+    /**
+     * Объединить кандидатов
+     * Переносит резюме исходных кандидатов к целевому и удаляет исходных кандидатов
+     * @param requestBody
+     * @returns Candidate successful operation
+     * @throws ApiError
+     */
+    public static mergeCandidates(
+        requestBody: MergeCandidatesRequest,
+    ): CancelablePromise<Candidate> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cv/database/candidates/merge',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Bad request`,
+                401: `Unauthorized`,
+                404: `Candidate not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Получить папки базы резюме
+     * @returns ResumeFolder successful operation
+     * @throws ApiError
+     */
+    public static getResumeFolders(): CancelablePromise<Array<ResumeFolder>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cv/database/folders',
+            errors: {
+                401: `Unauthorized`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Создать папку в базе резюме
+     * @param requestBody
+     * @returns ResumeFolder Папка создана
+     * @throws ApiError
+     */
+    public static createResumeFolder(
+        requestBody: ResumeFolderRequest,
+    ): CancelablePromise<ResumeFolder> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cv/database/folders',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Bad request`,
+                401: `Unauthorized`,
+                409: `Folder with this name already exists`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Переименовать папку
+     * @param id
+     * @param requestBody
+     * @returns ResumeFolder successful operation
+     * @throws ApiError
+     */
+    public static renameResumeFolder(
+        id: string,
+        requestBody: ResumeFolderRequest,
+    ): CancelablePromise<ResumeFolder> {
+        return __request(OpenAPI, {
+            method: 'PUT',
+            url: '/api/v1/cv/database/folders/{id}',
+            path: {
+                'id': id,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Bad request`,
+                401: `Unauthorized`,
+                404: `Folder not found`,
+                409: `Folder with this name already exists`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Удалить папку
+     * Резюме из папки остаются в базе
+     * @param id
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteResumeFolder(
+        id: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/cv/database/folders/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Folder not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Добавить резюме в папку
+     * @param id
+     * @param resumeId
+     * @returns void
+     * @throws ApiError
+     */
+    public static addResumeToFolder(
+        id: string,
+        resumeId: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'PUT',
+            url: '/api/v1/cv/database/folders/{id}/resumes/{resume_id}',
+            path: {
+                'id': id,
+                'resume_id': resumeId,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Folder or resume not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Убрать резюме из папки
+     * @param id
+     * @param resumeId
+     * @returns void
+     * @throws ApiError
+     */
+    public static removeResumeFromFolder(
+        id: string,
+        resumeId: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/cv/database/folders/{id}/resumes/{resume_id}',
+            path: {
+                'id': id,
+                'resume_id': resumeId,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Folder not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
     /**
      * Подобрать кандидатов из базы резюме для вакансии
+     * Поиск отбирает наиболее близкие к вакансии резюме, модель оценивает их пачками по критериям. Результат сохраняется и возвращается повторно, пока не изменились вакансия и отобранные резюме
      * @param jobId
+     * @param limit Количество лучших кандидатов в ответе
+     * @param refresh Оценить кандидатов заново, не используя сохранённый подбор
      * @returns MatchCandidatesResponse successful operation
      * @throws ApiError
      */
     public static matchCandidatesFromDatabase(
         jobId: string,
+        limit: number = 5,
+        refresh: boolean = false,
     ): CancelablePromise<MatchCandidatesResponse> {
         return __request(OpenAPI, {
             method: 'POST',
@@ -98,22 +522,74 @@ export class CvService {
             path: {
                 'job_id': jobId,
             },
+            query: {
+                'limit': limit,
+                'refresh': refresh,
+            },
+            errors: {
+                400: `Invalid limit`,
+                401: `Unauthorized`,
+                403: `Forbidden - not job author`,
+                404: `Job not found`,
+                500: `Internal Server Error`,
+                502: `AI service returned a response that does not match the schema`,
+                503: `AI service is unavailable`,
+            },
+        });
+    }
+    /**
+     * Подобрать кандидатов среди пользователей платформы для вакансии
+     * Модель оценивает откликнувшихся на вакансию пользователей по опыту работы из профиля и загруженному резюме. С include_profiles в оценку попадают также пользователи, разрешившие подбор в профиле. Результат сохраняется и возвращается повторно, пока не изменились вакансия и профили отобранных пользователей
+     * @param jobId
+     * @param limit Количество лучших кандидатов в ответе
+     * @param includeProfiles Оценить также пользователей, открытых к предложениям, но не откликавшихся на вакансию
+     * @param refresh Оценить кандидатов заново, не используя сохранённый подбор
+     * @returns MatchApplicantsResponse successful operation
+     * @throws ApiError
+     */
+    public static matchApplicants(
+        jobId: string,
+        limit: number = 5,
+        includeProfiles: boolean = false,
+        refresh: boolean = false,
+    ): CancelablePromise<MatchApplicantsResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cv/applicants/match/{job_id}',
+            path: {
+                'job_id': jobId,
+            },
+            query: {
+                'limit': limit,
+                'include_profiles': includeProfiles,
+                'refresh': refresh,
+            },
             errors: {
+                400: `Invalid limit`,
                 401: `Unauthorized`,
                 403: `Forbidden - not job author`,
                 404: `Job not found`,
                 500: `Internal Server Error`,
+                502: `AI service returned a response that does not match the schema`,
+                503: `AI service is unavailable`,
             },
         });
     }
     /**
      * Получить резюме по имени файла
+     * Файл доступен владельцу, автору вакансии, на которую откликнулся владелец резюме,
+     * и владельцу базы резюме. По подписанной ссылке (expires и signature) файл отдаётся без авторизации.
+     *
      * @param filename Имя файла резюме
-     * @returns binary successful operation
+     * @param expires Срок действия подписанной ссылки (Unix time)
+     * @param signature Подпись ссылки, выданной getCVSignedURL
+     * @returns binary Файл с исходным именем в Content-Disposition
      * @throws ApiError
      */
     public static getCvByFilename(
         filename: string,
+        expires?: string,
+        signature?: string,
     ): CancelablePromise<Blob> {
         return __request(OpenAPI, {
             method: 'GET',
@@ -121,9 +597,38 @@ export class CvService {
             path: {
                 'filename': filename,
             },
+            query: {
+                'expires': expires,
+                'signature': signature,
+            },
             errors: {
                 400: `Bad request`,
                 401: `Unauthorized`,
+                403: `Нет доступа к файлу или ссылка недействительна`,
+                404: `CV file not found`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
+    /**
+     * Получить временную ссылку на резюме
+     * Ссылку можно открыть в браузере без заголовка авторизации, пока не истёк её срок
+     * @param filename Имя файла резюме
+     * @returns SignedFileURL successful operation
+     * @throws ApiError
+     */
+    public static getCvSignedUrl(
+        filename: string,
+    ): CancelablePromise<SignedFileURL> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cv/{filename}/url',
+            path: {
+                'filename': filename,
+            },
+            errors: {
+                401: `Unauthorized`,
+                403: `Нет доступа к файлу`,
                 404: `CV file not found`,
                 500: `Internal Server Error`,
             },
@@ -8,12 +8,21 @@ export { OpenAPI } from './core/OpenAPI';
 export type { OpenAPIConfig } from './core/OpenAPI';
 
 export type { ApplicantProfile } from './models/ApplicantProfile';
+export type { ApplicationCV } from './models/ApplicationCV';
 export { ApplicationStatus } from './models/ApplicationStatus';
+export type { ApplyToJobRequest } from './models/ApplyToJobRequest';
+export type { ClaimApplicationRequest } from './models/ClaimApplicationRequest';
 export { CreateJobRequest } from './models/CreateJobRequest';
 export { Job } from './models/Job';
 export { JobApplication } from './models/JobApplication';
 export type { JobDetails } from './models/JobDetails';
 export type { JobWithApplications } from './models/JobWithApplications';
+export type { MatchScoreBreakdown } from './models/MatchScoreBreakdown';
+export type { RecommendedJob } from './models/RecommendedJob';
+export type { RecommendedJobsResponse } from './models/RecommendedJobsResponse';
+export type { SourceCandidateRequest } from './models/SourceCandidateRequest';
+export type { SourcedApplicationResponse } from './models/SourcedApplicationResponse';
+export type { SourcedCandidate } from './models/SourcedCandidate';
 export { UpdateApplicationStatusRequest } from './models/UpdateApplicationStatusRequest';
 export { UpdateJobRequest } from './models/UpdateJobRequest';
 
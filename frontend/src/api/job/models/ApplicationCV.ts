@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * CV version the candidate applied with
+ */
+export type ApplicationCV = {
+    id: string;
+    /**
+     * Version label set by the candidate, may be empty
+     */
+    label: string;
+    link: string;
+};
+
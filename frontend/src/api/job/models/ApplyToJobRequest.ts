@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ApplyToJobRequest = {
+    /**
+     * CV version to attach; the primary version is used if omitted
+     */
+    cv_id?: string;
+};
+
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ClaimApplicationRequest = {
+    /**
+     * Token from the invitation link
+     */
+    token: string;
+};
+
@@ -3,13 +3,24 @@
 /* tslint:disable */
 /* eslint-disable */
 import type { ApplicantProfile } from './ApplicantProfile';
+import type { ApplicationCV } from './ApplicationCV';
+import type { SourcedCandidate } from './SourcedCandidate';
 export type JobApplication = {
     id: string;
     job_id: string;
-    applicant_id: string;
-    applicant_profile: ApplicantProfile;
+    /**
+     * Null for a candidate from the resume database who has not accepted the invitation yet
+     */
+    applicant_id: string | null;
+    applicant_profile: ApplicantProfile | null;
     applied_at: string;
     status: JobApplication.status;
+    /**
+     * platform - the user applied, database - HR added a candidate from the resume database
+     */
+    source: JobApplication.source;
+    sourced_candidate?: SourcedCandidate;
+    cv?: ApplicationCV;
 };
 export namespace JobApplication {
     export enum status {
@@ -18,5 +29,12 @@ export namespace JobApplication {
         ACCEPTED = 'accepted',
         REJECTED = 'rejected',
     }
+    /**
+     * platform - the user applied, database - HR added a candidate from the resume database
+     */
+    export enum source {
+        PLATFORM = 'platform',
+        DATABASE = 'database',
+    }
 }
 
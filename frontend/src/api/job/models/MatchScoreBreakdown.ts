@@ -0,0 +1,17 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Per-criterion scores from 1 to 100
+ */
+export type MatchScoreBreakdown = {
+    skills: number;
+    experience: number;
+    seniority: number;
+    /**
+     * Location fit; null if the candidate's location is unknown
+     */
+    location: number | null;
+};
+
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { Job } from './Job';
+import type { MatchScoreBreakdown } from './MatchScoreBreakdown';
+export type RecommendedJob = {
+    job: Job;
+    /**
+     * Match score from 1 to 100
+     */
+    match_score: number;
+    scores: MatchScoreBreakdown;
+    /**
+     * Why the job was recommended
+     */
+    reasoning: string;
+};
+
@@ -0,0 +1,21 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { RecommendedJob } from './RecommendedJob';
+export type RecommendedJobsResponse = {
+    jobs: Array<RecommendedJob>;
+    /**
+     * Number of jobs shortlisted by search and scored by the model
+     */
+    shortlist_size: number;
+    /**
+     * The result was reused: the profile and the shortlisted jobs have not changed
+     */
+    cached: boolean;
+    /**
+     * When the jobs were scored
+     */
+    matched_at: string;
+};
+
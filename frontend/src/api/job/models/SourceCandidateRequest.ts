@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type SourceCandidateRequest = {
+    /**
+     * Resume from the resume database of the job author
+     */
+    resume_id: string;
+    /**
+     * Send the candidate an invitation email with the claim link
+     */
+    send_email?: boolean;
+    /**
+     * Personal message added to the invitation email
+     */
+    message?: string | null;
+};
+
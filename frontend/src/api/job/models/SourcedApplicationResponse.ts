@@ -0,0 +1,17 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { JobApplication } from './JobApplication';
+export type SourcedApplicationResponse = {
+    application: JobApplication;
+    /**
+     * Link for the candidate to claim the application. It is shown only once
+     */
+    claim_url: string;
+    /**
+     * The invitation email was sent
+     */
+    email_sent: boolean;
+};
+
@@ -0,0 +1,24 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Candidate from the resume database added to the job by HR
+ */
+export type SourcedCandidate = {
+    /**
+     * Null if the resume was deleted from the database
+     */
+    resume_id: string | null;
+    name: string;
+    email: string | null;
+    /**
+     * When the invitation email was sent
+     */
+    invited_at: string | null;
+    /**
+     * When the candidate accepted the invitation
+     */
+    claimed_at: string | null;
+};
+
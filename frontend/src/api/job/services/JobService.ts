@@ -3,11 +3,16 @@
 /* tslint:disable */
 /* eslint-disable */
 import type { ApplicationStatus } from '../models/ApplicationStatus';
+import type { ApplyToJobRequest } from '../models/ApplyToJobRequest';
+import type { ClaimApplicationRequest } from '../models/ClaimApplicationRequest';
 import type { CreateJobRequest } from '../models/CreateJobRequest';
 import type { Job } from '../models/Job';
 import type { JobApplication } from '../models/JobApplication';
 import type { JobDetails } from '../models/JobDetails';
 import type { JobWithApplications } from '../models/JobWithApplications';
+import type { RecommendedJobsResponse } from '../models/RecommendedJobsResponse';
+import type { SourceCandidateRequest } from '../models/SourceCandidateRequest';
+import type { SourcedApplicationResponse } from '../models/SourcedApplicationResponse';
 import type { UpdateApplicationStatusRequest } from '../models/UpdateApplicationStatusRequest';
 import type { UpdateJobRequest } from '../models/UpdateJobRequest';
 import type { CancelablePromise } from '../core/CancelablePromise';
@@ -60,6 +65,30 @@ export class JobService {
             },
         });
     }
+    /**
+     * Claim an application from an invitation
+     * Links the application of a candidate added from the resume database to the current user. The invitation link works once and expires after 30 days
+     * @param requestBody
+     * @returns Job Application claimed
+     * @throws ApiError
+     */
+    public static claimSourcedApplication(
+        requestBody: ClaimApplicationRequest,
+    ): CancelablePromise<Job> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/job/applications/claim',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Cannot apply to your own job`,
+                401: `Unauthorized`,
+                404: `Invitation not found or expired`,
+                409: `Already applied to this job`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
     /**
      * Get my published jobs
      * @param limit
@@ -84,6 +113,35 @@ export class JobService {
             },
         });
     }
+    /**
+     * Get jobs recommended for me
+     * Active jobs the user has not applied to, ranked against their profile, work experience and uploaded CV. Each job comes with an explanation of why it was recommended. The result is reused until the profile or the shortlisted jobs change
+     * @param limit Number of best jobs in the response
+     * @param refresh Score jobs again instead of reusing the saved recommendations
+     * @returns RecommendedJobsResponse Successful operation
+     * @throws ApiError
+     */
+    public static getRecommendedJobs(
+        limit: number = 10,
+        refresh: boolean = false,
+    ): CancelablePromise<RecommendedJobsResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/job/recommended',
+            query: {
+                'limit': limit,
+                'refresh': refresh,
+            },
+            errors: {
+                400: `Invalid limit`,
+                401: `Unauthorized`,
+                404: `Profile not found`,
+                500: `Internal Server Error`,
+                502: `AI service returned a response that does not match the schema`,
+                503: `AI service is unavailable`,
+            },
+        });
+    }
     /**
      * Get job by ID
      * @param jobId
@@ -158,11 +216,13 @@ export class JobService {
     /**
      * Apply to job
      * @param jobId
+     * @param requestBody
      * @returns any Application submitted
      * @throws ApiError
      */
     public static applyToJob(
         jobId: string,
+        requestBody?: ApplyToJobRequest,
     ): CancelablePromise<any> {
         return __request(OpenAPI, {
             method: 'POST',
@@ -170,8 +230,10 @@ export class JobService {
             path: {
                 'job_id': jobId,
             },
+            body: requestBody,
+            mediaType: 'application/json',
             errors: {
-                400: `Already applied`,
+                400: `Already applied or CV version not found`,
                 401: `Unauthorized`,
                 404: `Job not found`,
                 500: `Internal Server Error`,
@@ -234,7 +296,7 @@ export class JobService {
     /**
      * Update job application status (for job author)
      * @param jobId
-     * @param applicantId
+     * @param applicantId Applicant ID, or application ID for a candidate from the resume database who has not accepted the invitation yet
      * @param requestBody
      * @returns JobApplication Application status updated
      * @throws ApiError
@@ -261,4 +323,34 @@ export class JobService {
             },
         });
     }
+    /**
+     * Add a candidate from the resume database to the job (for job author)
+     * Creates an application with source "database" that is not linked to a user until the candidate claims it by the link. The link is returned once and can be sent to the candidate by email
+     * @param jobId
+     * @param requestBody
+     * @returns SourcedApplicationResponse Candidate added
+     * @throws ApiError
+     */
+    public static sourceCandidate(
+        jobId: string,
+        requestBody: SourceCandidateRequest,
+    ): CancelablePromise<SourcedApplicationResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/job/{job_id}/sourced',
+            path: {
+                'job_id': jobId,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Job is not active, candidate has no email or email is not configured`,
+                401: `Unauthorized`,
+                403: `Forbidden`,
+                404: `Job or resume not found`,
+                409: `Candidate already added to this job`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
 }
@@ -12,6 +12,7 @@ export type { ApiGetProfile } from './models/ApiGetProfile';
 export type { ApiSearchProfileResp } from './models/ApiSearchProfileResp';
 export type { ApiUpdateProfile } from './models/ApiUpdateProfile';
 export type { Experience } from './models/Experience';
+export type { ImageThumbnails } from './models/ImageThumbnails';
 export type { ShortProfile } from './models/ShortProfile';
 
 export { DefaultService } from './services/DefaultService';
@@ -2,6 +2,7 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { ImageThumbnails } from './ImageThumbnails';
 export type ApiGetProfile = {
     /**
      * GUID пользователя
@@ -35,6 +36,7 @@ export type ApiGetProfile = {
      * Ссылка на аватар пользователя
      */
     avatar?: string;
+    avatar_thumbnails?: ImageThumbnails;
     /**
      * Ссылка на резюме пользователя
      */
@@ -45,5 +47,9 @@ export type ApiGetProfile = {
      * Название компании в которой работает пользователь
      */
     company_name?: string;
+    /**
+     * Профиль участвует в подборе кандидатов на вакансии, на которые пользователь не откликался
+     */
+    open_to_offers?: boolean;
 };
 
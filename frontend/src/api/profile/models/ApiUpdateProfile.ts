@@ -31,5 +31,9 @@ export type ApiUpdateProfile = {
      * Ссылка на резюме пользователя
      */
     cv?: string;
+    /**
+     * Профиль участвует в подборе кандидатов на вакансии, на которые пользователь не откликался
+     */
+    open_to_offers?: boolean;
 };
 
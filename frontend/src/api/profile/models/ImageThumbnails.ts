@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ImageThumbnails = {
+    /**
+     * Ссылка на копию 64x64
+     */
+    small: string;
+    /**
+     * Ссылка на копию 256x256
+     */
+    medium: string;
+    /**
+     * Ссылка на копию 512x512
+     */
+    large: string;
+};
+
@@ -62,6 +62,37 @@ export class ProfileService {
             },
         });
     }
+    /**
+     * Загрузить аватар
+     * Изображение проверяется, метаданные (EXIF) удаляются, сохраняются копии
+     * нескольких размеров. Ссылка на большую копию становится аватаром профиля.
+     *
+     * @param formData
+     * @returns ApiGetProfile successful operation
+     * @throws ApiError
+     */
+    public static uploadOwnAvatar(
+        formData: {
+            /**
+             * Изображение JPEG, PNG, GIF или WebP
+             */
+            file?: Blob;
+        },
+    ): CancelablePromise<ApiGetProfile> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/profile/avatar',
+            formData: formData,
+            mediaType: 'multipart/form-data',
+            errors: {
+                400: `Файл не является изображением поддерживаемого формата`,
+                401: `Unauthorized`,
+                404: `Not found`,
+                413: `Изображение превышает допустимый размер или разрешение`,
+                500: `Internal Server Error`,
+            },
+        });
+    }
     /**
      * Найти данные чужого профиля по части ФИО
      * @param description Часть ФИО
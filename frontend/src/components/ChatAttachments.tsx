@@ -0,0 +1,112 @@
+import { useState, useEffect } from 'react';
+import { Box, Chip, CircularProgress } from '@mui/material';
+import {
+  AttachFile as AttachFileIcon,
+  Description as DescriptionIcon,
+  Image as ImageIcon,
+} from '@mui/icons-material';
+import { apiClient } from '../api/config';
+import { AttachmentKind } from '../api/chat';
+import type { Attachment } from '../api/chat';
+
+// Вложения отдаются только участникам чата с авторизацией, поэтому файл
+// загружается через apiClient и показывается по локальной ссылке
+const loadAttachment = async (url: string) => {
+  const response = await apiClient.get<Blob>(url, { responseType: 'blob' });
+  return URL.createObjectURL(response.data);
+};
+
+export const formatFileSize = (size: number) => {
+  if (size < 1024) return `${size} Б`;
+  if (size < 1024 * 1024) return `${(size / 1024).toFixed(1)} КБ`;
+  return `${(size / 1024 / 1024).toFixed(1)} МБ`;
+};
+
+export const attachmentIcon = (kind: AttachmentKind) => {
+  switch (kind) {
+    case AttachmentKind.IMAGE:
+      return <ImageIcon />;
+    case AttachmentKind.CV:
+      return <DescriptionIcon />;
+    default:
+      return <AttachFileIcon />;
+  }
+};
+
+const downloadAttachment = async (attachment: Attachment) => {
+  const objectUrl = await loadAttachment(attachment.url);
+  const link = document.createElement('a');
+  link.href = objectUrl;
+  link.download = attachment.filename;
+  link.click();
+  URL.revokeObjectURL(objectUrl);
+};
+
+const ImagePreview = ({ attachment }: { attachment: Attachment }) => {
+  const [src, setSrc] = useState<string | null>(null);
+  const [failed, setFailed] = useState(false);
+
+  useEffect(() => {
+    if (!attachment.preview_url) return;
+    let objectUrl: string | null = null;
+    let cancelled = false;
+    loadAttachment(attachment.preview_url)
+      .then((url) => {
+        objectUrl = url;
+        if (cancelled) URL.revokeObjectURL(url);
+        else setSrc(url);
+      })
+      .catch((err) => {
+        console.error('Error loading attachment preview:', err);
+        if (!cancelled) setFailed(true);
+      });
+    return () => {
+      cancelled = true;
+      if (objectUrl) URL.revokeObjectURL(objectUrl);
+    };
+  }, [attachment.preview_url]);
+
+  if (failed || !attachment.preview_url) {
+    return <AttachmentChip attachment={attachment} />;
+  }
+  if (!src) {
+    return <CircularProgress size={24} />;
+  }
+  return (
+    <Box
+      component="img"
+      src={src}
+      alt={attachment.filename}
+      title={attachment.filename}
+      onClick={() => downloadAttachment(attachment).catch((err) => console.error('Error downloading attachment:', err))}
+      sx={{ display: 'block', maxWidth: 240, maxHeight: 240, borderRadius: 1, cursor: 'pointer' }}
+    />
+  );
+};
+
+const AttachmentChip = ({ attachment }: { attachment: Attachment }) => (
+  <Chip
+    icon={attachmentIcon(attachment.kind)}
+    label={`${attachment.filename} · ${formatFileSize(attachment.size)}`}
+    onClick={() => downloadAttachment(attachment).catch((err) => console.error('Error downloading attachment:', err))}
+    sx={{ maxWidth: '100%', bgcolor: 'background.paper' }}
+  />
+);
+
+interface MessageAttachmentsProps {
+  attachments?: Attachment[];
+}
+
+// Вложения сообщения: превью изображений и файлы для скачивания
+export const MessageAttachments = ({ attachments }: MessageAttachmentsProps) => {
+  if (!attachments || attachments.length === 0) return null;
+  return (
+    <Box sx={{ display: 'flex', flexDirection: 'column', gap: 1, mt: 1 }}>
+      {attachments.map((attachment) => attachment.kind === AttachmentKind.IMAGE ? (
+        <ImagePreview key={attachment.id} attachment={attachment} />
+      ) : (
+        <AttachmentChip key={attachment.id} attachment={attachment} />
+      ))}
+    </Box>
+  );
+};
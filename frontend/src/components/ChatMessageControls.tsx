@@ -0,0 +1,212 @@
+import { useState, useEffect } from 'react';
+import {
+  Box,
+  Chip,
+  Dialog,
+  DialogTitle,
+  DialogContent,
+  DialogActions,
+  Button,
+  IconButton,
+  List,
+  ListItem,
+  ListItemText,
+  Menu,
+  MenuItem,
+  Popover,
+  Typography,
+  Alert,
+} from '@mui/material';
+import {
+  MoreVert as MoreVertIcon,
+  AddReaction as AddReactionIcon,
+} from '@mui/icons-material';
+import { ChatService } from '../api/chat';
+import type { ChatUser, Message, MessageEdit, MessageReply } from '../api/chat';
+
+const QUICK_REACTIONS = ['👍', '❤️', '😂', '😮', '😢', '🙏'];
+
+interface MessageQuoteProps {
+  reply: MessageReply;
+  users: ChatUser[];
+  onClick?: () => void;
+}
+
+// Цитата сообщения, на которое отвечают
+export const MessageQuote = ({ reply, users, onClick }: MessageQuoteProps) => (
+  <Box
+    onClick={onClick}
+    sx={{
+      borderLeft: 3,
+      borderColor: 'divider',
+      pl: 1,
+      mb: 0.5,
+      opacity: 0.8,
+      cursor: onClick ? 'pointer' : 'default',
+    }}
+  >
+    <Typography variant="caption" sx={{ fontWeight: 'bold', display: 'block' }}>
+      {users.find((u) => u.id === reply.user_id)?.description || 'Участник'}
+    </Typography>
+    <Typography variant="caption" noWrap sx={{ display: 'block', fontStyle: reply.deleted ? 'italic' : 'normal' }}>
+      {reply.deleted ? 'Сообщение удалено' : reply.text || '📎 Вложение'}
+    </Typography>
+  </Box>
+);
+
+interface MessageReactionsProps {
+  message: Message;
+  currentUserId: string | null;
+  onToggle: (emoji: string, reacted: boolean) => void;
+}
+
+// Реакции под сообщением; своя реакция снимается повторным нажатием
+export const MessageReactions = ({ message, currentUserId, onToggle }: MessageReactionsProps) => {
+  if (!message.reactions || message.reactions.length === 0) return null;
+  return (
+    <Box sx={{ display: 'flex', flexWrap: 'wrap', gap: 0.5, mt: 0.5 }}>
+      {message.reactions.map((reaction) => {
+        const reacted = !!currentUserId && reaction.user_ids.includes(currentUserId);
+        return (
+          <Chip
+            key={reaction.emoji}
+            size="small"
+            label={`${reaction.emoji} ${reaction.count}`}
+            color={reacted ? 'primary' : 'default'}
+            variant={reacted ? 'filled' : 'outlined'}
+            onClick={() => onToggle(reaction.emoji, reacted)}
+            sx={{ bgcolor: reacted ? undefined : 'background.paper' }}
+          />
+        );
+      })}
+    </Box>
+  );
+};
+
+interface MessageActionsProps {
+  message: Message;
+  isOwn: boolean;
+  onReply: () => void;
+  onEdit: () => void;
+  onDelete: () => void;
+  onShowEdits: () => void;
+  onReact: (emoji: string) => void;
+}
+
+// Кнопки действий с сообщением: реакция и меню
+export const MessageActions = ({ message, isOwn, onReply, onEdit, onDelete, onShowEdits, onReact }: MessageActionsProps) => {
+  const [menuAnchor, setMenuAnchor] = useState<HTMLElement | null>(null);
+  const [reactionAnchor, setReactionAnchor] = useState<HTMLElement | null>(null);
+
+  const closeMenu = (action?: () => void) => {
+    setMenuAnchor(null);
+    action?.();
+  };
+
+  return (
+    <Box sx={{ display: 'flex' }}>
+      <IconButton size="small" title="Реакция" onClick={(e) => setReactionAnchor(e.currentTarget)}>
+        <AddReactionIcon fontSize="small" />
+      </IconButton>
+      <IconButton size="small" title="Действия" onClick={(e) => setMenuAnchor(e.currentTarget)}>
+        <MoreVertIcon fontSize="small" />
+      </IconButton>
+
+      <Popover
+        open={!!reactionAnchor}
+        anchorEl={reactionAnchor}
+        onClose={() => setReactionAnchor(null)}
+        anchorOrigin={{ vertical: 'top', horizontal: 'center' }}
+        transformOrigin={{ vertical: 'bottom', horizontal: 'center' }}
+      >
+        <Box sx={{ display: 'flex', p: 0.5 }}>
+          {QUICK_REACTIONS.map((emoji) => (
+            <IconButton
+              key={emoji}
+              size="small"
+              onClick={() => {
+                setReactionAnchor(null);
+                onReact(emoji);
+              }}
+            >
+              {emoji}
+            </IconButton>
+          ))}
+        </Box>
+      </Popover>
+
+      <Menu anchorEl={menuAnchor} open={!!menuAnchor} onClose={() => closeMenu()}>
+        <MenuItem onClick={() => closeMenu(onReply)}>Ответить</MenuItem>
+        {isOwn && <MenuItem onClick={() => closeMenu(onEdit)}>Изменить</MenuItem>}
+        {message.edited_at && <MenuItem onClick={() => closeMenu(onShowEdits)}>История правок</MenuItem>}
+        {isOwn && (
+          <MenuItem onClick={() => closeMenu(onDelete)} sx={{ color: 'error.main' }}>
+            Удалить
+          </MenuItem>
+        )}
+      </Menu>
+    </Box>
+  );
+};
+
+interface MessageEditsDialogProps {
+  chatId: string;
+  message: Message | null;
+  onClose: () => void;
+}
+
+// История правок: прежние версии текста и текущий текст
+export const MessageEditsDialog = ({ chatId, message, onClose }: MessageEditsDialogProps) => {
+  const [edits, setEdits] = useState<MessageEdit[]>([]);
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!message) return;
+    setEdits([]);
+    setError(null);
+    ChatService.getMessageEdits(chatId, message.id)
+      .then(setEdits)
+      .catch((err) => {
+        console.error('Error loading message edits:', err);
+        setError('Не удалось загрузить историю правок');
+      });
+  }, [chatId, message?.id]);
+
+  const formatTime = (timestamp: string) =>
+    new Date(timestamp).toLocaleString('ru-RU', {
+      day: '2-digit',
+      month: '2-digit',
+      hour: '2-digit',
+      minute: '2-digit',
+    });
+
+  return (
+    <Dialog open={!!message} onClose={onClose} maxWidth="sm" fullWidth>
+      <DialogTitle>История правок</DialogTitle>
+      <DialogContent>
+        {error && <Alert severity="error">{error}</Alert>}
+        <List dense>
+          {edits.map((edit, index) => (
+            <ListItem key={index}>
+              <ListItemText
+                primary={edit.text || '—'}
+                secondary={`Заменено ${formatTime(edit.replaced_at)}`}
+              />
+            </ListItem>
+          ))}
+          {message && (
+            <ListItem>
+              <ListItemText
+                primary={message.text || '—'}
+                secondary={message.edited_at ? `Текущая версия · ${formatTime(message.edited_at)}` : 'Текущая версия'}
+              />
+            </ListItem>
+          )}
+        </List>
+      </DialogContent>
+      <DialogActions>
+        <Button onClick={onClose}>Закрыть</Button>
+      </DialogActions>
+    </Dialog>
+  );
+};
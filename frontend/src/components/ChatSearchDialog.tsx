@@ -0,0 +1,140 @@
+import { useState, useEffect } from 'react';
+import {
+  Box,
+  Dialog,
+  DialogTitle,
+  DialogContent,
+  TextField,
+  FormControlLabel,
+  Checkbox,
+  List,
+  ListItem,
+  ListItemText,
+  CircularProgress,
+  Alert,
+  Typography,
+} from '@mui/material';
+import { ChatService } from '../api/chat';
+import type { Chat, ChatWithLastMessage, Message, MessageSearchResult, SnippetFragment } from '../api/chat';
+
+const SEARCH_DEBOUNCE_MS = 300;
+
+// Текст с выделенными совпадениями; фрагменты не интерпретируются как HTML
+const Snippet = ({ fragments }: { fragments: SnippetFragment[] }) => (
+  <>
+    {fragments.map((fragment, index) => fragment.highlight ? (
+      <Box key={index} component="mark" sx={{ bgcolor: 'warning.light', color: 'inherit', px: 0.25 }}>
+        {fragment.text}
+      </Box>
+    ) : (
+      <span key={index}>{fragment.text}</span>
+    ))}
+  </>
+);
+
+interface ChatSearchDialogProps {
+  open: boolean;
+  chats: ChatWithLastMessage[];
+  currentChatId: string | null;
+  chatTitle: (chat: Chat) => string;
+  onClose: () => void;
+  onSelect: (message: Message) => void;
+}
+
+// Поиск по сообщениям во всех чатах пользователя или в открытом чате
+export const ChatSearchDialog = ({ open, chats, currentChatId, chatTitle, onClose, onSelect }: ChatSearchDialogProps) => {
+  const [query, setQuery] = useState('');
+  const [currentChatOnly, setCurrentChatOnly] = useState(false);
+  const [results, setResults] = useState<MessageSearchResult[]>([]);
+  const [loading, setLoading] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  const chatId = currentChatOnly && currentChatId ? currentChatId : undefined;
+
+  useEffect(() => {
+    const q = query.trim();
+    if (!open || !q) {
+      setResults([]);
+      return;
+    }
+
+    let cancelled = false;
+    const timer = setTimeout(() => {
+      setLoading(true);
+      setError(null);
+      ChatService.searchChatMessages(q, chatId)
+        .then((found) => {
+          if (!cancelled) setResults(found);
+        })
+        .catch((err) => {
+          console.error('Error searching messages:', err);
+          if (!cancelled) setError('Не удалось выполнить поиск');
+        })
+        .finally(() => {
+          if (!cancelled) setLoading(false);
+        });
+    }, SEARCH_DEBOUNCE_MS);
+
+    return () => {
+      cancelled = true;
+      clearTimeout(timer);
+    };
+  }, [open, query, chatId]);
+
+  const formatTime = (timestamp: string) =>
+    new Date(timestamp).toLocaleString('ru-RU', {
+      day: '2-digit',
+      month: '2-digit',
+      year: '2-digit',
+      hour: '2-digit',
+      minute: '2-digit',
+    });
+
+  const resultChatTitle = (message: Message) => {
+    const chat = chats.find((c) => c.chat.id === message.chat_id)?.chat;
+    return chat ? chatTitle(chat) : 'Чат';
+  };
+
+  return (
+    <Dialog open={open} onClose={onClose} maxWidth="sm" fullWidth>
+      <DialogTitle>Поиск сообщений</DialogTitle>
+      <DialogContent>
+        <TextField
+          autoFocus
+          fullWidth
+          margin="dense"
+          placeholder="Слова из сообщения"
+          value={query}
+          onChange={(e) => setQuery(e.target.value)}
+        />
+        {currentChatId && (
+          <FormControlLabel
+            control={<Checkbox checked={currentChatOnly} onChange={(e) => setCurrentChatOnly(e.target.checked)} />}
+            label="Только в открытом чате"
+          />
+        )}
+        {error && <Alert severity="error" sx={{ mt: 1 }}>{error}</Alert>}
+        {loading ? (
+          <Box sx={{ display: 'flex', justifyContent: 'center', mt: 2 }}>
+            <CircularProgress size={24} />
+          </Box>
+        ) : query.trim() && results.length === 0 && !error ? (
+          <Typography color="text.secondary" sx={{ mt: 2 }}>
+            Ничего не найдено
+          </Typography>
+        ) : (
+          <List>
+            {results.map((result) => (
+              <ListItem key={result.message.id} button onClick={() => onSelect(result.message)}>
+                <ListItemText
+                  primary={<Snippet fragments={result.snippet} />}
+                  secondary={`${resultChatTitle(result.message)} · ${result.message.user?.description || 'Участник'} · ${formatTime(result.message.created_at)}`}
+                />
+              </ListItem>
+            ))}
+          </List>
+        )}
+      </DialogContent>
+    </Dialog>
+  );
+};
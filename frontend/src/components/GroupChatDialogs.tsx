@@ -0,0 +1,287 @@
+import { useState, useEffect } from 'react';
+import {
+  Box,
+  Button,
+  Dialog,
+  DialogTitle,
+  DialogContent,
+  DialogActions,
+  TextField,
+  List,
+  ListItem,
+  ListItemAvatar,
+  ListItemText,
+  Avatar,
+  Checkbox,
+  Chip,
+  IconButton,
+  Alert,
+  MenuItem,
+  Typography,
+} from '@mui/material';
+import {
+  PersonRemove as PersonRemoveIcon,
+  AdminPanelSettings as AdminIcon,
+} from '@mui/icons-material';
+import { ApiError, ChatRole, ChatService, ChatType } from '../api/chat';
+import type { Chat, ChatUser } from '../api/chat';
+
+const getErrorMessage = (err: unknown, fallback: string) => {
+  const status = err instanceof ApiError ? err.status : 0;
+  return [400, 403, 409].includes(status) ? (err as ApiError).message : fallback;
+};
+
+interface CreateGroupChatDialogProps {
+  open: boolean;
+  contacts: ChatUser[];
+  onClose: () => void;
+  onCreated: (chat: Chat) => void;
+}
+
+export const CreateGroupChatDialog = ({ open, contacts, onClose, onCreated }: CreateGroupChatDialogProps) => {
+  const [title, setTitle] = useState('');
+  const [selected, setSelected] = useState<string[]>([]);
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  useEffect(() => {
+    if (open) {
+      setTitle('');
+      setSelected([]);
+      setError('');
+    }
+  }, [open]);
+
+  const toggle = (id: string) => {
+    setSelected((prev) => (prev.includes(id) ? prev.filter((s) => s !== id) : [...prev, id]));
+  };
+
+  const handleCreate = async () => {
+    try {
+      setLoading(true);
+      const chat = await ChatService.createChat({
+        type: ChatType.GROUP,
+        title: title.trim(),
+        users: selected,
+      });
+      onCreated(chat);
+    } catch (err) {
+      setError(getErrorMessage(err, 'Не удалось создать группу'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <Dialog open={open} onClose={onClose} maxWidth="sm" fullWidth>
+      <DialogTitle>Новая группа</DialogTitle>
+      <DialogContent>
+        {error && <Alert severity="error" sx={{ mb: 2 }}>{error}</Alert>}
+        <TextField
+          fullWidth
+          label="Название"
+          value={title}
+          onChange={(e) => setTitle(e.target.value)}
+          inputProps={{ maxLength: 100 }}
+          sx={{ mt: 1, mb: 2 }}
+        />
+        <Typography variant="subtitle2" gutterBottom>
+          Участники
+        </Typography>
+        {contacts.length === 0 ? (
+          <Typography color="text.secondary">
+            Участников можно будет добавить после создания группы
+          </Typography>
+        ) : (
+          <List dense>
+            {contacts.map((user) => (
+              <ListItem key={user.id} button onClick={() => toggle(user.id)}>
+                <Checkbox edge="start" checked={selected.includes(user.id)} tabIndex={-1} disableRipple />
+                <ListItemAvatar>
+                  <Avatar src={user.avatar || undefined}>{user.description?.charAt(0)?.toUpperCase() || '?'}</Avatar>
+                </ListItemAvatar>
+                <ListItemText primary={user.description} />
+              </ListItem>
+            ))}
+          </List>
+        )}
+      </DialogContent>
+      <DialogActions>
+        <Button onClick={onClose}>Отмена</Button>
+        <Button variant="contained" onClick={handleCreate} disabled={loading || !title.trim()}>
+          Создать
+        </Button>
+      </DialogActions>
+    </Dialog>
+  );
+};
+
+interface ChatMembersDialogProps {
+  open: boolean;
+  chat: Chat;
+  contacts: ChatUser[];
+  currentUserId: string | null;
+  onClose: () => void;
+  onChanged: () => void;
+  onLeft: () => void;
+}
+
+export const ChatMembersDialog = ({
+  open,
+  chat,
+  contacts,
+  currentUserId,
+  onClose,
+  onChanged,
+  onLeft,
+}: ChatMembersDialogProps) => {
+  const [title, setTitle] = useState(chat.title ?? '');
+  const [newMember, setNewMember] = useState('');
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  useEffect(() => {
+    if (open) {
+      setTitle(chat.title ?? '');
+      setNewMember('');
+      setError('');
+    }
+  }, [open, chat.id]);
+
+  const isAdmin = chat.users.some((u) => u.id === currentUserId && u.role === ChatRole.ADMIN);
+  const candidates = contacts.filter((c) => !chat.users.some((u) => u.id === c.id));
+
+  const run = async (action: () => Promise<unknown>, fallback: string) => {
+    try {
+      setLoading(true);
+      setError('');
+      await action();
+      onChanged();
+    } catch (err) {
+      setError(getErrorMessage(err, fallback));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const handleLeave = async () => {
+    if (!currentUserId || !window.confirm('Покинуть группу?')) return;
+    try {
+      setLoading(true);
+      await ChatService.removeChatMember(chat.id, currentUserId);
+      onLeft();
+    } catch (err) {
+      setError(getErrorMessage(err, 'Не удалось покинуть группу'));
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <Dialog open={open} onClose={onClose} maxWidth="sm" fullWidth>
+      <DialogTitle>Участники группы</DialogTitle>
+      <DialogContent>
+        {error && <Alert severity="error" sx={{ mb: 2 }}>{error}</Alert>}
+
+        {isAdmin && (
+          <Box sx={{ display: 'flex', gap: 1, mt: 1, mb: 2 }}>
+            <TextField
+              fullWidth
+              size="small"
+              label="Название"
+              value={title}
+              onChange={(e) => setTitle(e.target.value)}
+              inputProps={{ maxLength: 100 }}
+            />
+            <Button
+              variant="outlined"
+              disabled={loading || !title.trim() || title.trim() === chat.title}
+              onClick={() => run(() => ChatService.updateChat(chat.id, { title: title.trim() }), 'Не удалось переименовать группу')}
+            >
+              Сохранить
+            </Button>
+          </Box>
+        )}
+
+        <List dense>
+          {chat.users.map((user) => (
+            <ListItem
+              key={user.id}
+              secondaryAction={
+                isAdmin && user.id !== currentUserId && (
+                  <>
+                    <IconButton
+                      title={user.role === ChatRole.ADMIN ? 'Снять права администратора' : 'Сделать администратором'}
+                      disabled={loading}
+                      onClick={() => run(
+                        () => ChatService.updateChatMember(chat.id, user.id, {
+                          role: user.role === ChatRole.ADMIN ? ChatRole.MEMBER : ChatRole.ADMIN,
+                        }),
+                        'Не удалось изменить роль',
+                      )}
+                    >
+                      <AdminIcon color={user.role === ChatRole.ADMIN ? 'primary' : 'inherit'} />
+                    </IconButton>
+                    <IconButton
+                      title="Исключить"
+                      disabled={loading}
+                      onClick={() => run(() => ChatService.removeChatMember(chat.id, user.id), 'Не удалось исключить участника')}
+                    >
+                      <PersonRemoveIcon />
+                    </IconButton>
+                  </>
+                )
+              }
+            >
+              <ListItemAvatar>
+                <Avatar src={user.avatar || undefined}>{user.description?.charAt(0)?.toUpperCase() || '?'}</Avatar>
+              </ListItemAvatar>
+              <ListItemText
+                primary={
+                  <Box sx={{ display: 'flex', alignItems: 'center', gap: 1 }}>
+                    {user.description}
+                    {user.id === currentUserId && <Typography variant="caption" color="text.secondary">(вы)</Typography>}
+                    {user.role === ChatRole.ADMIN && <Chip label="Администратор" size="small" />}
+                  </Box>
+                }
+              />
+            </ListItem>
+          ))}
+        </List>
+
+        {isAdmin && candidates.length > 0 && (
+          <Box sx={{ display: 'flex', gap: 1, mt: 2 }}>
+            <TextField
+              select
+              fullWidth
+              size="small"
+              label="Добавить участника"
+              value={newMember}
+              onChange={(e) => setNewMember(e.target.value)}
+            >
+              {candidates.map((c) => (
+                <MenuItem key={c.id} value={c.id}>{c.description}</MenuItem>
+              ))}
+            </TextField>
+            <Button
+              variant="outlined"
+              disabled={loading || !newMember}
+              onClick={() => run(async () => {
+                await ChatService.addChatMember(chat.id, { user_id: newMember });
+                setNewMember('');
+              }, 'Не удалось добавить участника')}
+            >
+              Добавить
+            </Button>
+          </Box>
+        )}
+      </DialogContent>
+      <DialogActions>
+        <Button color="error" onClick={handleLeave} disabled={loading}>
+          Покинуть группу
+        </Button>
+        <Button onClick={onClose}>Закрыть</Button>
+      </DialogActions>
+    </Dialog>
+  );
+};
@@ -1,6 +1,7 @@
 import { createContext, useContext, useState, useEffect, useCallback } from 'react';
 import type { ReactNode } from 'react';
 import { useNavigate, useLocation } from 'react-router-dom';
+import type { Location } from 'react-router-dom';
 import { apiClient } from '../api/config';
 
 interface AuthContextType {
@@ -41,6 +42,12 @@ export const AuthProvider = ({ children }: AuthProviderProps) => {
     }
   }, [navigate, location.pathname]);
 
+  // Возвращаем на страницу, с которой ProtectedRoute отправил на вход
+  const redirectAfterAuth = () => {
+    const from = (location.state as { from?: Location } | null)?.from;
+    navigate(from ? `${from.pathname}${from.search}` : '/', { replace: true });
+  };
+
   useEffect(() => {
     const validateToken = async () => {
       try {
@@ -95,7 +102,7 @@ export const AuthProvider = ({ children }: AuthProviderProps) => {
       localStorage.setItem('access_token', access_token);
       localStorage.setItem('refresh_token', refresh_token);
       setIsAuthenticated(true);
-      navigate('/', { replace: true });
+      redirectAfterAuth();
     } catch (error: any) {
       console.error('Login failed:', error.response?.data || error);
       throw error;
@@ -124,7 +131,7 @@ export const AuthProvider = ({ children }: AuthProviderProps) => {
       localStorage.setItem('access_token', access_token);
       localStorage.setItem('refresh_token', refresh_token);
       setIsAuthenticated(true);
-      navigate('/', { replace: true });
+      redirectAfterAuth();
     } catch (error) {
       console.error('Registration failed:', error);
       throw error;
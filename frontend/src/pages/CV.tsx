@@ -9,43 +9,114 @@ import {
   Alert,
   CircularProgress,
   Link,
+  List,
+  ListItem,
+  ListItemText,
+  IconButton,
+  Chip,
+  TextField,
+  FormControlLabel,
+  Checkbox,
 } from '@mui/material';
-import { Upload as UploadIcon } from '@mui/icons-material';
-import { ProfileService } from '../api/profile';
-import { CvService } from '../api/cv';
-import type { ApiGetProfile } from '../api/profile';
+import {
+  Upload as UploadIcon,
+  Delete as DeleteIcon,
+  Edit as EditIcon,
+  Star as StarIcon,
+  StarBorder as StarBorderIcon,
+} from '@mui/icons-material';
+import { CvService, ApiError } from '../api/cv';
+import type { CVVersion, UpdateCVVersionRequest } from '../api/cv';
+import { openFile, getOpenFileError } from '../utils/openFile';
 
 export const CV = () => {
   const [error, setError] = useState('');
   const [success, setSuccess] = useState(false);
+  const [label, setLabel] = useState('');
+  const [primary, setPrimary] = useState(true);
   const queryClient = useQueryClient();
 
-  const { data: profile, isLoading } = useQuery<ApiGetProfile>({
-    queryKey: ['profile'],
-    queryFn: () => ProfileService.fetchOwnProfile(),
+  const { data: versions, isLoading } = useQuery<CVVersion[]>({
+    queryKey: ['cvVersions'],
+    queryFn: () => CvService.listCvVersions(),
   });
 
+  const invalidate = () => {
+    queryClient.invalidateQueries({ queryKey: ['cvVersions'] });
+    queryClient.invalidateQueries({ queryKey: ['profile'] });
+  };
+
   const uploadMutation = useMutation({
     mutationFn: async (file: File) => {
-      const response = await CvService.uploadCv({ file });
+      const response = await CvService.uploadCv({ file, label: label.trim() || undefined, primary });
       return response;
     },
-    onSuccess: (data) => {
-      queryClient.invalidateQueries({ queryKey: ['profile'] });
+    onSuccess: () => {
+      invalidate();
       setSuccess(true);
       setError('');
+      setLabel('');
     },
-    onError: () => {
-      setError('Ошибка при загрузке файла');
+    onError: (err) => {
+      // Для ошибок проверки файла показываем причину отказа
+      const status = err instanceof ApiError ? err.status : 0;
+      setError([400, 413, 422].includes(status) ? err.message : 'Ошибка при загрузке файла');
       setSuccess(false);
     },
   });
 
+  const updateMutation = useMutation({
+    mutationFn: ({ id, update }: { id: string; update: UpdateCVVersionRequest }) =>
+      CvService.updateCvVersion(id, update),
+    onSuccess: () => {
+      invalidate();
+      setError('');
+    },
+    onError: (err) => {
+      const status = err instanceof ApiError ? err.status : 0;
+      setError(status === 400 ? err.message : 'Не удалось изменить версию резюме');
+    },
+  });
+
+  const deleteMutation = useMutation({
+    mutationFn: (id: string) => CvService.deleteCvVersion(id),
+    onSuccess: () => {
+      invalidate();
+      setError('');
+    },
+    onError: (err) => {
+      const status = err instanceof ApiError ? err.status : 0;
+      setError(status === 409
+        ? 'Эта версия приложена к отклику, который ещё рассматривается'
+        : 'Не удалось удалить версию резюме');
+    },
+  });
+
+  const handleOpenCv = (e: React.MouseEvent, link: string) => {
+    e.preventDefault();
+    setError('');
+    openFile(link).catch((err) => setError(getOpenFileError(err)));
+  };
+
   const handleFileChange = (e: React.ChangeEvent<HTMLInputElement>) => {
     const file = e.target.files?.[0];
     if (file) {
       uploadMutation.mutate(file);
     }
+    e.target.value = '';
+  };
+
+  const handleRename = (version: CVVersion) => {
+    const newLabel = window.prompt('Подпись версии', version.label);
+    if (newLabel !== null && newLabel.trim() !== version.label) {
+      updateMutation.mutate({ id: version.id, update: { label: newLabel.trim() } });
+    }
+  };
+
+  const handleDelete = (version: CVVersion) => {
+    if (window.confirm(`Удалить версию «${versionTitle(version)}»?`)) {
+      deleteMutation.mutate(version.id);
+    }
   };
 
   if (isLoading) {
@@ -75,14 +146,52 @@ export const CV = () => {
             </Alert>
           )}
 
-          {profile?.cv ? (
+          {versions && versions.length > 0 ? (
             <Box sx={{ mb: 3 }}>
               <Typography variant="h6" gutterBottom>
-                Текущее резюме
+                Версии резюме
               </Typography>
-              <Link href={profile.cv} target="_blank" rel="noopener noreferrer">
-                {profile.cv}
-              </Link>
+              <List>
+                {versions.map((version) => (
+                  <ListItem
+                    key={version.id}
+                    divider
+                    secondaryAction={
+                      <>
+                        <IconButton
+                          title={version.is_primary ? 'Основная версия' : 'Сделать основной'}
+                          disabled={version.is_primary || updateMutation.isPending}
+                          onClick={() => updateMutation.mutate({ id: version.id, update: { is_primary: true } })}
+                        >
+                          {version.is_primary ? <StarIcon color="primary" /> : <StarBorderIcon />}
+                        </IconButton>
+                        <IconButton title="Изменить подпись" onClick={() => handleRename(version)}>
+                          <EditIcon />
+                        </IconButton>
+                        <IconButton
+                          title="Удалить"
+                          disabled={deleteMutation.isPending}
+                          onClick={() => handleDelete(version)}
+                        >
+                          <DeleteIcon />
+                        </IconButton>
+                      </>
+                    }
+                  >
+                    <ListItemText
+                      primary={
+                        <Box sx={{ display: 'flex', alignItems: 'center', gap: 1 }}>
+                          <Link href={version.link} onClick={(e) => handleOpenCv(e, version.link)}>
+                            {versionTitle(version)}
+                          </Link>
+                          {version.is_primary && <Chip label="Основная" size="small" color="primary" />}
+                        </Box>
+                      }
+                      secondary={`Загружено ${new Date(version.created_at).toLocaleDateString('ru-RU')}`}
+                    />
+                  </ListItem>
+                ))}
+              </List>
             </Box>
           ) : (
             <Typography variant="body1" sx={{ mb: 3 }}>
@@ -90,7 +199,19 @@ export const CV = () => {
             </Typography>
           )}
 
-          <Box>
+          <Box sx={{ display: 'flex', alignItems: 'center', gap: 2, flexWrap: 'wrap' }}>
+            <TextField
+              size="small"
+              label="Подпись новой версии"
+              placeholder="Например, Backend CV"
+              value={label}
+              onChange={(e) => setLabel(e.target.value)}
+              inputProps={{ maxLength: 100 }}
+            />
+            <FormControlLabel
+              control={<Checkbox checked={primary} onChange={(e) => setPrimary(e.target.checked)} />}
+              label="Сделать основной"
+            />
             <input
               accept=".pdf,.doc,.docx"
               style={{ display: 'none' }}
@@ -111,10 +232,14 @@ export const CV = () => {
           </Box>
 
           <Typography variant="body2" color="text.secondary" sx={{ mt: 2 }}>
-            Поддерживаемые форматы: PDF, DOC, DOCX
+            Поддерживаемые форматы: PDF, DOC, DOCX. Основная версия показывается в профиле и
+            прикладывается к откликам, если не выбрать другую.
           </Typography>
         </Paper>
       </Box>
     </Container>
   );
-}; 
\ No newline at end of file
+};
+
+const versionTitle = (version: CVVersion) =>
+  version.label || version.original_filename || version.link;
@@ -1,5 +1,5 @@
 import { useState, useEffect, useRef } from 'react';
-import { useQuery } from '@tanstack/react-query';
+import { useQuery, useQueryClient } from '@tanstack/react-query';
 import { useParams, useNavigate, useSearchParams } from 'react-router-dom';
 import {
   Container,
@@ -21,13 +21,44 @@ import {
   DialogTitle,
   DialogContent,
   DialogActions,
+  Chip,
 } from '@mui/material';
-import { Send as SendIcon, VideoCall as VideoCallIcon, Phone as PhoneIcon, PhoneDisabled as PhoneDisabledIcon } from '@mui/icons-material';
-import { ChatService } from '../api/chat';
+import {
+  Send as SendIcon,
+  VideoCall as VideoCallIcon,
+  Phone as PhoneIcon,
+  PhoneDisabled as PhoneDisabledIcon,
+  Group as GroupIcon,
+  GroupAdd as GroupAddIcon,
+  AttachFile as AttachFileIcon,
+  Description as DescriptionIcon,
+  Reply as ReplyIcon,
+  Edit as EditIcon,
+  Close as CloseIcon,
+  Search as SearchIcon,
+} from '@mui/icons-material';
+import { ApiError, ChatService, ChatRole, ChatType, MessageType } from '../api/chat';
 import { CallService } from '../api/call';
-import type { ChatWithLastMessage, Message } from '../api/chat';
+import type { Attachment, Chat as ChatModel, ChatUser, ChatWithLastMessage, Message, ReadReceipt, UserPresence } from '../api/chat';
 import { useWebSocket } from '../hooks/useWebSocket';
 import { VideoCallWithTranscript } from '../components/VideoCallWithTranscript';
+import { ChatMembersDialog, CreateGroupChatDialog } from '../components/GroupChatDialogs';
+import { MessageAttachments, attachmentIcon, formatFileSize } from '../components/ChatAttachments';
+import { MessageActions, MessageEditsDialog, MessageQuote, MessageReactions } from '../components/ChatMessageControls';
+import { ChatSearchDialog } from '../components/ChatSearchDialog';
+
+// typing-start повторяется, пока пользователь печатает; без повтора набор считается законченным
+const TYPING_REPEAT_MS = 3000;
+const TYPING_EXPIRE_MS = 5000;
+const MAX_MESSAGE_ATTACHMENTS = 10;
+
+const getAttachmentError = (err: unknown, fallback: string) => {
+  const status = err instanceof ApiError ? err.status : 0;
+  if (status === 413) return 'Файл слишком большой';
+  if (status === 422) return 'Файл не прошёл проверку на вирусы';
+  if (status === 400) return (err as ApiError).message;
+  return fallback;
+};
 
 export const Chat = () => {
   const { chatId } = useParams<{ chatId: string }>();
@@ -43,7 +74,26 @@ export const Chat = () => {
     callerName: string;
   } | null>(null);
   const [isIncomingCall, setIsIncomingCall] = useState(false);
+  const [readReceipts, setReadReceipts] = useState<ReadReceipt[]>([]);
+  const [presence, setPresence] = useState<Record<string, UserPresence>>({});
+  const [typingUsers, setTypingUsers] = useState<string[]>([]);
+  const typingTimersRef = useRef<Record<string, ReturnType<typeof setTimeout>>>({});
+  const lastTypingSentRef = useRef(0);
+  const [createGroupOpen, setCreateGroupOpen] = useState(false);
+  const [membersOpen, setMembersOpen] = useState(false);
+  const [pendingAttachments, setPendingAttachments] = useState<Attachment[]>([]);
+  const [shareCv, setShareCv] = useState(false);
+  const [uploading, setUploading] = useState(false);
+  const [attachmentError, setAttachmentError] = useState<string | null>(null);
+  const fileInputRef = useRef<HTMLInputElement>(null);
+  const [replyTo, setReplyTo] = useState<Message | null>(null);
+  const [editingMessage, setEditingMessage] = useState<Message | null>(null);
+  const [editsMessage, setEditsMessage] = useState<Message | null>(null);
+  const [searchOpen, setSearchOpen] = useState(false);
+  // Сообщение из поиска: вместо последних сообщений показывается окрестность найденного
+  const [jumpTarget, setJumpTarget] = useState<{ chatId: string; messageId: string } | null>(null);
   const messagesEndRef = useRef<HTMLDivElement>(null);
+  const queryClient = useQueryClient();
 
   const { sendMessage, onMessage } = useWebSocket(selectedChat);
 
@@ -172,6 +222,40 @@ export const Chat = () => {
           return;
         }
 
+        // Собеседник прочитал сообщения
+        if (parsedMessage.type === 'read') {
+          if (parsedMessage.chat_id === selectedChat) {
+            setReadReceipts((prev) => [
+              ...prev.filter((receipt) => receipt.user_id !== parsedMessage.user_id),
+              parsedMessage,
+            ]);
+          }
+          return;
+        }
+
+        // Собеседник печатает или перестал печатать
+        if (parsedMessage.type === 'typing-start' || parsedMessage.type === 'typing-stop') {
+          if (parsedMessage.chat_id === selectedChat) {
+            setTyping(parsedMessage.user_id, parsedMessage.type === 'typing-start');
+          }
+          return;
+        }
+
+        // Собеседник появился в сети или вышел
+        if (parsedMessage.type === 'presence') {
+          setPresence((prev) => ({ ...prev, [parsedMessage.user_id]: parsedMessage }));
+          return;
+        }
+
+        // Сообщение изменено, удалено или получило реакцию
+        if (parsedMessage.type === 'message-updated') {
+          if (parsedMessage.message?.chat_id === selectedChat) {
+            replaceMessage(parsedMessage.message);
+          }
+          queryClient.invalidateQueries({ queryKey: ['chats'] });
+          return;
+        }
+
         // Проверяем, что сообщение относится к текущему выбранному чату
         if (parsedMessage.chat_id !== selectedChat) {
           console.log('⚠️ Message is for different chat, ignoring:', parsedMessage.chat_id, 'current:', selectedChat);
@@ -179,6 +263,16 @@ export const Chat = () => {
         }
 
         console.log('📨 Adding WebSocket message to state:', parsedMessage);
+        setTyping(parsedMessage.user?.id, false);
+        // Системное сообщение - изменился состав или название группы
+        if (parsedMessage.type && parsedMessage.type !== MessageType.TEXT) {
+          queryClient.invalidateQueries({ queryKey: ['chats'] });
+        }
+        // Открыта история вокруг найденного сообщения - новое появится после возврата к последним
+        if (jumpTarget) {
+          queryClient.invalidateQueries({ queryKey: ['messages', selectedChat] });
+          return;
+        }
         setMessages((prev) => {
           if (!Array.isArray(prev)) return [parsedMessage];
           if (prev.some(m => m.id === parsedMessage.id)) {
@@ -198,10 +292,10 @@ export const Chat = () => {
         unsubscribe();
       }
     };
-  }, [selectedChat, onMessage]);
+  }, [selectedChat, onMessage, jumpTarget]);
 
   useEffect(() => {
-    if (chatMessages) {
+    if (chatMessages && !jumpTarget) {
       const newMessages = Array.isArray(chatMessages) ? chatMessages : [];
       console.log('📥 Received chatMessages from API for chat:', selectedChat, 'messages:', newMessages.length);
       
@@ -216,24 +310,110 @@ export const Chat = () => {
       // Это безопасно для переключения чатов
       setMessages(sortedMessages);
     }
-  }, [chatMessages, selectedChat]);
+  }, [chatMessages, selectedChat, jumpTarget]);
 
+  // Переход к результату поиска: загружаем сообщения вокруг найденного
   useEffect(() => {
-    scrollToBottom();
+    if (!jumpTarget || jumpTarget.chatId !== selectedChat) return;
+    ChatService.getMessageContext(jumpTarget.chatId, jumpTarget.messageId)
+      .then(setMessages)
+      .catch((err) => {
+        console.error('Error loading message context:', err);
+        setJumpTarget(null);
+      });
+  }, [jumpTarget, selectedChat]);
+
+  useEffect(() => {
+    if (jumpTarget) {
+      scrollToMessage(jumpTarget.messageId);
+    } else {
+      scrollToBottom();
+    }
   }, [messages]);
 
+  // Отметки о прочтении выбранного чата приходят со списком чатов
+  useEffect(() => {
+    const currentChat = chats?.find((c) => c.chat.id === selectedChat);
+    setReadReceipts(currentChat?.read_receipts ?? []);
+  }, [chats, selectedChat]);
+
+  // Статусы участников приходят со списком чатов, дальше обновляются событиями presence
+  useEffect(() => {
+    const initial: Record<string, UserPresence> = {};
+    chats?.forEach((c) => c.presence?.forEach((p) => { initial[p.user_id] = p; }));
+    setPresence(initial);
+  }, [chats]);
+
+  // При смене чата сбрасываем индикаторы набора
+  useEffect(() => {
+    Object.values(typingTimersRef.current).forEach(clearTimeout);
+    typingTimersRef.current = {};
+    setTypingUsers([]);
+    lastTypingSentRef.current = 0;
+    return () => Object.values(typingTimersRef.current).forEach(clearTimeout);
+  }, [selectedChat]);
+
+  const setTyping = (userId: string | undefined, typing: boolean) => {
+    if (!userId) return;
+    clearTimeout(typingTimersRef.current[userId]);
+    delete typingTimersRef.current[userId];
+    if (typing) {
+      typingTimersRef.current[userId] = setTimeout(() => setTyping(userId, false), TYPING_EXPIRE_MS);
+    }
+    setTypingUsers((prev) => {
+      const others = prev.filter((id) => id !== userId);
+      return typing ? [...others, userId] : others;
+    });
+  };
+
+  const sendTyping = (typing: boolean) => {
+    if (!sendMessage) return;
+    const now = Date.now();
+    if (typing && now - lastTypingSentRef.current < TYPING_REPEAT_MS) return;
+    if (!typing && lastTypingSentRef.current === 0) return;
+    lastTypingSentRef.current = typing ? now : 0;
+    sendMessage(JSON.stringify({ type: typing ? 'typing-start' : 'typing-stop' }));
+  };
+
+  const handleMessageChange = (value: string) => {
+    setNewMessage(value);
+    sendTyping(value.trim() !== '');
+  };
+
+  // Отмечаем прочитанным последнее сообщение собеседника в открытом чате;
+  // свои сообщения сервер отмечает при отправке
+  const lastMessage = messages.length > 0 ? messages[messages.length - 1] : null;
+  useEffect(() => {
+    if (!selectedChat || !lastMessage || isMyMessage(lastMessage)) return;
+    ChatService.markChatRead(selectedChat, { message_id: lastMessage.id })
+      .then(() => queryClient.invalidateQueries({ queryKey: ['chats'] }))
+      .catch((err) => console.error('Error marking chat as read:', err));
+  }, [selectedChat, lastMessage?.id]);
+
   const scrollToBottom = () => {
     messagesEndRef.current?.scrollIntoView({ behavior: 'smooth' });
   };
 
+  const replaceMessage = (updated: Message) => {
+    setMessages((prev) => prev.map((m) => (m.id === updated.id ? updated : m)));
+  };
+
   const handleSendMessage = async (e: React.FormEvent) => {
     e.preventDefault();
-    if (!newMessage.trim() || !selectedChat) return;
+    if (editingMessage) {
+      await handleSaveEdit();
+      return;
+    }
+    if (!canSend || !selectedChat) return;
 
     console.log('📤 Sending message:', newMessage.trim());
+    setAttachmentError(null);
     try {
       var message = await ChatService.sendMessage(selectedChat, {
         text: newMessage.trim(),
+        attachment_ids: pendingAttachments.map((a) => a.id),
+        share_cv: shareCv,
+        reply_to_id: replyTo?.id,
       });
 
       if (typeof message === 'string') {
@@ -252,6 +432,14 @@ export const Chat = () => {
         return [...prev, message];
       });
       setNewMessage('');
+      setPendingAttachments([]);
+      setShareCv(false);
+      setReplyTo(null);
+      sendTyping(false);
+      if (jumpTarget) {
+        setJumpTarget(null);
+        queryClient.invalidateQueries({ queryKey: ['messages', selectedChat] });
+      }
       
       // Отправляем через WebSocket для уведомления других пользователей
       // Отправляем весь объект сообщения для real-time обновлений
@@ -261,9 +449,99 @@ export const Chat = () => {
       }
     } catch (err) {
       console.error('Error sending message:', err);
+      setAttachmentError(getAttachmentError(err, 'Не удалось отправить сообщение'));
+    }
+  };
+
+  const handleSaveEdit = async () => {
+    if (!editingMessage || !selectedChat) return;
+    const text = newMessage.trim();
+    if (!text && editingMessage.attachments.length === 0) return;
+    setAttachmentError(null);
+    try {
+      const message = await ChatService.editMessage(selectedChat, editingMessage.id, { text });
+      replaceMessage(message);
+      setEditingMessage(null);
+      setNewMessage('');
+      sendTyping(false);
+    } catch (err) {
+      console.error('Error editing message:', err);
+      setAttachmentError('Не удалось изменить сообщение');
+    }
+  };
+
+  const handleStartEdit = (message: Message) => {
+    setReplyTo(null);
+    setEditingMessage(message);
+    setNewMessage(message.text);
+  };
+
+  const handleCancelEdit = () => {
+    setEditingMessage(null);
+    setNewMessage('');
+  };
+
+  const handleDeleteMessage = async (message: Message) => {
+    if (!selectedChat || !confirm('Удалить сообщение?')) return;
+    try {
+      await ChatService.deleteMessage(selectedChat, message.id);
+      replaceMessage({
+        ...message,
+        text: '',
+        attachments: [],
+        reactions: [],
+        deleted_at: new Date().toISOString(),
+      });
+      queryClient.invalidateQueries({ queryKey: ['chats'] });
+    } catch (err) {
+      console.error('Error deleting message:', err);
+      setAttachmentError('Не удалось удалить сообщение');
+    }
+  };
+
+  const handleToggleReaction = async (message: Message, emoji: string, reacted: boolean) => {
+    if (!selectedChat) return;
+    try {
+      const updated = reacted
+        ? await ChatService.removeMessageReaction(selectedChat, message.id, emoji)
+        : await ChatService.addMessageReaction(selectedChat, message.id, { emoji });
+      replaceMessage(updated);
+    } catch (err) {
+      console.error('Error updating reaction:', err);
     }
   };
 
+  const scrollToMessage = (messageId: string) => {
+    document.getElementById(`message-${messageId}`)?.scrollIntoView({ behavior: 'smooth', block: 'center' });
+  };
+
+  const handleAttachFiles = async (files: FileList | null) => {
+    if (!files || !selectedChat) return;
+    const chatId = selectedChat;
+    const selected = Array.from(files).slice(0, MAX_MESSAGE_ATTACHMENTS - pendingAttachments.length);
+    setAttachmentError(null);
+    setUploading(true);
+    try {
+      for (const file of selected) {
+        const attachment = await ChatService.uploadChatAttachment(chatId, { file });
+        setPendingAttachments((prev) => [...prev, attachment]);
+      }
+    } catch (err) {
+      console.error('Error uploading attachment:', err);
+      setAttachmentError(getAttachmentError(err, 'Не удалось загрузить файл'));
+    } finally {
+      setUploading(false);
+      if (fileInputRef.current) fileInputRef.current.value = '';
+    }
+  };
+
+  const handleRemoveAttachment = (attachment: Attachment) => {
+    setPendingAttachments((prev) => prev.filter((a) => a.id !== attachment.id));
+    if (!selectedChat) return;
+    ChatService.deleteChatAttachment(selectedChat, attachment.id)
+      .catch((err) => console.error('Error deleting attachment:', err));
+  };
+
   const handleStartVideoCall = async () => {
     if (!selectedChat || !chats) return;
 
@@ -351,11 +629,32 @@ export const Chat = () => {
 
   const handleChatSelect = (chatId: string) => {
     console.log('🔄 Switching to chat:', chatId, 'from:', selectedChat);
-    // Очищаем сообщения при смене чата
+    // Очищаем сообщения при смене чата, неотправленные файлы удаляем
     setMessages([]);
+    if (selectedChat && selectedChat !== chatId) {
+      pendingAttachments.forEach((attachment) => {
+        ChatService.deleteChatAttachment(selectedChat, attachment.id)
+          .catch((err) => console.error('Error deleting attachment:', err));
+      });
+      setPendingAttachments([]);
+      setShareCv(false);
+      setAttachmentError(null);
+      setReplyTo(null);
+      setEditingMessage(null);
+      setNewMessage('');
+    }
+    setJumpTarget(null);
     setSelectedChat(chatId);
   };
 
+  const handleJumpToMessage = (message: Message) => {
+    setSearchOpen(false);
+    if (message.chat_id !== selectedChat) {
+      handleChatSelect(message.chat_id);
+    }
+    setJumpTarget({ chatId: message.chat_id, messageId: message.id });
+  };
+
   const getCurrentUserId = () => {
     const token = localStorage.getItem('access_token');
     if (!token) return null;
@@ -379,8 +678,84 @@ export const Chat = () => {
     return message.user.id === getCurrentUserId();
   };
 
+  // Текст системного сообщения о смене состава или названия группы
+  const formatSystemMessage = (message: Message, users: ChatUser[]) => {
+    const actor = message.user?.description || 'Участник';
+    const target = users.find((u) => u.id === message.target_user_id)?.description || 'участника';
+    switch (message.type) {
+      case MessageType.CHAT_CREATED:
+        return `${actor} создал(а) группу «${message.text}»`;
+      case MessageType.MEMBER_ADDED:
+        return `${actor} добавил(а) ${target}`;
+      case MessageType.MEMBER_REMOVED:
+        return `${actor} исключил(а) ${target}`;
+      case MessageType.MEMBER_LEFT:
+        return `${actor} покинул(а) группу`;
+      case MessageType.ROLE_CHANGED:
+        return message.text === ChatRole.ADMIN
+          ? `${target} теперь администратор`
+          : `${target} больше не администратор`;
+      case MessageType.TITLE_CHANGED:
+        return `${actor} переименовал(а) группу в «${message.text}»`;
+      default:
+        return message.text;
+    }
+  };
+
+  const isSystemMessage = (message: Message) => !!message.type && message.type !== MessageType.TEXT;
+
+  // Сообщение без текста в списке чатов показывается по первому вложению
+  const messagePreview = (message: Message) => {
+    if (message.deleted_at) return 'Сообщение удалено';
+    if (message.text) return message.text;
+    const attachment = message.attachments?.[0];
+    return attachment ? `📎 ${attachment.filename}` : '';
+  };
+
+  const canSend = editingMessage
+    ? !!newMessage.trim() || editingMessage.attachments.length > 0
+    : !uploading && (!!newMessage.trim() || pendingAttachments.length > 0 || shareCv);
+
+  const chatTitle = (chat: ChatModel) => {
+    if (chat.type === ChatType.GROUP) return chat.title || 'Группа';
+    return chat.users?.find((u) => u.id !== currentUserId)?.description || 'Без названия';
+  };
+
+  const formatPresence = (userId?: string) => {
+    const status = userId ? presence[userId] : undefined;
+    if (!status) return '';
+    if (status.online) return 'в сети';
+    if (!status.last_seen_at) return '';
+    return `был(а) в сети ${new Date(status.last_seen_at).toLocaleString('ru-RU', {
+      day: '2-digit',
+      month: '2-digit',
+      hour: '2-digit',
+      minute: '2-digit',
+    })}`;
+  };
+
   const currentUserId = getCurrentUserId();
 
+  // Последнее сообщение, прочитанное собеседником: все свои сообщения до него прочитаны
+  const otherReadIndex = Math.max(
+    -1,
+    ...readReceipts
+      .filter((receipt) => receipt.user_id !== currentUserId)
+      .map((receipt) => messages.findIndex((m) => m.id === receipt.message_id)),
+  );
+
+  const selectedChatData = chats?.find((c) => c.chat.id === selectedChat)?.chat;
+  const isGroup = selectedChatData?.type === ChatType.GROUP;
+  const selectedOtherUser = selectedChatData?.users?.find((u) => u.id !== currentUserId);
+
+  // Собеседники из всех чатов - кандидаты в участники групп
+  const contacts: ChatUser[] = [];
+  chats?.forEach((c) => c.chat.users?.forEach((u) => {
+    if (u.id !== currentUserId && !contacts.some((contact) => contact.id === u.id)) {
+      contacts.push({ id: u.id, description: u.description, avatar: u.avatar });
+    }
+  }));
+
   if (chatsLoading) {
     return (
       <Box sx={{ display: 'flex', justifyContent: 'center', mt: 4 }}>
@@ -456,9 +831,19 @@ export const Chat = () => {
         <Box sx={{ display: 'flex', height: '100%', gap: 2 }}>
           {/* Список чатов */}
           <Paper sx={{ width: 300, overflow: 'auto' }}>
+            <Box sx={{ p: 1, borderBottom: 1, borderColor: 'divider', display: 'flex' }}>
+              <Button fullWidth startIcon={<GroupAddIcon />} onClick={() => setCreateGroupOpen(true)}>
+                Новая группа
+              </Button>
+              <IconButton title="Поиск сообщений" onClick={() => setSearchOpen(true)}>
+                <SearchIcon />
+              </IconButton>
+            </Box>
             <List>
               {Array.isArray(chats) && chats.map((chat) => {
-                const otherUser = chat.chat.users?.find(user => user.id !== currentUserId);
+                const group = chat.chat.type === ChatType.GROUP;
+                const otherUser = group ? undefined : chat.chat.users?.find(user => user.id !== currentUserId);
+                const lastMessage = chat.last_message;
                 return (
                   <ListItem
                     key={chat.chat.id}
@@ -468,14 +853,29 @@ export const Chat = () => {
                   >
                     <ListItemAvatar>
                       <Badge badgeContent={chat.unread_count} color="primary">
-                        <Avatar src={otherUser?.avatar || undefined}>
-                          {otherUser?.description?.charAt(0)?.toUpperCase() || '?'}
-                        </Avatar>
+                        <Badge
+                          variant="dot"
+                          color="success"
+                          overlap="circular"
+                          anchorOrigin={{ vertical: 'bottom', horizontal: 'right' }}
+                          invisible={!(otherUser && presence[otherUser.id]?.online)}
+                        >
+                          <Avatar src={otherUser?.avatar || undefined}>
+                            {group ? <GroupIcon /> : otherUser?.description?.charAt(0)?.toUpperCase() || '?'}
+                          </Avatar>
+                        </Badge>
                       </Badge>
                     </ListItemAvatar>
                     <ListItemText
-                      primary={otherUser?.description || 'Без названия'}
-                      secondary={chat.last_message?.text || 'Нет сообщений'}
+                      primary={chatTitle(chat.chat)}
+                      secondary={
+                        !lastMessage
+                          ? 'Нет сообщений'
+                          : isSystemMessage(lastMessage)
+                            ? formatSystemMessage(lastMessage, chat.chat.users)
+                            : messagePreview(lastMessage)
+                      }
+                      secondaryTypographyProps={{ noWrap: true }}
                     />
                   </ListItem>
                 );
@@ -489,19 +889,53 @@ export const Chat = () => {
               <>
                 {/* Заголовок чата */}
                 <Box sx={{ p: 2, borderBottom: 1, borderColor: 'divider', display: 'flex', justifyContent: 'space-between', alignItems: 'center' }}>
-                  <Typography variant="h6">
-                    {chats.find(c => c.chat.id === selectedChat)?.chat.users?.find(u => u.id !== currentUserId)?.description || 'Чат'}
-                  </Typography>
-                  <Button
-                    variant="contained"
-                    startIcon={<VideoCallIcon />}
-                    onClick={handleStartVideoCall}
-                    sx={{ ml: 2 }}
-                  >
-                    Видеозвонок
-                  </Button>
+                  <Box>
+                    <Typography variant="h6">
+                      {selectedChatData ? chatTitle(selectedChatData) : 'Чат'}
+                    </Typography>
+                    <Typography variant="caption" color="text.secondary">
+                      {typingUsers.length > 0
+                        ? isGroup
+                          ? `${typingUsers
+                              .map((id) => selectedChatData?.users.find((u) => u.id === id)?.description || 'Участник')
+                              .join(', ')} печатает…`
+                          : 'печатает…'
+                        : isGroup
+                          ? `Участников: ${selectedChatData?.users.length ?? 0}`
+                          : formatPresence(selectedOtherUser?.id)}
+                    </Typography>
+                  </Box>
+                  <Box>
+                    {isGroup && (
+                      <Button startIcon={<GroupIcon />} onClick={() => setMembersOpen(true)}>
+                        Участники
+                      </Button>
+                    )}
+                    <Button
+                      variant="contained"
+                      startIcon={<VideoCallIcon />}
+                      onClick={handleStartVideoCall}
+                      sx={{ ml: 2 }}
+                    >
+                      Видеозвонок
+                    </Button>
+                  </Box>
                 </Box>
 
+                {jumpTarget && (
+                  <Alert
+                    severity="info"
+                    action={
+                      <Button color="inherit" size="small" onClick={() => setJumpTarget(null)}>
+                        К последним
+                      </Button>
+                    }
+                    sx={{ borderRadius: 0 }}
+                  >
+                    Сообщения вокруг найденного
+                  </Alert>
+                )}
+
                 {/* Область сообщений */}
                 <Box sx={{ flex: 1, overflow: 'auto', p: 2 }}>
                   {messagesLoading ? (
@@ -520,12 +954,21 @@ export const Chat = () => {
                     </Box>
                   ) : (
                     <List>
-                      {Array.isArray(messages) && messages.map((message) => (
+                      {Array.isArray(messages) && messages.map((message, index) => isSystemMessage(message) ? (
+                        <ListItem key={message.id} sx={{ justifyContent: 'center' }}>
+                          <Typography variant="caption" color="text.secondary" sx={{ textAlign: 'center' }}>
+                            {formatSystemMessage(message, selectedChatData?.users ?? [])} · {formatMessageTime(message.created_at)}
+                          </Typography>
+                        </ListItem>
+                      ) : (
                         <ListItem
                           key={message.id}
+                          id={`message-${message.id}`}
                           sx={{
-                            flexDirection: 'column',
-                            alignItems: isMyMessage(message) ? 'flex-end' : 'flex-start',
+                            flexDirection: isMyMessage(message) ? 'row-reverse' : 'row',
+                            alignItems: 'flex-start',
+                            gap: 0.5,
+                            bgcolor: message.id === jumpTarget?.messageId ? 'action.selected' : undefined,
                           }}
                         >
                           <Box sx={{ 
@@ -541,11 +984,48 @@ export const Chat = () => {
                                 {message.user?.description || 'Неизвестный пользователь'}
                               </Typography>
                             )}
-                            <Typography variant="body1">{message.text}</Typography>
+                            {message.reply_to && (
+                              <MessageQuote
+                                reply={message.reply_to}
+                                users={selectedChatData?.users ?? []}
+                                onClick={() => scrollToMessage(message.reply_to!.id)}
+                              />
+                            )}
+                            {message.deleted_at ? (
+                              <Typography variant="body1" sx={{ fontStyle: 'italic', opacity: 0.7 }}>
+                                Сообщение удалено
+                              </Typography>
+                            ) : (
+                              <>
+                                {message.text && <Typography variant="body1">{message.text}</Typography>}
+                                <MessageAttachments attachments={message.attachments} />
+                              </>
+                            )}
                             <Typography variant="caption" sx={{ display: 'block', mt: 0.5, opacity: 0.7 }}>
                               {formatMessageTime(message.created_at)}
+                              {message.edited_at && !message.deleted_at && ' · изменено'}
+                              {isMyMessage(message) && (index <= otherReadIndex ? ' · Прочитано' : ' · Отправлено')}
                             </Typography>
+                            <MessageReactions
+                              message={message}
+                              currentUserId={currentUserId}
+                              onToggle={(emoji, reacted) => handleToggleReaction(message, emoji, reacted)}
+                            />
                           </Box>
+                          {!message.deleted_at && (
+                            <MessageActions
+                              message={message}
+                              isOwn={isMyMessage(message)}
+                              onReply={() => {
+                                setEditingMessage(null);
+                                setReplyTo(message);
+                              }}
+                              onEdit={() => handleStartEdit(message)}
+                              onDelete={() => handleDeleteMessage(message)}
+                              onShowEdits={() => setEditsMessage(message)}
+                              onReact={(emoji) => handleToggleReaction(message, emoji, false)}
+                            />
+                          )}
                         </ListItem>
                       ))}
                     </List>
@@ -559,17 +1039,92 @@ export const Chat = () => {
                   onSubmit={handleSendMessage}
                   sx={{ p: 2, borderTop: 1, borderColor: 'divider' }}
                 >
+                  {attachmentError && (
+                    <Alert severity="error" onClose={() => setAttachmentError(null)} sx={{ mb: 1 }}>
+                      {attachmentError}
+                    </Alert>
+                  )}
+                  {(replyTo || editingMessage) && (
+                    <Box sx={{ display: 'flex', alignItems: 'center', gap: 1, mb: 1 }}>
+                      {replyTo ? <ReplyIcon color="action" /> : <EditIcon color="action" />}
+                      <Box sx={{ flex: 1, minWidth: 0 }}>
+                        {replyTo ? (
+                          <MessageQuote
+                            reply={{ id: replyTo.id, user_id: replyTo.user.id, text: replyTo.text, deleted: false }}
+                            users={selectedChatData?.users ?? []}
+                            onClick={() => scrollToMessage(replyTo.id)}
+                          />
+                        ) : (
+                          <Typography variant="caption" color="text.secondary">
+                            Редактирование сообщения
+                          </Typography>
+                        )}
+                      </Box>
+                      <IconButton
+                        size="small"
+                        title="Отмена"
+                        onClick={() => (replyTo ? setReplyTo(null) : handleCancelEdit())}
+                      >
+                        <CloseIcon fontSize="small" />
+                      </IconButton>
+                    </Box>
+                  )}
+                  {(pendingAttachments.length > 0 || shareCv || uploading) && (
+                    <Box sx={{ display: 'flex', flexWrap: 'wrap', gap: 1, mb: 1 }}>
+                      {pendingAttachments.map((attachment) => (
+                        <Chip
+                          key={attachment.id}
+                          icon={attachmentIcon(attachment.kind)}
+                          label={`${attachment.filename} · ${formatFileSize(attachment.size)}`}
+                          onDelete={() => handleRemoveAttachment(attachment)}
+                        />
+                      ))}
+                      {shareCv && (
+                        <Chip
+                          icon={<DescriptionIcon />}
+                          label="Моё резюме"
+                          color="primary"
+                          variant="outlined"
+                          onDelete={() => setShareCv(false)}
+                        />
+                      )}
+                      {uploading && <CircularProgress size={24} />}
+                    </Box>
+                  )}
                   <Box sx={{ display: 'flex', gap: 1 }}>
+                    <input
+                      ref={fileInputRef}
+                      type="file"
+                      multiple
+                      hidden
+                      onChange={(e) => handleAttachFiles(e.target.files)}
+                    />
+                    <IconButton
+                      title="Прикрепить файл"
+                      onClick={() => fileInputRef.current?.click()}
+                      disabled={!!editingMessage || uploading || pendingAttachments.length >= MAX_MESSAGE_ATTACHMENTS}
+                    >
+                      <AttachFileIcon />
+                    </IconButton>
+                    <IconButton
+                      title="Отправить моё резюме"
+                      color={shareCv ? 'primary' : 'default'}
+                      onClick={() => setShareCv((prev) => !prev)}
+                      disabled={!!editingMessage}
+                    >
+                      <DescriptionIcon />
+                    </IconButton>
                     <TextField
                       fullWidth
                       value={newMessage}
-                      onChange={(e) => setNewMessage(e.target.value)}
+                      onChange={(e) => handleMessageChange(e.target.value)}
+                      onBlur={() => sendTyping(false)}
                       placeholder="Введите сообщение..."
                     />
                     <IconButton
                       type="submit"
                       color="primary"
-                      disabled={!newMessage.trim()}
+                      disabled={!canSend}
                     >
                       <SendIcon />
                     </IconButton>
@@ -599,6 +1154,51 @@ export const Chat = () => {
         </Box>
       </Container>
 
+      <CreateGroupChatDialog
+        open={createGroupOpen}
+        contacts={contacts}
+        onClose={() => setCreateGroupOpen(false)}
+        onCreated={(chat) => {
+          setCreateGroupOpen(false);
+          queryClient.invalidateQueries({ queryKey: ['chats'] });
+          handleChatSelect(chat.id);
+        }}
+      />
+
+      {selectedChatData && isGroup && (
+        <ChatMembersDialog
+          open={membersOpen}
+          chat={selectedChatData}
+          contacts={contacts}
+          currentUserId={currentUserId}
+          onClose={() => setMembersOpen(false)}
+          onChanged={() => queryClient.invalidateQueries({ queryKey: ['chats'] })}
+          onLeft={() => {
+            setMembersOpen(false);
+            setSelectedChat(null);
+            setMessages([]);
+            queryClient.invalidateQueries({ queryKey: ['chats'] });
+          }}
+        />
+      )}
+
+      <ChatSearchDialog
+        open={searchOpen}
+        chats={chats}
+        currentChatId={selectedChat}
+        chatTitle={chatTitle}
+        onClose={() => setSearchOpen(false)}
+        onSelect={handleJumpToMessage}
+      />
+
+      {selectedChat && (
+        <MessageEditsDialog
+          chatId={selectedChat}
+          message={editsMessage}
+          onClose={() => setEditsMessage(null)}
+        />
+      )}
+
       {/* Компонент видеозвонка - всегда рендерим если есть activeCallId */}
       {activeCallId && (
         <VideoCallWithTranscript
@@ -0,0 +1,60 @@
+import { useEffect, useRef, useState } from 'react';
+import { useParams, useNavigate } from 'react-router-dom';
+import {
+  Box,
+  Button,
+  CircularProgress,
+  Alert,
+  Container,
+} from '@mui/material';
+import { JobService } from '../api/job/services/JobService';
+import { ApiError } from '../api/job/core/ApiError';
+
+const claimErrors: Record<number, string> = {
+  400: 'Нельзя откликнуться на собственную вакансию',
+  404: 'Приглашение не найдено или срок его действия истёк',
+  409: 'Вы уже откликнулись на эту вакансию',
+};
+
+export const ClaimApplication = () => {
+  const { token } = useParams<{ token: string }>();
+  const navigate = useNavigate();
+  const [error, setError] = useState<string | null>(null);
+  // Ссылка одноразовая, поэтому не отправляем запрос повторно при перемонтировании
+  const claimed = useRef(false);
+
+  useEffect(() => {
+    if (!token || claimed.current) return;
+    claimed.current = true;
+
+    const claim = async () => {
+      try {
+        const job = await JobService.claimSourcedApplication({ token });
+        navigate(`/jobs/${job.id}`, { replace: true });
+      } catch (err) {
+        console.error('Error claiming application:', err);
+        const status = err instanceof ApiError ? err.status : 0;
+        setError(claimErrors[status] || 'Ошибка при принятии приглашения');
+      }
+    };
+
+    claim();
+  }, [token, navigate]);
+
+  if (!error) {
+    return (
+      <Box display="flex" justifyContent="center" my={4}>
+        <CircularProgress />
+      </Box>
+    );
+  }
+
+  return (
+    <Container maxWidth="sm" sx={{ py: 4 }}>
+      <Alert severity="error" sx={{ mb: 2 }}>{error}</Alert>
+      <Button variant="contained" onClick={() => navigate('/jobs')}>
+        К вакансиям
+      </Button>
+    </Container>
+  );
+};
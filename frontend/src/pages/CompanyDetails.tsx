@@ -12,6 +12,7 @@ import {
   CircularProgress,
   Alert,
   IconButton,
+  Avatar,
 } from '@mui/material';
 import {
   Edit as EditIcon,
@@ -19,7 +20,7 @@ import {
   Cancel as CancelIcon,
   Delete as DeleteIcon,
 } from '@mui/icons-material';
-import { CompanyService } from '../api/company';
+import { ApiError, CompanyService } from '../api/company';
 import type { ApiGetCompany, ApiUpdateCompany } from '../api/company';
 
 export const CompanyDetails = () => {
@@ -49,6 +50,31 @@ export const CompanyDetails = () => {
     },
   });
 
+  const uploadLogoMutation = useMutation({
+    mutationFn: (file: File) => CompanyService.uploadCompanyLogo(company!.guid, { file }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['company', id] });
+      setSuccess(true);
+      setError('');
+    },
+    onError: (error: any) => {
+      if (error instanceof ApiError && [400, 413].includes(error.status)) {
+        setError(error.message);
+      } else {
+        setError('Ошибка при загрузке логотипа');
+      }
+      setSuccess(false);
+    },
+  });
+
+  const handleLogoChange = (event: React.ChangeEvent<HTMLInputElement>) => {
+    const file = event.target.files?.[0];
+    if (file) {
+      uploadLogoMutation.mutate(file);
+    }
+    event.target.value = '';
+  };
+
   const deleteCompanyMutation = useMutation({
     mutationFn: () => CompanyService.deleteCompany(id!),
     onSuccess: () => {
@@ -175,6 +201,27 @@ export const CompanyDetails = () => {
             </Alert>
           )}
 
+          <Box sx={{ display: 'flex', alignItems: 'center', mb: 3 }}>
+            <Avatar
+              variant="rounded"
+              src={company.avatar_thumbnails?.medium || company.avatar}
+              sx={{ width: 96, height: 96, mr: 2 }}
+            />
+            <Button
+              component="label"
+              variant="outlined"
+              disabled={uploadLogoMutation.isPending}
+            >
+              {uploadLogoMutation.isPending ? 'Загрузка...' : 'Загрузить логотип'}
+              <input
+                type="file"
+                hidden
+                accept="image/jpeg,image/png,image/gif,image/webp"
+                onChange={handleLogoChange}
+              />
+            </Button>
+          </Box>
+
           <Grid container spacing={3}>
             <Grid item xs={12}>
               <TextField
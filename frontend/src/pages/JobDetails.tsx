@@ -27,6 +27,9 @@ import {
   ListItemIcon,
   Grid,
   Paper,
+  FormControlLabel,
+  Switch,
+  TextField,
 } from '@mui/material';
 import {
   LocationOn as LocationIcon,
@@ -44,15 +47,27 @@ import {
   Search as SearchIcon,
   Star as StarIcon,
   OpenInNew as OpenInNewIcon,
+  PeopleAlt as PeopleIcon,
+  PersonAdd as PersonAddIcon,
+  Description as DescriptionIcon,
+  Forum as ForumIcon,
 } from '@mui/icons-material';
 import { useParams, useNavigate } from 'react-router-dom';
 import { JobService } from '../api/job/services/JobService';
 import { CvService } from '../api/cv/services/CvService';
+import { ChatService, ChatType } from '../api/chat';
 import type { JobDetails as JobDetailsType } from '../api/job/models/JobDetails';
 import { JobApplication } from '../api/job/models/JobApplication';
 import { UpdateApplicationStatusRequest } from '../api/job/models/UpdateApplicationStatusRequest';
+import type { SourcedApplicationResponse } from '../api/job/models/SourcedApplicationResponse';
+import type { CVVersion } from '../api/cv/models/CVVersion';
+import { ApiError } from '../api/job/core/ApiError';
 import type { MatchCandidatesResponse } from '../api/cv/models/MatchCandidatesResponse';
 import type { MatchedCandidate } from '../api/cv/models/MatchedCandidate';
+import type { MatchApplicantsResponse } from '../api/cv/models/MatchApplicantsResponse';
+import type { MatchedApplicant } from '../api/cv/models/MatchedApplicant';
+import type { MatchScoreBreakdown } from '../api/cv/models/MatchScoreBreakdown';
+import { openFile, getOpenFileError } from '../utils/openFile';
 
 export const JobDetails = () => {
   const { jobId } = useParams<{ jobId: string }>();
@@ -67,6 +82,22 @@ export const JobDetails = () => {
   const [selectedApplication, setSelectedApplication] = useState<JobApplication | null>(null);
   const [anchorEl, setAnchorEl] = useState<null | HTMLElement>(null);
   const [matchedCandidates, setMatchedCandidates] = useState<MatchedCandidate[]>([]);
+  const [matchInfo, setMatchInfo] = useState<Omit<MatchCandidatesResponse, 'candidates'> | null>(null);
+  const [applicantMatchLoading, setApplicantMatchLoading] = useState(false);
+  const [applicantMatchDialogOpen, setApplicantMatchDialogOpen] = useState(false);
+  const [matchedApplicants, setMatchedApplicants] = useState<MatchedApplicant[]>([]);
+  const [applicantMatchInfo, setApplicantMatchInfo] = useState<Omit<MatchApplicantsResponse, 'candidates'> | null>(null);
+  const [includeProfiles, setIncludeProfiles] = useState(false);
+  const [fileError, setFileError] = useState<string | null>(null);
+  const [inviteCandidate, setInviteCandidate] = useState<MatchedCandidate | null>(null);
+  const [inviteSendEmail, setInviteSendEmail] = useState(true);
+  const [inviteMessage, setInviteMessage] = useState('');
+  const [inviteLoading, setInviteLoading] = useState(false);
+  const [inviteError, setInviteError] = useState<string | null>(null);
+  const [inviteResult, setInviteResult] = useState<SourcedApplicationResponse | null>(null);
+  const [cvVersions, setCvVersions] = useState<CVVersion[]>([]);
+  const [selectedCvId, setSelectedCvId] = useState('');
+  const [teamRoomLoading, setTeamRoomLoading] = useState(false);
 
   const loadJobDetails = async () => {
     if (!jobId) return;
@@ -89,12 +120,23 @@ export const JobDetails = () => {
     loadJobDetails();
   }, [jobId]);
 
+  // Версии CV нужны только кандидату, который может откликнуться
+  useEffect(() => {
+    if (!jobDetails?.can_apply) return;
+    CvService.listCvVersions()
+      .then((versions) => {
+        setCvVersions(versions);
+        setSelectedCvId(versions.find((version) => version.is_primary)?.id ?? '');
+      })
+      .catch((err) => console.error('Error loading CV versions:', err));
+  }, [jobDetails?.can_apply]);
+
   const handleApply = async () => {
     if (!jobId) return;
 
     try {
       setApplyLoading(true);
-      await JobService.applyToJob(jobId);
+      await JobService.applyToJob(jobId, selectedCvId ? { cv_id: selectedCvId } : undefined);
       setApplyDialogOpen(true);
       await loadJobDetails(); // Refresh to update application status
     } catch (err) {
@@ -105,6 +147,27 @@ export const JobDetails = () => {
     }
   };
 
+  // Комната команды найма: группа, привязанная к вакансии; участников добавляет автор
+  const handleCreateTeamRoom = async () => {
+    if (!jobDetails) return;
+
+    try {
+      setTeamRoomLoading(true);
+      const room = await ChatService.createChat({
+        type: ChatType.GROUP,
+        title: `Команда: ${jobDetails.job.title}`.slice(0, 100),
+        job_id: jobDetails.job.id,
+        users: [],
+      });
+      navigate(`/chat?chatId=${room.id}`);
+    } catch (err) {
+      console.error('Error creating team room:', err);
+      setError('Не удалось создать комнату команды');
+    } finally {
+      setTeamRoomLoading(false);
+    }
+  };
+
   const handleMenuClick = (event: React.MouseEvent<HTMLElement>, application: JobApplication) => {
     setAnchorEl(event.currentTarget);
     setSelectedApplication(application);
@@ -126,7 +189,7 @@ export const JobDetails = () => {
     try {
       await JobService.updateJobApplicationStatus(
         jobId, 
-        application.applicant_id, 
+        application.applicant_id ?? application.id, 
         { status: UpdateApplicationStatusRequest.status.ACCEPTED }
       );
       await loadJobDetails(); // Refresh to show updated status
@@ -143,7 +206,7 @@ export const JobDetails = () => {
     try {
       await JobService.updateJobApplicationStatus(
         jobId, 
-        application.applicant_id, 
+        application.applicant_id ?? application.id, 
         { status: UpdateApplicationStatusRequest.status.REJECTED }
       );
       await loadJobDetails(); // Refresh to show updated status
@@ -154,15 +217,16 @@ export const JobDetails = () => {
     }
   };
 
-  const handleMatchCandidates = async () => {
+  const handleMatchCandidates = async (refresh = false) => {
     if (!jobId) return;
 
     try {
       setMatchLoading(true);
       setError(null);
       
-      const response: MatchCandidatesResponse = await CvService.matchCandidatesFromDatabase(jobId);
-      setMatchedCandidates(response.candidates);
+      const { candidates, ...info }: MatchCandidatesResponse = await CvService.matchCandidatesFromDatabase(jobId, 5, refresh);
+      setMatchedCandidates(candidates);
+      setMatchInfo(info);
       setMatchDialogOpen(true);
     } catch (err) {
       console.error('Error matching candidates:', err);
@@ -172,12 +236,93 @@ export const JobDetails = () => {
     }
   };
 
+  const handleMatchApplicants = async (withProfiles = includeProfiles, refresh = false) => {
+    if (!jobId) return;
+
+    try {
+      setApplicantMatchLoading(true);
+      setError(null);
+
+      const { candidates, ...info }: MatchApplicantsResponse = await CvService.matchApplicants(jobId, 10, withProfiles, refresh);
+      setIncludeProfiles(withProfiles);
+      setMatchedApplicants(candidates);
+      setApplicantMatchInfo(info);
+      setApplicantMatchDialogOpen(true);
+    } catch (err) {
+      console.error('Error matching applicants:', err);
+      setError('Ошибка оценки откликнувшихся кандидатов');
+    } finally {
+      setApplicantMatchLoading(false);
+    }
+  };
+
+  const handleOpenFile = (link: string) => {
+    setFileError(null);
+    openFile(link).catch((err) => {
+      console.error('Error opening file:', err);
+      setFileError(getOpenFileError(err));
+    });
+  };
+
+  const handleOpenInvite = (candidate: MatchedCandidate) => {
+    setInviteCandidate(candidate);
+    setInviteSendEmail(true);
+    setInviteMessage('');
+    setInviteError(null);
+    setInviteResult(null);
+  };
+
+  const handleCloseInvite = () => {
+    setInviteCandidate(null);
+    setInviteResult(null);
+  };
+
+  const handleInvite = async () => {
+    if (!jobId || !inviteCandidate) return;
+
+    try {
+      setInviteLoading(true);
+      setInviteError(null);
+
+      const result = await JobService.sourceCandidate(jobId, {
+        resume_id: inviteCandidate.resume_id,
+        send_email: inviteSendEmail,
+        message: inviteMessage.trim() || null,
+      });
+      setInviteResult(result);
+      await loadJobDetails();
+    } catch (err) {
+      console.error('Error sourcing candidate:', err);
+      const inviteErrors: Record<number, string> = {
+        400: 'Не удалось отправить приглашение: у кандидата нет email или отправка писем не настроена',
+        409: 'Кандидат уже добавлен к этой вакансии',
+      };
+      const status = err instanceof ApiError ? err.status : 0;
+      setInviteError(inviteErrors[status] || 'Ошибка добавления кандидата');
+    } finally {
+      setInviteLoading(false);
+    }
+  };
+
+  const getApplicantName = (application: JobApplication) =>
+    application.applicant_profile?.description || application.sourced_candidate?.name || 'Кандидат';
+
+  const getApplicantEmail = (application: JobApplication) =>
+    application.applicant_profile?.email || application.sourced_candidate?.email || '—';
+
   const getMatchScoreColor = (score: number) => {
     if (score >= 80) return 'success';
     if (score >= 60) return 'warning';
     return 'error';
   };
 
+  const scoreCriteria: { key: keyof MatchScoreBreakdown; label: string }[] = [
+    { key: 'skills', label: 'Навыки' },
+    { key: 'experience', label: 'Опыт' },
+    { key: 'seniority', label: 'Уровень' },
+    { key: 'location', label: 'Локация' },
+  ];
+
   const formatSalary = (salaryFrom?: number, salaryTo?: number) => {
     if (!salaryFrom && !salaryTo) return 'З/п не указана';
     if (salaryFrom && salaryTo) return `${salaryFrom.toLocaleString()} - ${salaryTo.toLocaleString()} ₽`;
@@ -266,6 +411,23 @@ export const JobDetails = () => {
               </Box>
               {!is_author && (
                 <Box>
+                  {can_apply && cvVersions.length > 1 && (
+                    <TextField
+                      select
+                      size="small"
+                      label="Резюме для отклика"
+                      value={selectedCvId}
+                      onChange={(e) => setSelectedCvId(e.target.value)}
+                      sx={{ minWidth: 200, mr: 2 }}
+                    >
+                      {cvVersions.map((version) => (
+                        <MenuItem key={version.id} value={version.id}>
+                          {version.label || version.original_filename || 'Без подписи'}
+                          {version.is_primary && ' (основное)'}
+                        </MenuItem>
+                      ))}
+                    </TextField>
+                  )}
                   {can_apply && (
                     <Button
                       variant="contained"
@@ -355,14 +517,32 @@ export const JobDetails = () => {
               <Typography variant="h6">
                 Отклики ({applications.length})
               </Typography>
-              <Button
-                variant="outlined"
-                startIcon={matchLoading ? <CircularProgress size={20} /> : <SearchIcon />}
-                onClick={handleMatchCandidates}
-                disabled={matchLoading}
-              >
-                {matchLoading ? 'Подбираем...' : 'Подобрать кандидата из моей базы'}
-              </Button>
+              <Box display="flex" gap={1}>
+                <Button
+                  variant="outlined"
+                  startIcon={teamRoomLoading ? <CircularProgress size={20} /> : <ForumIcon />}
+                  onClick={handleCreateTeamRoom}
+                  disabled={teamRoomLoading}
+                >
+                  Комната команды
+                </Button>
+                <Button
+                  variant="outlined"
+                  startIcon={applicantMatchLoading ? <CircularProgress size={20} /> : <PeopleIcon />}
+                  onClick={() => handleMatchApplicants()}
+                  disabled={applicantMatchLoading}
+                >
+                  {applicantMatchLoading ? 'Оцениваем...' : 'Оценить откликнувшихся'}
+                </Button>
+                <Button
+                  variant="outlined"
+                  startIcon={matchLoading ? <CircularProgress size={20} /> : <SearchIcon />}
+                  onClick={() => handleMatchCandidates()}
+                  disabled={matchLoading}
+                >
+                  {matchLoading ? 'Подбираем...' : 'Подобрать кандидата из моей базы'}
+                </Button>
+              </Box>
             </Box>
             
             {applications.length === 0 ? (
@@ -378,25 +558,44 @@ export const JobDetails = () => {
                   <React.Fragment key={application.id}>
                     <ListItem>
                       <ListItemAvatar>
-                        <Avatar src={application.applicant_profile.avatar || undefined}>
+                        <Avatar src={application.applicant_profile?.avatar || undefined}>
                           <PersonIcon />
                         </Avatar>
                       </ListItemAvatar>
                       <ListItemText
-                        primary={application.applicant_profile.description}
+                        primary={getApplicantName(application)}
                         secondary={
                           <Stack spacing={1}>
                             <Box display="flex" alignItems="center" gap={1}>
                               <EmailIcon fontSize="small" />
                               <Typography variant="body2">
-                                {application.applicant_profile.email}
+                                {getApplicantEmail(application)}
                               </Typography>
                             </Box>
                             <Box display="flex" alignItems="center" gap={2}>
                               <Typography variant="body2" color="text.secondary">
-                                Подал отклик: {new Date(application.applied_at).toLocaleDateString('ru-RU')}
+                                {application.source === JobApplication.source.DATABASE ? 'Добавлен' : 'Подал отклик'}: {new Date(application.applied_at).toLocaleDateString('ru-RU')}
                               </Typography>
                               {getApplicationStatusChip(application.status)}
+                              {application.source === JobApplication.source.DATABASE && (
+                                <Chip
+                                  label={application.applicant_id ? 'Из базы резюме' : 'Из базы резюме · ожидает ответа'}
+                                  variant="outlined"
+                                  size="small"
+                                />
+                              )}
+                              {application.cv && (
+                                <Chip
+                                  icon={<DescriptionIcon />}
+                                  label={application.cv.label || 'Резюме'}
+                                  variant="outlined"
+                                  size="small"
+                                  clickable
+                                  onClick={() => {
+                                    openFile(application.cv!.link).catch((err) => setError(getOpenFileError(err)));
+                                  }}
+                                />
+                              )}
                             </Box>
                           </Stack>
                         }
@@ -443,6 +642,17 @@ export const JobDetails = () => {
           </Box>
         </DialogTitle>
         <DialogContent>
+          {fileError && (
+            <Alert severity="error" sx={{ mb: 2 }} onClose={() => setFileError(null)}>
+              {fileError}
+            </Alert>
+          )}
+          {matchInfo && matchInfo.shortlist_size > 0 && (
+            <Typography variant="body2" color="text.secondary" sx={{ mb: 2 }}>
+              Оценено резюме: {matchInfo.shortlist_size}
+              {matchInfo.cached && ` · сохранённый подбор от ${new Date(matchInfo.matched_at).toLocaleString('ru-RU')}`}
+            </Typography>
+          )}
           {matchedCandidates.length === 0 ? (
             <Box textAlign="center" py={4}>
               <PersonIcon sx={{ fontSize: 48, color: 'text.secondary', mb: 2 }} />
@@ -473,15 +683,39 @@ export const JobDetails = () => {
                             #{index + 1} кандидат
                           </Typography>
                         </Box>
+                        <Box display="flex" flexWrap="wrap" gap={1}>
+                          {scoreCriteria.map(({ key, label }) => {
+                            const score = candidate.scores[key];
+                            return (
+                              <Chip
+                                key={key}
+                                label={`${label}: ${score ?? '—'}`}
+                                color={score == null ? 'default' : getMatchScoreColor(score)}
+                                variant="outlined"
+                                size="small"
+                              />
+                            );
+                          })}
+                        </Box>
                       </Box>
-                      <Button
-                        variant="outlined"
-                        size="small"
-                        startIcon={<OpenInNewIcon />}
-                        onClick={() => window.open(candidate.file_url, '_blank')}
-                      >
-                        Резюме
-                      </Button>
+                      <Stack spacing={1}>
+                        <Button
+                          variant="outlined"
+                          size="small"
+                          startIcon={<OpenInNewIcon />}
+                          onClick={() => handleOpenFile(candidate.file_url)}
+                        >
+                          Резюме
+                        </Button>
+                        <Button
+                          variant="contained"
+                          size="small"
+                          startIcon={<PersonAddIcon />}
+                          onClick={() => handleOpenInvite(candidate)}
+                        >
+                          Пригласить
+                        </Button>
+                      </Stack>
                     </Box>
                     
                     <Typography variant="body2" color="text.secondary" sx={{ fontStyle: 'italic' }}>
@@ -498,6 +732,11 @@ export const JobDetails = () => {
         </DialogContent>
         <DialogActions>
           <Button onClick={() => setMatchDialogOpen(false)}>Закрыть</Button>
+          {matchInfo?.cached && (
+            <Button onClick={() => handleMatchCandidates(true)} disabled={matchLoading}>
+              {matchLoading ? 'Подбираем...' : 'Оценить заново'}
+            </Button>
+          )}
           <Button 
             variant="contained" 
             onClick={() => navigate('/resume-database')}
@@ -507,22 +746,227 @@ export const JobDetails = () => {
         </DialogActions>
       </Dialog>
 
+      {/* Invite Candidate Dialog */}
+      <Dialog open={Boolean(inviteCandidate)} onClose={handleCloseInvite} maxWidth="sm" fullWidth>
+        <DialogTitle>Пригласить {inviteCandidate?.candidate_name}</DialogTitle>
+        <DialogContent>
+          {inviteResult ? (
+            <Stack spacing={2} sx={{ mt: 1 }}>
+              <Alert severity={inviteResult.email_sent || !inviteSendEmail ? 'success' : 'warning'}>
+                {inviteResult.email_sent
+                  ? 'Кандидат добавлен к вакансии, приглашение отправлено на почту'
+                  : inviteSendEmail
+                    ? 'Кандидат добавлен к вакансии, но письмо отправить не удалось. Передайте ссылку кандидату самостоятельно'
+                    : 'Кандидат добавлен к вакансии. Передайте ссылку кандидату самостоятельно'}
+              </Alert>
+              <TextField
+                label="Ссылка для кандидата"
+                value={inviteResult.claim_url}
+                helperText="Ссылка показывается только один раз"
+                InputProps={{ readOnly: true }}
+                fullWidth
+              />
+            </Stack>
+          ) : (
+            <Stack spacing={2} sx={{ mt: 1 }}>
+              {inviteError && <Alert severity="error">{inviteError}</Alert>}
+              <Typography variant="body2" color="text.secondary">
+                Кандидат появится в списке откликов. Когда он перейдёт по ссылке и войдёт на платформу, заявка будет привязана к его профилю.
+              </Typography>
+              <FormControlLabel
+                control={
+                  <Switch
+                    checked={inviteSendEmail}
+                    onChange={(e) => setInviteSendEmail(e.target.checked)}
+                  />
+                }
+                label="Отправить приглашение на почту"
+              />
+              {inviteSendEmail && (
+                <TextField
+                  label="Сообщение кандидату"
+                  value={inviteMessage}
+                  onChange={(e) => setInviteMessage(e.target.value)}
+                  inputProps={{ maxLength: 2000 }}
+                  multiline
+                  rows={4}
+                  fullWidth
+                />
+              )}
+            </Stack>
+          )}
+        </DialogContent>
+        <DialogActions>
+          <Button onClick={handleCloseInvite}>{inviteResult ? 'Готово' : 'Отмена'}</Button>
+          {!inviteResult && (
+            <Button variant="contained" onClick={handleInvite} disabled={inviteLoading}>
+              {inviteLoading ? 'Добавляем...' : 'Пригласить'}
+            </Button>
+          )}
+        </DialogActions>
+      </Dialog>
+
+      {/* Matched Applicants Dialog */}
+      <Dialog open={applicantMatchDialogOpen} onClose={() => setApplicantMatchDialogOpen(false)} maxWidth="md" fullWidth>
+        <DialogTitle>
+          <Box display="flex" alignItems="center" gap={1}>
+            <PeopleIcon color="primary" />
+            Оценка кандидатов на платформе
+          </Box>
+        </DialogTitle>
+        <DialogContent>
+          {fileError && (
+            <Alert severity="error" sx={{ mb: 2 }} onClose={() => setFileError(null)}>
+              {fileError}
+            </Alert>
+          )}
+          <Box display="flex" justifyContent="space-between" alignItems="center" sx={{ mb: 2 }}>
+            <Typography variant="body2" color="text.secondary">
+              {applicantMatchInfo && applicantMatchInfo.shortlist_size > 0 && (
+                <>
+                  Оценено пользователей: {applicantMatchInfo.shortlist_size}
+                  {applicantMatchInfo.cached && ` · сохранённый подбор от ${new Date(applicantMatchInfo.matched_at).toLocaleString('ru-RU')}`}
+                </>
+              )}
+            </Typography>
+            <FormControlLabel
+              control={
+                <Switch
+                  checked={includeProfiles}
+                  onChange={(e) => handleMatchApplicants(e.target.checked)}
+                  disabled={applicantMatchLoading}
+                />
+              }
+              label="Открытые к предложениям"
+            />
+          </Box>
+          {matchedApplicants.length === 0 ? (
+            <Box textAlign="center" py={4}>
+              <PersonIcon sx={{ fontSize: 48, color: 'text.secondary', mb: 2 }} />
+              <Typography variant="h6" gutterBottom>
+                Кандидаты не найдены
+              </Typography>
+              <Typography color="text.secondary">
+                На вакансию пока никто не откликнулся{includeProfiles ? ', и нет открытых к предложениям профилей' : ''}
+              </Typography>
+            </Box>
+          ) : (
+            <Grid container spacing={2}>
+              {matchedApplicants.map((applicant, index) => (
+                <Grid item xs={12} key={applicant.profile_id}>
+                  <Paper sx={{ p: 2, border: 1, borderColor: 'divider' }}>
+                    <Box display="flex" justifyContent="space-between" alignItems="flex-start" mb={2}>
+                      <Box display="flex" gap={2} flex={1}>
+                        <Avatar src={applicant.avatar || undefined}>
+                          <PersonIcon />
+                        </Avatar>
+                        <Box flex={1}>
+                          <Typography variant="h6">
+                            {applicant.name}
+                          </Typography>
+                          <Typography variant="body2" color="text.secondary" gutterBottom>
+                            {applicant.email}
+                          </Typography>
+                          <Box display="flex" alignItems="center" gap={2} mb={1}>
+                            <Chip
+                              label={`${applicant.match_score}% соответствие`}
+                              color={getMatchScoreColor(applicant.match_score)}
+                              size="small"
+                            />
+                            {applicant.application_status
+                              ? getApplicationStatusChip(applicant.application_status)
+                              : <Chip label="Не откликался" variant="outlined" size="small" />}
+                            <Typography variant="body2" color="text.secondary">
+                              #{index + 1} кандидат
+                            </Typography>
+                          </Box>
+                          <Box display="flex" flexWrap="wrap" gap={1}>
+                            {scoreCriteria.map(({ key, label }) => {
+                              const score = applicant.scores[key];
+                              return (
+                                <Chip
+                                  key={key}
+                                  label={`${label}: ${score ?? '—'}`}
+                                  color={score == null ? 'default' : getMatchScoreColor(score)}
+                                  variant="outlined"
+                                  size="small"
+                                />
+                              );
+                            })}
+                          </Box>
+                        </Box>
+                      </Box>
+                      <Stack spacing={1}>
+                        <Button
+                          variant="outlined"
+                          size="small"
+                          startIcon={<ViewIcon />}
+                          onClick={() => handleViewProfile(applicant.profile_id)}
+                        >
+                          Профиль
+                        </Button>
+                        {applicant.cv_link && (
+                          <Button
+                            variant="outlined"
+                            size="small"
+                            startIcon={<OpenInNewIcon />}
+                            onClick={() => handleOpenFile(applicant.cv_link!)}
+                          >
+                            Резюме
+                          </Button>
+                        )}
+                      </Stack>
+                    </Box>
+
+                    <Typography variant="body2" color="text.secondary" sx={{ fontStyle: 'italic' }}>
+                      Почему подходит:
+                    </Typography>
+                    <Typography variant="body2" sx={{ mt: 1, whiteSpace: 'pre-wrap' }}>
+                      {applicant.reasoning}
+                    </Typography>
+                  </Paper>
+                </Grid>
+              ))}
+            </Grid>
+          )}
+        </DialogContent>
+        <DialogActions>
+          <Button onClick={() => setApplicantMatchDialogOpen(false)}>Закрыть</Button>
+          {applicantMatchInfo?.cached && (
+            <Button onClick={() => handleMatchApplicants(includeProfiles, true)} disabled={applicantMatchLoading}>
+              {applicantMatchLoading ? 'Оцениваем...' : 'Оценить заново'}
+            </Button>
+          )}
+        </DialogActions>
+      </Dialog>
+
       {/* Application Actions Menu */}
       <Menu
         anchorEl={anchorEl}
         open={Boolean(anchorEl)}
         onClose={handleMenuClose}
       >
-        <MenuItem onClick={() => {
-          if (selectedApplication) {
-            handleViewProfile(selectedApplication.applicant_profile.id);
-          }
-        }}>
-          <ListItemIcon>
-            <ViewIcon fontSize="small" />
-          </ListItemIcon>
-          Посмотреть профиль
-        </MenuItem>
+        {selectedApplication?.applicant_profile ? (
+          <MenuItem onClick={() => handleViewProfile(selectedApplication.applicant_profile!.id)}>
+            <ListItemIcon>
+              <ViewIcon fontSize="small" />
+            </ListItemIcon>
+            Посмотреть профиль
+          </MenuItem>
+        ) : (
+          <MenuItem
+            disabled={!selectedApplication?.sourced_candidate?.resume_id}
+            onClick={() => {
+              navigate('/resume-database');
+              handleMenuClose();
+            }}
+          >
+            <ListItemIcon>
+              <ViewIcon fontSize="small" />
+            </ListItemIcon>
+            Открыть базу резюме
+          </MenuItem>
+        )}
         <MenuItem onClick={() => {
           if (selectedApplication) {
             handleAcceptApplication(selectedApplication);
@@ -14,16 +14,20 @@ import {
   Pagination,
   Container,
   Stack,
+  Paper,
 } from '@mui/material';
 import {
   Search as SearchIcon,
   LocationOn as LocationIcon,
   Business as BusinessIcon,
   Payment as PaymentIcon,
+  Star as StarIcon,
 } from '@mui/icons-material';
 import { useNavigate } from 'react-router-dom';
 import { JobService } from '../api/job/services/JobService';
 import { Job } from '../api/job/models/Job';
+import type { RecommendedJobsResponse } from '../api/job/models/RecommendedJobsResponse';
+import type { MatchScoreBreakdown } from '../api/job/models/MatchScoreBreakdown';
 
 export const Jobs = () => {
   const navigate = useNavigate();
@@ -34,6 +38,8 @@ export const Jobs = () => {
   const [searchInput, setSearchInput] = useState('');
   const [page, setPage] = useState(1);
   const [totalPages, setTotalPages] = useState(1);
+  const [recommendations, setRecommendations] = useState<RecommendedJobsResponse | null>(null);
+  const [recommendationsLoading, setRecommendationsLoading] = useState(false);
   const limit = 20;
 
   const loadJobs = async (searchTerm?: string, pageNum = 1) => {
@@ -56,10 +62,27 @@ export const Jobs = () => {
     }
   };
 
+  const loadRecommendations = async (refresh = false) => {
+    try {
+      setRecommendationsLoading(true);
+      const response = await JobService.getRecommendedJobs(5, refresh);
+      setRecommendations(response);
+    } catch (err) {
+      // Рекомендации необязательны: без профиля или при недоступности модели блок просто не показываем
+      console.error('Error loading recommendations:', err);
+    } finally {
+      setRecommendationsLoading(false);
+    }
+  };
+
   useEffect(() => {
     loadJobs(search, page);
   }, [search, page]);
 
+  useEffect(() => {
+    loadRecommendations();
+  }, []);
+
   const handleSearch = (e: React.FormEvent) => {
     e.preventDefault();
     setSearch(searchInput);
@@ -104,12 +127,99 @@ export const Jobs = () => {
     return colors[type] || 'primary';
   };
 
+  const getMatchScoreColor = (score: number) => {
+    if (score >= 80) return 'success';
+    if (score >= 60) return 'warning';
+    return 'error';
+  };
+
+  const scoreCriteria: { key: keyof MatchScoreBreakdown; label: string }[] = [
+    { key: 'skills', label: 'Навыки' },
+    { key: 'experience', label: 'Опыт' },
+    { key: 'seniority', label: 'Уровень' },
+    { key: 'location', label: 'Локация' },
+  ];
+
   return (
     <Container maxWidth="lg" sx={{ py: 4 }}>
       <Typography variant="h4" component="h1" gutterBottom>
         Вакансии
       </Typography>
 
+      {/* Recommendations */}
+      {recommendations && recommendations.jobs.length > 0 && (
+        <Box sx={{ mb: 4 }}>
+          <Box display="flex" justifyContent="space-between" alignItems="center" mb={2}>
+            <Box display="flex" alignItems="center" gap={1}>
+              <StarIcon color="primary" />
+              <Typography variant="h6" component="h2">
+                Рекомендованные вакансии
+              </Typography>
+            </Box>
+            {recommendations.cached && (
+              <Button size="small" onClick={() => loadRecommendations(true)} disabled={recommendationsLoading}>
+                {recommendationsLoading ? 'Подбираем...' : 'Обновить'}
+              </Button>
+            )}
+          </Box>
+          <Typography variant="body2" color="text.secondary" sx={{ mb: 2 }}>
+            Подобраны по вашему профилю, опыту работы и резюме
+            {recommendations.cached && ` · подборка от ${new Date(recommendations.matched_at).toLocaleString('ru-RU')}`}
+          </Typography>
+          <Grid container spacing={2}>
+            {recommendations.jobs.map((recommendation) => (
+              <Grid item xs={12} key={recommendation.job.id}>
+                <Paper
+                  sx={{
+                    p: 2,
+                    border: 1,
+                    borderColor: 'divider',
+                    cursor: 'pointer',
+                    '&:hover': {
+                      boxShadow: 3,
+                    },
+                  }}
+                  onClick={() => handleJobClick(recommendation.job.id)}
+                >
+                  <Box display="flex" justifyContent="space-between" alignItems="flex-start" mb={1}>
+                    <Box flex={1}>
+                      <Typography variant="subtitle1" fontWeight="bold">
+                        {recommendation.job.title}
+                      </Typography>
+                      <Typography variant="body2" color="text.secondary">
+                        {recommendation.job.company_name} · {recommendation.job.location}
+                      </Typography>
+                    </Box>
+                    <Chip
+                      label={`${recommendation.match_score}% соответствие`}
+                      color={getMatchScoreColor(recommendation.match_score)}
+                      size="small"
+                    />
+                  </Box>
+                  <Box display="flex" flexWrap="wrap" gap={1} mb={1}>
+                    {scoreCriteria.map(({ key, label }) => {
+                      const score = recommendation.scores[key];
+                      return (
+                        <Chip
+                          key={key}
+                          label={`${label}: ${score ?? '—'}`}
+                          color={score == null ? 'default' : getMatchScoreColor(score)}
+                          variant="outlined"
+                          size="small"
+                        />
+                      );
+                    })}
+                  </Box>
+                  <Typography variant="body2" sx={{ whiteSpace: 'pre-wrap' }}>
+                    {recommendation.reasoning}
+                  </Typography>
+                </Paper>
+              </Grid>
+            ))}
+          </Grid>
+        </Box>
+      )}
+
       {/* Search Form */}
       <Box component="form" onSubmit={handleSearch} sx={{ mb: 4 }}>
         <TextField
@@ -1,5 +1,5 @@
 import { useState } from 'react';
-import { Link as RouterLink } from 'react-router-dom';
+import { Link as RouterLink, useLocation } from 'react-router-dom';
 import {
   Container,
   Box,
@@ -17,6 +17,7 @@ export const Login = () => {
   const [password, setPassword] = useState('');
   const [error, setError] = useState('');
   const { login } = useAuth();
+  const location = useLocation();
 
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault();
@@ -102,7 +103,7 @@ export const Login = () => {
               Войти
             </Button>
             <Box sx={{ display: 'flex', justifyContent: 'space-between' }}>
-              <Link component={RouterLink} to="/register" variant="body2">
+              <Link component={RouterLink} to="/register" state={location.state} variant="body2">
                 Нет аккаунта? Зарегистрироваться
               </Link>
               <Link component={RouterLink} to="/restore" variant="body2">
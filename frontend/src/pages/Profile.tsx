@@ -24,10 +24,13 @@ import {
   DialogTitle,
   DialogContent,
   DialogActions,
+  FormControlLabel,
+  Switch,
 } from '@mui/material';
 import { Edit as EditIcon, Save as SaveIcon, Cancel as CancelIcon, Add as AddIcon, Delete as DeleteIcon } from '@mui/icons-material';
-import { ProfileService } from '../api/profile';
+import { ApiError, ProfileService } from '../api/profile';
 import type { ApiGetProfile, ApiUpdateProfile, Experience } from '../api/profile';
+import { openFile, getOpenFileError } from '../utils/openFile';
 
 interface ExperienceFormData {
   company_name: string;
@@ -84,6 +87,31 @@ export const Profile = () => {
     },
   });
 
+  const uploadAvatarMutation = useMutation({
+    mutationFn: (file: File) => ProfileService.uploadOwnAvatar({ file }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['profile'] });
+      setSuccess(true);
+      setError('');
+    },
+    onError: (error: any) => {
+      if (error instanceof ApiError && [400, 413].includes(error.status)) {
+        setError(error.message);
+      } else {
+        setError('Ошибка при загрузке аватара');
+      }
+      setSuccess(false);
+    },
+  });
+
+  const handleAvatarChange = (event: React.ChangeEvent<HTMLInputElement>) => {
+    const file = event.target.files?.[0];
+    if (file) {
+      uploadAvatarMutation.mutate(file);
+    }
+    event.target.value = '';
+  };
+
   const updateExperienceMutation = useMutation({
     mutationFn: (data: Experience) => ProfileService.storeOwnExperience(data),
     onSuccess: () => {
@@ -131,10 +159,16 @@ export const Profile = () => {
         gender: profile.gender,
         avatar: profile.avatar,
         cv: profile.cv,
+        open_to_offers: profile.open_to_offers,
       });
     }
   }, [profile]);
 
+  const handleOpenCv = (link: string) => {
+    setError('');
+    openFile(link).catch((err) => setError(getOpenFileError(err)));
+  };
+
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault();
     updateProfileMutation.mutate(formData);
@@ -238,10 +272,25 @@ export const Profile = () => {
       {/* Profile Header */}
       <Paper sx={{ p: 3, mb: 3, position: 'relative' }}>
         <Box sx={{ display: 'flex', alignItems: 'center', mb: 3 }}>
-          <Avatar
-            src={profile?.avatar}
-            sx={{ width: 120, height: 120, mr: 3 }}
-          />
+          <Box sx={{ display: 'flex', flexDirection: 'column', alignItems: 'center', mr: 3 }}>
+            <Avatar
+              src={profile?.avatar_thumbnails?.medium || profile?.avatar}
+              sx={{ width: 120, height: 120, mb: 1 }}
+            />
+            <Button
+              component="label"
+              size="small"
+              disabled={uploadAvatarMutation.isPending}
+            >
+              {uploadAvatarMutation.isPending ? 'Загрузка...' : 'Изменить фото'}
+              <input
+                type="file"
+                hidden
+                accept="image/jpeg,image/png,image/gif,image/webp"
+                onChange={handleAvatarChange}
+              />
+            </Button>
+          </Box>
           <Box sx={{ flex: 1 }}>
             <Box sx={{ display: 'flex', justifyContent: 'space-between', alignItems: 'flex-start' }}>
               <Typography variant="h4" gutterBottom>
@@ -280,9 +329,7 @@ export const Profile = () => {
             <Grid item xs={12}>
               <Button
                 variant="outlined"
-                href={profile.cv}
-                target="_blank"
-                rel="noopener noreferrer"
+                onClick={() => handleOpenCv(profile.cv!)}
               >
                 Скачать резюме
               </Button>
@@ -431,6 +478,7 @@ export const Profile = () => {
                   gender: profile?.gender,
                   avatar: profile?.avatar,
                   cv: profile?.cv,
+                  open_to_offers: profile?.open_to_offers,
                 });
               }}>
                 <CancelIcon />
@@ -509,6 +557,17 @@ export const Profile = () => {
                 onChange={handleChange}
               />
             </Grid>
+            <Grid item xs={12}>
+              <FormControlLabel
+                control={
+                  <Switch
+                    checked={formData.open_to_offers || false}
+                    onChange={(e) => setFormData((prev) => ({ ...prev, open_to_offers: e.target.checked }))}
+                  />
+                }
+                label="Открыт к предложениям: работодатели смогут находить мой профиль при подборе кандидатов"
+              />
+            </Grid>
           </Grid>
         </Paper>
       )}
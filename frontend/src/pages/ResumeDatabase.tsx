@@ -1,5 +1,5 @@
 import React, { useState, useCallback } from 'react';
-import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
+import { useQuery, useMutation, useQueryClient, keepPreviousData } from '@tanstack/react-query';
 import {
   Container,
   Box,
@@ -25,6 +25,9 @@ import {
   DialogTitle,
   DialogContent,
   DialogActions,
+  LinearProgress,
+  TextField,
+  MenuItem,
 } from '@mui/material';
 import {
   CloudUpload as CloudUploadIcon,
@@ -34,35 +37,300 @@ import {
   Description as DescriptionIcon,
   AccessTime as AccessTimeIcon,
   OpenInNew as OpenInNewIcon,
+  Search as SearchIcon,
+  Delete as DeleteIcon,
+  CreateNewFolder as CreateNewFolderIcon,
 } from '@mui/icons-material';
 import { CvService } from '../api/cv';
-import type { ResumeRecord, UploadDatabaseResponse } from '../api/cv';
+import { IngestionFile, IngestionJob } from '../api/cv';
+import type { ResumeFolder, ResumeRecord, ResumeSearchResult, SnippetFragment } from '../api/cv';
+import { openFile, getOpenFileError } from '../utils/openFile';
+
+interface ResumeSearch {
+  q: string;
+  minAge?: number;
+  maxAge?: number;
+  minExperience?: number;
+  maxExperience?: number;
+}
+
+const emptySearchForm = { q: '', minAge: '', maxAge: '', minExperience: '', maxExperience: '' };
+
+const parseNumber = (value: string): number | undefined => {
+  const parsed = parseFloat(value.replace(',', '.'));
+  return Number.isNaN(parsed) ? undefined : parsed;
+};
+
+const renderSnippet = (snippet: SnippetFragment[]) =>
+  snippet.map((fragment, index) =>
+    fragment.highlight ? <mark key={index}>{fragment.text}</mark> : <React.Fragment key={index}>{fragment.text}</React.Fragment>
+  );
+
+const ingestionFileStatusLabels: Record<IngestionFile.status, string> = {
+  [IngestionFile.status.PENDING]: 'В очереди',
+  [IngestionFile.status.PROCESSING]: 'Обрабатывается',
+  [IngestionFile.status.SUCCEEDED]: 'Готово',
+  [IngestionFile.status.FAILED]: 'Ошибка',
+  [IngestionFile.status.SKIPPED]: 'Уже загружен',
+};
+
+const ingestionFileStatusColors: Record<IngestionFile.status, 'default' | 'info' | 'success' | 'error' | 'warning'> = {
+  [IngestionFile.status.PENDING]: 'default',
+  [IngestionFile.status.PROCESSING]: 'info',
+  [IngestionFile.status.SUCCEEDED]: 'success',
+  [IngestionFile.status.FAILED]: 'error',
+  [IngestionFile.status.SKIPPED]: 'warning',
+};
+
+const ingestionStageLabels: Record<IngestionFile.stage, string> = {
+  [IngestionFile.stage.ARCHIVE]: 'Чтение архива',
+  [IngestionFile.stage.VALIDATION]: 'Проверка формата',
+  [IngestionFile.stage.STORAGE]: 'Сохранение файла',
+  [IngestionFile.stage.EXTRACTION]: 'Извлечение текста',
+  [IngestionFile.stage.ANALYSIS]: 'Анализ',
+  [IngestionFile.stage.DATABASE]: 'Сохранение в базу',
+};
+
+const parseTags = (value: string): string[] =>
+  value.split(',').map((tag) => tag.trim()).filter((tag) => tag !== '');
+
+// Теги, заметки и папки резюме в раскрытой строке таблицы
+const ResumeAnnotations = ({ resume, folders }: { resume: ResumeRecord; folders: ResumeFolder[] }) => {
+  const queryClient = useQueryClient();
+  const [tags, setTags] = useState(resume.tags.join(', '));
+  const [notes, setNotes] = useState(resume.notes);
+
+  const invalidate = () => {
+    queryClient.invalidateQueries({ queryKey: ['resumeDatabase'] });
+    queryClient.invalidateQueries({ queryKey: ['resumeSearch'] });
+    queryClient.invalidateQueries({ queryKey: ['resumeFolders'] });
+  };
+
+  const updateMutation = useMutation({
+    mutationFn: () => CvService.updateResume(resume.id, { tags: parseTags(tags), notes }),
+    onSuccess: (data) => {
+      setTags(data.tags.join(', '));
+      invalidate();
+    },
+  });
+
+  const folderMutation = useMutation({
+    mutationFn: ({ folderId, inFolder }: { folderId: string; inFolder: boolean }) =>
+      inFolder
+        ? CvService.removeResumeFromFolder(folderId, resume.id)
+        : CvService.addResumeToFolder(folderId, resume.id),
+    onSuccess: invalidate,
+  });
+
+  const isChanged = tags !== resume.tags.join(', ') || notes !== resume.notes;
+
+  return (
+    <Box sx={{ mt: 2 }}>
+      <Grid container spacing={2}>
+        <Grid item xs={12} md={6}>
+          <TextField
+            fullWidth
+            size="small"
+            label="Теги через запятую"
+            value={tags}
+            onChange={(e) => setTags(e.target.value)}
+          />
+        </Grid>
+        <Grid item xs={12} md={6}>
+          <TextField
+            fullWidth
+            size="small"
+            multiline
+            minRows={2}
+            label="Заметки"
+            value={notes}
+            onChange={(e) => setNotes(e.target.value)}
+          />
+        </Grid>
+      </Grid>
+      <Box sx={{ display: 'flex', gap: 1, mt: 1, alignItems: 'center' }}>
+        <Button
+          size="small"
+          variant="contained"
+          onClick={() => updateMutation.mutate()}
+          disabled={!isChanged || updateMutation.isPending}
+        >
+          Сохранить
+        </Button>
+        {updateMutation.isError && (
+          <Typography variant="body2" color="error.main">
+            Ошибка сохранения: {updateMutation.error?.message}
+          </Typography>
+        )}
+      </Box>
+      {folders.length > 0 && (
+        <Box sx={{ display: 'flex', gap: 1, mt: 2, flexWrap: 'wrap', alignItems: 'center' }}>
+          <Typography variant="body2" color="text.secondary">
+            Папки:
+          </Typography>
+          {folders.map((folder) => {
+            const inFolder = !!resume.folder_ids?.includes(folder.id);
+            return (
+              <Chip
+                key={folder.id}
+                label={folder.name}
+                size="small"
+                color={inFolder ? 'primary' : 'default'}
+                variant={inFolder ? 'filled' : 'outlined'}
+                onClick={() => folderMutation.mutate({ folderId: folder.id, inFolder })}
+                disabled={folderMutation.isPending}
+              />
+            );
+          })}
+        </Box>
+      )}
+    </Box>
+  );
+};
 
 export const ResumeDatabase = () => {
   const [selectedFile, setSelectedFile] = useState<File | null>(null);
   const [isDragOver, setIsDragOver] = useState(false);
   const [expandedRows, setExpandedRows] = useState<Set<string>>(new Set());
   const [uploadDialogOpen, setUploadDialogOpen] = useState(false);
-  const [uploadResult, setUploadResult] = useState<UploadDatabaseResponse | null>(null);
+  const [ingestionJobId, setIngestionJobId] = useState<string | null>(null);
+  const [openFileError, setOpenFileError] = useState<string | null>(null);
 
   const queryClient = useQueryClient();
 
+  // Поиск по базе: форма и применённые параметры
+  const [searchForm, setSearchForm] = useState(emptySearchForm);
+  const [search, setSearch] = useState<ResumeSearch | null>(null);
+
+  // Фильтр базы по тегу и папке
+  const [tagFilter, setTagFilter] = useState('');
+  const [folderFilter, setFolderFilter] = useState('');
+  const [newFolderName, setNewFolderName] = useState('');
+
   // Загрузка существующих резюме
-  const { data: resumes, isLoading, error } = useQuery<ResumeRecord[]>({
-    queryKey: ['resumeDatabase'],
-    queryFn: () => CvService.getResumeDatabase(),
+  const { data: allResumes, isLoading, error } = useQuery<ResumeRecord[]>({
+    queryKey: ['resumeDatabase', tagFilter, folderFilter],
+    queryFn: () => CvService.getResumeDatabase(tagFilter || undefined, folderFilter || undefined),
+    // При смене фильтра показываем прежний список, чтобы не терять форму
+    placeholderData: keepPreviousData,
+  });
+
+  const { data: folders } = useQuery<ResumeFolder[]>({
+    queryKey: ['resumeFolders'],
+    queryFn: () => CvService.getResumeFolders(),
+  });
+
+  const createFolderMutation = useMutation({
+    mutationFn: (name: string) => CvService.createResumeFolder({ name }),
+    onSuccess: () => {
+      setNewFolderName('');
+      queryClient.invalidateQueries({ queryKey: ['resumeFolders'] });
+    },
+  });
+
+  const deleteFolderMutation = useMutation({
+    mutationFn: (folderId: string) => CvService.deleteResumeFolder(folderId),
+    onSuccess: (_, folderId) => {
+      if (folderFilter === folderId) {
+        setFolderFilter('');
+      }
+      queryClient.invalidateQueries({ queryKey: ['resumeFolders'] });
+      queryClient.invalidateQueries({ queryKey: ['resumeDatabase'] });
+    },
+  });
+
+  const deleteResumeMutation = useMutation({
+    mutationFn: (resumeId: string) => CvService.deleteResume(resumeId),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ['resumeDatabase'] });
+      queryClient.invalidateQueries({ queryKey: ['resumeSearch'] });
+      queryClient.invalidateQueries({ queryKey: ['resumeFolders'] });
+    },
+  });
+
+  const handleDeleteResume = (resume: ResumeRecord) => {
+    if (window.confirm(`Удалить резюме кандидата ${resume.candidate_name}?`)) {
+      deleteResumeMutation.mutate(resume.id);
+    }
+  };
+
+  const handleDeleteFolder = (folder: ResumeFolder) => {
+    if (window.confirm(`Удалить папку «${folder.name}»? Резюме останутся в базе.`)) {
+      deleteFolderMutation.mutate(folder.id);
+    }
+  };
+
+  const handleCreateFolder = (e: React.FormEvent) => {
+    e.preventDefault();
+    if (newFolderName.trim()) {
+      createFolderMutation.mutate(newFolderName.trim());
+    }
+  };
+
+  const { data: searchResults, isFetching: isSearching, error: searchError } = useQuery<ResumeSearchResult[]>({
+    queryKey: ['resumeSearch', search],
+    queryFn: () => CvService.searchResumeDatabase(
+      search!.q || undefined,
+      search!.minAge,
+      search!.maxAge,
+      search!.minExperience,
+      search!.maxExperience,
+    ),
+    enabled: !!search,
   });
 
+  const resumes = search ? searchResults?.map((result) => result.resume) : allResumes;
+  const snippets = new Map(searchResults?.map((result) => [result.resume.id, result.snippet]));
+
+  const handleSearch = (e: React.FormEvent) => {
+    e.preventDefault();
+    const params: ResumeSearch = {
+      q: searchForm.q.trim(),
+      minAge: parseNumber(searchForm.minAge),
+      maxAge: parseNumber(searchForm.maxAge),
+      minExperience: parseNumber(searchForm.minExperience),
+      maxExperience: parseNumber(searchForm.maxExperience),
+    };
+    const hasFilters = Object.values(params).some((value) => value !== undefined && value !== '');
+    setSearch(hasFilters ? params : null);
+  };
+
+  const handleResetSearch = () => {
+    setSearchForm(emptySearchForm);
+    setSearch(null);
+  };
+
   // Мутация для загрузки архива
   const uploadMutation = useMutation({
     mutationFn: async (file: File) => {
        return CvService.uploadResumeDatabase({ archive: file });
     },
     onSuccess: (data) => {
-      setUploadResult(data);
+      queryClient.setQueryData(['ingestionJob', data.id], data);
+      setIngestionJobId(data.id);
       setUploadDialogOpen(true);
       setSelectedFile(null);
+    },
+  });
+
+  // Прогресс обработки архива: опрашиваем, пока задача не завершится
+  const { data: ingestionJob } = useQuery<IngestionJob>({
+    queryKey: ['ingestionJob', ingestionJobId],
+    queryFn: async () => {
+      const job = await CvService.getIngestionJob(ingestionJobId!);
       queryClient.invalidateQueries({ queryKey: ['resumeDatabase'] });
+      return job;
+    },
+    enabled: !!ingestionJobId,
+    refetchInterval: (query) =>
+      query.state.data?.status === IngestionJob.status.COMPLETED ? false : 3000,
+  });
+
+  // Повторная обработка файлов с ошибками
+  const retryMutation = useMutation({
+    mutationFn: (jobId: string) => CvService.retryIngestionJob(jobId),
+    onSuccess: (data) => {
+      queryClient.setQueryData(['ingestionJob', data.id], data);
     },
   });
 
@@ -117,7 +385,8 @@ export const ResumeDatabase = () => {
   };
 
   const handleOpenResume = (fileUrl: string) => {
-    window.open(fileUrl, '_blank');
+    setOpenFileError(null);
+    openFile(fileUrl).catch((err) => setOpenFileError(getOpenFileError(err)));
   };
 
   const formatDate = (dateString: string) => {
@@ -188,7 +457,7 @@ export const ResumeDatabase = () => {
               {selectedFile ? selectedFile.name : 'Перетащите ZIP архив сюда или нажмите для выбора'}
             </Typography>
             <Typography color="text.secondary">
-              Поддерживаются файлы: PDF, TXT, DOC, DOCX
+              Поддерживаются файлы: PDF, TXT, DOC, DOCX, RTF, ODT
             </Typography>
           </label>
         </Box>
@@ -217,14 +486,14 @@ export const ResumeDatabase = () => {
       </Paper>
 
       {/* Статистика */}
-      {resumes && resumes.length > 0 && (
+      {allResumes && allResumes.length > 0 && (
         <Grid container spacing={3} sx={{ mb: 4 }}>
           <Grid item xs={12} sm={4}>
             <Card>
               <CardContent sx={{ textAlign: 'center' }}>
                 <PersonIcon sx={{ fontSize: 40, color: 'primary.main', mb: 1 }} />
                 <Typography variant="h4" component="div" color="primary">
-                  {resumes.length}
+                  {allResumes.length}
                 </Typography>
                 <Typography color="text.secondary">
                   Всего резюме
@@ -237,7 +506,7 @@ export const ResumeDatabase = () => {
               <CardContent sx={{ textAlign: 'center' }}>
                 <DescriptionIcon sx={{ fontSize: 40, color: 'success.main', mb: 1 }} />
                 <Typography variant="h4" component="div" color="success.main">
-                  {resumes.filter(r => r.analysis).length}
+                  {allResumes.filter(r => r.analysis).length}
                 </Typography>
                 <Typography color="text.secondary">
                   Проанализировано
@@ -250,10 +519,10 @@ export const ResumeDatabase = () => {
               <CardContent sx={{ textAlign: 'center' }}>
                 <AccessTimeIcon sx={{ fontSize: 40, color: 'info.main', mb: 1 }} />
                 <Typography variant="h4" component="div" color="info.main">
-                  {resumes.length > 0 ? Math.round(resumes.reduce((sum, r) => {
+                  {allResumes.length > 0 ? Math.round(allResumes.reduce((sum, r) => {
                     const years = parseInt(r.experience_years.match(/\d+/)?.[0] || '0');
                     return sum + years;
-                  }, 0) / resumes.length) : 0}
+                  }, 0) / allResumes.length) : 0}
                 </Typography>
                 <Typography color="text.secondary">
                   Средний опыт (лет)
@@ -264,6 +533,141 @@ export const ResumeDatabase = () => {
         </Grid>
       )}
 
+      {/* Теги и папки */}
+      <Paper sx={{ p: 2, mb: 3 }}>
+        <Grid container spacing={2} alignItems="center">
+          <Grid item xs={12} md={3}>
+            <TextField
+              fullWidth
+              size="small"
+              select
+              label="Папка"
+              value={folderFilter}
+              onChange={(e) => setFolderFilter(e.target.value)}
+            >
+              <MenuItem value="">Все резюме</MenuItem>
+              {folders?.map((folder) => (
+                <MenuItem key={folder.id} value={folder.id}>
+                  {folder.name} ({folder.resume_count})
+                </MenuItem>
+              ))}
+            </TextField>
+          </Grid>
+          <Grid item xs={12} md={3}>
+            <TextField
+              fullWidth
+              size="small"
+              label="Тег"
+              value={tagFilter}
+              onChange={(e) => setTagFilter(e.target.value.trim().toLowerCase())}
+            />
+          </Grid>
+          <Grid item xs={12} md={6} component="form" onSubmit={handleCreateFolder} sx={{ display: 'flex', gap: 1 }}>
+            <TextField
+              fullWidth
+              size="small"
+              label="Новая папка"
+              value={newFolderName}
+              onChange={(e) => setNewFolderName(e.target.value)}
+            />
+            <Button
+              type="submit"
+              variant="outlined"
+              startIcon={<CreateNewFolderIcon />}
+              disabled={!newFolderName.trim() || createFolderMutation.isPending}
+            >
+              Создать
+            </Button>
+          </Grid>
+        </Grid>
+        {folders && folders.length > 0 && (
+          <Box sx={{ display: 'flex', gap: 1, mt: 2, flexWrap: 'wrap' }}>
+            {folders.map((folder) => (
+              <Chip
+                key={folder.id}
+                label={`${folder.name} (${folder.resume_count})`}
+                size="small"
+                color={folderFilter === folder.id ? 'primary' : 'default'}
+                onClick={() => setFolderFilter(folderFilter === folder.id ? '' : folder.id)}
+                onDelete={() => handleDeleteFolder(folder)}
+              />
+            ))}
+          </Box>
+        )}
+        {createFolderMutation.isError && (
+          <Alert severity="error" sx={{ mt: 2 }}>
+            Не удалось создать папку: {createFolderMutation.error?.message}
+          </Alert>
+        )}
+      </Paper>
+
+      {/* Поиск */}
+      {allResumes && allResumes.length > 0 && (
+        <Paper component="form" onSubmit={handleSearch} sx={{ p: 2, mb: 3 }}>
+          <Grid container spacing={2} alignItems="center">
+            <Grid item xs={12} md={4}>
+              <TextField
+                fullWidth
+                size="small"
+                label="Навыки, должность, опыт"
+                value={searchForm.q}
+                onChange={(e) => setSearchForm({ ...searchForm, q: e.target.value })}
+              />
+            </Grid>
+            <Grid item xs={6} md={1.5}>
+              <TextField
+                fullWidth
+                size="small"
+                type="number"
+                label="Возраст от"
+                value={searchForm.minAge}
+                onChange={(e) => setSearchForm({ ...searchForm, minAge: e.target.value })}
+              />
+            </Grid>
+            <Grid item xs={6} md={1.5}>
+              <TextField
+                fullWidth
+                size="small"
+                type="number"
+                label="до"
+                value={searchForm.maxAge}
+                onChange={(e) => setSearchForm({ ...searchForm, maxAge: e.target.value })}
+              />
+            </Grid>
+            <Grid item xs={6} md={1.5}>
+              <TextField
+                fullWidth
+                size="small"
+                type="number"
+                label="Опыт от, лет"
+                value={searchForm.minExperience}
+                onChange={(e) => setSearchForm({ ...searchForm, minExperience: e.target.value })}
+              />
+            </Grid>
+            <Grid item xs={6} md={1.5}>
+              <TextField
+                fullWidth
+                size="small"
+                type="number"
+                label="до"
+                value={searchForm.maxExperience}
+                onChange={(e) => setSearchForm({ ...searchForm, maxExperience: e.target.value })}
+              />
+            </Grid>
+            <Grid item xs={12} md={2} sx={{ display: 'flex', gap: 1 }}>
+              <Button type="submit" variant="contained" startIcon={<SearchIcon />} disabled={isSearching}>
+                Найти
+              </Button>
+              {search && (
+                <Button onClick={handleResetSearch}>
+                  Сбросить
+                </Button>
+              )}
+            </Grid>
+          </Grid>
+        </Paper>
+      )}
+
       {/* Список резюме */}
       {error && (
         <Alert severity="error" sx={{ mb: 4 }}>
@@ -271,7 +675,41 @@ export const ResumeDatabase = () => {
         </Alert>
       )}
 
-      {resumes && resumes.length === 0 && (
+      {openFileError && (
+        <Alert severity="error" sx={{ mb: 4 }} onClose={() => setOpenFileError(null)}>
+          {openFileError}
+        </Alert>
+      )}
+
+      {deleteResumeMutation.isError && (
+        <Alert severity="error" sx={{ mb: 4 }}>
+          Не удалось удалить резюме: {deleteResumeMutation.error?.message}
+        </Alert>
+      )}
+
+      {searchError && (
+        <Alert severity="error" sx={{ mb: 4 }}>
+          Ошибка поиска: {searchError.message}
+        </Alert>
+      )}
+
+      {search && resumes && resumes.length === 0 && (
+        <Paper sx={{ p: 4, textAlign: 'center' }}>
+          <Typography color="text.secondary">
+            По запросу ничего не найдено
+          </Typography>
+        </Paper>
+      )}
+
+      {!search && (tagFilter || folderFilter) && resumes && resumes.length === 0 && (
+        <Paper sx={{ p: 4, textAlign: 'center' }}>
+          <Typography color="text.secondary">
+            Нет резюме с выбранным тегом или в выбранной папке
+          </Typography>
+        </Paper>
+      )}
+
+      {!search && !tagFilter && !folderFilter && resumes && resumes.length === 0 && (
         <Paper sx={{ p: 4, textAlign: 'center' }}>
           <PersonIcon sx={{ fontSize: 64, color: 'text.secondary', mb: 2 }} />
           <Typography variant="h6" gutterBottom>
@@ -305,6 +743,18 @@ export const ResumeDatabase = () => {
                         <Typography variant="subtitle2" fontWeight="medium">
                           {resume.candidate_name}
                         </Typography>
+                        {resume.tags.length > 0 && (
+                          <Box sx={{ display: 'flex', gap: 0.5, mt: 0.5, flexWrap: 'wrap' }}>
+                            {resume.tags.map((tag) => (
+                              <Chip key={tag} label={tag} size="small" variant="outlined" onClick={() => setTagFilter(tag)} />
+                            ))}
+                          </Box>
+                        )}
+                        {snippets.get(resume.id)?.length ? (
+                          <Typography variant="body2" color="text.secondary">
+                            {renderSnippet(snippets.get(resume.id)!)}
+                          </Typography>
+                        ) : null}
                       </TableCell>
                       <TableCell>
                         {resume.candidate_age ? (
@@ -329,6 +779,14 @@ export const ResumeDatabase = () => {
                         >
                           <OpenInNewIcon />
                         </IconButton>
+                        <IconButton
+                          onClick={() => handleDeleteResume(resume)}
+                          size="small"
+                          title="Удалить резюме"
+                          disabled={deleteResumeMutation.isPending}
+                        >
+                          <DeleteIcon />
+                        </IconButton>
                       </TableCell>
                       <TableCell>
                         <IconButton
@@ -349,6 +807,7 @@ export const ResumeDatabase = () => {
                             <Typography variant="body2" sx={{ whiteSpace: 'pre-wrap' }}>
                               {resume.analysis}
                             </Typography>
+                            <ResumeAnnotations resume={resume} folders={folders ?? []} />
                           </Box>
                         </Collapse>
                       </TableCell>
@@ -365,14 +824,14 @@ export const ResumeDatabase = () => {
       <Dialog open={uploadDialogOpen} onClose={() => setUploadDialogOpen(false)} maxWidth="md" fullWidth>
         <DialogTitle>Результаты загрузки</DialogTitle>
         <DialogContent>
-          {uploadResult && (
+          {ingestionJob && (
             <Box>
               <Grid container spacing={2} sx={{ mb: 3 }}>
-                <Grid item xs={4}>
+                <Grid item xs={3}>
                   <Card variant="outlined">
                     <CardContent sx={{ textAlign: 'center' }}>
                       <Typography variant="h4" color="primary">
-                        {uploadResult.processed_count}
+                        {ingestionJob.processed_count} / {ingestionJob.total_count}
                       </Typography>
                       <Typography color="text.secondary">
                         Обработано файлов
@@ -380,11 +839,11 @@ export const ResumeDatabase = () => {
                     </CardContent>
                   </Card>
                 </Grid>
-                <Grid item xs={4}>
+                <Grid item xs={3}>
                   <Card variant="outlined">
                     <CardContent sx={{ textAlign: 'center' }}>
                       <Typography variant="h4" color="success.main">
-                        {uploadResult.successful_count}
+                        {ingestionJob.successful_count}
                       </Typography>
                       <Typography color="text.secondary">
                         Успешно
@@ -392,11 +851,23 @@ export const ResumeDatabase = () => {
                     </CardContent>
                   </Card>
                 </Grid>
-                <Grid item xs={4}>
+                <Grid item xs={3}>
+                  <Card variant="outlined">
+                    <CardContent sx={{ textAlign: 'center' }}>
+                      <Typography variant="h4" color="warning.main">
+                        {ingestionJob.skipped_count}
+                      </Typography>
+                      <Typography color="text.secondary">
+                        Дубликатов
+                      </Typography>
+                    </CardContent>
+                  </Card>
+                </Grid>
+                <Grid item xs={3}>
                   <Card variant="outlined">
                     <CardContent sx={{ textAlign: 'center' }}>
                       <Typography variant="h4" color="error.main">
-                        {uploadResult.failed_count}
+                        {ingestionJob.failed_count}
                       </Typography>
                       <Typography color="text.secondary">
                         Ошибок
@@ -406,25 +877,50 @@ export const ResumeDatabase = () => {
                 </Grid>
               </Grid>
 
+              {ingestionJob.status === IngestionJob.status.PROCESSING && (
+                <LinearProgress
+                  variant="determinate"
+                  value={ingestionJob.total_count ? (ingestionJob.processed_count / ingestionJob.total_count) * 100 : 0}
+                  sx={{ mb: 2 }}
+                />
+              )}
+
               <Divider sx={{ my: 2 }} />
 
               <Typography variant="h6" gutterBottom>
-                Добавленные резюме:
+                Файлы архива:
               </Typography>
-              {uploadResult.resumes.map((resume) => (
-                <Box key={resume.id} sx={{ mb: 2, p: 2, backgroundColor: 'grey.50', borderRadius: 1 }}>
-                  <Typography variant="subtitle2" fontWeight="medium">
-                    {resume.candidate_name}
-                  </Typography>
-                  <Typography variant="body2" color="text.secondary">
-                    {resume.experience_years} • {resume.candidate_age ? `${resume.candidate_age} лет` : 'Возраст не указан'}
-                  </Typography>
+              {ingestionJob.files.map((file) => (
+                <Box key={file.id} sx={{ mb: 2, p: 2, backgroundColor: 'grey.50', borderRadius: 1 }}>
+                  <Box sx={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center' }}>
+                    <Typography variant="subtitle2" fontWeight="medium">
+                      {file.file_name}
+                    </Typography>
+                    <Chip
+                      label={ingestionFileStatusLabels[file.status]}
+                      color={ingestionFileStatusColors[file.status]}
+                      size="small"
+                    />
+                  </Box>
+                  {file.error && (
+                    <Typography variant="body2" color="error.main" sx={{ mt: 1 }}>
+                      {file.stage ? `${ingestionStageLabels[file.stage]}: ` : ''}{file.error}
+                    </Typography>
+                  )}
                 </Box>
               ))}
             </Box>
           )}
         </DialogContent>
         <DialogActions>
+          {ingestionJob && ingestionJob.status === IngestionJob.status.COMPLETED && ingestionJob.files.some((file) => file.retryable) && (
+            <Button
+              onClick={() => retryMutation.mutate(ingestionJob.id)}
+              disabled={retryMutation.isPending}
+            >
+              Повторить для ошибок
+            </Button>
+          )}
           <Button onClick={() => setUploadDialogOpen(false)}>Закрыть</Button>
         </DialogActions>
       </Dialog>
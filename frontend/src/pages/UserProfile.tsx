@@ -19,6 +19,7 @@ import { Chat as ChatIcon } from '@mui/icons-material';
 import { DefaultService } from '../api/profile';
 import { ChatService } from '../api/chat';
 import type { ApiGetProfile, Experience } from '../api/profile';
+import { openFile, getOpenFileError } from '../utils/openFile';
 
 export const UserProfile = () => {
   const { guid } = useParams<{ guid: string }>();
@@ -67,6 +68,11 @@ export const UserProfile = () => {
     },
   });
 
+  const handleOpenCv = (link: string) => {
+    setError('');
+    openFile(link).catch((err) => setError(getOpenFileError(err)));
+  };
+
   const handleStartChat = () => {
     if (guid) {
       const token = localStorage.getItem('access_token');
@@ -169,9 +175,7 @@ export const UserProfile = () => {
             <Grid item xs={12}>
               <Button
                 variant="outlined"
-                href={profile.cv}
-                target="_blank"
-                rel="noopener noreferrer"
+                onClick={() => handleOpenCv(profile.cv!)}
               >
                 Скачать резюме
               </Button>
@@ -17,6 +17,7 @@ import { Jobs } from './pages/Jobs';
 import { MyJobs } from './pages/MyJobs';
 import { JobDetails } from './pages/JobDetails';
 import { JobForm } from './pages/JobForm';
+import { ClaimApplication } from './pages/ClaimApplication';
 import { ResumeDatabase } from './pages/ResumeDatabase';
 
 export const AppRoutes = () => {
@@ -49,6 +50,7 @@ export const AppRoutes = () => {
         <Route path="jobs/my" element={<MyJobs />} />
         <Route path="jobs/new" element={<JobForm />} />
         <Route path="jobs/edit/:jobId" element={<JobForm />} />
+        <Route path="jobs/claim/:token" element={<ClaimApplication />} />
         <Route path="jobs/:jobId" element={<JobDetails />} />
       </Route>
     </Routes>
@@ -0,0 +1,37 @@
+import { CvService } from '../api/cv/services/CvService';
+import { ApiError } from '../api/cv/core/ApiError';
+
+const CV_FILES_PATH = '/api/v1/cv/';
+
+// Файлы резюме на платформе отдаются только с авторизацией, поэтому в новой
+// вкладке открывается временная подписанная ссылка. Внешние и уже подписанные
+// ссылки открываются как есть.
+export const openFile = async (link: string) => {
+  const url = new URL(link, window.location.href);
+  if (!url.pathname.startsWith(CV_FILES_PATH) || url.searchParams.has('signature')) {
+    window.open(link, '_blank', 'noopener,noreferrer');
+    return;
+  }
+
+  // Вкладка открывается до запроса, иначе браузер заблокирует всплывающее окно
+  const target = window.open('', '_blank');
+  try {
+    const filename = decodeURIComponent(url.pathname.slice(CV_FILES_PATH.length));
+    const signed = await CvService.getCvSignedUrl(filename);
+    if (target) {
+      target.opener = null;
+      target.location.href = signed.url;
+    } else {
+      window.open(signed.url, '_blank', 'noopener,noreferrer');
+    }
+  } catch (err) {
+    target?.close();
+    throw err;
+  }
+};
+
+export const getOpenFileError = (err: unknown) => {
+  if (err instanceof ApiError && err.status === 403) return 'Нет доступа к файлу резюме';
+  if (err instanceof ApiError && err.status === 404) return 'Файл резюме не найден';
+  return 'Не удалось открыть файл резюме';
+};